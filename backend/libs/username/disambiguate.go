@@ -0,0 +1,22 @@
+package username
+
+import "strconv"
+
+// Disambiguate returns the display names for players sharing a view, e.g. the players of a lobby.
+// names must be in join order: the first player keeps the name, later players whose name has the
+// same Key get a suffix, "Bob", "bob" becomes "Bob", "bob #2".
+// The suffix cannot collide with a real name, "#" is not allowed in usernames.
+func Disambiguate(names []string) []string {
+	out := make([]string, len(names))
+	seen := make(map[string]int, len(names))
+	for i, name := range names {
+		k := Key(name)
+		seen[k]++
+		if n := seen[k]; n > 1 {
+			out[i] = name + " #" + strconv.Itoa(n)
+			continue
+		}
+		out[i] = name
+	}
+	return out
+}
@@ -0,0 +1,5 @@
+module github.com/KnuffelGame/KnuffelGame/backend/libs/username
+
+go 1.25.3
+
+require golang.org/x/text v0.27.0
@@ -0,0 +1,72 @@
+package username
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// leet maps digits and symbols commonly used in place of letters
+var leet = strings.NewReplacer("0", "o", "1", "i", "3", "e", "4", "a", "5", "s", "7", "t", "@", "a", "$", "s")
+
+// Policy rejects names containing a blocked word. The zero value blocks nothing.
+type Policy struct {
+	blocked []string
+}
+
+// NewPolicy creates a policy blocking the given words. Empty words are ignored.
+func NewPolicy(words []string) *Policy {
+	p := &Policy{}
+	for _, w := range words {
+		if s := skeleton(w); s != "" {
+			p.blocked = append(p.blocked, s)
+		}
+	}
+	return p
+}
+
+// LoadPolicy reads the blocked words from a file, one per line. Blank lines and lines starting with # are skipped.
+func LoadPolicy(path string) (*Policy, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open blocklist: %w", err)
+	}
+	defer f.Close()
+
+	var words []string
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		words = append(words, line)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read blocklist: %w", err)
+	}
+	return NewPolicy(words), nil
+}
+
+// Len returns the number of blocked words
+func (p *Policy) Len() int {
+	return len(p.blocked)
+}
+
+// Blocked reports whether name contains a blocked word. Matching ignores case, spaces, lookalike
+// letters and digits used as letters, so "B A D", "b4d" and "bad" all match the word "bad".
+func (p *Policy) Blocked(name string) bool {
+	s := skeleton(name)
+	for _, w := range p.blocked {
+		if strings.Contains(s, w) {
+			return true
+		}
+	}
+	return false
+}
+
+// skeleton reduces a name to the letters used for blocklist matching
+func skeleton(name string) string {
+	return leet.Replace(strings.ReplaceAll(Key(name), " ", ""))
+}
@@ -0,0 +1,82 @@
+package username
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestNormalize(t *testing.T) {
+	cases := map[string]string{
+		"  Alice   Smith ":   "Alice Smith",
+		"Bob\t\n2":           "Bob 2",
+		"\uff22\uff4f\uff42": "Bob",
+		"Воb":                "Bob", // Cyrillic В and о
+		"Zoë":                "Zoe",
+		"Al\u200bice":        "Alice",
+		"Player 1":           "Player 1",
+	}
+	for in, want := range cases {
+		if got := Normalize(in); got != want {
+			t.Errorf("Normalize(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestKey(t *testing.T) {
+	if Key("BOB") != Key("bob") || Key("Воb") != Key("bob") {
+		t.Fatalf("expected lookalike names to share a key")
+	}
+	if Key("Bob") == Key("Rob") {
+		t.Fatalf("expected different names to have different keys")
+	}
+}
+
+func TestPolicyBlocked(t *testing.T) {
+	p := NewPolicy([]string{"badword", " ", ""})
+	if p.Len() != 1 {
+		t.Fatalf("expected empty words to be ignored, got %d words", p.Len())
+	}
+	for _, name := range []string{"badword", "BadWord 99", "b4dw0rd", "bad word", "ｂａｄｗｏｒｄ", "xbadwordx"} {
+		if !p.Blocked(name) {
+			t.Errorf("expected %q to be blocked", name)
+		}
+	}
+	for _, name := range []string{"Alice", "bad", "word bad"} {
+		if p.Blocked(name) {
+			t.Errorf("expected %q to be allowed", name)
+		}
+	}
+	if (&Policy{}).Blocked("badword") {
+		t.Fatalf("expected the zero policy to block nothing")
+	}
+}
+
+func TestLoadPolicy(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "blocklist.txt")
+	if err := os.WriteFile(path, []byte("# comment\nfoo\n\n  bar  \n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	p, err := LoadPolicy(path)
+	if err != nil {
+		t.Fatalf("LoadPolicy error: %v", err)
+	}
+	if p.Len() != 2 || !p.Blocked("Mr Bar") || p.Blocked("comment") {
+		t.Fatalf("unexpected policy %+v", p)
+	}
+	if _, err := LoadPolicy(filepath.Join(t.TempDir(), "missing.txt")); err == nil {
+		t.Fatalf("expected an error for a missing file")
+	}
+}
+
+func TestDisambiguate(t *testing.T) {
+	got := Disambiguate([]string{"Bob", "Alice", "bob", "Воb", "Alice"})
+	want := []string{"Bob", "Alice", "bob #2", "Воb #3", "Alice #2"}
+	if !slices.Equal(got, want) {
+		t.Fatalf("Disambiguate = %q, want %q", got, want)
+	}
+	if len(Disambiguate(nil)) != 0 {
+		t.Fatalf("expected no names for no players")
+	}
+}
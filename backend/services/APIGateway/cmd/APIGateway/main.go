@@ -0,0 +1,69 @@
+package main
+
+import (
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/KnuffelGame/KnuffelGame/backend/libs/logger"
+	router "github.com/KnuffelGame/KnuffelGame/backend/services/APIGateway/internal"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/APIGateway/internal/authservice"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/APIGateway/pkg/config"
+)
+
+func main() {
+	// ensure SERVICE_NAME env is present (fallback if empty)
+	if os.Getenv("SERVICE_NAME") == "" {
+		_ = os.Setenv("SERVICE_NAME", "APIGateway")
+	}
+	log := logger.FromEnv().With(slog.String("component", "bootstrap"))
+
+	cfg := config.Load()
+
+	cacheTTL, err := time.ParseDuration(cfg.AuthCacheTTL)
+	if err != nil || cacheTTL < 0 {
+		log.Error("invalid AUTH_CACHE_TTL", slog.String("ttl", cfg.AuthCacheTTL))
+		os.Exit(1)
+	}
+
+	cacheSize, err := strconv.Atoi(cfg.AuthCacheSize)
+	if err != nil || cacheSize <= 0 {
+		log.Error("invalid AUTH_CACHE_SIZE", slog.String("size", cfg.AuthCacheSize))
+		os.Exit(1)
+	}
+
+	upstreams := router.Upstreams{
+		Auth:  parseUpstream(log, "AUTH_SERVICE_URL", cfg.AuthServiceURL),
+		Lobby: parseUpstream(log, "LOBBY_SERVICE_URL", cfg.LobbyServiceURL),
+		Game:  parseUpstream(log, "GAME_SERVICE_URL", cfg.GameServiceURL),
+		SSE:   parseUpstream(log, "SSE_SERVICE_URL", cfg.SSEServiceURL),
+	}
+
+	var validator authservice.Validator = authservice.NewHTTPValidator(cfg.AuthServiceURL)
+	if cacheTTL > 0 {
+		validator = authservice.NewCachedValidator(validator, cacheTTL, cacheSize)
+	} else {
+		log.Warn("AUTH_CACHE_TTL is 0, every request is validated with the Auth Service")
+	}
+
+	r := router.New(validator, upstreams)
+	log.Info("listening", slog.String("port", cfg.Port))
+	// no WriteTimeout: proxied event streams stay open indefinitely
+	if err := http.ListenAndServe(":"+cfg.Port, r); err != nil {
+		log.Error("server exited", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+}
+
+// parseUpstream exits if the configured backend URL is not an absolute http(s) URL
+func parseUpstream(log *slog.Logger, name, raw string) *url.URL {
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		log.Error("invalid "+name, slog.String("url", raw))
+		os.Exit(1)
+	}
+	return u
+}
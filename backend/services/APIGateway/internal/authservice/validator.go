@@ -0,0 +1,166 @@
+// Package authservice validates session tokens with the Auth Service.
+package authservice
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/KnuffelGame/KnuffelGame/backend/services/APIGateway/internal/models"
+	"github.com/google/uuid"
+)
+
+const requestTimeout = 3 * time.Second
+
+// ErrInvalidToken is returned for tokens the Auth Service rejected (bad signature, expired, malformed, ...)
+var ErrInvalidToken = errors.New("invalid token")
+
+// Identity is the user a valid token was issued to
+type Identity struct {
+	UserID   uuid.UUID
+	Username string
+	IsGuest  bool
+}
+
+// Validator resolves a token to the identity it was issued to.
+type Validator interface {
+	Validate(ctx context.Context, token string) (Identity, error)
+}
+
+// Invalidator is implemented by validators that remember tokens; Invalidate forgets token.
+type Invalidator interface {
+	Invalidate(token string)
+}
+
+// HTTPValidator calls the Auth Service POST /internal/validate endpoint.
+type HTTPValidator struct {
+	baseURL string
+	client  *http.Client
+}
+
+// NewHTTPValidator creates a validator for the Auth Service at baseURL (e.g. http://AuthService:8081).
+func NewHTTPValidator(baseURL string) *HTTPValidator {
+	return &HTTPValidator{baseURL: baseURL, client: &http.Client{Timeout: requestTimeout}}
+}
+
+// Validate returns ErrInvalidToken if the Auth Service rejected the token
+// and another error if it could not be asked.
+func (v *HTTPValidator) Validate(ctx context.Context, token string) (Identity, error) {
+	body, err := json.Marshal(models.ValidateTokenRequest{Token: token})
+	if err != nil {
+		return Identity{}, fmt.Errorf("failed to encode request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, v.baseURL+"/internal/validate", bytes.NewReader(body))
+	if err != nil {
+		return Identity{}, fmt.Errorf("failed to build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return Identity{}, fmt.Errorf("failed to validate token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// 400 and 401 carry the rejection reason, everything else is a failure of the Auth Service
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusBadRequest, http.StatusUnauthorized:
+	default:
+		return Identity{}, fmt.Errorf("validate returned status %d", resp.StatusCode)
+	}
+
+	var out models.ValidateTokenResponse
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return Identity{}, fmt.Errorf("failed to decode response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK || !out.Valid {
+		return Identity{}, fmt.Errorf("%w: %s", ErrInvalidToken, out.Error)
+	}
+	userID, err := uuid.Parse(out.UserID)
+	if err != nil || out.Username == "" {
+		return Identity{}, fmt.Errorf("%w: incomplete identity", ErrInvalidToken)
+	}
+	return Identity{UserID: userID, Username: out.Username, IsGuest: out.IsGuest}, nil
+}
+
+// CachedValidator remembers valid tokens for a short time so that not every request
+// costs a round trip to the Auth Service. Rejections and errors are not cached.
+// A token that expires or is revoked stays accepted for at most the TTL; the Auth Service does not notify the
+// gateway of revocations. Only a logout through this gateway replica invalidates the token here immediately.
+type CachedValidator struct {
+	next       Validator
+	ttl        time.Duration
+	maxEntries int
+	now        func() time.Time
+
+	mu      sync.Mutex
+	entries map[[sha256.Size]byte]cacheEntry
+}
+
+type cacheEntry struct {
+	identity  Identity
+	expiresAt time.Time
+}
+
+// NewCachedValidator caches the identities returned by next for ttl, holding at most maxEntries tokens
+func NewCachedValidator(next Validator, ttl time.Duration, maxEntries int) *CachedValidator {
+	return &CachedValidator{
+		next:       next,
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		now:        time.Now,
+		entries:    make(map[[sha256.Size]byte]cacheEntry),
+	}
+}
+
+// Validate returns the cached identity or asks the wrapped validator
+func (c *CachedValidator) Validate(ctx context.Context, token string) (Identity, error) {
+	// keyed by hash so the cache does not hold usable tokens
+	key := sha256.Sum256([]byte(token))
+	now := c.now()
+
+	c.mu.Lock()
+	e, ok := c.entries[key]
+	c.mu.Unlock()
+	if ok && now.Before(e.expiresAt) {
+		return e.identity, nil
+	}
+
+	identity, err := c.next.Validate(ctx, token)
+	if err != nil {
+		return Identity{}, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.entries) >= c.maxEntries {
+		c.evictLocked(now)
+	}
+	c.entries[key] = cacheEntry{identity: identity, expiresAt: now.Add(c.ttl)}
+	return identity, nil
+}
+
+// Invalidate forgets the cached validation of token, so its next use is checked with the Auth Service
+func (c *CachedValidator) Invalidate(token string) {
+	key := sha256.Sum256([]byte(token))
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, key)
+}
+
+// evictLocked drops expired entries, or everything if the cache is full of live ones
+func (c *CachedValidator) evictLocked(now time.Time) {
+	for key, e := range c.entries {
+		if !now.Before(e.expiresAt) {
+			delete(c.entries, key)
+		}
+	}
+	if len(c.entries) >= c.maxEntries {
+		clear(c.entries)
+	}
+}
@@ -0,0 +1,144 @@
+package authservice
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/KnuffelGame/KnuffelGame/backend/services/APIGateway/internal/models"
+	"github.com/google/uuid"
+)
+
+func newAuthService(t *testing.T, status int, resp models.ValidateTokenResponse) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/internal/validate" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		var req models.ValidateTokenRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Token == "" {
+			t.Errorf("expected a token in the request body, got %+v (%v)", req, err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestHTTPValidatorValid(t *testing.T) {
+	userID := uuid.New()
+	srv := newAuthService(t, http.StatusOK, models.ValidateTokenResponse{Valid: true, UserID: userID.String(), Username: "Alice", IsGuest: true})
+
+	identity, err := NewHTTPValidator(srv.URL).Validate(context.Background(), "a.b.c")
+	if err != nil {
+		t.Fatalf("Validate error: %v", err)
+	}
+	if identity != (Identity{UserID: userID, Username: "Alice", IsGuest: true}) {
+		t.Fatalf("unexpected identity %+v", identity)
+	}
+}
+
+func TestHTTPValidatorRejected(t *testing.T) {
+	for _, status := range []int{http.StatusBadRequest, http.StatusUnauthorized} {
+		srv := newAuthService(t, status, models.ValidateTokenResponse{Valid: false, Error: "token expired"})
+		if _, err := NewHTTPValidator(srv.URL).Validate(context.Background(), "a.b.c"); !errors.Is(err, ErrInvalidToken) {
+			t.Fatalf("status %d: expected ErrInvalidToken, got %v", status, err)
+		}
+	}
+}
+
+func TestHTTPValidatorUnavailable(t *testing.T) {
+	srv := newAuthService(t, http.StatusInternalServerError, models.ValidateTokenResponse{})
+	_, err := NewHTTPValidator(srv.URL).Validate(context.Background(), "a.b.c")
+	if err == nil || errors.Is(err, ErrInvalidToken) {
+		t.Fatalf("expected a non-token error, got %v", err)
+	}
+}
+
+type countingValidator struct {
+	calls    int
+	identity Identity
+	err      error
+}
+
+func (v *countingValidator) Validate(context.Context, string) (Identity, error) {
+	v.calls++
+	return v.identity, v.err
+}
+
+func TestCachedValidator(t *testing.T) {
+	next := &countingValidator{identity: Identity{UserID: uuid.New(), Username: "Alice"}}
+	c := NewCachedValidator(next, 30*time.Second, 10)
+	now := time.Now()
+	c.now = func() time.Time { return now }
+
+	for i := 0; i < 3; i++ {
+		identity, err := c.Validate(context.Background(), "token")
+		if err != nil || identity != next.identity {
+			t.Fatalf("unexpected result %+v, %v", identity, err)
+		}
+	}
+	if next.calls != 1 {
+		t.Fatalf("expected 1 call to the Auth Service, got %d", next.calls)
+	}
+
+	now = now.Add(31 * time.Second)
+	if _, err := c.Validate(context.Background(), "token"); err != nil {
+		t.Fatalf("Validate error: %v", err)
+	}
+	if next.calls != 2 {
+		t.Fatalf("expected the expired entry to be validated again, got %d calls", next.calls)
+	}
+}
+
+func TestCachedValidatorInvalidate(t *testing.T) {
+	next := &countingValidator{identity: Identity{UserID: uuid.New(), Username: "Alice"}}
+	c := NewCachedValidator(next, time.Minute, 10)
+
+	if _, err := c.Validate(context.Background(), "token"); err != nil {
+		t.Fatalf("Validate error: %v", err)
+	}
+	c.Invalidate("token")
+	// revoked by the logout, the Auth Service now rejects it
+	next.err = ErrInvalidToken
+	if _, err := c.Validate(context.Background(), "token"); !errors.Is(err, ErrInvalidToken) {
+		t.Fatalf("expected the invalidated token to be validated again, got %v", err)
+	}
+	if next.calls != 2 {
+		t.Fatalf("expected 2 calls to the Auth Service, got %d", next.calls)
+	}
+}
+
+func TestCachedValidatorDoesNotCacheRejections(t *testing.T) {
+	next := &countingValidator{err: ErrInvalidToken}
+	c := NewCachedValidator(next, time.Minute, 10)
+
+	for i := 0; i < 2; i++ {
+		if _, err := c.Validate(context.Background(), "token"); !errors.Is(err, ErrInvalidToken) {
+			t.Fatalf("expected ErrInvalidToken, got %v", err)
+		}
+	}
+	if next.calls != 2 {
+		t.Fatalf("expected every rejected token to be validated, got %d calls", next.calls)
+	}
+}
+
+func TestCachedValidatorBounded(t *testing.T) {
+	next := &countingValidator{identity: Identity{UserID: uuid.New(), Username: "Alice"}}
+	c := NewCachedValidator(next, time.Minute, 2)
+
+	for _, token := range []string{"a", "b", "c"} {
+		if _, err := c.Validate(context.Background(), token); err != nil {
+			t.Fatalf("Validate error: %v", err)
+		}
+	}
+	if len(c.entries) > 2 {
+		t.Fatalf("expected at most 2 cached tokens, got %d", len(c.entries))
+	}
+}
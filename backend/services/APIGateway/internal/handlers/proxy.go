@@ -0,0 +1,46 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+
+	"github.com/KnuffelGame/KnuffelGame/backend/libs/httpx"
+	"github.com/KnuffelGame/KnuffelGame/backend/libs/logger"
+)
+
+// ProxyHandler returns an http.Handler that forwards requests unchanged to the backend at target
+// Path and query are kept, e.g. GET /games/{id} -> {target}/games/{id}
+// Cookies are forwarded as far as they are still present; Authenticate removes them on authenticated routes
+// Event streams (text/event-stream) are flushed as they arrive
+// Returns: the backend response, 502 if the backend is unreachable
+func ProxyHandler(target *url.URL) http.Handler {
+	return &httputil.ReverseProxy{
+		Rewrite: func(pr *httputil.ProxyRequest) {
+			pr.SetURL(target)
+			pr.SetXForwarded()
+		},
+		ErrorHandler: func(w http.ResponseWriter, r *http.Request, err error) {
+			log := logger.Logger(r.Context()).WithGroup("handler").With(slog.String("action", "proxy"))
+			if r.Context().Err() != nil {
+				// client went away, e.g. a closed event stream
+				log.Debug("client disconnected", slog.String("upstream", target.Host))
+				return
+			}
+			log.Error("upstream request failed", slog.String("upstream", target.Host), slog.String("error", err.Error()))
+			httpx.WriteError(w, http.StatusBadGateway, "service_unavailable", "Backend service temporarily unavailable", nil, log)
+		},
+	}
+}
+
+// InternalForbiddenHandler returns an http.HandlerFunc that refuses /internal/* requests from outside
+// Internal endpoints of the backends are reachable only inside the service network
+// Returns: 403 Forbidden
+func InternalForbiddenHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log := logger.Logger(r.Context()).WithGroup("handler").With(slog.String("action", "internal_forbidden"))
+		log.Warn("refused request to internal endpoint", slog.String("path", r.URL.Path))
+		httpx.WriteForbidden(w, "Internal endpoints are not accessible", log)
+	}
+}
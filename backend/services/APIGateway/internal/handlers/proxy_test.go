@@ -0,0 +1,67 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestProxyHandlerForwardsRequest(t *testing.T) {
+	var got *http.Request
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer backend.Close()
+	target, _ := url.Parse(backend.URL)
+
+	req := httptest.NewRequest(http.MethodPost, "/lobbies/join?x=1", nil)
+	req.Header.Set("X-User-ID", "6b0f7c0e-4c1a-4c55-9b3a-0b8f4a6f6d11")
+	req.Header.Set("X-Username", "Alice")
+	req.AddCookie(&http.Cookie{Name: "jwt", Value: "a.b.c"})
+	rec := httptest.NewRecorder()
+	ProxyHandler(target).ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("expected the backend status 201, got %d", rec.Code)
+	}
+	if got == nil {
+		t.Fatalf("backend was not called")
+	}
+	if got.Method != http.MethodPost || got.URL.Path != "/lobbies/join" || got.URL.RawQuery != "x=1" {
+		t.Fatalf("unexpected upstream request %s %s", got.Method, got.URL)
+	}
+	if got.Header.Get("X-User-ID") != "6b0f7c0e-4c1a-4c55-9b3a-0b8f4a6f6d11" || got.Header.Get("X-Username") != "Alice" {
+		t.Fatalf("expected identity headers to be forwarded, got %v", got.Header)
+	}
+	if got.Header.Get("Cookie") != "jwt=a.b.c" {
+		t.Fatalf("expected cookies to be forwarded, got %q", got.Header.Get("Cookie"))
+	}
+}
+
+func TestProxyHandlerBackendDown(t *testing.T) {
+	backend := httptest.NewServer(http.NotFoundHandler())
+	target, _ := url.Parse(backend.URL)
+	backend.Close()
+
+	rec := httptest.NewRecorder()
+	ProxyHandler(target).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/games/123", nil))
+
+	if rec.Code != http.StatusBadGateway {
+		t.Fatalf("expected 502, got %d", rec.Code)
+	}
+	var payload map[string]any
+	if err := json.Unmarshal(rec.Body.Bytes(), &payload); err != nil || payload["error"] != "service_unavailable" {
+		t.Fatalf("unexpected response %s", rec.Body.String())
+	}
+}
+
+func TestInternalForbiddenHandler(t *testing.T) {
+	rec := httptest.NewRecorder()
+	InternalForbiddenHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/internal/publish", nil))
+	if rec.Code != http.StatusForbidden {
+		t.Fatalf("expected 403, got %d", rec.Code)
+	}
+}
@@ -0,0 +1,80 @@
+package middleware
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/KnuffelGame/KnuffelGame/backend/libs/auth"
+	"github.com/KnuffelGame/KnuffelGame/backend/libs/httpx"
+	"github.com/KnuffelGame/KnuffelGame/backend/libs/logger"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/APIGateway/internal/authservice"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/APIGateway/internal/models"
+)
+
+// identityHeaderPrefix matches X-User-ID, X-Username and any other X-User* header
+const identityHeaderPrefix = "X-User"
+
+// StripIdentityHeaders removes client supplied X-User* headers.
+// The backends trust these headers, so only Authenticate may set them.
+func StripIdentityHeaders(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for name := range r.Header {
+			if strings.HasPrefix(http.CanonicalHeaderKey(name), identityHeaderPrefix) {
+				log := logger.Logger(r.Context()).WithGroup("middleware").With(slog.String("action", "strip_identity_headers"))
+				log.Warn("removed client supplied identity header", slog.String("header", name))
+				r.Header.Del(name)
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// InvalidateSession forgets the cached validation of the JWT cookie before the request is forwarded.
+// Mounted on logout, so the revoked token is not accepted from the cache of this replica.
+func InvalidateSession(inv authservice.Invalidator) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if cookie, err := r.Cookie(models.SessionCookie); err == nil && cookie.Value != "" {
+				inv.Invalidate(cookie.Value)
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// Authenticate validates the JWT cookie with v and sets X-User-ID and X-Username for the backend.
+// The Cookie header is removed, backends rely on the identity headers only.
+// Responds 401 if the cookie is missing or the token is rejected, 503 if the Auth Service is unavailable.
+func Authenticate(v authservice.Validator) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			log := logger.Logger(r.Context()).WithGroup("middleware").With(slog.String("action", "authenticate"))
+
+			cookie, err := r.Cookie(models.SessionCookie)
+			if err != nil || cookie.Value == "" {
+				log.Info("missing session cookie")
+				httpx.WriteUnauthorized(w, "Authentication required", log)
+				return
+			}
+
+			identity, err := v.Validate(r.Context(), cookie.Value)
+			if err != nil {
+				if errors.Is(err, authservice.ErrInvalidToken) {
+					log.Info("token rejected", slog.String("error", err.Error()))
+					httpx.WriteUnauthorized(w, "Invalid or expired authentication token", log)
+					return
+				}
+				log.Error("failed to validate token", slog.String("error", err.Error()))
+				httpx.WriteError(w, http.StatusServiceUnavailable, "service_unavailable", "Backend service temporarily unavailable", nil, log)
+				return
+			}
+
+			r.Header.Del("Cookie")
+			r.Header.Set(auth.DefaultHeaderUserID, identity.UserID.String())
+			r.Header.Set(auth.DefaultHeaderUsername, identity.Username)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
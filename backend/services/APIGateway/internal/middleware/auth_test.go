@@ -0,0 +1,137 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/KnuffelGame/KnuffelGame/backend/services/APIGateway/internal/authservice"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/APIGateway/internal/models"
+	"github.com/google/uuid"
+)
+
+type fakeValidator struct {
+	identity authservice.Identity
+	err      error
+}
+
+func (v fakeValidator) Validate(context.Context, string) (authservice.Identity, error) {
+	return v.identity, v.err
+}
+
+// echoHeaders responds with the identity headers the backend would see
+var echoHeaders = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	_ = json.NewEncoder(w).Encode(map[string]string{
+		"user_id":  r.Header.Get("X-User-ID"),
+		"username": r.Header.Get("X-Username"),
+		"role":     r.Header.Get("X-User-Role"),
+		"cookie":   r.Header.Get("Cookie"),
+	})
+})
+
+func serve(h http.Handler, cookie string, headers map[string]string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/lobbies/123", nil)
+	if cookie != "" {
+		req.AddCookie(&http.Cookie{Name: models.SessionCookie, Value: cookie})
+	}
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func decode(t *testing.T, rec *httptest.ResponseRecorder) map[string]string {
+	t.Helper()
+	var payload map[string]string
+	if err := json.Unmarshal(rec.Body.Bytes(), &payload); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	return payload
+}
+
+func TestAuthenticateInjectsVerifiedIdentity(t *testing.T) {
+	userID := uuid.New()
+	h := StripIdentityHeaders(Authenticate(fakeValidator{identity: authservice.Identity{UserID: userID, Username: "Alice"}})(echoHeaders))
+
+	rec := serve(h, "a.b.c", map[string]string{"X-User-ID": uuid.New().String(), "X-Username": "Mallory", "X-User-Role": "admin"})
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	got := decode(t, rec)
+	if got["user_id"] != userID.String() || got["username"] != "Alice" || got["role"] != "" {
+		t.Fatalf("expected only the verified identity to reach the backend, got %v", got)
+	}
+	if got["cookie"] != "" {
+		t.Fatalf("expected the session cookie not to reach the backend, got %q", got["cookie"])
+	}
+}
+
+func TestStripIdentityHeadersWithoutAuthentication(t *testing.T) {
+	rec := serve(StripIdentityHeaders(echoHeaders), "", map[string]string{"X-User-ID": uuid.New().String(), "X-Username": "Mallory"})
+	if got := decode(t, rec); got["user_id"] != "" || got["username"] != "" {
+		t.Fatalf("expected spoofed headers to be removed, got %v", got)
+	}
+}
+
+func TestAuthenticateMissingCookie(t *testing.T) {
+	rec := serve(Authenticate(fakeValidator{})(echoHeaders), "", nil)
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401, got %d", rec.Code)
+	}
+	if got := decode(t, rec); got["error"] != "unauthorized" || got["message"] != "Authentication required" {
+		t.Fatalf("unexpected error %v", got)
+	}
+}
+
+func TestAuthenticateInvalidToken(t *testing.T) {
+	rec := serve(Authenticate(fakeValidator{err: authservice.ErrInvalidToken})(echoHeaders), "a.b.c", nil)
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401, got %d", rec.Code)
+	}
+	if got := decode(t, rec); got["message"] != "Invalid or expired authentication token" {
+		t.Fatalf("unexpected error %v", got)
+	}
+}
+
+func TestAuthenticateAuthServiceUnavailable(t *testing.T) {
+	rec := serve(Authenticate(fakeValidator{err: errors.New("connection refused")})(echoHeaders), "a.b.c", nil)
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503, got %d", rec.Code)
+	}
+	if got := decode(t, rec); got["error"] != "service_unavailable" {
+		t.Fatalf("unexpected error %v", got)
+	}
+}
+
+type recordingInvalidator struct {
+	tokens []string
+}
+
+func (i *recordingInvalidator) Invalidate(token string) {
+	i.tokens = append(i.tokens, token)
+}
+
+func TestInvalidateSession(t *testing.T) {
+	inv := &recordingInvalidator{}
+	rec := serve(InvalidateSession(inv)(echoHeaders), "a.b.c", nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+	if len(inv.tokens) != 1 || inv.tokens[0] != "a.b.c" {
+		t.Fatalf("expected the session token to be invalidated, got %v", inv.tokens)
+	}
+	// the Auth Service needs the cookie to revoke the token
+	if got := decode(t, rec); got["cookie"] == "" {
+		t.Fatal("expected the session cookie to be forwarded")
+	}
+
+	serve(InvalidateSession(inv)(echoHeaders), "", nil)
+	if len(inv.tokens) != 1 {
+		t.Fatalf("expected nothing to be invalidated without a cookie, got %v", inv.tokens)
+	}
+}
@@ -0,0 +1,28 @@
+package middleware
+
+import (
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/KnuffelGame/KnuffelGame/backend/libs/httpx"
+	"github.com/KnuffelGame/KnuffelGame/backend/libs/logger"
+)
+
+// RejectDotSegments refuses paths containing "." or ".." segments, also percent-encoded.
+// Routing matches the path literally, so "/lobbies/../internal/..." must not reach a backend that resolves it.
+func RejectDotSegments(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for _, p := range []string{r.URL.Path, r.URL.EscapedPath()} {
+			for _, segment := range strings.Split(p, "/") {
+				if segment == "." || segment == ".." || strings.Contains(strings.ToLower(segment), "%2e") {
+					log := logger.Logger(r.Context()).WithGroup("middleware").With(slog.String("action", "reject_dot_segments"))
+					log.Warn("rejected path with dot segments", slog.String("path", r.URL.EscapedPath()))
+					httpx.WriteBadRequest(w, "Invalid path", nil, log)
+					return
+				}
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,28 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRejectDotSegments(t *testing.T) {
+	ok := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusOK) })
+	cases := map[string]int{
+		"/lobbies/123":                        http.StatusOK,
+		"/games/abc/select-field":             http.StatusOK,
+		"/lobbies/../internal/lobbies":        http.StatusBadRequest,
+		"/lobbies/%2e%2e/internal/lobbies":    http.StatusBadRequest,
+		"/games/./123":                        http.StatusBadRequest,
+		"/events/game/%2E%2E/%2E%2E/internal": http.StatusBadRequest,
+	}
+	for target, want := range cases {
+		t.Run(target, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			RejectDotSegments(ok).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+			if rec.Code != want {
+				t.Fatalf("expected %d, got %d", want, rec.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,18 @@
+package models
+
+// SessionCookie is the name of the cookie holding the JWT
+const SessionCookie = "jwt"
+
+// ValidateTokenRequest is the request body for Auth Service POST /internal/validate
+type ValidateTokenRequest struct {
+	Token string `json:"token"`
+}
+
+// ValidateTokenResponse is the response of Auth Service POST /internal/validate
+type ValidateTokenResponse struct {
+	Valid    bool   `json:"valid"`
+	UserID   string `json:"user_id,omitempty"`
+	Username string `json:"username,omitempty"`
+	IsGuest  bool   `json:"is_guest,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
@@ -0,0 +1,60 @@
+package router
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/KnuffelGame/KnuffelGame/backend/libs/healthcheck"
+	"github.com/KnuffelGame/KnuffelGame/backend/libs/logger"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/APIGateway/internal/authservice"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/APIGateway/internal/handlers"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/APIGateway/internal/middleware"
+	"github.com/go-chi/chi/v5"
+)
+
+// Upstreams are the base URLs of the backends the gateway routes to
+type Upstreams struct {
+	Auth  *url.URL
+	Lobby *url.URL
+	Game  *url.URL
+	SSE   *url.URL
+}
+
+// New constructs the HTTP router with the token validator and the backend URLs
+func New(v authservice.Validator, up Upstreams) http.Handler {
+	r := chi.NewRouter()
+	// replace chi default logger with structured slog based middleware
+	l := logger.Default()
+	r.Use(logger.ChiMiddleware(l))
+	// identity headers are set by Authenticate only; never trust the client's
+	r.Use(middleware.StripIdentityHeaders)
+	r.Use(middleware.RejectDotSegments)
+
+	// Healthcheck
+	healthcheck.Mount(r)
+
+	// Internal endpoints of the backends are never exposed
+	r.Handle("/internal", handlers.InternalForbiddenHandler())
+	r.Handle("/internal/*", handlers.InternalForbiddenHandler())
+
+	// Session endpoints (guest sign-in, refresh) are public; the Auth Service sets the session cookies
+	authProxy := handlers.ProxyHandler(up.Auth)
+	if inv, ok := v.(authservice.Invalidator); ok {
+		r.With(middleware.InvalidateSession(inv)).Handle("/auth/logout", authProxy)
+	}
+	r.Handle("/auth/*", authProxy)
+
+	// Proxied routes grouped under JWT cookie authentication
+	r.Group(func(r chi.Router) {
+		r.Use(middleware.Authenticate(v))
+
+		lobby := handlers.ProxyHandler(up.Lobby)
+		r.Handle("/lobbies", lobby)
+		r.Handle("/lobbies/*", lobby)
+
+		r.Handle("/games/*", handlers.ProxyHandler(up.Game))
+		r.Handle("/events/*", handlers.ProxyHandler(up.SSE))
+	})
+
+	return r
+}
@@ -0,0 +1,67 @@
+package config
+
+import "os"
+
+// Config holds runtime configuration loaded from environment variables.
+// PORT defaults to 8080 if unset.
+// AUTH_SERVICE_URL, LOBBY_SERVICE_URL, GAME_SERVICE_URL and SSE_SERVICE_URL default to the docker compose addresses.
+// AUTH_CACHE_TTL is how long a validated token is trusted without asking the Auth Service again (default 30s).
+// AUTH_CACHE_SIZE is the maximum number of cached tokens (default 10000).
+// Extend here for future configuration values.
+
+type Config struct {
+	Port            string
+	AuthServiceURL  string
+	LobbyServiceURL string
+	GameServiceURL  string
+	SSEServiceURL   string
+	AuthCacheTTL    string
+	AuthCacheSize   string
+}
+
+func Load() *Config {
+	port := os.Getenv("PORT")
+	if port == "" {
+		port = "8080"
+	}
+
+	authURL := os.Getenv("AUTH_SERVICE_URL")
+	if authURL == "" {
+		authURL = "http://AuthService:8081"
+	}
+
+	lobbyURL := os.Getenv("LOBBY_SERVICE_URL")
+	if lobbyURL == "" {
+		lobbyURL = "http://LobbyService:8083"
+	}
+
+	gameURL := os.Getenv("GAME_SERVICE_URL")
+	if gameURL == "" {
+		gameURL = "http://GameService:8082"
+	}
+
+	sseURL := os.Getenv("SSE_SERVICE_URL")
+	if sseURL == "" {
+		sseURL = "http://SSEService:8084"
+	}
+
+	cacheTTL := os.Getenv("AUTH_CACHE_TTL")
+	if cacheTTL == "" {
+		cacheTTL = "30s"
+	}
+
+	cacheSize := os.Getenv("AUTH_CACHE_SIZE")
+	if cacheSize == "" {
+		cacheSize = "10000"
+	}
+
+	return &Config{
+		Port:            port,
+		AuthServiceURL:  authURL,
+		LobbyServiceURL: lobbyURL,
+		GameServiceURL:  gameURL,
+		SSEServiceURL:   sseURL,
+		AuthCacheTTL:    cacheTTL,
+		AuthCacheSize:   cacheSize,
+	}
+}
@@ -1,13 +1,13 @@
 package main
 
 import (
+	"context"
 	"log/slog"
 	"net/http"
 	"os"
 
 	"github.com/KnuffelGame/KnuffelGame/backend/libs/logger"
-	router "github.com/KnuffelGame/KnuffelGame/backend/services/AuthService/internal"
-	"github.com/KnuffelGame/KnuffelGame/backend/services/AuthService/internal/jwt"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/AuthService/internal/app"
 	"github.com/KnuffelGame/KnuffelGame/backend/services/AuthService/pkg/config"
 )
 
@@ -18,16 +18,21 @@ func main() {
 	}
 	log := logger.FromEnv().With(slog.String("component", "bootstrap"))
 
-	cfg := config.Load()
-	gen := jwt.NewGenerator(cfg.JWTSecret)
-	val := jwt.NewValidator(cfg.JWTSecret)
-	if cfg.JWTSecret == "" {
-		log.Warn("JWT_SECRET is empty; token operations will fail")
+	cfg, err := config.Load()
+	if err != nil {
+		log.Error("invalid configuration", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+
+	a, err := app.New(context.Background(), cfg, log)
+	if err != nil {
+		log.Error("failed to start", slog.String("error", err.Error()))
+		os.Exit(1)
 	}
+	defer a.Close()
 
-	r := router.New(gen, val)
 	log.Info("listening", slog.String("port", cfg.Port))
-	if err := http.ListenAndServe(":"+cfg.Port, r); err != nil {
+	if err := http.ListenAndServe(":"+cfg.Port, a.Handler); err != nil {
 		log.Error("server exited", slog.String("error", err.Error()))
 		os.Exit(1)
 	}
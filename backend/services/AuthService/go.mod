@@ -3,12 +3,17 @@ module github.com/KnuffelGame/KnuffelGame/backend/services/AuthService
 go 1.25.3
 
 require (
+	github.com/DATA-DOG/go-sqlmock v1.5.2
 	github.com/KnuffelGame/KnuffelGame/backend/libs/healthcheck v0.0.0
 	github.com/KnuffelGame/KnuffelGame/backend/libs/httpx v0.0.0
 	github.com/KnuffelGame/KnuffelGame/backend/libs/logger v0.0.0
+	github.com/KnuffelGame/KnuffelGame/backend/libs/username v0.0.0
 	github.com/go-chi/chi/v5 v5.2.3
 	github.com/go-playground/validator/v10 v10.22.0
 	github.com/golang-jwt/jwt/v5 v5.3.0
+	github.com/google/uuid v1.6.0
+	github.com/lib/pq v1.10.9
+	github.com/pressly/goose/v3 v3.26.0
 )
 
 require (
@@ -16,10 +21,14 @@ require (
 	github.com/go-playground/locales v0.14.1 // indirect
 	github.com/go-playground/universal-translator v0.18.1 // indirect
 	github.com/leodido/go-urn v1.4.0 // indirect
-	golang.org/x/crypto v0.19.0 // indirect
-	golang.org/x/net v0.21.0 // indirect
-	golang.org/x/sys v0.17.0 // indirect
-	golang.org/x/text v0.14.0 // indirect
+	github.com/mfridman/interpolate v0.0.2 // indirect
+	github.com/sethvargo/go-retry v0.3.0 // indirect
+	go.uber.org/multierr v1.11.0 // indirect
+	golang.org/x/crypto v0.40.0 // indirect
+	golang.org/x/net v0.42.0 // indirect
+	golang.org/x/sync v0.16.0 // indirect
+	golang.org/x/sys v0.34.0 // indirect
+	golang.org/x/text v0.27.0 // indirect
 )
 
 replace github.com/KnuffelGame/KnuffelGame/backend/libs/healthcheck => ../../libs/healthcheck
@@ -27,3 +36,5 @@ replace github.com/KnuffelGame/KnuffelGame/backend/libs/healthcheck => ../../lib
 replace github.com/KnuffelGame/KnuffelGame/backend/libs/httpx => ../../libs/httpx
 
 replace github.com/KnuffelGame/KnuffelGame/backend/libs/logger => ../../libs/logger
+
+replace github.com/KnuffelGame/KnuffelGame/backend/libs/username => ../../libs/username
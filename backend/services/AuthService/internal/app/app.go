@@ -0,0 +1,80 @@
+// Package app builds the Auth Service from its configuration: stores, token services and the HTTP router.
+package app
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	router "github.com/KnuffelGame/KnuffelGame/backend/services/AuthService/internal"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/AuthService/internal/db"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/AuthService/internal/handlers"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/AuthService/pkg/config"
+)
+
+// revocationSyncInterval bounds how long a token revoked on another replica is still accepted
+const revocationSyncInterval = 10 * time.Second
+
+// App is the assembled Auth Service
+type App struct {
+	Handler http.Handler
+	conn    *db.Connection
+}
+
+// New builds the service described by cfg and starts its background jobs, which stop with ctx.
+// The database is only opened if one of the stores lives in Postgres.
+func New(ctx context.Context, cfg *config.Config, log *slog.Logger) (*App, error) {
+	a := &App{}
+	if cfg.UsesPostgres() {
+		conn, err := openDatabase(cfg)
+		if err != nil {
+			return nil, err
+		}
+		a.conn = conn
+	}
+
+	gen, val, ring, err := newTokens(ctx, cfg, a.conn, log)
+	if err != nil {
+		a.Close()
+		return nil, err
+	}
+
+	revoked, err := newRevocations(ctx, cfg, a.conn)
+	if err != nil {
+		a.Close()
+		return nil, err
+	}
+	val = val.WithRevocations(revoked)
+	go revoked.Run(ctx, revocationSyncInterval, log)
+
+	names, err := newNamePolicy(cfg, log)
+	if err != nil {
+		a.Close()
+		return nil, err
+	}
+
+	providers, err := newProviders(cfg, log)
+	if err != nil {
+		a.Close()
+		return nil, err
+	}
+
+	sessions := newSessions(cfg, a.conn, log)
+	go sessions.Run(ctx, time.Hour, log)
+
+	if !cfg.CookieSecure {
+		log.Warn("COOKIE_SECURE is false; session cookies are sent over plain HTTP")
+	}
+	cookie := handlers.CookieConfig{Secure: cfg.CookieSecure, Domain: cfg.CookieDomain, SameSite: cfg.CookieSameSite}
+
+	a.Handler = router.New(gen, val, ring, revoked, newUserStore(cfg, a.conn, log), names, sessions, cookie, providers)
+	return a, nil
+}
+
+// Close releases the database connection, if one was opened
+func (a *App) Close() {
+	if a.conn != nil {
+		a.conn.Close()
+	}
+}
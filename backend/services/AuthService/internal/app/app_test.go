@@ -0,0 +1,47 @@
+package app
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/KnuffelGame/KnuffelGame/backend/services/AuthService/pkg/config"
+)
+
+func TestNewWithMemoryStores(t *testing.T) {
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	a, err := New(ctx, cfg, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	defer a.Close()
+
+	rec := httptest.NewRecorder()
+	a.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/.well-known/jwks.json", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected the signing keys to be served, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestNewRejectsMissingBlocklist(t *testing.T) {
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	cfg.UsernameBlocklist = t.TempDir() + "/missing.txt"
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if _, err := New(ctx, cfg, slog.New(slog.NewTextHandler(io.Discard, nil))); err == nil {
+		t.Fatal("expected an error for a missing blocklist file")
+	}
+}
@@ -0,0 +1,139 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/KnuffelGame/KnuffelGame/backend/libs/username"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/AuthService/internal/db"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/AuthService/internal/jwt"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/AuthService/internal/keys"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/AuthService/internal/oidc"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/AuthService/internal/refresh"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/AuthService/internal/revocation"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/AuthService/internal/users"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/AuthService/pkg/config"
+)
+
+// openDatabase connects to Postgres and runs the migrations
+func openDatabase(cfg *config.Config) (*db.Connection, error) {
+	conn, err := db.New(db.Config{
+		Host:     cfg.DatabaseHost,
+		Port:     cfg.DatabasePort,
+		User:     cfg.DatabaseUser,
+		Password: cfg.DatabasePassword,
+		Database: cfg.DatabaseName,
+		SSLMode:  cfg.DatabaseSSLMode,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+	if err := db.RunMigrations(conn.DB); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to run migrations: %w", err)
+	}
+	return conn, nil
+}
+
+// newTokens creates the JWT generator and validator. With an asymmetric algorithm it also returns the key ring,
+// loaded and rotating until ctx ends; with HS256 the ring is nil.
+func newTokens(ctx context.Context, cfg *config.Config, conn *db.Connection, log *slog.Logger) (*jwt.Generator, *jwt.Validator, *keys.Ring, error) {
+	if cfg.JWTSigningAlg == config.AlgHS256 {
+		if cfg.JWTSecret == "" {
+			log.Warn("JWT_SECRET is empty; token operations will fail")
+		}
+		log.Warn("tokens are signed with HS256; services cannot verify them without the shared secret")
+		return jwt.NewGenerator(cfg.JWTSecret, cfg.AccessTokenTTL), jwt.NewValidator(cfg.JWTSecret), nil, nil
+	}
+
+	alg, err := keys.ParseAlgorithm(cfg.JWTSigningAlg)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	var store keys.Store = keys.NewMemory()
+	if cfg.SigningKeyStore == config.StorePostgres {
+		store = keys.NewPostgres(conn.DB)
+	}
+
+	// retired keys verify for one more token lifetime
+	ring := keys.NewRing(store, alg, cfg.KeyRotation, cfg.AccessTokenTTL)
+	if err := ring.Sync(ctx); err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to load signing keys: %w", err)
+	}
+	go ring.Run(ctx, time.Minute, log)
+
+	if cfg.JWTSecret != "" {
+		log.Warn("JWT_SECRET is set; HS256 tokens are still accepted, unset it once they have expired")
+	}
+	return jwt.NewKeyGenerator(ring, cfg.AccessTokenTTL), jwt.NewKeyValidator(ring, cfg.JWTSecret), ring, nil
+}
+
+// newRevocations creates the revocation list, loaded from Postgres if it is stored there
+func newRevocations(ctx context.Context, cfg *config.Config, conn *db.Connection) (*revocation.List, error) {
+	if cfg.RevocationStore != config.StorePostgres {
+		return revocation.NewList(nil), nil
+	}
+	list := revocation.NewList(revocation.NewPostgres(conn.DB))
+	if err := list.Sync(ctx); err != nil {
+		return nil, fmt.Errorf("failed to load revoked tokens: %w", err)
+	}
+	return list, nil
+}
+
+// newUserStore creates the store of user accounts
+func newUserStore(cfg *config.Config, conn *db.Connection, log *slog.Logger) users.Store {
+	if cfg.UserStore == config.StorePostgres {
+		return users.NewPostgres(conn.DB)
+	}
+	log.Warn("users are kept in memory; accounts are lost on restart")
+	return users.NewMemory()
+}
+
+// newSessions creates the refresh token manager
+func newSessions(cfg *config.Config, conn *db.Connection, log *slog.Logger) *refresh.Manager {
+	var store refresh.Store
+	if cfg.RefreshTokenStore == config.StorePostgres {
+		store = refresh.NewPostgres(conn.DB)
+	} else {
+		log.Warn("refresh tokens are kept in memory; sessions end on restart")
+		store = refresh.NewMemory()
+	}
+	return refresh.NewManager(store, cfg.RefreshTokenTTL)
+}
+
+// newNamePolicy loads the username blocklist, if one is configured
+func newNamePolicy(cfg *config.Config, log *slog.Logger) (*username.Policy, error) {
+	if cfg.UsernameBlocklist == "" {
+		return username.NewPolicy(nil), nil
+	}
+	names, err := username.LoadPolicy(cfg.UsernameBlocklist)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load USERNAME_BLOCKLIST_FILE: %w", err)
+	}
+	log.Info("username blocklist loaded", slog.Int("words", names.Len()))
+	return names, nil
+}
+
+// newProviders creates the verifier of the configured OIDC provider; without OIDC_ISSUER there is none
+func newProviders(cfg *config.Config, log *slog.Logger) (map[string]oidc.Verifier, error) {
+	providers := map[string]oidc.Verifier{}
+	if cfg.OIDCIssuer == "" {
+		return providers, nil
+	}
+	var keySet *oidc.KeySet
+	if cfg.OIDCJWKSURL != "" {
+		keySet = oidc.NewRemoteKeySet(cfg.OIDCJWKSURL, &http.Client{Timeout: 5 * time.Second})
+	} else {
+		var err error
+		keySet, err = oidc.LoadKeySet(cfg.OIDCJWKSFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load OIDC_JWKS_FILE: %w", err)
+		}
+	}
+	providers[cfg.OIDCProvider] = oidc.NewJWKSVerifier(cfg.OIDCIssuer, cfg.OIDCAudience, keySet)
+	log.Info("oidc exchange enabled", slog.String("provider", cfg.OIDCProvider), slog.String("issuer", cfg.OIDCIssuer))
+	return providers, nil
+}
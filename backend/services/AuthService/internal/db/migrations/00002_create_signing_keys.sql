@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Create signing_keys table
+-- Holds the JWT signing keys shared by all replicas as PKCS#8 DER. The key without retired_at signs,
+-- retired keys keep verifying until the tokens they signed have expired and are deleted afterwards.
+CREATE TABLE IF NOT EXISTS signing_keys (
+    kid TEXT PRIMARY KEY,
+    algorithm VARCHAR(10) NOT NULL,
+    private_key BYTEA NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    retired_at TIMESTAMPTZ NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_signing_keys_retired_at ON signing_keys(retired_at);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS signing_keys;
+-- +goose StatementEnd
@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+)
+
+const (
+	// SessionCookieName is the cookie the API Gateway reads the JWT (access token) from
+	SessionCookieName = "jwt"
+	// RefreshCookieName holds the refresh token; it is only sent to the /auth endpoints
+	RefreshCookieName = "refresh_token"
+	refreshCookiePath = "/auth"
+)
+
+// CookieConfig holds the deployment dependent attributes of the session cookies.
+// The cookies are always HttpOnly and live as long as the token they hold.
+type CookieConfig struct {
+	Secure   bool
+	Domain   string
+	SameSite http.SameSite
+}
+
+// setSessionCookies sets the access token cookie and the refresh token cookie
+func setSessionCookies(w http.ResponseWriter, cfg CookieConfig, access string, accessTTL time.Duration, refresh string, refreshTTL time.Duration) {
+	http.SetCookie(w, cfg.cookie(SessionCookieName, "/", access, int(accessTTL/time.Second)))
+	http.SetCookie(w, cfg.cookie(RefreshCookieName, refreshCookiePath, refresh, int(refreshTTL/time.Second)))
+}
+
+// clearSessionCookies tells the browser to drop both session cookies
+func clearSessionCookies(w http.ResponseWriter, cfg CookieConfig) {
+	http.SetCookie(w, cfg.cookie(SessionCookieName, "/", "", -1))
+	http.SetCookie(w, cfg.cookie(RefreshCookieName, refreshCookiePath, "", -1))
+}
+
+func (cfg CookieConfig) cookie(name, path, value string, maxAge int) *http.Cookie {
+	return &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     path,
+		Domain:   cfg.Domain,
+		MaxAge:   maxAge,
+		Secure:   cfg.Secure,
+		HttpOnly: true,
+		SameSite: cfg.SameSite,
+	}
+}
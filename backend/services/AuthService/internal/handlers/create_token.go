@@ -2,20 +2,26 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"log/slog"
 	"net/http"
+	"time"
 
 	"github.com/KnuffelGame/KnuffelGame/backend/libs/httpx"
 	"github.com/KnuffelGame/KnuffelGame/backend/libs/logger"
+	"github.com/KnuffelGame/KnuffelGame/backend/libs/username"
 	"github.com/KnuffelGame/KnuffelGame/backend/services/AuthService/internal/jwt"
 	"github.com/KnuffelGame/KnuffelGame/backend/services/AuthService/internal/models"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/AuthService/internal/users"
+	"github.com/google/uuid"
 )
 
 const maxBodySize = 1 << 20 // 1MB
 
-// CreateTokenHandler returns an http.HandlerFunc bound to a JWT generator.
-// Requires user_id and username. Guest tokens only; guest claim always true.
-func CreateTokenHandler(gen *jwt.Generator) http.HandlerFunc {
+// CreateTokenHandler returns an http.HandlerFunc bound to a JWT generator, the user store and the username policy.
+// Requires user_id and username; the username is normalized and must not contain a blocked word. Unknown users are stored as guests; the guest claim is the stored state,
+// so it is false for a user that was upgraded to an account.
+func CreateTokenHandler(gen *jwt.Generator, accounts users.Store, names *username.Policy) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		r.Body = http.MaxBytesReader(w, r.Body, maxBodySize)
 		log := logger.Logger(r.Context()).WithGroup("handler").With(slog.String("action", "create_token"))
@@ -27,20 +33,49 @@ func CreateTokenHandler(gen *jwt.Generator) http.HandlerFunc {
 			httpx.WriteError(w, http.StatusBadRequest, "invalid_request", "Invalid JSON body", map[string]interface{}{"detail": err.Error()}, log)
 			return
 		}
-		errMap := req.Validate()
+		errMap := validateCreateRequest(&req, names)
 		if len(errMap) > 0 {
 			log.Info("validation failed", slog.Any("errors", errMap))
 			httpx.WriteError(w, http.StatusBadRequest, "invalid_request", "Validation failed", map[string]interface{}{"fields": errMap}, log)
 			return
 		}
-		// Always guest tokens from this service
-		token, err := gen.CreateToken(req.UserID, req.Username, true)
+		guest, err := storedGuest(r, accounts, req.UserID, req.Username)
+		if err != nil {
+			log.Error("failed to load user", slog.String("error", err.Error()), slog.String("user_id", req.UserID))
+			httpx.WriteInternalError(w, "Failed to load user", nil, log)
+			return
+		}
+		token, err := gen.CreateToken(req.UserID, req.Username, guest)
 		if err != nil {
 			log.Error("token generation failed", slog.String("error", err.Error()), slog.String("user_id", req.UserID))
 			httpx.WriteError(w, http.StatusInternalServerError, "token_generation_failed", "Failed to generate JWT token", map[string]interface{}{"detail": err.Error()}, log)
 			return
 		}
-		log.Info("token generated", slog.String("user_id", req.UserID), slog.String("username", req.Username), slog.Bool("guest", true))
+		log.Info("token generated", slog.String("user_id", req.UserID), slog.String("username", req.Username), slog.Bool("guest", guest))
 		httpx.WriteJSON(w, http.StatusOK, models.CreateTokenResponse{Token: token}, log)
 	}
 }
+
+// validateCreateRequest normalizes the username of req and validates it against the username rules and names.
+// A username containing a blocked word fails with tag "blocked".
+func validateCreateRequest(req *models.CreateJWTRequest, names *username.Policy) map[string]string {
+	req.Username = username.Normalize(req.Username)
+	errMap := req.Validate()
+	if _, invalid := errMap["Username"]; !invalid && names.Blocked(req.Username) {
+		errMap["Username"] = "blocked"
+	}
+	return errMap
+}
+
+// storedGuest returns the guest flag of the user, storing unknown users as guests
+func storedGuest(r *http.Request, accounts users.Store, userID, name string) (bool, error) {
+	id := uuid.MustParse(userID)
+	u, err := accounts.Get(r.Context(), id)
+	if err == nil {
+		return u.Guest, nil
+	}
+	if !errors.Is(err, users.ErrNotFound) {
+		return false, err
+	}
+	return true, accounts.Create(r.Context(), users.User{ID: id, Username: name, Guest: true, CreatedAt: time.Now()})
+}
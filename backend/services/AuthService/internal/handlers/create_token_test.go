@@ -6,13 +6,15 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/KnuffelGame/KnuffelGame/backend/services/AuthService/internal/jwt"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/AuthService/internal/users"
 )
 
 func TestCreateToken_Success(t *testing.T) {
-	gen := jwt.NewGenerator("12345678901234567890123456789012")
-	h := CreateTokenHandler(gen)
+	gen := jwt.NewGenerator("12345678901234567890123456789012", 24*time.Hour)
+	h := CreateTokenHandler(gen, users.NewMemory(), testNames)
 	body, _ := json.Marshal(map[string]interface{}{"username": "Alice", "user_id": "550e8400-e29b-41d4-a716-446655440000"})
 	req := httptest.NewRequest(http.MethodPost, "/internal/create", bytes.NewReader(body))
 	rec := httptest.NewRecorder()
@@ -28,8 +30,8 @@ func TestCreateToken_Success(t *testing.T) {
 }
 
 func TestCreateToken_MissingUserID(t *testing.T) {
-	gen := jwt.NewGenerator("12345678901234567890123456789012")
-	h := CreateTokenHandler(gen)
+	gen := jwt.NewGenerator("12345678901234567890123456789012", 24*time.Hour)
+	h := CreateTokenHandler(gen, users.NewMemory(), testNames)
 	body, _ := json.Marshal(map[string]interface{}{"username": "Alice"})
 	req := httptest.NewRequest(http.MethodPost, "/internal/create", bytes.NewReader(body))
 	rec := httptest.NewRecorder()
@@ -40,8 +42,8 @@ func TestCreateToken_MissingUserID(t *testing.T) {
 }
 
 func TestCreateToken_ValidationFail(t *testing.T) {
-	gen := jwt.NewGenerator("12345678901234567890123456789012")
-	h := CreateTokenHandler(gen)
+	gen := jwt.NewGenerator("12345678901234567890123456789012", 24*time.Hour)
+	h := CreateTokenHandler(gen, users.NewMemory(), testNames)
 	body, _ := json.Marshal(map[string]interface{}{"username": "Al", "user_id": "550e8400-e29b-41d4-a716-446655440000"}) // username too short
 	req := httptest.NewRequest(http.MethodPost, "/internal/create", bytes.NewReader(body))
 	rec := httptest.NewRecorder()
@@ -51,9 +53,27 @@ func TestCreateToken_ValidationFail(t *testing.T) {
 	}
 }
 
+func TestCreateToken_BlockedUsername(t *testing.T) {
+	gen := jwt.NewGenerator("12345678901234567890123456789012", 24*time.Hour)
+	h := CreateTokenHandler(gen, users.NewMemory(), testNames)
+	body, _ := json.Marshal(map[string]interface{}{"username": "The Badword", "user_id": "550e8400-e29b-41d4-a716-446655440000"})
+	req := httptest.NewRequest(http.MethodPost, "/internal/create", bytes.NewReader(body))
+	rec := httptest.NewRecorder()
+	h(rec, req)
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", rec.Code)
+	}
+	var resp map[string]interface{}
+	_ = json.Unmarshal(rec.Body.Bytes(), &resp)
+	fields, _ := resp["details"].(map[string]interface{})["fields"].(map[string]interface{})
+	if fields["Username"] != "blocked" {
+		t.Fatalf("expected Username blocked, got %+v", resp)
+	}
+}
+
 func TestCreateToken_UnknownField(t *testing.T) {
-	gen := jwt.NewGenerator("12345678901234567890123456789012")
-	h := CreateTokenHandler(gen)
+	gen := jwt.NewGenerator("12345678901234567890123456789012", 24*time.Hour)
+	h := CreateTokenHandler(gen, users.NewMemory(), testNames)
 	body, _ := json.Marshal(map[string]interface{}{"username": "Alice", "user_id": "550e8400-e29b-41d4-a716-446655440000", "extra": "x"})
 	req := httptest.NewRequest(http.MethodPost, "/internal/create", bytes.NewReader(body))
 	rec := httptest.NewRecorder()
@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/KnuffelGame/KnuffelGame/backend/libs/httpx"
+	"github.com/KnuffelGame/KnuffelGame/backend/libs/logger"
+	"github.com/KnuffelGame/KnuffelGame/backend/libs/username"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/AuthService/internal/jwt"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/AuthService/internal/models"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/AuthService/internal/refresh"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/AuthService/internal/users"
+	"github.com/google/uuid"
+)
+
+// GuestSessionHandler returns an http.HandlerFunc bound to a JWT generator, the user store, the username policy, the refresh token manager and cookie settings.
+// Public endpoint, exposed through the API Gateway as POST /auth/guest.
+// Request body: {"username": "..."}; the user id is generated (uuid4) and stored as guest user.
+// Returns: 200 with SessionResponse, the JWT and the refresh token in Set-Cookie headers, 400 on validation errors.
+func GuestSessionHandler(gen *jwt.Generator, accounts users.Store, names *username.Policy, sessions *refresh.Manager, cookie CookieConfig) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		r.Body = http.MaxBytesReader(w, r.Body, maxBodySize)
+		log := logger.Logger(r.Context()).WithGroup("handler").With(slog.String("action", "create_guest_session"))
+		var body models.CreateGuestRequest
+		dec := json.NewDecoder(r.Body)
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&body); err != nil {
+			log.Warn("decode failed", slog.String("error", err.Error()))
+			httpx.WriteError(w, http.StatusBadRequest, "invalid_request", "Invalid JSON body", map[string]interface{}{"detail": err.Error()}, log)
+			return
+		}
+		// Same rules as /internal/create, with a fresh user id
+		req := models.CreateJWTRequest{UserID: uuid.NewString(), Username: body.Username}
+		errMap := validateCreateRequest(&req, names)
+		if len(errMap) > 0 {
+			log.Info("validation failed", slog.Any("errors", errMap))
+			httpx.WriteError(w, http.StatusBadRequest, "invalid_request", "Validation failed", map[string]interface{}{"fields": errMap}, log)
+			return
+		}
+		now := time.Now()
+		if err := accounts.Create(r.Context(), users.User{ID: uuid.MustParse(req.UserID), Username: req.Username, Guest: true, CreatedAt: now}); err != nil {
+			log.Error("failed to store user", slog.String("error", err.Error()), slog.String("user_id", req.UserID))
+			httpx.WriteInternalError(w, "Failed to create user", nil, log)
+			return
+		}
+		expiresAt := now.Add(gen.Lifetime())
+		token, err := gen.CreateToken(req.UserID, req.Username, true)
+		if err != nil {
+			log.Error("token generation failed", slog.String("error", err.Error()), slog.String("user_id", req.UserID))
+			httpx.WriteError(w, http.StatusInternalServerError, "token_generation_failed", "Failed to generate JWT token", map[string]interface{}{"detail": err.Error()}, log)
+			return
+		}
+		refreshToken, err := sessions.Issue(r.Context(), req.UserID, req.Username, true)
+		if err != nil {
+			log.Error("refresh token generation failed", slog.String("error", err.Error()), slog.String("user_id", req.UserID))
+			httpx.WriteInternalError(w, "Failed to create session", nil, log)
+			return
+		}
+		setSessionCookies(w, cookie, token, gen.Lifetime(), refreshToken, sessions.TTL())
+		log.Info("guest session created", slog.String("user_id", req.UserID), slog.String("username", req.Username))
+		httpx.WriteJSON(w, http.StatusOK, models.SessionResponse{
+			UserID:    req.UserID,
+			Username:  req.Username,
+			IsGuest:   true,
+			ExpiresAt: expiresAt.UTC().Truncate(time.Second),
+		}, log)
+	}
+}
@@ -0,0 +1,140 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/KnuffelGame/KnuffelGame/backend/libs/username"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/AuthService/internal/jwt"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/AuthService/internal/models"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/AuthService/internal/refresh"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/AuthService/internal/users"
+	"github.com/google/uuid"
+)
+
+const testSecret = "12345678901234567890123456789012"
+
+var testNames = username.NewPolicy([]string{"badword"})
+
+func newSessionDeps() (*jwt.Generator, *users.MemoryStore, *refresh.Manager) {
+	return jwt.NewGenerator(testSecret, 15*time.Minute), users.NewMemory(), refresh.NewManager(refresh.NewMemory(), 24*time.Hour)
+}
+
+// cookiesByName returns the cookies set on rec
+func cookiesByName(rec *httptest.ResponseRecorder) map[string]*http.Cookie {
+	out := map[string]*http.Cookie{}
+	for _, c := range rec.Result().Cookies() {
+		out[c.Name] = c
+	}
+	return out
+}
+
+func createGuest(t *testing.T, h http.HandlerFunc, username string) *httptest.ResponseRecorder {
+	t.Helper()
+	body, _ := json.Marshal(map[string]interface{}{"username": username})
+	rec := httptest.NewRecorder()
+	h(rec, httptest.NewRequest(http.MethodPost, "/auth/guest", bytes.NewReader(body)))
+	return rec
+}
+
+func TestGuestSession_Success(t *testing.T) {
+	gen, accounts, sessions := newSessionDeps()
+	h := GuestSessionHandler(gen, accounts, testNames, sessions, CookieConfig{Secure: true, Domain: "knuffel.example", SameSite: http.SameSiteStrictMode})
+	rec := createGuest(t, h, "Alice")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	var resp models.SessionResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	id, err := uuid.Parse(resp.UserID)
+	if err != nil || id.Version() != 4 {
+		t.Fatalf("expected a uuid4 user id, got %q", resp.UserID)
+	}
+	if resp.Username != "Alice" || !resp.IsGuest || resp.ExpiresAt.IsZero() {
+		t.Fatalf("unexpected response %+v", resp)
+	}
+
+	cookies := cookiesByName(rec)
+	c := cookies[SessionCookieName]
+	if c == nil || !c.HttpOnly || !c.Secure || c.SameSite != http.SameSiteStrictMode || c.Domain != "knuffel.example" || c.Path != "/" || c.MaxAge != 900 {
+		t.Fatalf("unexpected session cookie %+v", c)
+	}
+	rc := cookies[RefreshCookieName]
+	if rc == nil || rc.Value == "" || !rc.HttpOnly || !rc.Secure || rc.Path != "/auth" || rc.MaxAge != 86400 {
+		t.Fatalf("unexpected refresh cookie %+v", rc)
+	}
+
+	claims, err := jwt.NewValidator(testSecret).ValidateToken(c.Value)
+	if err != nil {
+		t.Fatalf("expected a valid token in the cookie: %v", err)
+	}
+	if claims.Subject != resp.UserID || claims.Username != "Alice" || !claims.Guest {
+		t.Fatalf("token does not match the response: %+v", claims)
+	}
+}
+
+func TestGuestSession_FreshUserIDs(t *testing.T) {
+	gen, accounts, sessions := newSessionDeps()
+	h := GuestSessionHandler(gen, accounts, testNames, sessions, CookieConfig{})
+	seen := map[string]bool{}
+	for i := 0; i < 2; i++ {
+		rec := createGuest(t, h, "Alice")
+		var resp models.SessionResponse
+		_ = json.Unmarshal(rec.Body.Bytes(), &resp)
+		if seen[resp.UserID] {
+			t.Fatalf("user id %s issued twice", resp.UserID)
+		}
+		seen[resp.UserID] = true
+	}
+}
+
+func TestGuestSession_ValidationFail(t *testing.T) {
+	gen, accounts, sessions := newSessionDeps()
+	h := GuestSessionHandler(gen, accounts, testNames, sessions, CookieConfig{})
+	for _, name := range []string{"", "Al", "Alice!", "   A   ", "B4d W0rd"} {
+		rec := createGuest(t, h, name)
+		if rec.Code != http.StatusBadRequest {
+			t.Fatalf("username %q: expected 400, got %d", name, rec.Code)
+		}
+		if len(rec.Result().Cookies()) != 0 {
+			t.Fatalf("username %q: expected no cookie", name)
+		}
+	}
+}
+
+func TestGuestSession_NormalizesUsername(t *testing.T) {
+	gen, accounts, sessions := newSessionDeps()
+	h := GuestSessionHandler(gen, accounts, testNames, sessions, CookieConfig{})
+	rec := createGuest(t, h, "  Ａlice \u200b  Wоnder ") // fullwidth A, zero width space, Cyrillic о
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var resp models.SessionResponse
+	_ = json.Unmarshal(rec.Body.Bytes(), &resp)
+	if resp.Username != "Alice Wonder" {
+		t.Fatalf("expected the normalized username, got %q", resp.Username)
+	}
+	u, err := accounts.Get(context.Background(), uuid.MustParse(resp.UserID))
+	if err != nil || u.Username != "Alice Wonder" {
+		t.Fatalf("expected the normalized username to be stored, got %+v (%v)", u, err)
+	}
+}
+
+func TestGuestSession_UserIDNotAccepted(t *testing.T) {
+	gen, accounts, sessions := newSessionDeps()
+	h := GuestSessionHandler(gen, accounts, testNames, sessions, CookieConfig{})
+	body, _ := json.Marshal(map[string]interface{}{"username": "Alice", "user_id": "550e8400-e29b-41d4-a716-446655440000"})
+	rec := httptest.NewRecorder()
+	h(rec, httptest.NewRequest(http.MethodPost, "/auth/guest", bytes.NewReader(body)))
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for a caller chosen user id, got %d", rec.Code)
+	}
+}
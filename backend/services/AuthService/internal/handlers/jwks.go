@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"log/slog"
+	"math/big"
+	"net/http"
+
+	"github.com/KnuffelGame/KnuffelGame/backend/libs/httpx"
+	"github.com/KnuffelGame/KnuffelGame/backend/libs/logger"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/AuthService/internal/keys"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/AuthService/internal/models"
+)
+
+// jwksMaxAge is how long clients may cache the key set; they should refetch earlier on an unknown kid
+const jwksMaxAge = "max-age=300"
+
+// JWKSHandler returns an http.HandlerFunc serving the public keys of ring as JSON Web Key Set.
+// Contains the signing key and every retired key whose tokens may not have expired yet.
+// ring may be nil when tokens are signed with HS256 only; the set is empty then.
+// Returns: 200 with JWKSResponse
+func JWKSHandler(ring *keys.Ring) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log := logger.Logger(r.Context()).WithGroup("handler").With(slog.String("action", "jwks"))
+		resp := models.JWKSResponse{Keys: []models.JWK{}}
+		if ring != nil {
+			for _, k := range ring.Keys() {
+				jwk, ok := toJWK(k)
+				if !ok {
+					log.Warn("skipping key of unknown type", slog.String("kid", k.ID))
+					continue
+				}
+				resp.Keys = append(resp.Keys, jwk)
+			}
+		}
+		w.Header().Set("Cache-Control", jwksMaxAge)
+		httpx.WriteJSON(w, http.StatusOK, resp, log)
+	}
+}
+
+func toJWK(k keys.Key) (models.JWK, bool) {
+	jwk := models.JWK{Kid: k.ID, Use: "sig", Alg: string(k.Algorithm)}
+	switch pub := k.Public().(type) {
+	case *rsa.PublicKey:
+		jwk.Kty = "RSA"
+		jwk.N = base64.RawURLEncoding.EncodeToString(pub.N.Bytes())
+		jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes())
+	case ed25519.PublicKey:
+		jwk.Kty = "OKP"
+		jwk.Crv = "Ed25519"
+		jwk.X = base64.RawURLEncoding.EncodeToString(pub)
+	default:
+		return models.JWK{}, false
+	}
+	return jwk, true
+}
@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/KnuffelGame/KnuffelGame/backend/services/AuthService/internal/jwt"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/AuthService/internal/keys"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/AuthService/internal/models"
+	jwtlib "github.com/golang-jwt/jwt/v5"
+)
+
+func newTestRing(t *testing.T, alg keys.Algorithm) *keys.Ring {
+	t.Helper()
+	ring := keys.NewRing(keys.NewMemory(), alg, 24*time.Hour, 15*time.Minute)
+	if err := ring.Sync(context.Background()); err != nil {
+		t.Fatalf("Sync error: %v", err)
+	}
+	return ring
+}
+
+func fetchJWKS(t *testing.T, ring *keys.Ring) models.JWKSResponse {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	JWKSHandler(ring)(rec, httptest.NewRequest(http.MethodGet, "/.well-known/jwks.json", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+	var resp models.JWKSResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	return resp
+}
+
+// A token from the generator verifies with nothing but the published key set
+func TestJWKS_VerifiesIssuedTokens(t *testing.T) {
+	for _, alg := range []keys.Algorithm{keys.EdDSA, keys.RS256} {
+		t.Run(string(alg), func(t *testing.T) {
+			ring := newTestRing(t, alg)
+			token, err := jwt.NewKeyGenerator(ring, 15*time.Minute).CreateToken("550e8400-e29b-41d4-a716-446655440000", "Alice", true)
+			if err != nil {
+				t.Fatalf("CreateToken error: %v", err)
+			}
+			set := fetchJWKS(t, ring)
+			if len(set.Keys) != 1 {
+				t.Fatalf("expected one key, got %+v", set.Keys)
+			}
+
+			_, err = jwtlib.Parse(token, func(tok *jwtlib.Token) (interface{}, error) {
+				for _, k := range set.Keys {
+					if k.Kid != tok.Header["kid"] || k.Alg != tok.Method.Alg() {
+						continue
+					}
+					switch k.Kty {
+					case "OKP":
+						x, _ := base64.RawURLEncoding.DecodeString(k.X)
+						return ed25519.PublicKey(x), nil
+					case "RSA":
+						n, _ := base64.RawURLEncoding.DecodeString(k.N)
+						e, _ := base64.RawURLEncoding.DecodeString(k.E)
+						return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+					}
+				}
+				return nil, jwtlib.ErrTokenUnverifiable
+			})
+			if err != nil {
+				t.Fatalf("expected the token to verify against the JWKS: %v", err)
+			}
+		})
+	}
+}
+
+func TestJWKS_HS256Only(t *testing.T) {
+	if set := fetchJWKS(t, nil); set.Keys == nil || len(set.Keys) != 0 {
+		t.Fatalf("expected an empty key set, got %+v", set.Keys)
+	}
+}
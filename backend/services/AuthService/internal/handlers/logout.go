@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/KnuffelGame/KnuffelGame/backend/libs/httpx"
+	"github.com/KnuffelGame/KnuffelGame/backend/libs/logger"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/AuthService/internal/jwt"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/AuthService/internal/refresh"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/AuthService/internal/revocation"
+)
+
+// LogoutHandler returns an http.HandlerFunc bound to a JWT validator, the revocation list, the refresh token manager and cookie settings.
+// Public endpoint, exposed through the API Gateway as POST /auth/logout. No body.
+// Revokes the JWT from the jwt cookie until it expires and the session of the refresh_token cookie.
+// Missing, invalid or expired tokens are skipped, so logging out twice succeeds.
+// Returns: 204 with both cookies cleared, 500 if a revocation could not be stored.
+func LogoutHandler(val *jwt.Validator, revoked *revocation.List, sessions *refresh.Manager, cookie CookieConfig) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log := logger.Logger(r.Context()).WithGroup("handler").With(slog.String("action", "logout"))
+
+		if c, err := r.Cookie(SessionCookieName); err == nil && c.Value != "" {
+			claims, err := val.ValidateToken(c.Value)
+			switch {
+			case err == nil && claims.ID != "":
+				if err := revoked.Revoke(r.Context(), claims.ID, claims.ExpiresAt.Time); err != nil {
+					log.Error("failed to revoke token", slog.String("error", err.Error()), slog.String("user_id", claims.Subject))
+					httpx.WriteInternalError(w, "Failed to log out", nil, log)
+					return
+				}
+				log.Info("token revoked", slog.String("user_id", claims.Subject))
+			case err == nil:
+				log.Info("token without jti cannot be revoked", slog.String("user_id", claims.Subject))
+			case errors.Is(err, jwt.ErrTokenRevoked):
+				// logged out before
+			default:
+				log.Info("skipping invalid token", slog.String("error", err.Error()))
+			}
+		}
+
+		if c, err := r.Cookie(RefreshCookieName); err == nil && c.Value != "" {
+			if err := sessions.Revoke(r.Context(), c.Value); err != nil {
+				log.Error("failed to revoke session", slog.String("error", err.Error()))
+				httpx.WriteInternalError(w, "Failed to log out", nil, log)
+				return
+			}
+		}
+
+		clearSessionCookies(w, cookie)
+		httpx.WriteNoContent(w)
+	}
+}
@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/KnuffelGame/KnuffelGame/backend/services/AuthService/internal/jwt"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/AuthService/internal/revocation"
+)
+
+func logoutWith(h http.HandlerFunc, cookies ...*http.Cookie) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/auth/logout", nil)
+	for _, c := range cookies {
+		req.AddCookie(c)
+	}
+	rec := httptest.NewRecorder()
+	h(rec, req)
+	return rec
+}
+
+func TestLogout_RevokesTokenAndSession(t *testing.T) {
+	gen, accounts, sessions := newSessionDeps()
+	list := revocation.NewList(nil)
+	val := jwt.NewValidator(testSecret).WithRevocations(list)
+	guest := cookiesByName(createGuest(t, GuestSessionHandler(gen, accounts, testNames, sessions, CookieConfig{}), "Alice"))
+
+	rec := logoutWith(LogoutHandler(val, list, sessions, CookieConfig{}), guest[SessionCookieName], guest[RefreshCookieName])
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d: %s", rec.Code, rec.Body.String())
+	}
+	for name, c := range cookiesByName(rec) {
+		if c.MaxAge >= 0 || c.Value != "" {
+			t.Fatalf("expected cookie %s to be cleared, got %+v", name, c)
+		}
+	}
+
+	if _, err := val.ValidateToken(guest[SessionCookieName].Value); err != jwt.ErrTokenRevoked {
+		t.Fatalf("expected ErrTokenRevoked after logout, got %v", err)
+	}
+	if rec := refreshWith(RefreshSessionHandler(gen, accounts, sessions, CookieConfig{}), guest[RefreshCookieName].Value); rec.Code != http.StatusUnauthorized {
+		t.Fatalf("expected the refresh token to be unusable after logout, got %d", rec.Code)
+	}
+}
+
+func TestLogout_WithoutCookies(t *testing.T) {
+	_, _, sessions := newSessionDeps()
+	list := revocation.NewList(nil)
+	h := LogoutHandler(jwt.NewValidator(testSecret).WithRevocations(list), list, sessions, CookieConfig{})
+
+	rec := logoutWith(h, &http.Cookie{Name: SessionCookieName, Value: "not-a-jwt"})
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d", rec.Code)
+	}
+	if rec := logoutWith(h); rec.Code != http.StatusNoContent {
+		t.Fatalf("expected 204 without cookies, got %d", rec.Code)
+	}
+}
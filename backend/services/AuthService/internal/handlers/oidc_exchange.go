@@ -0,0 +1,141 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/KnuffelGame/KnuffelGame/backend/libs/httpx"
+	"github.com/KnuffelGame/KnuffelGame/backend/libs/logger"
+	"github.com/KnuffelGame/KnuffelGame/backend/libs/username"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/AuthService/internal/jwt"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/AuthService/internal/models"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/AuthService/internal/oidc"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/AuthService/internal/users"
+)
+
+const (
+	minUsernameLen = 3
+	maxUsernameLen = 20
+	// fallbackUsername is used when the profile holds no usable name
+	fallbackUsername = "Player"
+)
+
+// OIDCExchangeHandler returns an http.HandlerFunc bound to a JWT generator, the user store, the username policy and the configured OIDC providers by name.
+// Request body: {"provider": "google", "id_token": "<ID token of the provider>"}.
+// The ID token is verified by the provider's verifier. Its identity resolves to the user it is linked to;
+// on first sign-in a registered user is created with an id derived from issuer and subject.
+// The token carries the stored guest state, false for every linked user.
+// Returns: 200 with OIDCExchangeResponse, 400 for invalid bodies or unknown providers,
+// 401 invalid_token if the ID token is rejected, 503 if the provider keys cannot be fetched.
+func OIDCExchangeHandler(gen *jwt.Generator, accounts users.Store, names *username.Policy, providers map[string]oidc.Verifier) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		r.Body = http.MaxBytesReader(w, r.Body, maxBodySize)
+		log := logger.Logger(r.Context()).WithGroup("handler").With(slog.String("action", "oidc_exchange"))
+		identity, ok := verifyIDToken(w, r, providers, log)
+		if !ok {
+			return
+		}
+
+		u, err := accounts.FindByIdentity(r.Context(), identity.Issuer, identity.Subject)
+		if errors.Is(err, users.ErrNotFound) {
+			now := time.Now()
+			u, err = accounts.Link(r.Context(),
+				users.User{ID: identity.UserID(), Username: usernameFromIdentity(identity, names), CreatedAt: now},
+				linkedIdentity(identity, now))
+		}
+		if err != nil {
+			log.Error("failed to load user", slog.String("error", err.Error()), slog.String("subject", identity.Subject))
+			httpx.WriteInternalError(w, "Failed to load user", nil, log)
+			return
+		}
+
+		userID := u.ID.String()
+		token, err := gen.CreateToken(userID, u.Username, u.Guest)
+		if err != nil {
+			log.Error("token generation failed", slog.String("error", err.Error()), slog.String("user_id", userID))
+			httpx.WriteError(w, http.StatusInternalServerError, "token_generation_failed", "Failed to generate JWT token", map[string]interface{}{"detail": err.Error()}, log)
+			return
+		}
+		log.Info("token generated", slog.String("user_id", userID), slog.Bool("guest", u.Guest))
+		httpx.WriteJSON(w, http.StatusOK, models.OIDCExchangeResponse{
+			Token:    token,
+			UserID:   userID,
+			Username: u.Username,
+			Email:    identity.Email,
+			IsGuest:  u.Guest,
+		}, log)
+	}
+}
+
+// verifyIDToken decodes an OIDCExchangeRequest from r and verifies its ID token with the named provider.
+// Writes the error response and returns false if the request or the token is rejected.
+func verifyIDToken(w http.ResponseWriter, r *http.Request, providers map[string]oidc.Verifier, log *slog.Logger) (oidc.Identity, bool) {
+	var req models.OIDCExchangeRequest
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&req); err != nil {
+		log.Warn("decode failed", slog.String("error", err.Error()))
+		httpx.WriteError(w, http.StatusBadRequest, "invalid_request", "Invalid JSON body", map[string]interface{}{"detail": err.Error()}, log)
+		return oidc.Identity{}, false
+	}
+	errMap := req.Validate()
+	if len(errMap) > 0 {
+		log.Info("validation failed", slog.Any("errors", errMap))
+		httpx.WriteError(w, http.StatusBadRequest, "invalid_request", "Validation failed", map[string]interface{}{"fields": errMap}, log)
+		return oidc.Identity{}, false
+	}
+	verifier, ok := providers[req.Provider]
+	if !ok {
+		log.Info("unsupported provider", slog.String("provider", req.Provider))
+		httpx.WriteError(w, http.StatusBadRequest, "invalid_request", "Unsupported provider", map[string]interface{}{"fields": map[string]string{"Provider": "oneof"}}, log)
+		return oidc.Identity{}, false
+	}
+
+	identity, err := verifier.Verify(r.Context(), req.IDToken)
+	if err != nil {
+		if errors.Is(err, oidc.ErrInvalidToken) {
+			log.Info("id token rejected", slog.String("provider", req.Provider), slog.String("error", err.Error()))
+			httpx.WriteError(w, http.StatusUnauthorized, "invalid_token", "OIDC token validation failed", nil, log)
+			return oidc.Identity{}, false
+		}
+		log.Error("failed to verify id token", slog.String("provider", req.Provider), slog.String("error", err.Error()))
+		httpx.WriteError(w, http.StatusServiceUnavailable, "service_unavailable", "OIDC provider temporarily unavailable", nil, log)
+		return oidc.Identity{}, false
+	}
+	return identity, true
+}
+
+func linkedIdentity(id oidc.Identity, now time.Time) users.Identity {
+	return users.Identity{Issuer: id.Issuer, Subject: id.Subject, Email: id.Email, LinkedAt: now}
+}
+
+// usernameFromIdentity derives a username matching the username rules (3-20 letters, digits and spaces)
+// from the profile: preferred_username, name or the local part of the email, in that order.
+// Candidates are normalized first; those containing a blocked word are skipped.
+func usernameFromIdentity(id oidc.Identity, names *username.Policy) string {
+	local, _, _ := strings.Cut(id.Email, "@")
+	for _, candidate := range []string{id.PreferredUsername, id.Name, local} {
+		var b strings.Builder
+		for _, r := range username.Normalize(candidate) {
+			switch {
+			case r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)):
+				b.WriteRune(r)
+			case unicode.IsSpace(r) || r == '.' || r == '_' || r == '-':
+				b.WriteRune(' ')
+			}
+		}
+		name := strings.Join(strings.Fields(b.String()), " ")
+		if len(name) > maxUsernameLen {
+			name = strings.TrimSpace(name[:maxUsernameLen])
+		}
+		if len(name) >= minUsernameLen && !names.Blocked(name) {
+			return name
+		}
+	}
+	return fallbackUsername
+}
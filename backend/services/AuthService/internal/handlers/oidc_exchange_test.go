@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/KnuffelGame/KnuffelGame/backend/services/AuthService/internal/jwt"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/AuthService/internal/models"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/AuthService/internal/oidc"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/AuthService/internal/oidc/oidctest"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/AuthService/internal/users"
+)
+
+func testProviders(iss *oidctest.Issuer) map[string]oidc.Verifier {
+	return map[string]oidc.Verifier{"google": oidc.NewJWKSVerifier(iss.URL(), oidctest.Audience, oidc.NewRemoteKeySet(iss.JWKSURL(), http.DefaultClient))}
+}
+
+func newExchangeHandler(iss *oidctest.Issuer) http.HandlerFunc {
+	return OIDCExchangeHandler(jwt.NewGenerator(testSecret, 15*time.Minute), users.NewMemory(), testNames, testProviders(iss))
+}
+
+func exchange(h http.HandlerFunc, provider, idToken string) *httptest.ResponseRecorder {
+	body, _ := json.Marshal(map[string]string{"provider": provider, "id_token": idToken})
+	rec := httptest.NewRecorder()
+	h(rec, httptest.NewRequest(http.MethodPost, "/internal/oidc-exchange", bytes.NewReader(body)))
+	return rec
+}
+
+func TestOIDCExchange_Success(t *testing.T) {
+	iss := oidctest.NewIssuer(t)
+	h := newExchangeHandler(iss)
+
+	rec := exchange(h, "google", iss.Sign(t, iss.Claims("alice")))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var resp models.OIDCExchangeResponse
+	_ = json.Unmarshal(rec.Body.Bytes(), &resp)
+	if resp.IsGuest || resp.Username != "Alice Example" || resp.Email != "alice@example.com" {
+		t.Fatalf("unexpected response %+v", resp)
+	}
+	claims, err := jwt.NewValidator(testSecret).ValidateToken(resp.Token)
+	if err != nil || claims.Guest || claims.Subject != resp.UserID {
+		t.Fatalf("expected a non-guest token for %s, got %+v (%v)", resp.UserID, claims, err)
+	}
+
+	// signing in again with the same account yields the same user
+	var again models.OIDCExchangeResponse
+	_ = json.Unmarshal(exchange(h, "google", iss.Sign(t, iss.Claims("alice"))).Body.Bytes(), &again)
+	if again.UserID != resp.UserID {
+		t.Fatalf("expected a stable user id, got %s and %s", resp.UserID, again.UserID)
+	}
+}
+
+func TestOIDCExchange_Rejected(t *testing.T) {
+	iss := oidctest.NewIssuer(t)
+	h := newExchangeHandler(iss)
+	claims := iss.Claims("alice")
+	claims["aud"] = "another-client"
+
+	rec := exchange(h, "google", iss.Sign(t, claims))
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401, got %d", rec.Code)
+	}
+	var resp models.ErrorResponse
+	_ = json.Unmarshal(rec.Body.Bytes(), &resp)
+	if resp.Error != "invalid_token" {
+		t.Fatalf("expected invalid_token, got %+v", resp)
+	}
+}
+
+func TestOIDCExchange_UnknownProvider(t *testing.T) {
+	iss := oidctest.NewIssuer(t)
+	if rec := exchange(newExchangeHandler(iss), "github", iss.Sign(t, iss.Claims("alice"))); rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", rec.Code)
+	}
+}
+
+func TestUsernameFromIdentity(t *testing.T) {
+	tests := []struct {
+		identity oidc.Identity
+		want     string
+	}{
+		{oidc.Identity{PreferredUsername: "alice_w", Name: "Alice"}, "alice w"},
+		{oidc.Identity{Name: "Zoë  O'Brien"}, "Zoe OBrien"},
+		{oidc.Identity{PreferredUsername: "badword99", Name: "Carol"}, "Carol"},
+		{oidc.Identity{Name: "李", Email: "bob.smith@example.com"}, "bob smith"},
+		{oidc.Identity{Name: "Maximilian Alexander Mustermann"}, "Maximilian Alexander"},
+		{oidc.Identity{Email: "x@example.com"}, "Player"},
+	}
+	for _, tt := range tests {
+		if got := usernameFromIdentity(tt.identity, testNames); got != tt.want {
+			t.Errorf("usernameFromIdentity(%+v) = %q, want %q", tt.identity, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/KnuffelGame/KnuffelGame/backend/libs/httpx"
+	"github.com/KnuffelGame/KnuffelGame/backend/libs/logger"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/AuthService/internal/jwt"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/AuthService/internal/models"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/AuthService/internal/refresh"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/AuthService/internal/users"
+	"github.com/google/uuid"
+)
+
+// RefreshSessionHandler returns an http.HandlerFunc bound to a JWT generator, the user store, the refresh token manager and cookie settings.
+// Public endpoint, exposed through the API Gateway as POST /auth/refresh.
+// Requires the refresh_token cookie, no body. The refresh token is single use and replaced by a new one.
+// Username and guest flag of the new JWT are read from the user store, so an upgraded guest gets an account token.
+// Returns: 200 with SessionResponse and new JWT and refresh token cookies,
+// 401 if the refresh token is missing, unknown, expired or revoked (both cookies are cleared).
+// Presenting a refresh token a second time revokes every token of its session.
+func RefreshSessionHandler(gen *jwt.Generator, accounts users.Store, sessions *refresh.Manager, cookie CookieConfig) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log := logger.Logger(r.Context()).WithGroup("handler").With(slog.String("action", "refresh_session"))
+		c, err := r.Cookie(RefreshCookieName)
+		if err != nil || c.Value == "" {
+			log.Info("missing refresh cookie")
+			httpx.WriteUnauthorized(w, "Refresh token required", log)
+			return
+		}
+
+		session, next, err := sessions.Rotate(r.Context(), c.Value)
+		if err != nil {
+			switch {
+			case errors.Is(err, refresh.ErrReused):
+				log.Warn("refresh token reused, session revoked")
+			case errors.Is(err, refresh.ErrNotFound), errors.Is(err, refresh.ErrExpired), errors.Is(err, refresh.ErrRevoked):
+				log.Info("refresh token rejected", slog.String("error", err.Error()))
+			default:
+				log.Error("failed to rotate refresh token", slog.String("error", err.Error()))
+				httpx.WriteInternalError(w, "Failed to refresh session", nil, log)
+				return
+			}
+			clearSessionCookies(w, cookie)
+			httpx.WriteUnauthorized(w, "Invalid or expired refresh token", log)
+			return
+		}
+
+		// sessions started before the user store existed have no user record
+		username, guest := session.Username, session.Guest
+		if id, err := uuid.Parse(session.UserID); err == nil {
+			u, err := accounts.Get(r.Context(), id)
+			switch {
+			case err == nil:
+				username, guest = u.Username, u.Guest
+			case !errors.Is(err, users.ErrNotFound):
+				log.Error("failed to load user", slog.String("error", err.Error()), slog.String("user_id", session.UserID))
+				httpx.WriteInternalError(w, "Failed to refresh session", nil, log)
+				return
+			}
+		}
+
+		expiresAt := time.Now().Add(gen.Lifetime())
+		token, err := gen.CreateToken(session.UserID, username, guest)
+		if err != nil {
+			log.Error("token generation failed", slog.String("error", err.Error()), slog.String("user_id", session.UserID))
+			httpx.WriteError(w, http.StatusInternalServerError, "token_generation_failed", "Failed to generate JWT token", map[string]interface{}{"detail": err.Error()}, log)
+			return
+		}
+		setSessionCookies(w, cookie, token, gen.Lifetime(), next, sessions.TTL())
+		log.Info("session refreshed", slog.String("user_id", session.UserID))
+		httpx.WriteJSON(w, http.StatusOK, models.SessionResponse{
+			UserID:    session.UserID,
+			Username:  username,
+			IsGuest:   guest,
+			ExpiresAt: expiresAt.UTC().Truncate(time.Second),
+		}, log)
+	}
+}
@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/KnuffelGame/KnuffelGame/backend/services/AuthService/internal/jwt"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/AuthService/internal/models"
+)
+
+func refreshWith(h http.HandlerFunc, token string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/auth/refresh", nil)
+	if token != "" {
+		req.AddCookie(&http.Cookie{Name: RefreshCookieName, Value: token})
+	}
+	rec := httptest.NewRecorder()
+	h(rec, req)
+	return rec
+}
+
+func TestRefreshSession_Success(t *testing.T) {
+	gen, accounts, sessions := newSessionDeps()
+	guest := createGuest(t, GuestSessionHandler(gen, accounts, testNames, sessions, CookieConfig{}), "Alice")
+	var created models.SessionResponse
+	_ = json.Unmarshal(guest.Body.Bytes(), &created)
+
+	rec := refreshWith(RefreshSessionHandler(gen, accounts, sessions, CookieConfig{}), cookiesByName(guest)[RefreshCookieName].Value)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var resp models.SessionResponse
+	_ = json.Unmarshal(rec.Body.Bytes(), &resp)
+	if resp.UserID != created.UserID || resp.Username != "Alice" || !resp.IsGuest {
+		t.Fatalf("expected the same user after refresh, got %+v", resp)
+	}
+
+	cookies := cookiesByName(rec)
+	claims, err := jwt.NewValidator(testSecret).ValidateToken(cookies[SessionCookieName].Value)
+	if err != nil || claims.Subject != created.UserID {
+		t.Fatalf("expected a new valid token for the same user, got %+v (%v)", claims, err)
+	}
+	if next := cookies[RefreshCookieName]; next == nil || next.Value == "" || next.Value == cookiesByName(guest)[RefreshCookieName].Value {
+		t.Fatalf("expected a rotated refresh token, got %+v", next)
+	}
+}
+
+func TestRefreshSession_Reuse(t *testing.T) {
+	gen, accounts, sessions := newSessionDeps()
+	h := RefreshSessionHandler(gen, accounts, sessions, CookieConfig{})
+	guest := createGuest(t, GuestSessionHandler(gen, accounts, testNames, sessions, CookieConfig{}), "Alice")
+	first := cookiesByName(guest)[RefreshCookieName].Value
+
+	second := cookiesByName(refreshWith(h, first))[RefreshCookieName].Value
+	rec := refreshWith(h, first)
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401 on reuse, got %d", rec.Code)
+	}
+	if c := cookiesByName(rec)[SessionCookieName]; c == nil || c.MaxAge >= 0 {
+		t.Fatalf("expected the session cookie to be cleared, got %+v", c)
+	}
+	if rec := refreshWith(h, second); rec.Code != http.StatusUnauthorized {
+		t.Fatalf("expected the whole session to be revoked, got %d", rec.Code)
+	}
+}
+
+func TestRefreshSession_MissingCookie(t *testing.T) {
+	gen, accounts, sessions := newSessionDeps()
+	rec := refreshWith(RefreshSessionHandler(gen, accounts, sessions, CookieConfig{}), "")
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401, got %d", rec.Code)
+	}
+}
+
+func TestRefreshSession_UnknownToken(t *testing.T) {
+	gen, accounts, sessions := newSessionDeps()
+	rec := refreshWith(RefreshSessionHandler(gen, accounts, sessions, CookieConfig{}), "forged")
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401, got %d", rec.Code)
+	}
+}
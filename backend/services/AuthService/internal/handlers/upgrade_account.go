@@ -0,0 +1,119 @@
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/KnuffelGame/KnuffelGame/backend/libs/httpx"
+	"github.com/KnuffelGame/KnuffelGame/backend/libs/logger"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/AuthService/internal/jwt"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/AuthService/internal/models"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/AuthService/internal/oidc"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/AuthService/internal/refresh"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/AuthService/internal/revocation"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/AuthService/internal/users"
+	"github.com/google/uuid"
+)
+
+// UpgradeAccountHandler returns an http.HandlerFunc turning the guest of the current session into a registered user.
+// Public endpoint, exposed through the API Gateway as POST /auth/upgrade.
+// Requires the jwt cookie of a guest. Request body: {"provider": "google", "id_token": "..."}, as for /internal/oidc-exchange.
+// The identity of the ID token is linked to the guest; user id and username stay the same, so lobbies and games stay attached.
+// The guest tokens are revoked and a new session is started with an account token.
+// Returns: 200 with SessionResponse and new cookies, 401 without a valid session or if the ID token is rejected,
+// 409 already_registered if the user has an account, 409 identity_taken if the identity belongs to another user.
+func UpgradeAccountHandler(gen *jwt.Generator, val *jwt.Validator, accounts users.Store, revoked *revocation.List, sessions *refresh.Manager, cookie CookieConfig, providers map[string]oidc.Verifier) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		r.Body = http.MaxBytesReader(w, r.Body, maxBodySize)
+		log := logger.Logger(r.Context()).WithGroup("handler").With(slog.String("action", "upgrade_account"))
+
+		c, err := r.Cookie(SessionCookieName)
+		if err != nil || c.Value == "" {
+			log.Info("missing session cookie")
+			httpx.WriteUnauthorized(w, "Authentication required", log)
+			return
+		}
+		claims, err := val.ValidateToken(c.Value)
+		if err != nil {
+			log.Info("token rejected", slog.String("error", err.Error()))
+			httpx.WriteUnauthorized(w, "Invalid or expired authentication token", log)
+			return
+		}
+		userID, err := uuid.Parse(claims.Subject)
+		if err != nil {
+			log.Info("token subject is not a user id", slog.String("user_id", claims.Subject))
+			httpx.WriteUnauthorized(w, "Invalid or expired authentication token", log)
+			return
+		}
+		log = log.With(slog.String("user_id", claims.Subject))
+
+		// the token may predate the upgrade, the store is authoritative
+		u, err := accounts.Get(r.Context(), userID)
+		switch {
+		case errors.Is(err, users.ErrNotFound):
+			// guest from before the user store existed, Link creates the record
+			u = users.User{ID: userID, Username: claims.Username, Guest: true, CreatedAt: claims.IssuedAt.Time}
+		case err != nil:
+			log.Error("failed to load user", slog.String("error", err.Error()))
+			httpx.WriteInternalError(w, "Failed to load user", nil, log)
+			return
+		}
+		if !u.Guest {
+			log.Info("user is already registered")
+			httpx.WriteError(w, http.StatusConflict, "already_registered", "User is already registered", nil, log)
+			return
+		}
+
+		identity, ok := verifyIDToken(w, r, providers, log)
+		if !ok {
+			return
+		}
+		now := time.Now()
+		u, err = accounts.Link(r.Context(), u, linkedIdentity(identity, now))
+		if err != nil {
+			if errors.Is(err, users.ErrIdentityTaken) {
+				log.Info("identity linked to another user")
+				httpx.WriteError(w, http.StatusConflict, "identity_taken", "This account is already registered, sign in instead", nil, log)
+				return
+			}
+			log.Error("failed to link identity", slog.String("error", err.Error()))
+			httpx.WriteInternalError(w, "Failed to upgrade user", nil, log)
+			return
+		}
+
+		// end the guest session, its tokens still carry guest=true
+		if claims.ID != "" {
+			if err := revoked.Revoke(r.Context(), claims.ID, claims.ExpiresAt.Time); err != nil {
+				log.Error("failed to revoke guest token", slog.String("error", err.Error()))
+			}
+		}
+		if rc, err := r.Cookie(RefreshCookieName); err == nil && rc.Value != "" {
+			if err := sessions.Revoke(r.Context(), rc.Value); err != nil {
+				log.Error("failed to revoke guest session", slog.String("error", err.Error()))
+			}
+		}
+
+		token, err := gen.CreateToken(u.ID.String(), u.Username, u.Guest)
+		if err != nil {
+			log.Error("token generation failed", slog.String("error", err.Error()))
+			httpx.WriteError(w, http.StatusInternalServerError, "token_generation_failed", "Failed to generate JWT token", map[string]interface{}{"detail": err.Error()}, log)
+			return
+		}
+		refreshToken, err := sessions.Issue(r.Context(), u.ID.String(), u.Username, u.Guest)
+		if err != nil {
+			log.Error("refresh token generation failed", slog.String("error", err.Error()))
+			httpx.WriteInternalError(w, "Failed to create session", nil, log)
+			return
+		}
+		setSessionCookies(w, cookie, token, gen.Lifetime(), refreshToken, sessions.TTL())
+		log.Info("guest upgraded")
+		httpx.WriteJSON(w, http.StatusOK, models.SessionResponse{
+			UserID:    u.ID.String(),
+			Username:  u.Username,
+			IsGuest:   u.Guest,
+			ExpiresAt: now.Add(gen.Lifetime()).UTC().Truncate(time.Second),
+		}, log)
+	}
+}
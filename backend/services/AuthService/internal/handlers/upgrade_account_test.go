@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/KnuffelGame/KnuffelGame/backend/services/AuthService/internal/jwt"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/AuthService/internal/models"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/AuthService/internal/oidc/oidctest"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/AuthService/internal/revocation"
+)
+
+func upgradeWith(h http.HandlerFunc, idToken string, cookies ...*http.Cookie) *httptest.ResponseRecorder {
+	body, _ := json.Marshal(map[string]string{"provider": "google", "id_token": idToken})
+	req := httptest.NewRequest(http.MethodPost, "/auth/upgrade", bytes.NewReader(body))
+	for _, c := range cookies {
+		req.AddCookie(c)
+	}
+	rec := httptest.NewRecorder()
+	h(rec, req)
+	return rec
+}
+
+func TestUpgradeAccount_KeepsUserID(t *testing.T) {
+	iss := oidctest.NewIssuer(t)
+	gen, accounts, sessions := newSessionDeps()
+	list := revocation.NewList(nil)
+	val := jwt.NewValidator(testSecret).WithRevocations(list)
+	h := UpgradeAccountHandler(gen, val, accounts, list, sessions, CookieConfig{}, testProviders(iss))
+
+	guestRec := createGuest(t, GuestSessionHandler(gen, accounts, testNames, sessions, CookieConfig{}), "Alice")
+	var guest models.SessionResponse
+	_ = json.Unmarshal(guestRec.Body.Bytes(), &guest)
+	cookies := cookiesByName(guestRec)
+	// a session of the same guest on another device
+	otherDevice, _ := sessions.Issue(context.Background(), guest.UserID, "Alice", true)
+
+	rec := upgradeWith(h, iss.Sign(t, iss.Claims("alice")), cookies[SessionCookieName], cookies[RefreshCookieName])
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var resp models.SessionResponse
+	_ = json.Unmarshal(rec.Body.Bytes(), &resp)
+	if resp.UserID != guest.UserID || resp.Username != "Alice" || resp.IsGuest {
+		t.Fatalf("expected the guest to become a registered user with the same id, got %+v", resp)
+	}
+	claims, err := val.ValidateToken(cookiesByName(rec)[SessionCookieName].Value)
+	if err != nil || claims.Guest || claims.Subject != guest.UserID {
+		t.Fatalf("expected an account token, got %+v (%v)", claims, err)
+	}
+	if _, err := val.ValidateToken(cookies[SessionCookieName].Value); err != jwt.ErrTokenRevoked {
+		t.Fatalf("expected the guest token to be revoked, got %v", err)
+	}
+
+	// other sessions of the user get account tokens on their next refresh
+	refreshed := refreshWith(RefreshSessionHandler(gen, accounts, sessions, CookieConfig{}), otherDevice)
+	var after models.SessionResponse
+	_ = json.Unmarshal(refreshed.Body.Bytes(), &after)
+	if refreshed.Code != http.StatusOK || after.IsGuest {
+		t.Fatalf("expected a non-guest session after refresh, got %d %+v", refreshed.Code, after)
+	}
+
+	// signing in with the identity later resolves to the same user
+	var signIn models.OIDCExchangeResponse
+	_ = json.Unmarshal(exchange(OIDCExchangeHandler(gen, accounts, testNames, testProviders(iss)), "google", iss.Sign(t, iss.Claims("alice"))).Body.Bytes(), &signIn)
+	if signIn.UserID != guest.UserID || signIn.IsGuest {
+		t.Fatalf("expected sign-in to resolve to the upgraded user, got %+v", signIn)
+	}
+
+	// upgrading again is rejected
+	again := upgradeWith(h, iss.Sign(t, iss.Claims("alice")), cookiesByName(rec)[SessionCookieName])
+	if again.Code != http.StatusConflict {
+		t.Fatalf("expected 409 for a registered user, got %d", again.Code)
+	}
+}
+
+func TestUpgradeAccount_IdentityTaken(t *testing.T) {
+	iss := oidctest.NewIssuer(t)
+	gen, accounts, sessions := newSessionDeps()
+	list := revocation.NewList(nil)
+	h := UpgradeAccountHandler(gen, jwt.NewValidator(testSecret), accounts, list, sessions, CookieConfig{}, testProviders(iss))
+
+	first := cookiesByName(createGuest(t, GuestSessionHandler(gen, accounts, testNames, sessions, CookieConfig{}), "Alice"))
+	if rec := upgradeWith(h, iss.Sign(t, iss.Claims("alice")), first[SessionCookieName]); rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+	second := cookiesByName(createGuest(t, GuestSessionHandler(gen, accounts, testNames, sessions, CookieConfig{}), "Bob"))
+	rec := upgradeWith(h, iss.Sign(t, iss.Claims("alice")), second[SessionCookieName])
+	if rec.Code != http.StatusConflict {
+		t.Fatalf("expected 409, got %d", rec.Code)
+	}
+	var resp models.ErrorResponse
+	_ = json.Unmarshal(rec.Body.Bytes(), &resp)
+	if resp.Error != "identity_taken" {
+		t.Fatalf("expected identity_taken, got %+v", resp)
+	}
+}
+
+func TestUpgradeAccount_RequiresSession(t *testing.T) {
+	iss := oidctest.NewIssuer(t)
+	gen, accounts, sessions := newSessionDeps()
+	h := UpgradeAccountHandler(gen, jwt.NewValidator(testSecret), accounts, revocation.NewList(nil), sessions, CookieConfig{}, testProviders(iss))
+	if rec := upgradeWith(h, iss.Sign(t, iss.Claims("alice"))); rec.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401, got %d", rec.Code)
+	}
+}
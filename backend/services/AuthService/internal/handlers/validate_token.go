@@ -60,6 +60,8 @@ func mapValidationError(err error) string {
 		return "invalid issuer"
 	case jwt.ErrMissingClaims:
 		return "missing claims"
+	case jwt.ErrTokenRevoked:
+		return "token revoked"
 	default:
 		return "invalid format"
 	}
@@ -2,6 +2,7 @@ package handlers_test
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
@@ -10,6 +11,7 @@ import (
 
 	"github.com/KnuffelGame/KnuffelGame/backend/services/AuthService/internal/handlers"
 	"github.com/KnuffelGame/KnuffelGame/backend/services/AuthService/internal/jwt"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/AuthService/internal/revocation"
 	"github.com/go-chi/chi/v5"
 	jwtlib "github.com/golang-jwt/jwt/v5"
 )
@@ -21,7 +23,7 @@ func makeRouter(val *jwt.Validator) http.Handler {
 }
 
 func TestValidateTokenHandler_Success(t *testing.T) {
-	gen := jwt.NewGenerator("12345678901234567890123456789012")
+	gen := jwt.NewGenerator("12345678901234567890123456789012", 24*time.Hour)
 	val := jwt.NewValidator("12345678901234567890123456789012")
 	r := makeRouter(val)
 	tok, err := gen.CreateToken("usr_valid", "Alice", true)
@@ -140,7 +142,7 @@ func TestValidateTokenHandler_MissingClaims(t *testing.T) {
 }
 
 func TestValidateTokenHandler_UnknownField(t *testing.T) {
-	gen := jwt.NewGenerator("12345678901234567890123456789012")
+	gen := jwt.NewGenerator("12345678901234567890123456789012", 24*time.Hour)
 	val := jwt.NewValidator("12345678901234567890123456789012")
 	r := makeRouter(val)
 	tok, err := gen.CreateToken("usr_extra", "Alice", true)
@@ -160,3 +162,28 @@ func TestValidateTokenHandler_UnknownField(t *testing.T) {
 		t.Fatalf("expected invalid_request error, got %+v", resp)
 	}
 }
+
+func TestValidateTokenHandler_Revoked(t *testing.T) {
+	gen := jwt.NewGenerator("12345678901234567890123456789012", 24*time.Hour)
+	list := revocation.NewList(nil)
+	val := jwt.NewValidator("12345678901234567890123456789012").WithRevocations(list)
+	r := makeRouter(val)
+	tok, err := gen.CreateToken("usr_revoked", "Alice", true)
+	if err != nil {
+		t.Fatalf("token create error: %v", err)
+	}
+	claims, _ := val.ValidateToken(tok)
+	_ = list.Revoke(context.Background(), claims.ID, claims.ExpiresAt.Time)
+	body, _ := json.Marshal(map[string]string{"token": tok})
+	req := httptest.NewRequest(http.MethodPost, "/internal/validate", bytes.NewReader(body))
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401, got %d", rec.Code)
+	}
+	var resp map[string]interface{}
+	_ = json.Unmarshal(rec.Body.Bytes(), &resp)
+	if resp["valid"] != false || resp["error"] != "token revoked" {
+		t.Fatalf("expected token revoked, got %+v", resp)
+	}
+}
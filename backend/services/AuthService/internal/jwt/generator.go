@@ -7,7 +7,9 @@ import (
 	"time"
 
 	"github.com/KnuffelGame/KnuffelGame/backend/libs/logger"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/AuthService/internal/keys"
 	jwtlib "github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 )
 
 const (
@@ -25,19 +27,24 @@ var (
 //  sub  -> user id
 //  name -> username
 //  iat  -> issued at (unix)
-//  exp  -> expiry (unix) (24h)
+//  exp  -> expiry (unix) (iat + lifetime, ACCESS_TOKEN_TTL)
 //  iss  -> knuffel-auth-service
-// Signed with HS256 using secret from environment variable JWT_SECRET.
+//  jti  -> random token id (UUID4), used to revoke the token
+// Signed with the current key of a keys.Ring (RS256/EdDSA, kid header set),
+// or with HS256 using secret from environment variable JWT_SECRET.
 
 type Generator struct {
-	secret []byte
-	issuer string
-	log    *slog.Logger
+	secret   []byte
+	ring     *keys.Ring
+	issuer   string
+	lifetime time.Duration
+	log      *slog.Logger
 }
 
-// NewGenerator builds a new Generator. If secret argument empty, it falls back to env var JWT_SECRET.
+// NewGenerator builds a new Generator issuing tokens valid for lifetime.
+// If secret argument empty, it falls back to env var JWT_SECRET.
 // Warns when secret missing or weak.
-func NewGenerator(secret string) *Generator {
+func NewGenerator(secret string, lifetime time.Duration) *Generator {
 	if secret == "" {
 		secret = os.Getenv("JWT_SECRET")
 	}
@@ -47,7 +54,18 @@ func NewGenerator(secret string) *Generator {
 	} else if len(secret) < minSecretLen {
 		l.Warn("jwt secret length below recommended minimum", slog.Int("length", len(secret)))
 	}
-	return &Generator{secret: []byte(secret), issuer: Issuer, log: l}
+	return &Generator{secret: []byte(secret), issuer: Issuer, lifetime: lifetime, log: l}
+}
+
+// NewKeyGenerator builds a Generator signing with the current signing key of ring, issuing tokens valid for lifetime.
+func NewKeyGenerator(ring *keys.Ring, lifetime time.Duration) *Generator {
+	l := logger.Default().WithGroup("jwt").With(slog.String("component", "generator"))
+	return &Generator{ring: ring, issuer: Issuer, lifetime: lifetime, log: l}
+}
+
+// Lifetime returns the time from issue to expiry of the tokens created by g.
+func (g *Generator) Lifetime() time.Duration {
+	return g.lifetime
 }
 
 // Claims defines the token claims we embed.
@@ -59,6 +77,9 @@ type Claims struct {
 
 // CreateToken returns a signed JWT string or error if secret missing or signing fails.
 func (g *Generator) CreateToken(userID, username string, guest bool) (string, error) {
+	if g.ring != nil {
+		return g.createKeyToken(userID, username, guest)
+	}
 	if len(g.secret) == 0 {
 		g.log.Error("token generation failed: secret missing", slog.String("user_id", userID))
 		return "", ErrSecretMissing
@@ -67,25 +88,53 @@ func (g *Generator) CreateToken(userID, username string, guest bool) (string, er
 		g.log.Error("token generation failed: secret weak", slog.String("user_id", userID))
 		return "", ErrSecretWeak
 	}
+	token := jwtlib.NewWithClaims(jwtlib.SigningMethodHS256, g.claims(userID, username, guest))
+	signed, err := token.SignedString(g.secret)
+	if err != nil {
+		g.log.Error("token signing failed", slog.String("user_id", userID), slog.String("error", err.Error()))
+		return "", err
+	}
+	g.log.Debug("token created", slog.String("user_id", userID), slog.String("username", username))
+	return signed, nil
+}
+
+// createKeyToken signs with the current key of the ring and names it in the kid header
+func (g *Generator) createKeyToken(userID, username string, guest bool) (string, error) {
+	key, err := g.ring.SigningKey()
+	if err != nil {
+		g.log.Error("token generation failed: no signing key", slog.String("user_id", userID))
+		return "", err
+	}
+	token := jwtlib.NewWithClaims(signingMethod(key.Algorithm), g.claims(userID, username, guest))
+	token.Header["kid"] = key.ID
+	signed, err := token.SignedString(key.Private)
+	if err != nil {
+		g.log.Error("token signing failed", slog.String("user_id", userID), slog.String("kid", key.ID), slog.String("error", err.Error()))
+		return "", err
+	}
+	g.log.Debug("token created", slog.String("user_id", userID), slog.String("username", username), slog.String("kid", key.ID))
+	return signed, nil
+}
+
+func (g *Generator) claims(userID, username string, guest bool) Claims {
 	issuedAt := time.Now()
-	expiresAt := issuedAt.Add(24 * time.Hour)
-	claims := Claims{
+	return Claims{
 		Username: username,
 		Guest:    guest,
 		RegisteredClaims: jwtlib.RegisteredClaims{
+			ID:        uuid.NewString(),
 			Subject:   userID,
 			Issuer:    g.issuer,
 			IssuedAt:  jwtlib.NewNumericDate(issuedAt),
-			ExpiresAt: jwtlib.NewNumericDate(expiresAt),
+			ExpiresAt: jwtlib.NewNumericDate(issuedAt.Add(g.lifetime)),
 		},
 	}
+}
 
-	token := jwtlib.NewWithClaims(jwtlib.SigningMethodHS256, claims)
-	signed, err := token.SignedString(g.secret)
-	if err != nil {
-		g.log.Error("token signing failed", slog.String("user_id", userID), slog.String("error", err.Error()))
-		return "", err
+// signingMethod maps a key algorithm to its JWS signing method
+func signingMethod(alg keys.Algorithm) jwtlib.SigningMethod {
+	if alg == keys.EdDSA {
+		return jwtlib.SigningMethodEdDSA
 	}
-	g.log.Debug("token created", slog.String("user_id", userID), slog.String("username", username))
-	return signed, nil
+	return jwtlib.SigningMethodRS256
 }
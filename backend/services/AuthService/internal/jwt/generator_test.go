@@ -10,7 +10,7 @@ import (
 
 func TestCreateToken(t *testing.T) {
 	os.Setenv("JWT_SECRET", "12345678901234567890123456789012abcdefgh")
-	gen := NewGenerator("")
+	gen := NewGenerator("", 24*time.Hour)
 
 	tokenStr, err := gen.CreateToken("usr_test123", "Alice", true)
 	if err != nil {
@@ -58,3 +58,39 @@ func TestCreateToken(t *testing.T) {
 		t.Errorf("expected guest true, got %v", claims["guest"])
 	}
 }
+
+func TestCreateToken_ConfiguredLifetime(t *testing.T) {
+	gen := NewGenerator("12345678901234567890123456789012", 15*time.Minute)
+	tokenStr, err := gen.CreateToken("usr_test123", "Alice", true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	claims, err := NewValidator("12345678901234567890123456789012").ValidateToken(tokenStr)
+	if err != nil {
+		t.Fatalf("validate error: %v", err)
+	}
+	if got := claims.ExpiresAt.Sub(claims.IssuedAt.Time); got != 15*time.Minute {
+		t.Errorf("expected exp-iat == 15m, got %s", got)
+	}
+}
+
+func TestCreateToken_UniqueID(t *testing.T) {
+	secret := "12345678901234567890123456789012"
+	gen := NewGenerator(secret, 24*time.Hour)
+	val := NewValidator(secret)
+	seen := make(map[string]bool)
+	for i := 0; i < 2; i++ {
+		tokenStr, err := gen.CreateToken("usr_jti", "Alice", true)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		claims, err := val.ValidateToken(tokenStr)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if claims.ID == "" || seen[claims.ID] {
+			t.Fatalf("expected a unique jti, got %q", claims.ID)
+		}
+		seen[claims.ID] = true
+	}
+}
@@ -6,6 +6,8 @@ import (
 	"os"
 
 	"github.com/KnuffelGame/KnuffelGame/backend/libs/logger"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/AuthService/internal/keys"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/AuthService/internal/revocation"
 	jwtlib "github.com/golang-jwt/jwt/v5"
 )
 
@@ -15,12 +17,17 @@ var (
 	ErrMalformedToken   = errors.New("invalid format")
 	ErrInvalidIssuer    = errors.New("invalid issuer")
 	ErrMissingClaims    = errors.New("missing claims")
+	ErrTokenRevoked     = errors.New("token revoked")
 )
 
+// Validator verifies tokens. HS256 tokens are verified with the shared secret,
+// RS256/EdDSA tokens with the key named by their kid header.
 type Validator struct {
-	secret []byte
-	issuer string
-	log    *slog.Logger
+	secret  []byte
+	ring    *keys.Ring
+	revoked *revocation.List
+	issuer  string
+	log     *slog.Logger
 }
 
 // NewValidator builds validator; warns on missing/weak secret.
@@ -37,16 +44,25 @@ func NewValidator(secret string) *Validator {
 	return &Validator{secret: []byte(secret), issuer: Issuer, log: l}
 }
 
+// NewKeyValidator builds a validator selecting verification keys from ring by kid.
+// A non-empty secret additionally accepts HS256 tokens, for tokens issued before the switch to asymmetric keys.
+func NewKeyValidator(ring *keys.Ring, secret string) *Validator {
+	l := logger.Default().WithGroup("jwt").With(slog.String("component", "validator"))
+	return &Validator{secret: []byte(secret), ring: ring, issuer: Issuer, log: l}
+}
+
+// WithRevocations makes v reject tokens whose jti is on list with ErrTokenRevoked.
+// Tokens without jti, issued before revocation existed, cannot be revoked.
+func (v *Validator) WithRevocations(list *revocation.List) *Validator {
+	v.revoked = list
+	return v
+}
+
 func (v *Validator) ValidateToken(tokenString string) (*Claims, error) {
 	if tokenString == "" {
 		return nil, ErrMalformedToken
 	}
-	parsedToken, err := jwtlib.ParseWithClaims(tokenString, &Claims{}, func(t *jwtlib.Token) (interface{}, error) {
-		if t.Method.Alg() != jwtlib.SigningMethodHS256.Alg() {
-			return nil, ErrInvalidSignature
-		}
-		return v.secret, nil
-	})
+	parsedToken, err := jwtlib.ParseWithClaims(tokenString, &Claims{}, v.verificationKey)
 	if err != nil {
 		if errors.Is(err, jwtlib.ErrTokenExpired) {
 			return nil, ErrTokenExpired
@@ -66,6 +82,32 @@ func (v *Validator) ValidateToken(tokenString string) (*Claims, error) {
 	if claims.Subject == "" || claims.Username == "" {
 		return nil, ErrMissingClaims
 	}
+	// The API Gateway caches valid tokens for AUTH_CACHE_TTL; a revoked token may be accepted there that much longer,
+	// except on the gateway replica the logout went through
+	if v.revoked != nil && claims.ID != "" && v.revoked.IsRevoked(claims.ID) {
+		return nil, ErrTokenRevoked
+	}
 	v.log.Debug("token validated", slog.String("user_id", claims.Subject), slog.String("username", claims.Username))
 	return claims, nil
 }
+
+// verificationKey selects the key for t by its algorithm and kid header
+func (v *Validator) verificationKey(t *jwtlib.Token) (interface{}, error) {
+	alg := t.Method.Alg()
+	if alg == jwtlib.SigningMethodHS256.Alg() {
+		if len(v.secret) == 0 {
+			return nil, ErrInvalidSignature
+		}
+		return v.secret, nil
+	}
+	if v.ring == nil {
+		return nil, ErrInvalidSignature
+	}
+	kid, _ := t.Header["kid"].(string)
+	key, ok := v.ring.Lookup(kid)
+	// the algorithm must match the key, never the other way round
+	if !ok || string(key.Algorithm) != alg {
+		return nil, ErrInvalidSignature
+	}
+	return key.Public(), nil
+}
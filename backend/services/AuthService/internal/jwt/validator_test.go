@@ -1,9 +1,12 @@
 package jwt
 
 import (
+	"context"
 	"testing"
 	"time"
 
+	"github.com/KnuffelGame/KnuffelGame/backend/services/AuthService/internal/keys"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/AuthService/internal/revocation"
 	jwtlib "github.com/golang-jwt/jwt/v5"
 )
 
@@ -15,7 +18,7 @@ func makeToken(secret string, claims jwtlib.MapClaims) string {
 
 func TestValidateToken_Success(t *testing.T) {
 	secret := "12345678901234567890123456789012" // exactly 32 chars
-	gen := NewGenerator(secret)
+	gen := NewGenerator(secret, 24*time.Hour)
 	validator := NewValidator(secret)
 	jwtStr, err := gen.CreateToken("usr_val123", "Bob", true)
 	if err != nil {
@@ -105,3 +108,129 @@ func TestValidateToken_MissingClaims(t *testing.T) {
 		t.Fatalf("expected ErrMissingClaims, got %v", err)
 	}
 }
+
+// Tokens issued before access tokens became short-lived carry a 24h expiry; they stay valid until it passes
+func TestValidateToken_LegacyLifetime(t *testing.T) {
+	secret := "12345678901234567890123456789012" // exactly 32 chars
+	validator := NewValidator(secret)
+	claims := jwtlib.MapClaims{
+		"sub":   "usr_legacy",
+		"name":  "Alice",
+		"guest": true,
+		"iat":   time.Now().Add(-time.Hour).Unix(),
+		"exp":   time.Now().Add(23 * time.Hour).Unix(),
+		"iss":   Issuer,
+	}
+	if _, err := validator.ValidateToken(makeToken(secret, claims)); err != nil {
+		t.Fatalf("expected legacy token to validate, got %v", err)
+	}
+}
+
+func newRing(t *testing.T, alg keys.Algorithm) *keys.Ring {
+	t.Helper()
+	ring := keys.NewRing(keys.NewMemory(), alg, 24*time.Hour, 15*time.Minute)
+	if err := ring.Sync(context.Background()); err != nil {
+		t.Fatalf("ring sync error: %v", err)
+	}
+	return ring
+}
+
+func TestValidateToken_KeyRing(t *testing.T) {
+	for _, alg := range []keys.Algorithm{keys.RS256, keys.EdDSA} {
+		t.Run(string(alg), func(t *testing.T) {
+			ring := newRing(t, alg)
+			jwtStr, err := NewKeyGenerator(ring, 15*time.Minute).CreateToken("usr_key", "Carol", false)
+			if err != nil {
+				t.Fatalf("generator error: %v", err)
+			}
+			parsed, _, err := jwtlib.NewParser().ParseUnverified(jwtStr, &Claims{})
+			if err != nil {
+				t.Fatalf("parse error: %v", err)
+			}
+			signing, _ := ring.SigningKey()
+			if parsed.Header["kid"] != signing.ID || parsed.Method.Alg() != string(alg) {
+				t.Fatalf("expected kid %s and alg %s, got %v", signing.ID, alg, parsed.Header)
+			}
+
+			claims, err := NewKeyValidator(ring, "").ValidateToken(jwtStr)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if claims.Subject != "usr_key" || claims.Username != "Carol" || claims.Guest {
+				t.Errorf("claims mismatch: %+v", claims)
+			}
+		})
+	}
+}
+
+func TestValidateToken_UnknownKid(t *testing.T) {
+	jwtStr, err := NewKeyGenerator(newRing(t, keys.EdDSA), 15*time.Minute).CreateToken("usr_kid", "Dave", true)
+	if err != nil {
+		t.Fatalf("generator error: %v", err)
+	}
+	// a different ring does not know the kid
+	if _, err := NewKeyValidator(newRing(t, keys.EdDSA), "").ValidateToken(jwtStr); err != ErrInvalidSignature {
+		t.Fatalf("expected ErrInvalidSignature, got %v", err)
+	}
+}
+
+func TestValidateToken_AlgorithmMismatch(t *testing.T) {
+	ring := newRing(t, keys.EdDSA)
+	signing, _ := ring.SigningKey()
+	claims := jwtlib.MapClaims{
+		"sub":  "usr_alg",
+		"name": "Mallory",
+		"iat":  time.Now().Unix(),
+		"exp":  time.Now().Add(time.Hour).Unix(),
+		"iss":  Issuer,
+	}
+	// an RS256 header naming the EdDSA key must not be verified with it
+	other, _ := keys.Generate(keys.RS256, time.Now())
+	tok := jwtlib.NewWithClaims(jwtlib.SigningMethodRS256, claims)
+	tok.Header["kid"] = signing.ID
+	str, err := tok.SignedString(other.Private)
+	if err != nil {
+		t.Fatalf("sign error: %v", err)
+	}
+	if _, err := NewKeyValidator(ring, "").ValidateToken(str); err != ErrInvalidSignature {
+		t.Fatalf("expected ErrInvalidSignature, got %v", err)
+	}
+}
+
+func TestValidateToken_HS256Fallback(t *testing.T) {
+	secret := "12345678901234567890123456789012" // exactly 32 chars
+	ring := newRing(t, keys.EdDSA)
+	jwtStr, err := NewGenerator(secret, 24*time.Hour).CreateToken("usr_legacy", "Erin", true)
+	if err != nil {
+		t.Fatalf("generator error: %v", err)
+	}
+	if _, err := NewKeyValidator(ring, secret).ValidateToken(jwtStr); err != nil {
+		t.Fatalf("expected HS256 token to be accepted with a secret, got %v", err)
+	}
+	if _, err := NewKeyValidator(ring, "").ValidateToken(jwtStr); err != ErrInvalidSignature {
+		t.Fatalf("expected ErrInvalidSignature without a secret, got %v", err)
+	}
+}
+
+func TestValidateToken_Revoked(t *testing.T) {
+	secret := "12345678901234567890123456789012" // exactly 32 chars
+	gen := NewGenerator(secret, 24*time.Hour)
+	list := revocation.NewList(nil)
+	validator := NewValidator(secret).WithRevocations(list)
+	revoked, _ := gen.CreateToken("usr_rev", "Frank", true)
+	other, _ := gen.CreateToken("usr_rev", "Frank", true)
+
+	claims, err := validator.ValidateToken(revoked)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := list.Revoke(context.Background(), claims.ID, claims.ExpiresAt.Time); err != nil {
+		t.Fatalf("revoke error: %v", err)
+	}
+	if _, err := validator.ValidateToken(revoked); err != ErrTokenRevoked {
+		t.Fatalf("expected ErrTokenRevoked, got %v", err)
+	}
+	if _, err := validator.ValidateToken(other); err != nil {
+		t.Fatalf("expected other tokens of the user to stay valid, got %v", err)
+	}
+}
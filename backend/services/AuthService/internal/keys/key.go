@@ -0,0 +1,96 @@
+// Package keys manages the asymmetric keys tokens are signed with.
+//
+// The newest key signs, older keys only verify. A key is rotated out after the rotation interval
+// and keeps verifying until every token it signed has expired, then it is deleted.
+package keys
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Algorithm is the JWS algorithm a key signs with
+type Algorithm string
+
+const (
+	RS256 Algorithm = "RS256"
+	EdDSA Algorithm = "EdDSA"
+)
+
+// rsaBits is the modulus size of generated RSA keys
+const rsaBits = 2048
+
+// ErrUnsupportedAlgorithm is returned for algorithms other than RS256 and EdDSA
+var ErrUnsupportedAlgorithm = errors.New("unsupported signing algorithm")
+
+// Key is a signing key. RetiredAt is set once a newer key took over signing.
+type Key struct {
+	ID        string
+	Algorithm Algorithm
+	Private   crypto.Signer
+	CreatedAt time.Time
+	RetiredAt *time.Time
+}
+
+// Public returns the verification key (*rsa.PublicKey or ed25519.PublicKey)
+func (k Key) Public() crypto.PublicKey {
+	return k.Private.Public()
+}
+
+// ParseAlgorithm returns the Algorithm named s
+func ParseAlgorithm(s string) (Algorithm, error) {
+	switch Algorithm(s) {
+	case RS256, EdDSA:
+		return Algorithm(s), nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrUnsupportedAlgorithm, s)
+	}
+}
+
+// Generate creates a new key with a random id
+func Generate(alg Algorithm, now time.Time) (Key, error) {
+	var priv crypto.Signer
+	switch alg {
+	case RS256:
+		k, err := rsa.GenerateKey(rand.Reader, rsaBits)
+		if err != nil {
+			return Key{}, fmt.Errorf("failed to generate RSA key: %w", err)
+		}
+		priv = k
+	case EdDSA:
+		_, k, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return Key{}, fmt.Errorf("failed to generate Ed25519 key: %w", err)
+		}
+		priv = k
+	default:
+		return Key{}, fmt.Errorf("%w: %q", ErrUnsupportedAlgorithm, alg)
+	}
+	return Key{ID: uuid.NewString(), Algorithm: alg, Private: priv, CreatedAt: now}, nil
+}
+
+// marshalPrivate encodes the private key as PKCS #8 DER
+func marshalPrivate(k crypto.Signer) ([]byte, error) {
+	return x509.MarshalPKCS8PrivateKey(k)
+}
+
+// parsePrivate decodes a PKCS #8 DER private key
+func parsePrivate(der []byte) (crypto.Signer, error) {
+	k, err := x509.ParsePKCS8PrivateKey(der)
+	if err != nil {
+		return nil, err
+	}
+	signer, ok := k.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("unsupported private key type %T", k)
+	}
+	return signer, nil
+}
@@ -0,0 +1,104 @@
+package keys
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// rotationLock serializes rotations of all replicas
+const rotationLock = "signing_keys"
+
+// PostgresStore implements Store using a *sql.DB. Private keys are stored as PKCS #8 DER.
+type PostgresStore struct {
+	DB *sql.DB
+}
+
+// NewPostgres creates a new PostgresStore
+func NewPostgres(db *sql.DB) *PostgresStore {
+	return &PostgresStore{DB: db}
+}
+
+func (s *PostgresStore) List(ctx context.Context) ([]Key, error) {
+	rows, err := s.DB.QueryContext(ctx, `
+		SELECT kid, algorithm, private_key, created_at, retired_at
+		FROM signing_keys
+		ORDER BY created_at DESC
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []Key
+	for rows.Next() {
+		var k Key
+		var alg string
+		var der []byte
+		var retiredAt sql.NullTime
+		if err := rows.Scan(&k.ID, &alg, &der, &k.CreatedAt, &retiredAt); err != nil {
+			return nil, err
+		}
+		k.Algorithm = Algorithm(alg)
+		if k.Private, err = parsePrivate(der); err != nil {
+			return nil, fmt.Errorf("failed to parse key %s: %w", k.ID, err)
+		}
+		if retiredAt.Valid {
+			k.RetiredAt = &retiredAt.Time
+		}
+		out = append(out, k)
+	}
+	return out, rows.Err()
+}
+
+func (s *PostgresStore) Rotate(ctx context.Context, next Key, notBefore time.Time) (bool, error) {
+	der, err := marshalPrivate(next.Private)
+	if err != nil {
+		return false, fmt.Errorf("failed to encode key: %w", err)
+	}
+
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock(hashtext($1))`, rotationLock); err != nil {
+		return false, err
+	}
+	var current bool
+	if err := tx.QueryRowContext(ctx, `
+		SELECT EXISTS (
+			SELECT 1 FROM signing_keys
+			WHERE retired_at IS NULL AND algorithm = $1 AND created_at > $2
+		)
+	`, string(next.Algorithm), notBefore).Scan(&current); err != nil {
+		return false, err
+	}
+	if current {
+		return false, nil
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE signing_keys SET retired_at = $1 WHERE retired_at IS NULL`, next.CreatedAt); err != nil {
+		return false, err
+	}
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO signing_keys (kid, algorithm, private_key, created_at)
+		VALUES ($1, $2, $3, $4)
+	`, next.ID, string(next.Algorithm), der, next.CreatedAt); err != nil {
+		return false, err
+	}
+	if err := tx.Commit(); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (s *PostgresStore) DeleteRetired(ctx context.Context, cutoff time.Time) (int64, error) {
+	res, err := s.DB.ExecContext(ctx, `DELETE FROM signing_keys WHERE retired_at < $1`, cutoff)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
@@ -0,0 +1,93 @@
+package keys
+
+import (
+	"context"
+	"crypto/ed25519"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestPostgresRotate(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer db.Close()
+	store := NewPostgres(db)
+
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	next, err := Generate(EdDSA, now)
+	if err != nil {
+		t.Fatalf("Generate error: %v", err)
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec("SELECT pg_advisory_xact_lock").WithArgs(rotationLock).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("SELECT EXISTS").WithArgs("EdDSA", now.Add(-24*time.Hour)).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+	mock.ExpectExec("UPDATE signing_keys SET retired_at").WithArgs(now).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO signing_keys").WithArgs(next.ID, "EdDSA", sqlmock.AnyArg(), now).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	rotated, err := store.Rotate(context.Background(), next, now.Add(-24*time.Hour))
+	if err != nil || !rotated {
+		t.Fatalf("expected rotation, got %v (%v)", rotated, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestPostgresRotateNotDue(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer db.Close()
+	store := NewPostgres(db)
+
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	next, _ := Generate(EdDSA, now)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("SELECT pg_advisory_xact_lock").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("SELECT EXISTS").WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	mock.ExpectRollback()
+
+	rotated, err := store.Rotate(context.Background(), next, now.Add(-24*time.Hour))
+	if err != nil || rotated {
+		t.Fatalf("expected no rotation, got %v (%v)", rotated, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestPostgresList(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer db.Close()
+	store := NewPostgres(db)
+
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	k, _ := Generate(EdDSA, now)
+	der, _ := marshalPrivate(k.Private)
+
+	mock.ExpectQuery("SELECT kid, algorithm, private_key, created_at, retired_at FROM signing_keys").
+		WillReturnRows(sqlmock.NewRows([]string{"kid", "algorithm", "private_key", "created_at", "retired_at"}).
+			AddRow(k.ID, "EdDSA", der, now, nil))
+
+	keys, err := store.List(context.Background())
+	if err != nil {
+		t.Fatalf("List error: %v", err)
+	}
+	if len(keys) != 1 || keys[0].ID != k.ID || keys[0].RetiredAt != nil {
+		t.Fatalf("unexpected keys %+v", keys)
+	}
+	if !keys[0].Public().(ed25519.PublicKey).Equal(k.Public()) {
+		t.Fatalf("expected the stored key to round trip")
+	}
+}
@@ -0,0 +1,165 @@
+package keys
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+const (
+	// reloadInterval limits how often an unknown key id triggers a reload from the store
+	reloadInterval = 5 * time.Second
+	reloadTimeout  = 2 * time.Second
+)
+
+// ErrNoSigningKey is returned before the first successful Sync
+var ErrNoSigningKey = errors.New("no signing key available")
+
+// Ring caches the keys of a Store for signing and verification and rotates the signing key.
+// Every replica runs its own Ring on a shared store; the store makes sure only one of them rotates.
+type Ring struct {
+	store       Store
+	alg         Algorithm
+	rotateEvery time.Duration
+	verifyFor   time.Duration
+	now         func() time.Time
+
+	mu       sync.RWMutex
+	keys     []Key // newest first
+	loadedAt time.Time
+}
+
+// NewRing creates a ring that signs with alg, replaces the signing key every rotateEvery and keeps
+// a retired key for verifyFor, the lifetime of the tokens it signed. Call Sync before use.
+func NewRing(store Store, alg Algorithm, rotateEvery, verifyFor time.Duration) *Ring {
+	return &Ring{store: store, alg: alg, rotateEvery: rotateEvery, verifyFor: verifyFor, now: time.Now}
+}
+
+// Sync rotates the signing key if it is due (or signs with another algorithm),
+// deletes keys no unexpired token can refer to and reloads the keys from the store.
+func (r *Ring) Sync(ctx context.Context) error {
+	now := r.now()
+	notBefore := now.Add(-r.rotateEvery)
+
+	r.mu.RLock()
+	signing, ok := r.signingLocked()
+	r.mu.RUnlock()
+	if !ok || signing.Algorithm != r.alg || !signing.CreatedAt.After(notBefore) {
+		next, err := Generate(r.alg, now)
+		if err != nil {
+			return err
+		}
+		if _, err := r.store.Rotate(ctx, next, notBefore); err != nil {
+			return fmt.Errorf("failed to rotate signing key: %w", err)
+		}
+	}
+
+	if _, err := r.store.DeleteRetired(ctx, now.Add(-r.verifyFor)); err != nil {
+		return fmt.Errorf("failed to delete retired keys: %w", err)
+	}
+	return r.reload(ctx, now)
+}
+
+// Run calls Sync every interval until ctx is cancelled
+func (r *Ring) Run(ctx context.Context, interval time.Duration, log *slog.Logger) {
+	log = log.With(slog.String("component", "key_rotation"))
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := r.Sync(ctx); err != nil {
+				log.Error("failed to sync signing keys", slog.String("error", err.Error()))
+			}
+		}
+	}
+}
+
+// SigningKey returns the key new tokens are signed with
+func (r *Ring) SigningKey() (Key, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	k, ok := r.signingLocked()
+	if !ok {
+		return Key{}, ErrNoSigningKey
+	}
+	return k, nil
+}
+
+// Lookup returns the verification key with the given id. An unknown id triggers a reload,
+// as another replica may have rotated since the last Sync.
+func (r *Ring) Lookup(kid string) (Key, bool) {
+	if k, ok := r.find(kid); ok {
+		return k, true
+	}
+
+	now := r.now()
+	r.mu.Lock()
+	if now.Sub(r.loadedAt) < reloadInterval {
+		r.mu.Unlock()
+		return Key{}, false
+	}
+	// claim the reload so concurrent lookups of unknown ids do not all hit the store
+	r.loadedAt = now
+	r.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), reloadTimeout)
+	defer cancel()
+	if err := r.reload(ctx, now); err != nil {
+		return Key{}, false
+	}
+	return r.find(kid)
+}
+
+// Keys returns all keys that may still verify tokens, newest first
+func (r *Ring) Keys() []Key {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	out := make([]Key, len(r.keys))
+	copy(out, r.keys)
+	return out
+}
+
+func (r *Ring) find(kid string) (Key, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, k := range r.keys {
+		if k.ID == kid {
+			return k, true
+		}
+	}
+	return Key{}, false
+}
+
+func (r *Ring) reload(ctx context.Context, now time.Time) error {
+	stored, err := r.store.List(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load signing keys: %w", err)
+	}
+	keys := make([]Key, 0, len(stored))
+	for _, k := range stored {
+		if k.RetiredAt == nil || k.RetiredAt.Add(r.verifyFor).After(now) {
+			keys = append(keys, k)
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.keys = keys
+	r.loadedAt = now
+	return nil
+}
+
+func (r *Ring) signingLocked() (Key, bool) {
+	for _, k := range r.keys {
+		if k.RetiredAt == nil {
+			return k, true
+		}
+	}
+	return Key{}, false
+}
@@ -0,0 +1,133 @@
+package keys
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"errors"
+	"testing"
+	"time"
+)
+
+func newTestRing(store Store, alg Algorithm) (*Ring, *time.Time) {
+	r := NewRing(store, alg, 24*time.Hour, 15*time.Minute)
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	r.now = func() time.Time { return now }
+	return r, &now
+}
+
+func TestSyncCreatesSigningKey(t *testing.T) {
+	r, _ := newTestRing(NewMemory(), EdDSA)
+	if _, err := r.SigningKey(); !errors.Is(err, ErrNoSigningKey) {
+		t.Fatalf("expected ErrNoSigningKey before Sync, got %v", err)
+	}
+	if err := r.Sync(context.Background()); err != nil {
+		t.Fatalf("Sync error: %v", err)
+	}
+	k, err := r.SigningKey()
+	if err != nil {
+		t.Fatalf("SigningKey error: %v", err)
+	}
+	if k.Algorithm != EdDSA || k.ID == "" {
+		t.Fatalf("unexpected key %+v", k)
+	}
+	if _, ok := k.Public().(ed25519.PublicKey); !ok {
+		t.Fatalf("expected an Ed25519 key, got %T", k.Public())
+	}
+}
+
+func TestSyncRotatesAndRetiresKeys(t *testing.T) {
+	ctx := context.Background()
+	r, now := newTestRing(NewMemory(), EdDSA)
+	_ = r.Sync(ctx)
+	first, _ := r.SigningKey()
+
+	*now = now.Add(time.Hour)
+	_ = r.Sync(ctx)
+	if k, _ := r.SigningKey(); k.ID != first.ID {
+		t.Fatalf("expected no rotation before the interval")
+	}
+
+	*now = now.Add(24 * time.Hour)
+	if err := r.Sync(ctx); err != nil {
+		t.Fatalf("Sync error: %v", err)
+	}
+	second, _ := r.SigningKey()
+	if second.ID == first.ID {
+		t.Fatalf("expected a new signing key after the interval")
+	}
+	if _, ok := r.Lookup(first.ID); !ok {
+		t.Fatalf("expected the retired key to keep verifying")
+	}
+	if len(r.Keys()) != 2 {
+		t.Fatalf("expected 2 verification keys, got %d", len(r.Keys()))
+	}
+
+	// every token signed by the first key has expired now
+	*now = now.Add(16 * time.Minute)
+	_ = r.Sync(ctx)
+	if _, ok := r.Lookup(first.ID); ok {
+		t.Fatalf("expected the retired key to be dropped after the token lifetime")
+	}
+	if len(r.Keys()) != 1 {
+		t.Fatalf("expected 1 verification key, got %d", len(r.Keys()))
+	}
+}
+
+func TestSyncRotatesOnAlgorithmChange(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemory()
+	old, _ := newTestRing(store, EdDSA)
+	_ = old.Sync(ctx)
+
+	r, _ := newTestRing(store, RS256)
+	if err := r.Sync(ctx); err != nil {
+		t.Fatalf("Sync error: %v", err)
+	}
+	k, _ := r.SigningKey()
+	if _, ok := k.Public().(*rsa.PublicKey); !ok || k.Algorithm != RS256 {
+		t.Fatalf("expected an RS256 signing key, got %s %T", k.Algorithm, k.Public())
+	}
+}
+
+func TestReplicasShareRotation(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemory()
+	a, nowA := newTestRing(store, EdDSA)
+	b, nowB := newTestRing(store, EdDSA)
+	_ = a.Sync(ctx)
+	_ = b.Sync(ctx)
+	ka, _ := a.SigningKey()
+	kb, _ := b.SigningKey()
+	if ka.ID != kb.ID {
+		t.Fatalf("expected both replicas to sign with the same key")
+	}
+
+	*nowA = nowA.Add(25 * time.Hour)
+	*nowB = nowB.Add(25 * time.Hour)
+	_ = a.Sync(ctx)
+	rotated, _ := a.SigningKey()
+
+	// b has not synced yet but finds the new key on demand
+	if _, ok := b.Lookup(rotated.ID); !ok {
+		t.Fatalf("expected the other replica to load the rotated key")
+	}
+	_ = b.Sync(ctx)
+	if k, _ := b.SigningKey(); k.ID != rotated.ID {
+		t.Fatalf("expected no second rotation, got %s and %s", k.ID, rotated.ID)
+	}
+}
+
+func TestLookupUnknownKeyRateLimited(t *testing.T) {
+	r, now := newTestRing(NewMemory(), EdDSA)
+	_ = r.Sync(context.Background())
+	if _, ok := r.Lookup("unknown"); ok {
+		t.Fatalf("expected unknown key not to be found")
+	}
+	loadedAt := r.loadedAt
+	*now = now.Add(time.Second)
+	r.Lookup("unknown")
+	if r.loadedAt != loadedAt {
+		t.Fatalf("expected no reload within %s", reloadInterval)
+	}
+}
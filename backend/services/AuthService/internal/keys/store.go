@@ -0,0 +1,75 @@
+package keys
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Store persists signing keys.
+type Store interface {
+	// List returns all stored keys, newest first
+	List(ctx context.Context) ([]Key, error)
+	// Rotate retires the current signing key and stores next in its place, atomically.
+	// Nothing happens if a signing key with the same algorithm created after notBefore exists,
+	// e.g. because another replica rotated first; the result reports whether next was stored.
+	Rotate(ctx context.Context, next Key, notBefore time.Time) (bool, error)
+	// DeleteRetired removes keys retired before cutoff and returns how many were removed
+	DeleteRetired(ctx context.Context, cutoff time.Time) (int64, error)
+}
+
+// MemoryStore implements Store in process memory.
+// Keys are lost on restart, so tokens signed before a restart no longer verify. Single replica only.
+type MemoryStore struct {
+	mu   sync.Mutex
+	keys []Key
+}
+
+// NewMemory creates an empty MemoryStore
+func NewMemory() *MemoryStore {
+	return &MemoryStore{}
+}
+
+func (s *MemoryStore) List(_ context.Context) ([]Key, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make([]Key, len(s.keys))
+	copy(out, s.keys)
+	sort.SliceStable(out, func(i, j int) bool { return out[i].CreatedAt.After(out[j].CreatedAt) })
+	return out, nil
+}
+
+func (s *MemoryStore) Rotate(_ context.Context, next Key, notBefore time.Time) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, k := range s.keys {
+		if k.RetiredAt == nil && k.Algorithm == next.Algorithm && k.CreatedAt.After(notBefore) {
+			return false, nil
+		}
+	}
+	for i := range s.keys {
+		if s.keys[i].RetiredAt == nil {
+			retiredAt := next.CreatedAt
+			s.keys[i].RetiredAt = &retiredAt
+		}
+	}
+	s.keys = append(s.keys, next)
+	return true, nil
+}
+
+func (s *MemoryStore) DeleteRetired(_ context.Context, cutoff time.Time) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	kept := s.keys[:0]
+	var n int64
+	for _, k := range s.keys {
+		if k.RetiredAt != nil && k.RetiredAt.Before(cutoff) {
+			n++
+			continue
+		}
+		kept = append(kept, k)
+	}
+	s.keys = kept
+	return n, nil
+}
@@ -1,8 +1,10 @@
 package models
 
 import (
-	"github.com/go-playground/validator/v10"
 	"regexp"
+	"time"
+
+	"github.com/go-playground/validator/v10"
 )
 
 // ErrorResponse matches the shared error schema.
@@ -27,6 +29,21 @@ type CreateJWTRequest struct {
 	Username string `json:"username" validate:"required,min=3,max=20,usernameFmt"`
 }
 
+// CreateGuestRequest is the public guest session request; the user id is generated by the service.
+
+type CreateGuestRequest struct {
+	Username string `json:"username"`
+}
+
+// SessionResponse describes the user the session cookies were issued for; expires_at is the expiry of the access token.
+
+type SessionResponse struct {
+	UserID    string    `json:"user_id"`
+	Username  string    `json:"username"`
+	IsGuest   bool      `json:"is_guest"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
 type ValidateJWTRequest struct {
 	Token string `json:"token" validate:"required,jwt"`
 }
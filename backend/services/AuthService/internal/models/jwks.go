@@ -0,0 +1,20 @@
+package models
+
+// JWK is a public verification key (RFC 7517). RSA keys set N and E, Ed25519 keys Crv and X.
+
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+// JWKSResponse is the key set served at /.well-known/jwks.json.
+
+type JWKSResponse struct {
+	Keys []JWK `json:"keys"`
+}
@@ -0,0 +1,30 @@
+package models
+
+import "github.com/go-playground/validator/v10"
+
+// OIDCExchangeRequest carries an ID token issued by an external OpenID Connect provider.
+
+type OIDCExchangeRequest struct {
+	Provider string `json:"provider" validate:"required"`
+	IDToken  string `json:"id_token" validate:"required,jwt"`
+}
+
+// OIDCExchangeResponse is the Knuffel token issued for the account behind the ID token.
+
+type OIDCExchangeResponse struct {
+	Token    string `json:"token"`
+	UserID   string `json:"user_id"`
+	Username string `json:"username"`
+	Email    string `json:"email,omitempty"`
+	IsGuest  bool   `json:"is_guest"`
+}
+
+func (r *OIDCExchangeRequest) Validate() map[string]string {
+	errMap := map[string]string{}
+	if err := validate.Struct(r); err != nil {
+		for _, fe := range err.(validator.ValidationErrors) {
+			errMap[fe.Field()] = fe.Tag()
+		}
+	}
+	return errMap
+}
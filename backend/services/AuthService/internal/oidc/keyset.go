@@ -0,0 +1,221 @@
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+const (
+	// keysMaxAge is how long fetched keys are used before they are fetched again
+	keysMaxAge = time.Hour
+	// refetchInterval rate limits fetches caused by tokens with an unknown kid
+	refetchInterval = 10 * time.Second
+	// maxJWKSSize bounds the size of a fetched key set
+	maxJWKSSize = 1 << 20 // 1MB
+)
+
+// ErrKeysUnavailable is returned when the key set of the provider could not be fetched
+var ErrKeysUnavailable = errors.New("provider keys unavailable")
+
+// jwk is a JSON Web Key as published by OIDC providers (RFC 7517/7518/8037)
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// publicKey is a verification key; alg is empty if the provider did not restrict it
+type publicKey struct {
+	alg string
+	key crypto.PublicKey
+}
+
+// KeySet holds the verification keys of a provider, by kid.
+// A remote set is fetched on first use, refreshed hourly and refetched when a token names an unknown kid,
+// so keys the provider rotated in are picked up without a restart.
+type KeySet struct {
+	url    string
+	client *http.Client
+	now    func() time.Time
+
+	mu        sync.Mutex
+	keys      map[string]publicKey
+	fetchedAt time.Time
+}
+
+// NewRemoteKeySet creates a key set fetched from the JWKS at url (the jwks_uri of the provider)
+func NewRemoteKeySet(url string, client *http.Client) *KeySet {
+	return &KeySet{url: url, client: client, now: time.Now}
+}
+
+// LoadKeySet reads a static key set from a JWKS file
+func LoadKeySet(path string) (*KeySet, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key set: %w", err)
+	}
+	keys, err := parseKeySet(data)
+	if err != nil {
+		return nil, err
+	}
+	return &KeySet{keys: keys, now: time.Now}, nil
+}
+
+// key returns the key with kid, fetching the set if needed
+func (s *KeySet) key(ctx context.Context, kid string) (publicKey, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.url == "" {
+		k, ok := s.keys[kid]
+		return k, ok, nil
+	}
+
+	now := s.now()
+	k, ok := s.keys[kid]
+	stale := s.keys == nil || now.Sub(s.fetchedAt) >= keysMaxAge
+	if (ok && !stale) || (!ok && !stale && now.Sub(s.fetchedAt) < refetchInterval) {
+		return k, ok, nil
+	}
+
+	keys, err := s.fetch(ctx)
+	if err != nil {
+		// keep verifying with the known keys while the provider is unreachable
+		if ok {
+			return k, true, nil
+		}
+		return publicKey{}, false, fmt.Errorf("%w: %v", ErrKeysUnavailable, err)
+	}
+	s.keys, s.fetchedAt = keys, now
+	k, ok = s.keys[kid]
+	return k, ok, nil
+}
+
+func (s *KeySet) fetch(ctx context.Context) (map[string]publicKey, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("jwks returned status %d", resp.StatusCode)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxJWKSSize))
+	if err != nil {
+		return nil, err
+	}
+	return parseKeySet(data)
+}
+
+// parseKeySet decodes a JWKS. Keys of unsupported types or not meant for signatures are skipped.
+func parseKeySet(data []byte) (map[string]publicKey, error) {
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("failed to decode key set: %w", err)
+	}
+	keys := make(map[string]publicKey, len(set.Keys))
+	for _, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		pub, err := k.publicKey()
+		if err != nil {
+			continue
+		}
+		keys[k.Kid] = publicKey{alg: k.Alg, key: pub}
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("key set contains no usable keys")
+	}
+	return keys, nil
+}
+
+func (k jwk) publicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeSegment(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeSegment(k.E)
+		if err != nil {
+			return nil, err
+		}
+		exp := new(big.Int).SetBytes(e)
+		if !exp.IsInt64() || exp.Int64() > 1<<31-1 {
+			return nil, errors.New("rsa exponent too large")
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exp.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := decodeSegment(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeSegment(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		size := (curve.Params().BitSize + 7) / 8
+		if len(x) != size || len(y) != size {
+			return nil, errors.New("invalid ec coordinates")
+		}
+		return ecdsa.ParseUncompressedPublicKey(curve, append(append([]byte{4}, x...), y...))
+	case "OKP":
+		if k.Crv != "Ed25519" {
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := decodeSegment(k.X)
+		if err != nil {
+			return nil, err
+		}
+		if len(x) != ed25519.PublicKeySize {
+			return nil, errors.New("invalid ed25519 key")
+		}
+		return ed25519.PublicKey(x), nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+	}
+}
+
+func decodeSegment(s string) ([]byte, error) {
+	if s == "" {
+		return nil, errors.New("missing key parameter")
+	}
+	return base64.RawURLEncoding.DecodeString(s)
+}
@@ -0,0 +1,142 @@
+// Package oidctest provides a local OpenID Connect issuer for tests.
+package oidctest
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	jwtlib "github.com/golang-jwt/jwt/v5"
+)
+
+// Audience is the client id the tokens of an Issuer are issued for by default
+const Audience = "knuffel-test-client"
+
+// Issuer serves a JWKS over HTTP and signs ID tokens with its RS256 keys.
+type Issuer struct {
+	Server *httptest.Server
+
+	mu      sync.Mutex
+	keys    map[string]*rsa.PrivateKey
+	kid     string
+	fetches int
+}
+
+// NewIssuer starts an issuer with one key; it is closed with the test
+func NewIssuer(t *testing.T) *Issuer {
+	t.Helper()
+	iss := &Issuer{keys: map[string]*rsa.PrivateKey{}}
+	iss.Rotate(t)
+	iss.Server = httptest.NewServer(http.HandlerFunc(iss.serveJWKS))
+	t.Cleanup(iss.Server.Close)
+	return iss
+}
+
+// URL is the issuer identifier, the iss claim of its tokens
+func (i *Issuer) URL() string {
+	return i.Server.URL
+}
+
+// JWKSURL is where the issuer publishes its keys
+func (i *Issuer) JWKSURL() string {
+	return i.Server.URL + "/jwks"
+}
+
+// Rotate adds a new key and signs with it from now on; the previous keys stay published
+func (i *Issuer) Rotate(t *testing.T) string {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.kid = big.NewInt(int64(len(i.keys) + 1)).String()
+	i.keys[i.kid] = key
+	return i.kid
+}
+
+// Claims returns valid claims for subject, expiring in an hour
+func (i *Issuer) Claims(subject string) jwtlib.MapClaims {
+	now := time.Now()
+	return jwtlib.MapClaims{
+		"iss":            i.URL(),
+		"aud":            Audience,
+		"sub":            subject,
+		"iat":            now.Unix(),
+		"exp":            now.Add(time.Hour).Unix(),
+		"email":          subject + "@example.com",
+		"email_verified": true,
+		"name":           "Alice Example",
+	}
+}
+
+// Sign returns claims as ID token signed with the current key
+func (i *Issuer) Sign(t *testing.T, claims jwtlib.MapClaims) string {
+	t.Helper()
+	i.mu.Lock()
+	kid, key := i.kid, i.keys[i.kid]
+	i.mu.Unlock()
+	tok := jwtlib.NewWithClaims(jwtlib.SigningMethodRS256, claims)
+	tok.Header["kid"] = kid
+	signed, err := tok.SignedString(key)
+	if err != nil {
+		t.Fatalf("failed to sign id token: %v", err)
+	}
+	return signed
+}
+
+// Fetches returns how often the JWKS was requested
+func (i *Issuer) Fetches() int {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return i.fetches
+}
+
+// JWKS returns the published key set
+func (i *Issuer) JWKS() []byte {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	type jwk struct {
+		Kty string `json:"kty"`
+		Kid string `json:"kid"`
+		Use string `json:"use"`
+		Alg string `json:"alg"`
+		N   string `json:"n"`
+		E   string `json:"e"`
+	}
+	set := struct {
+		Keys []jwk `json:"keys"`
+	}{}
+	for kid, key := range i.keys {
+		set.Keys = append(set.Keys, jwk{
+			Kty: "RSA",
+			Kid: kid,
+			Use: "sig",
+			Alg: "RS256",
+			N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		})
+	}
+	data, _ := json.Marshal(set)
+	return data
+}
+
+func (i *Issuer) serveJWKS(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/jwks" {
+		http.NotFound(w, r)
+		return
+	}
+	i.mu.Lock()
+	i.fetches++
+	i.mu.Unlock()
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(i.JWKS())
+}
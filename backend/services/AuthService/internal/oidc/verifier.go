@@ -0,0 +1,115 @@
+// Package oidc verifies ID tokens issued by external OpenID Connect providers
+// and maps their subjects to Knuffel user ids.
+package oidc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	jwtlib "github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+// leeway tolerates clock skew between the provider and this service
+const leeway = 30 * time.Second
+
+// ErrInvalidToken is returned for ID tokens that fail verification (signature, issuer, audience, expiry, claims)
+var ErrInvalidToken = errors.New("invalid id token")
+
+// signingMethods are the algorithms accepted for ID tokens; symmetric ones never are
+var signingMethods = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512", "EdDSA"}
+
+// userNamespace is the UUID namespace Knuffel user ids of external subjects are derived in.
+// Changing it changes the user id of every account.
+var userNamespace = uuid.MustParse("6f2b8c1e-3d4a-5b7c-9e0f-1a2b3c4d5e6f")
+
+// Identity is the verified subject of an ID token
+type Identity struct {
+	Issuer            string
+	Subject           string
+	Email             string
+	EmailVerified     bool
+	Name              string
+	PreferredUsername string
+}
+
+// UserID returns the Knuffel user id of the subject. It is a UUID derived from issuer and subject (version 5),
+// so the same account always gets the same id and subjects of different providers never collide.
+func (i Identity) UserID() uuid.UUID {
+	return uuid.NewSHA1(userNamespace, []byte(i.Issuer+"\x00"+i.Subject))
+}
+
+// Verifier verifies ID tokens of one provider.
+type Verifier interface {
+	Verify(ctx context.Context, idToken string) (Identity, error)
+}
+
+// idTokenClaims are the claims read from an ID token
+type idTokenClaims struct {
+	Email             string `json:"email"`
+	EmailVerified     bool   `json:"email_verified"`
+	Name              string `json:"name"`
+	PreferredUsername string `json:"preferred_username"`
+	jwtlib.RegisteredClaims
+}
+
+// JWKSVerifier verifies ID tokens signed with the keys of a KeySet.
+type JWKSVerifier struct {
+	issuer   string
+	audience string
+	keys     *KeySet
+	now      func() time.Time
+}
+
+// NewJWKSVerifier creates a verifier accepting tokens of issuer for audience (the client id registered with the provider)
+func NewJWKSVerifier(issuer, audience string, keys *KeySet) *JWKSVerifier {
+	return &JWKSVerifier{issuer: issuer, audience: audience, keys: keys, now: time.Now}
+}
+
+// Verify checks signature, issuer, audience and expiry of idToken.
+// Returns ErrInvalidToken if the token is rejected and ErrKeysUnavailable if the provider keys could not be fetched.
+func (v *JWKSVerifier) Verify(ctx context.Context, idToken string) (Identity, error) {
+	parser := jwtlib.NewParser(
+		jwtlib.WithValidMethods(signingMethods),
+		jwtlib.WithIssuer(v.issuer),
+		jwtlib.WithAudience(v.audience),
+		jwtlib.WithExpirationRequired(),
+		jwtlib.WithIssuedAt(),
+		jwtlib.WithLeeway(leeway),
+		jwtlib.WithTimeFunc(v.now),
+	)
+	var claims idTokenClaims
+	_, err := parser.ParseWithClaims(idToken, &claims, func(t *jwtlib.Token) (interface{}, error) {
+		kid, _ := t.Header["kid"].(string)
+		key, ok, err := v.keys.key(ctx, kid)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, fmt.Errorf("unknown kid %q", kid)
+		}
+		if key.alg != "" && key.alg != t.Method.Alg() {
+			return nil, fmt.Errorf("algorithm %s does not match key %q", t.Method.Alg(), kid)
+		}
+		return key.key, nil
+	})
+	if err != nil {
+		if errors.Is(err, ErrKeysUnavailable) {
+			return Identity{}, err
+		}
+		return Identity{}, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+	if claims.Subject == "" {
+		return Identity{}, fmt.Errorf("%w: missing subject", ErrInvalidToken)
+	}
+	return Identity{
+		Issuer:            claims.Issuer,
+		Subject:           claims.Subject,
+		Email:             claims.Email,
+		EmailVerified:     claims.EmailVerified,
+		Name:              claims.Name,
+		PreferredUsername: claims.PreferredUsername,
+	}, nil
+}
@@ -0,0 +1,160 @@
+package oidc
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/KnuffelGame/KnuffelGame/backend/services/AuthService/internal/oidc/oidctest"
+	jwtlib "github.com/golang-jwt/jwt/v5"
+)
+
+func newTestVerifier(iss *oidctest.Issuer) *JWKSVerifier {
+	return NewJWKSVerifier(iss.URL(), oidctest.Audience, NewRemoteKeySet(iss.JWKSURL(), http.DefaultClient))
+}
+
+func TestVerify(t *testing.T) {
+	iss := oidctest.NewIssuer(t)
+	v := newTestVerifier(iss)
+
+	id, err := v.Verify(context.Background(), iss.Sign(t, iss.Claims("alice")))
+	if err != nil {
+		t.Fatalf("Verify error: %v", err)
+	}
+	if id.Subject != "alice" || id.Issuer != iss.URL() || id.Email != "alice@example.com" || !id.EmailVerified || id.Name != "Alice Example" {
+		t.Fatalf("unexpected identity %+v", id)
+	}
+}
+
+func TestVerifyRejects(t *testing.T) {
+	iss := oidctest.NewIssuer(t)
+	other := oidctest.NewIssuer(t)
+	v := newTestVerifier(iss)
+
+	tests := []struct {
+		name  string
+		token func() string
+	}{
+		{"wrong issuer", func() string {
+			c := iss.Claims("alice")
+			c["iss"] = "https://evil.example"
+			return iss.Sign(t, c)
+		}},
+		{"wrong audience", func() string {
+			c := iss.Claims("alice")
+			c["aud"] = "another-client"
+			return iss.Sign(t, c)
+		}},
+		{"expired", func() string {
+			c := iss.Claims("alice")
+			c["exp"] = time.Now().Add(-time.Hour).Unix()
+			return iss.Sign(t, c)
+		}},
+		{"missing expiry", func() string {
+			c := iss.Claims("alice")
+			delete(c, "exp")
+			return iss.Sign(t, c)
+		}},
+		{"missing subject", func() string {
+			c := iss.Claims("")
+			return iss.Sign(t, c)
+		}},
+		{"signed by another issuer", func() string {
+			c := other.Claims("alice")
+			c["iss"] = iss.URL()
+			return other.Sign(t, c)
+		}},
+		{"symmetric algorithm", func() string {
+			tok := jwtlib.NewWithClaims(jwtlib.SigningMethodHS256, iss.Claims("alice"))
+			tok.Header["kid"] = "1"
+			s, _ := tok.SignedString(iss.JWKS())
+			return s
+		}},
+		{"malformed", func() string { return "not.a.token" }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := v.Verify(context.Background(), tt.token()); !errors.Is(err, ErrInvalidToken) {
+				t.Fatalf("expected ErrInvalidToken, got %v", err)
+			}
+		})
+	}
+}
+
+func TestVerifyPicksUpRotatedKeys(t *testing.T) {
+	iss := oidctest.NewIssuer(t)
+	v := newTestVerifier(iss)
+	now := time.Now()
+	v.keys.now = func() time.Time { return now }
+
+	if _, err := v.Verify(context.Background(), iss.Sign(t, iss.Claims("alice"))); err != nil {
+		t.Fatalf("Verify error: %v", err)
+	}
+	iss.Rotate(t)
+	token := iss.Sign(t, iss.Claims("alice"))
+
+	// unknown kids are only refetched every refetchInterval
+	if _, err := v.Verify(context.Background(), token); !errors.Is(err, ErrInvalidToken) {
+		t.Fatalf("expected ErrInvalidToken right after a fetch, got %v", err)
+	}
+	now = now.Add(refetchInterval)
+	if _, err := v.Verify(context.Background(), token); err != nil {
+		t.Fatalf("expected the rotated key to be fetched, got %v", err)
+	}
+	if n := iss.Fetches(); n != 2 {
+		t.Fatalf("expected 2 fetches, got %d", n)
+	}
+}
+
+func TestVerifyProviderUnavailable(t *testing.T) {
+	iss := oidctest.NewIssuer(t)
+	v := newTestVerifier(iss)
+	token := iss.Sign(t, iss.Claims("alice"))
+	iss.Server.Close()
+
+	if _, err := v.Verify(context.Background(), token); !errors.Is(err, ErrKeysUnavailable) {
+		t.Fatalf("expected ErrKeysUnavailable, got %v", err)
+	}
+}
+
+func TestLoadKeySet(t *testing.T) {
+	pub, priv, _ := ed25519.GenerateKey(rand.Reader)
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	jwks := `{"keys":[{"kty":"OKP","crv":"Ed25519","kid":"file-key","use":"sig","x":"` + base64.RawURLEncoding.EncodeToString(pub) + `"},{"kty":"oct","kid":"ignored","k":"c2VjcmV0"}]}`
+	if err := os.WriteFile(path, []byte(jwks), 0o600); err != nil {
+		t.Fatalf("failed to write key set: %v", err)
+	}
+	keys, err := LoadKeySet(path)
+	if err != nil {
+		t.Fatalf("LoadKeySet error: %v", err)
+	}
+
+	now := time.Now()
+	tok := jwtlib.NewWithClaims(jwtlib.SigningMethodEdDSA, jwtlib.MapClaims{
+		"iss": "https://issuer.example", "aud": "client", "sub": "bob", "iat": now.Unix(), "exp": now.Add(time.Hour).Unix(),
+	})
+	tok.Header["kid"] = "file-key"
+	signed, _ := tok.SignedString(priv)
+
+	id, err := NewJWKSVerifier("https://issuer.example", "client", keys).Verify(context.Background(), signed)
+	if err != nil || id.Subject != "bob" {
+		t.Fatalf("expected bob, got %+v (%v)", id, err)
+	}
+}
+
+func TestUserIDStable(t *testing.T) {
+	alice := Identity{Issuer: "https://accounts.google.com", Subject: "1234"}
+	if alice.UserID() != (Identity{Issuer: "https://accounts.google.com", Subject: "1234", Email: "changed@example.com"}).UserID() {
+		t.Fatalf("expected the user id to depend on issuer and subject only")
+	}
+	if alice.UserID() == (Identity{Issuer: "https://issuer.example", Subject: "1234"}).UserID() {
+		t.Fatalf("expected subjects of different issuers to map to different user ids")
+	}
+}
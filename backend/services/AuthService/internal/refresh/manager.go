@@ -0,0 +1,119 @@
+package refresh
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// tokenBytes is the amount of randomness in a refresh token
+const tokenBytes = 32
+
+// Manager issues and rotates refresh tokens. Every rotation extends the session by the TTL (sliding session).
+type Manager struct {
+	store Store
+	ttl   time.Duration
+	now   func() time.Time
+}
+
+// NewManager creates a manager storing tokens in store that stay valid for ttl after their issue
+func NewManager(store Store, ttl time.Duration) *Manager {
+	return &Manager{store: store, ttl: ttl, now: time.Now}
+}
+
+// TTL returns how long a refresh token stays valid
+func (m *Manager) TTL() time.Duration {
+	return m.ttl
+}
+
+// Issue starts a new session for the user and returns its first refresh token
+func (m *Manager) Issue(ctx context.Context, userID, username string, guest bool) (string, error) {
+	token, hash, err := newToken()
+	if err != nil {
+		return "", err
+	}
+	t := Token{
+		Hash:      hash,
+		FamilyID:  uuid.New(),
+		UserID:    userID,
+		Username:  username,
+		Guest:     guest,
+		ExpiresAt: m.now().Add(m.ttl),
+	}
+	if err := m.store.Create(ctx, t); err != nil {
+		return "", fmt.Errorf("failed to store refresh token: %w", err)
+	}
+	return token, nil
+}
+
+// Rotate consumes token and returns the session it belongs to together with the successor token.
+// Returns ErrNotFound, ErrExpired, ErrReused or ErrRevoked if the token cannot be used.
+func (m *Manager) Rotate(ctx context.Context, token string) (Token, string, error) {
+	next, nextHash, err := newToken()
+	if err != nil {
+		return Token{}, "", err
+	}
+	now := m.now()
+	t, err := m.store.Rotate(ctx, hashToken(token), nextHash, now.Add(m.ttl), now)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) || errors.Is(err, ErrExpired) || errors.Is(err, ErrReused) || errors.Is(err, ErrRevoked) {
+			return Token{}, "", err
+		}
+		return Token{}, "", fmt.Errorf("failed to rotate refresh token: %w", err)
+	}
+	return t, next, nil
+}
+
+// Revoke ends the session token belongs to: neither token nor any other token of its family can be used afterwards.
+// Unknown tokens are ignored.
+func (m *Manager) Revoke(ctx context.Context, token string) error {
+	if err := m.store.RevokeFamilyOf(ctx, hashToken(token)); err != nil {
+		return fmt.Errorf("failed to revoke refresh token: %w", err)
+	}
+	return nil
+}
+
+// Run deletes expired tokens every interval until ctx is cancelled
+func (m *Manager) Run(ctx context.Context, interval time.Duration, log *slog.Logger) {
+	log = log.With(slog.String("component", "refresh_cleanup"))
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			n, err := m.store.DeleteExpired(ctx, m.now())
+			if err != nil {
+				log.Error("failed to delete expired refresh tokens", slog.String("error", err.Error()))
+				continue
+			}
+			if n > 0 {
+				log.Debug("deleted expired refresh tokens", slog.Int64("count", n))
+			}
+		}
+	}
+}
+
+// newToken returns a random token and the hash it is stored under
+func newToken() (string, string, error) {
+	b := make([]byte, tokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", fmt.Errorf("failed to generate refresh token: %w", err)
+	}
+	token := base64.RawURLEncoding.EncodeToString(b)
+	return token, hashToken(token), nil
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
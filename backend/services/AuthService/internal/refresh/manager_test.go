@@ -0,0 +1,132 @@
+package refresh
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func newTestManager(ttl time.Duration) (*Manager, *time.Time) {
+	m := NewManager(NewMemory(), ttl)
+	now := time.Date(2025, 1, 1, 20, 0, 0, 0, time.UTC)
+	m.now = func() time.Time { return now }
+	return m, &now
+}
+
+func TestRotate(t *testing.T) {
+	m, _ := newTestManager(time.Hour)
+	ctx := context.Background()
+
+	first, err := m.Issue(ctx, "550e8400-e29b-41d4-a716-446655440000", "Alice", true)
+	if err != nil {
+		t.Fatalf("Issue error: %v", err)
+	}
+	session, second, err := m.Rotate(ctx, first)
+	if err != nil {
+		t.Fatalf("Rotate error: %v", err)
+	}
+	if session.UserID != "550e8400-e29b-41d4-a716-446655440000" || session.Username != "Alice" || !session.Guest {
+		t.Fatalf("unexpected session %+v", session)
+	}
+	if second == "" || second == first {
+		t.Fatalf("expected a new refresh token")
+	}
+	if _, _, err := m.Rotate(ctx, second); err != nil {
+		t.Fatalf("expected the successor to be usable: %v", err)
+	}
+}
+
+func TestRotateSlidesExpiry(t *testing.T) {
+	m, now := newTestManager(time.Hour)
+	ctx := context.Background()
+
+	token, _ := m.Issue(ctx, "550e8400-e29b-41d4-a716-446655440000", "Alice", true)
+	// three hours in total, but never more than one hour between refreshes
+	for i := 0; i < 3; i++ {
+		*now = now.Add(50 * time.Minute)
+		_, next, err := m.Rotate(ctx, token)
+		if err != nil {
+			t.Fatalf("rotation %d: %v", i, err)
+		}
+		token = next
+	}
+
+	*now = now.Add(time.Hour)
+	if _, _, err := m.Rotate(ctx, token); !errors.Is(err, ErrExpired) {
+		t.Fatalf("expected ErrExpired after an hour without refresh, got %v", err)
+	}
+}
+
+func TestRotateReuseRevokesFamily(t *testing.T) {
+	m, _ := newTestManager(time.Hour)
+	ctx := context.Background()
+
+	first, _ := m.Issue(ctx, "550e8400-e29b-41d4-a716-446655440000", "Alice", true)
+	_, second, err := m.Rotate(ctx, first)
+	if err != nil {
+		t.Fatalf("Rotate error: %v", err)
+	}
+
+	// the old token shows up again: someone else had a copy
+	if _, _, err := m.Rotate(ctx, first); !errors.Is(err, ErrReused) {
+		t.Fatalf("expected ErrReused, got %v", err)
+	}
+	if _, _, err := m.Rotate(ctx, second); !errors.Is(err, ErrRevoked) {
+		t.Fatalf("expected the successor to be revoked, got %v", err)
+	}
+}
+
+func TestRotateOtherFamiliesUnaffected(t *testing.T) {
+	m, _ := newTestManager(time.Hour)
+	ctx := context.Background()
+
+	alice, _ := m.Issue(ctx, "550e8400-e29b-41d4-a716-446655440000", "Alice", true)
+	bob, _ := m.Issue(ctx, "6b0f7c0e-4c1a-4c55-9b3a-0b8f4a6f6d11", "Bob", true)
+	_, _, _ = m.Rotate(ctx, alice)
+	_, _, _ = m.Rotate(ctx, alice)
+
+	if _, _, err := m.Rotate(ctx, bob); err != nil {
+		t.Fatalf("expected Bob's session to survive, got %v", err)
+	}
+}
+
+func TestRotateUnknownToken(t *testing.T) {
+	m, _ := newTestManager(time.Hour)
+	if _, _, err := m.Rotate(context.Background(), "not-a-token"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestRevokeEndsSession(t *testing.T) {
+	m, _ := newTestManager(time.Hour)
+	ctx := context.Background()
+
+	first, _ := m.Issue(ctx, "550e8400-e29b-41d4-a716-446655440000", "Alice", true)
+	_, second, _ := m.Rotate(ctx, first)
+	// revoking with an already used token still ends the session
+	if err := m.Revoke(ctx, first); err != nil {
+		t.Fatalf("Revoke error: %v", err)
+	}
+	if _, _, err := m.Rotate(ctx, second); !errors.Is(err, ErrRevoked) {
+		t.Fatalf("expected ErrRevoked after revoke, got %v", err)
+	}
+	if err := m.Revoke(ctx, "unknown"); err != nil {
+		t.Fatalf("expected unknown tokens to be ignored, got %v", err)
+	}
+}
+
+func TestMemoryDeleteExpired(t *testing.T) {
+	s := NewMemory()
+	now := time.Now()
+	_ = s.Create(context.Background(), Token{Hash: "a", ExpiresAt: now.Add(-time.Minute)})
+	_ = s.Create(context.Background(), Token{Hash: "b", ExpiresAt: now.Add(time.Minute)})
+
+	n, err := s.DeleteExpired(context.Background(), now)
+	if err != nil || n != 1 {
+		t.Fatalf("expected 1 deleted token, got %d (%v)", n, err)
+	}
+	if _, ok := s.tokens["b"]; !ok {
+		t.Fatalf("expected the live token to be kept")
+	}
+}
@@ -0,0 +1,99 @@
+package refresh
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MemoryStore implements Store with an in-process map.
+// Tokens are lost on restart. Used in tests and for running without a database.
+type MemoryStore struct {
+	mu     sync.Mutex
+	tokens map[string]Token
+}
+
+// NewMemory creates an empty MemoryStore
+func NewMemory() *MemoryStore {
+	return &MemoryStore{tokens: make(map[string]Token)}
+}
+
+func (s *MemoryStore) Create(_ context.Context, t Token) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tokens[t.Hash] = t
+	return nil
+}
+
+func (s *MemoryStore) Rotate(_ context.Context, oldHash, newHash string, expiresAt, now time.Time) (Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t, ok := s.tokens[oldHash]
+	if !ok {
+		return Token{}, ErrNotFound
+	}
+	if t.Revoked {
+		return Token{}, ErrRevoked
+	}
+	if t.UsedAt != nil {
+		s.revokeFamilyLocked(t.FamilyID)
+		return Token{}, ErrReused
+	}
+	if !now.Before(t.ExpiresAt) {
+		return Token{}, ErrExpired
+	}
+
+	consumed := t
+	usedAt := now
+	t.UsedAt = &usedAt
+	s.tokens[oldHash] = t
+	s.tokens[newHash] = Token{
+		Hash:      newHash,
+		FamilyID:  t.FamilyID,
+		UserID:    t.UserID,
+		Username:  t.Username,
+		Guest:     t.Guest,
+		ExpiresAt: expiresAt,
+	}
+	return consumed, nil
+}
+
+func (s *MemoryStore) RevokeFamily(_ context.Context, familyID uuid.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.revokeFamilyLocked(familyID)
+	return nil
+}
+
+func (s *MemoryStore) RevokeFamilyOf(_ context.Context, hash string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if t, ok := s.tokens[hash]; ok {
+		s.revokeFamilyLocked(t.FamilyID)
+	}
+	return nil
+}
+
+func (s *MemoryStore) revokeFamilyLocked(familyID uuid.UUID) {
+	for hash, t := range s.tokens {
+		if t.FamilyID == familyID {
+			t.Revoked = true
+			s.tokens[hash] = t
+		}
+	}
+}
+
+func (s *MemoryStore) DeleteExpired(_ context.Context, now time.Time) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var n int64
+	for hash, t := range s.tokens {
+		if !now.Before(t.ExpiresAt) {
+			delete(s.tokens, hash)
+			n++
+		}
+	}
+	return n, nil
+}
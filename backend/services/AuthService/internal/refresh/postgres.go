@@ -0,0 +1,104 @@
+package refresh
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PostgresStore implements Store using a *sql.DB
+type PostgresStore struct {
+	DB *sql.DB
+}
+
+// NewPostgres creates a new PostgresStore
+func NewPostgres(db *sql.DB) *PostgresStore {
+	return &PostgresStore{DB: db}
+}
+
+func (s *PostgresStore) Create(ctx context.Context, t Token) error {
+	_, err := s.DB.ExecContext(ctx, `
+		INSERT INTO refresh_tokens (token_hash, family_id, user_id, username, is_guest, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`, t.Hash, t.FamilyID, t.UserID, t.Username, t.Guest, t.ExpiresAt)
+	return err
+}
+
+func (s *PostgresStore) Rotate(ctx context.Context, oldHash, newHash string, expiresAt, now time.Time) (Token, error) {
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return Token{}, err
+	}
+	defer tx.Rollback()
+
+	// FOR UPDATE serializes concurrent uses of the same token: the second one sees used_at
+	// only after the first committed its successor, so revoking the family also covers it
+	t := Token{Hash: oldHash}
+	var usedAt sql.NullTime
+	err = tx.QueryRowContext(ctx, `
+		SELECT family_id, user_id, username, is_guest, expires_at, used_at, revoked
+		FROM refresh_tokens
+		WHERE token_hash = $1
+		FOR UPDATE
+	`, oldHash).Scan(&t.FamilyID, &t.UserID, &t.Username, &t.Guest, &t.ExpiresAt, &usedAt, &t.Revoked)
+	if errors.Is(err, sql.ErrNoRows) {
+		return Token{}, ErrNotFound
+	}
+	if err != nil {
+		return Token{}, err
+	}
+
+	if t.Revoked {
+		return Token{}, ErrRevoked
+	}
+	if usedAt.Valid {
+		if _, err := tx.ExecContext(ctx, `UPDATE refresh_tokens SET revoked = TRUE WHERE family_id = $1`, t.FamilyID); err != nil {
+			return Token{}, err
+		}
+		if err := tx.Commit(); err != nil {
+			return Token{}, err
+		}
+		return Token{}, ErrReused
+	}
+	if !now.Before(t.ExpiresAt) {
+		return Token{}, ErrExpired
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE refresh_tokens SET used_at = $2 WHERE token_hash = $1`, oldHash, now); err != nil {
+		return Token{}, err
+	}
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO refresh_tokens (token_hash, family_id, user_id, username, is_guest, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`, newHash, t.FamilyID, t.UserID, t.Username, t.Guest, expiresAt); err != nil {
+		return Token{}, err
+	}
+	if err := tx.Commit(); err != nil {
+		return Token{}, err
+	}
+	return t, nil
+}
+
+func (s *PostgresStore) RevokeFamily(ctx context.Context, familyID uuid.UUID) error {
+	_, err := s.DB.ExecContext(ctx, `UPDATE refresh_tokens SET revoked = TRUE WHERE family_id = $1`, familyID)
+	return err
+}
+
+func (s *PostgresStore) RevokeFamilyOf(ctx context.Context, hash string) error {
+	_, err := s.DB.ExecContext(ctx, `
+		UPDATE refresh_tokens SET revoked = TRUE
+		WHERE family_id = (SELECT family_id FROM refresh_tokens WHERE token_hash = $1)
+	`, hash)
+	return err
+}
+
+func (s *PostgresStore) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	res, err := s.DB.ExecContext(ctx, `DELETE FROM refresh_tokens WHERE expires_at <= $1`, now)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
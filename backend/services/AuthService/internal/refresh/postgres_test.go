@@ -0,0 +1,85 @@
+package refresh
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+)
+
+var tokenColumns = []string{"family_id", "user_id", "username", "is_guest", "expires_at", "used_at", "revoked"}
+
+func newMockStore(t *testing.T) (*PostgresStore, sqlmock.Sqlmock) {
+	t.Helper()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return NewPostgres(db), mock
+}
+
+func TestPostgresRotate(t *testing.T) {
+	store, mock := newMockStore(t)
+	family := uuid.New()
+	now := time.Date(2025, 1, 1, 20, 0, 0, 0, time.UTC)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT family_id, user_id, username, is_guest, expires_at, used_at, revoked FROM refresh_tokens").
+		WithArgs("old").
+		WillReturnRows(sqlmock.NewRows(tokenColumns).AddRow(family.String(), "550e8400-e29b-41d4-a716-446655440000", "Alice", true, now.Add(time.Hour), nil, false))
+	mock.ExpectExec("UPDATE refresh_tokens SET used_at").WithArgs("old", now).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO refresh_tokens").
+		WithArgs("new", family, "550e8400-e29b-41d4-a716-446655440000", "Alice", true, now.Add(2*time.Hour)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	tok, err := store.Rotate(context.Background(), "old", "new", now.Add(2*time.Hour), now)
+	if err != nil {
+		t.Fatalf("Rotate error: %v", err)
+	}
+	if tok.FamilyID != family || tok.Username != "Alice" {
+		t.Fatalf("unexpected token %+v", tok)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestPostgresRotateReuseRevokesFamily(t *testing.T) {
+	store, mock := newMockStore(t)
+	family := uuid.New()
+	now := time.Date(2025, 1, 1, 20, 0, 0, 0, time.UTC)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT family_id").
+		WithArgs("old").
+		WillReturnRows(sqlmock.NewRows(tokenColumns).AddRow(family.String(), "550e8400-e29b-41d4-a716-446655440000", "Alice", true, now.Add(time.Hour), now.Add(-time.Minute), false))
+	mock.ExpectExec("UPDATE refresh_tokens SET revoked = TRUE WHERE family_id").WithArgs(family).WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+
+	if _, err := store.Rotate(context.Background(), "old", "new", now.Add(2*time.Hour), now); !errors.Is(err, ErrReused) {
+		t.Fatalf("expected ErrReused, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestPostgresRotateNotFound(t *testing.T) {
+	store, mock := newMockStore(t)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT family_id").WithArgs("old").WillReturnRows(sqlmock.NewRows(tokenColumns))
+	mock.ExpectRollback()
+
+	if _, err := store.Rotate(context.Background(), "old", "new", time.Now(), time.Now()); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unfulfilled expectations: %v", err)
+	}
+}
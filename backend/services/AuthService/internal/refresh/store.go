@@ -0,0 +1,53 @@
+// Package refresh issues and rotates the opaque refresh tokens that keep a session alive
+// after its short-lived access token (JWT) expired.
+//
+// Every refresh token can be used once. Using it returns a successor in the same family,
+// so a session is a chain of tokens. If a token is presented a second time, one of the two
+// holders must have stolen it, and the whole family is revoked.
+package refresh
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrNotFound is returned for unknown (or already cleaned up) tokens
+	ErrNotFound = errors.New("refresh token not found")
+	// ErrExpired is returned for tokens past their expiry
+	ErrExpired = errors.New("refresh token expired")
+	// ErrReused is returned when an already used token is presented again; its family is revoked
+	ErrReused = errors.New("refresh token reused")
+	// ErrRevoked is returned for tokens of a revoked family
+	ErrRevoked = errors.New("refresh token revoked")
+)
+
+// Token is a stored refresh token. Only the hash of the token value is stored.
+type Token struct {
+	Hash      string
+	FamilyID  uuid.UUID
+	UserID    string
+	Username  string
+	Guest     bool
+	ExpiresAt time.Time
+	UsedAt    *time.Time
+	Revoked   bool
+}
+
+// Store persists refresh tokens.
+type Store interface {
+	// Create stores the first token of a new family
+	Create(ctx context.Context, t Token) error
+	// Rotate marks the token with oldHash used and stores its successor with newHash in the same family, atomically.
+	// Returns the consumed token. If it was used before, the family is revoked and ErrReused returned.
+	Rotate(ctx context.Context, oldHash, newHash string, expiresAt, now time.Time) (Token, error)
+	// RevokeFamily revokes all tokens of the family
+	RevokeFamily(ctx context.Context, familyID uuid.UUID) error
+	// RevokeFamilyOf revokes all tokens of the family the token with hash belongs to; unknown hashes are ignored
+	RevokeFamilyOf(ctx context.Context, hash string) error
+	// DeleteExpired removes tokens that expired before now and returns how many were removed
+	DeleteExpired(ctx context.Context, now time.Time) (int64, error)
+}
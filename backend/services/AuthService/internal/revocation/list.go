@@ -0,0 +1,104 @@
+// Package revocation tracks access tokens (JWTs) invalidated before their expiry, by their jti claim.
+//
+// Revocations are checked on every validation, so the list is kept in memory.
+// A Store persists them across restarts and shares them between replicas.
+package revocation
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// Store persists revocations. A revocation is only needed until the token it names has expired.
+type Store interface {
+	// Revoke stores the revocation of jti; revoking a jti twice is not an error
+	Revoke(ctx context.Context, jti string, expiresAt time.Time) error
+	// Active returns the revocations of tokens that have not expired at now, jti to expiry
+	Active(ctx context.Context, now time.Time) (map[string]time.Time, error)
+	// DeleteExpired removes revocations of tokens that expired before now and returns how many were removed
+	DeleteExpired(ctx context.Context, now time.Time) (int64, error)
+}
+
+// List is the in-memory revocation list. With a Store, revocations are written through to it
+// and revocations made by other replicas are picked up by Sync.
+type List struct {
+	store Store
+	now   func() time.Time
+
+	mu      sync.RWMutex
+	revoked map[string]time.Time
+}
+
+// NewList creates an empty list persisting to store. store may be nil to keep revocations in memory only.
+func NewList(store Store) *List {
+	return &List{store: store, now: time.Now, revoked: make(map[string]time.Time)}
+}
+
+// Revoke invalidates the token with jti until expiresAt, its exp claim
+func (l *List) Revoke(ctx context.Context, jti string, expiresAt time.Time) error {
+	if l.store != nil {
+		if err := l.store.Revoke(ctx, jti, expiresAt); err != nil {
+			return fmt.Errorf("failed to store revocation: %w", err)
+		}
+	}
+	l.mu.Lock()
+	l.revoked[jti] = expiresAt
+	l.mu.Unlock()
+	return nil
+}
+
+// IsRevoked reports whether the token with jti was revoked
+func (l *List) IsRevoked(jti string) bool {
+	l.mu.RLock()
+	_, ok := l.revoked[jti]
+	l.mu.RUnlock()
+	return ok
+}
+
+// Sync drops revocations of expired tokens and loads the ones stored by other replicas
+func (l *List) Sync(ctx context.Context) error {
+	now := l.now()
+	var active map[string]time.Time
+	if l.store != nil {
+		if _, err := l.store.DeleteExpired(ctx, now); err != nil {
+			return fmt.Errorf("failed to delete expired revocations: %w", err)
+		}
+		var err error
+		if active, err = l.store.Active(ctx, now); err != nil {
+			return fmt.Errorf("failed to load revocations: %w", err)
+		}
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for jti, expiresAt := range l.revoked {
+		if !now.Before(expiresAt) {
+			delete(l.revoked, jti)
+		}
+	}
+	for jti, expiresAt := range active {
+		l.revoked[jti] = expiresAt
+	}
+	return nil
+}
+
+// Run calls Sync every interval until ctx is cancelled.
+// interval bounds how long a token revoked on another replica is still accepted here.
+func (l *List) Run(ctx context.Context, interval time.Duration, log *slog.Logger) {
+	log = log.With(slog.String("component", "revocation_sync"))
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := l.Sync(ctx); err != nil {
+				log.Error("failed to sync revocations", slog.String("error", err.Error()))
+			}
+		}
+	}
+}
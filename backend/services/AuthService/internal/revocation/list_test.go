@@ -0,0 +1,29 @@
+package revocation
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestRevokeAndExpire(t *testing.T) {
+	l := NewList(nil)
+	now := time.Date(2025, 1, 1, 20, 0, 0, 0, time.UTC)
+	l.now = func() time.Time { return now }
+
+	if err := l.Revoke(context.Background(), "jti-1", now.Add(time.Minute)); err != nil {
+		t.Fatalf("Revoke error: %v", err)
+	}
+	if !l.IsRevoked("jti-1") || l.IsRevoked("jti-2") {
+		t.Fatalf("expected only jti-1 to be revoked")
+	}
+
+	// the token has expired, its revocation is no longer needed
+	now = now.Add(time.Minute)
+	if err := l.Sync(context.Background()); err != nil {
+		t.Fatalf("Sync error: %v", err)
+	}
+	if len(l.revoked) != 0 {
+		t.Fatalf("expected the expired revocation to be dropped, got %v", l.revoked)
+	}
+}
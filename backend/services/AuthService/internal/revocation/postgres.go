@@ -0,0 +1,53 @@
+package revocation
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// PostgresStore implements Store using a *sql.DB
+type PostgresStore struct {
+	DB *sql.DB
+}
+
+// NewPostgres creates a new PostgresStore
+func NewPostgres(db *sql.DB) *PostgresStore {
+	return &PostgresStore{DB: db}
+}
+
+func (s *PostgresStore) Revoke(ctx context.Context, jti string, expiresAt time.Time) error {
+	_, err := s.DB.ExecContext(ctx, `
+		INSERT INTO revoked_tokens (jti, expires_at)
+		VALUES ($1, $2)
+		ON CONFLICT (jti) DO NOTHING
+	`, jti, expiresAt)
+	return err
+}
+
+func (s *PostgresStore) Active(ctx context.Context, now time.Time) (map[string]time.Time, error) {
+	rows, err := s.DB.QueryContext(ctx, `SELECT jti, expires_at FROM revoked_tokens WHERE expires_at > $1`, now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	active := make(map[string]time.Time)
+	for rows.Next() {
+		var jti string
+		var expiresAt time.Time
+		if err := rows.Scan(&jti, &expiresAt); err != nil {
+			return nil, err
+		}
+		active[jti] = expiresAt
+	}
+	return active, rows.Err()
+}
+
+func (s *PostgresStore) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	res, err := s.DB.ExecContext(ctx, `DELETE FROM revoked_tokens WHERE expires_at <= $1`, now)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
@@ -0,0 +1,44 @@
+package revocation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+// A revocation made by one replica reaches the other on its next sync
+func TestPostgresSyncBetweenReplicas(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer db.Close()
+	now := time.Date(2025, 1, 1, 20, 0, 0, 0, time.UTC)
+	expiresAt := now.Add(15 * time.Minute)
+
+	first, second := NewList(NewPostgres(db)), NewList(NewPostgres(db))
+	second.now = func() time.Time { return now }
+
+	mock.ExpectExec("INSERT INTO revoked_tokens").WithArgs("jti-1", expiresAt).WillReturnResult(sqlmock.NewResult(0, 1))
+	if err := first.Revoke(context.Background(), "jti-1", expiresAt); err != nil {
+		t.Fatalf("Revoke error: %v", err)
+	}
+
+	mock.ExpectExec("DELETE FROM revoked_tokens").WithArgs(now).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("SELECT jti, expires_at FROM revoked_tokens").WithArgs(now).
+		WillReturnRows(sqlmock.NewRows([]string{"jti", "expires_at"}).AddRow("jti-1", expiresAt))
+	if second.IsRevoked("jti-1") {
+		t.Fatalf("expected the second replica not to know the revocation before syncing")
+	}
+	if err := second.Sync(context.Background()); err != nil {
+		t.Fatalf("Sync error: %v", err)
+	}
+	if !second.IsRevoked("jti-1") {
+		t.Fatalf("expected the revocation after syncing")
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unfulfilled expectations: %v", err)
+	}
+}
@@ -5,13 +5,23 @@ import (
 
 	"github.com/KnuffelGame/KnuffelGame/backend/libs/healthcheck"
 	"github.com/KnuffelGame/KnuffelGame/backend/libs/logger"
+	"github.com/KnuffelGame/KnuffelGame/backend/libs/username"
 	"github.com/KnuffelGame/KnuffelGame/backend/services/AuthService/internal/handlers"
 	"github.com/KnuffelGame/KnuffelGame/backend/services/AuthService/internal/jwt"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/AuthService/internal/keys"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/AuthService/internal/oidc"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/AuthService/internal/refresh"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/AuthService/internal/revocation"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/AuthService/internal/users"
 	"github.com/go-chi/chi/v5"
 )
 
 // New constructs the HTTP router using the provided JWT generator and validator, and attaches logging middleware.
-func New(gen *jwt.Generator, val *jwt.Validator) http.Handler {
+// sessions issues the refresh tokens and cookie configures the session cookies set by the public /auth endpoints.
+// ring holds the public keys served as JWKS; it is nil when tokens are signed with HS256 only.
+// revoked is the revocation list val checks, logout adds to it.
+// accounts stores the users and names is the policy new usernames have to pass; providers are the OIDC providers accepted by /internal/oidc-exchange and /auth/upgrade, by name.
+func New(gen *jwt.Generator, val *jwt.Validator, ring *keys.Ring, revoked *revocation.List, accounts users.Store, names *username.Policy, sessions *refresh.Manager, cookie handlers.CookieConfig, providers map[string]oidc.Verifier) http.Handler {
 	r := chi.NewRouter()
 	// replace chi default logger with structured slog based middleware
 	l := logger.Default()
@@ -21,10 +31,22 @@ func New(gen *jwt.Generator, val *jwt.Validator) http.Handler {
 	healthcheck.Mount(r)
 
 	// Routes
+	// GET /.well-known/jwks.json -> public keys verifying RS256/EdDSA tokens
+	r.Get("/.well-known/jwks.json", handlers.JWKSHandler(ring))
+	// POST /auth/guest -> create guest user and set session cookie (public, via API Gateway)
+	r.Post("/auth/guest", handlers.GuestSessionHandler(gen, accounts, names, sessions, cookie))
+	// POST /auth/refresh -> rotate refresh token and issue a new JWT (public, via API Gateway)
+	r.Post("/auth/refresh", handlers.RefreshSessionHandler(gen, accounts, sessions, cookie))
+	// POST /auth/logout -> revoke JWT and refresh token, clear session cookies (public, via API Gateway)
+	r.Post("/auth/logout", handlers.LogoutHandler(val, revoked, sessions, cookie))
+	// POST /auth/upgrade -> link an OIDC identity to the guest of the session (public, via API Gateway)
+	r.Post("/auth/upgrade", handlers.UpgradeAccountHandler(gen, val, accounts, revoked, sessions, cookie, providers))
 	// POST /internal/create -> create (or guest) user token
-	r.Post("/internal/create", handlers.CreateTokenHandler(gen))
+	r.Post("/internal/create", handlers.CreateTokenHandler(gen, accounts, names))
 	// POST /internal/validate -> validate token
 	r.Post("/internal/validate", handlers.ValidateTokenHandler(val))
+	// POST /internal/oidc-exchange -> verify OIDC ID token and create account token
+	r.Post("/internal/oidc-exchange", handlers.OIDCExchangeHandler(gen, accounts, names, providers))
 
 	return r
 }
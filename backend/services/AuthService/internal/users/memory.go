@@ -0,0 +1,79 @@
+package users
+
+import (
+	"context"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// MemoryStore implements Store with in-process maps.
+// Users are lost on restart. Used in tests and for running without a database.
+type MemoryStore struct {
+	mu         sync.Mutex
+	users      map[uuid.UUID]User
+	identities map[[2]string]uuid.UUID
+}
+
+// NewMemory creates an empty MemoryStore
+func NewMemory() *MemoryStore {
+	return &MemoryStore{users: make(map[uuid.UUID]User), identities: make(map[[2]string]uuid.UUID)}
+}
+
+func (s *MemoryStore) Create(_ context.Context, u User) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.users[u.ID]; !ok {
+		u.Identities = nil
+		s.users[u.ID] = u
+	}
+	return nil
+}
+
+func (s *MemoryStore) Get(_ context.Context, id uuid.UUID) (User, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	u, ok := s.users[id]
+	if !ok {
+		return User{}, ErrNotFound
+	}
+	return clone(u), nil
+}
+
+func (s *MemoryStore) FindByIdentity(_ context.Context, issuer, subject string) (User, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id, ok := s.identities[[2]string{issuer, subject}]
+	if !ok {
+		return User{}, ErrNotFound
+	}
+	return clone(s.users[id]), nil
+}
+
+func (s *MemoryStore) Link(_ context.Context, u User, identity Identity) (User, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := [2]string{identity.Issuer, identity.Subject}
+	if owner, ok := s.identities[key]; ok {
+		if owner != u.ID {
+			return User{}, ErrIdentityTaken
+		}
+		return clone(s.users[owner]), nil
+	}
+
+	stored, ok := s.users[u.ID]
+	if !ok {
+		stored = User{ID: u.ID, Username: u.Username, CreatedAt: u.CreatedAt}
+	}
+	stored.Guest = false
+	stored.Identities = append(stored.Identities, identity)
+	s.users[u.ID] = stored
+	s.identities[key] = u.ID
+	return clone(stored), nil
+}
+
+// clone copies u so callers cannot modify the stored identities
+func clone(u User) User {
+	u.Identities = append([]Identity(nil), u.Identities...)
+	return u
+}
@@ -0,0 +1,59 @@
+package users
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestLinkUpgradesGuest(t *testing.T) {
+	s := NewMemory()
+	ctx := context.Background()
+	guest := User{ID: uuid.New(), Username: "Alice", Guest: true, CreatedAt: time.Now()}
+	if err := s.Create(ctx, guest); err != nil {
+		t.Fatalf("Create error: %v", err)
+	}
+
+	u, err := s.Link(ctx, guest, Identity{Issuer: "https://accounts.google.com", Subject: "1234"})
+	if err != nil {
+		t.Fatalf("Link error: %v", err)
+	}
+	if u.ID != guest.ID || u.Guest || len(u.Identities) != 1 {
+		t.Fatalf("expected the guest to become a registered user with the same id, got %+v", u)
+	}
+	found, err := s.FindByIdentity(ctx, "https://accounts.google.com", "1234")
+	if err != nil || found.ID != guest.ID {
+		t.Fatalf("expected the identity to resolve to the user, got %+v (%v)", found, err)
+	}
+	// linking again is idempotent
+	if _, err := s.Link(ctx, guest, Identity{Issuer: "https://accounts.google.com", Subject: "1234"}); err != nil {
+		t.Fatalf("expected relinking to succeed, got %v", err)
+	}
+}
+
+func TestLinkIdentityTaken(t *testing.T) {
+	s := NewMemory()
+	ctx := context.Background()
+	identity := Identity{Issuer: "https://accounts.google.com", Subject: "1234"}
+	if _, err := s.Link(ctx, User{ID: uuid.New(), Username: "Alice"}, identity); err != nil {
+		t.Fatalf("Link error: %v", err)
+	}
+
+	bob := User{ID: uuid.New(), Username: "Bob", Guest: true}
+	_ = s.Create(ctx, bob)
+	if _, err := s.Link(ctx, bob, identity); !errors.Is(err, ErrIdentityTaken) {
+		t.Fatalf("expected ErrIdentityTaken, got %v", err)
+	}
+	if u, _ := s.Get(ctx, bob.ID); !u.Guest {
+		t.Fatalf("expected Bob to stay a guest")
+	}
+}
+
+func TestGetUnknown(t *testing.T) {
+	if _, err := NewMemory().Get(context.Background(), uuid.New()); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,111 @@
+package users
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+// PostgresStore implements Store using a *sql.DB
+type PostgresStore struct {
+	DB *sql.DB
+}
+
+// NewPostgres creates a new PostgresStore
+func NewPostgres(db *sql.DB) *PostgresStore {
+	return &PostgresStore{DB: db}
+}
+
+func (s *PostgresStore) Create(ctx context.Context, u User) error {
+	_, err := s.DB.ExecContext(ctx, `
+		INSERT INTO users (id, username, is_guest, created_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (id) DO NOTHING
+	`, u.ID, u.Username, u.Guest, u.CreatedAt)
+	return err
+}
+
+func (s *PostgresStore) Get(ctx context.Context, id uuid.UUID) (User, error) {
+	u := User{ID: id}
+	err := s.DB.QueryRowContext(ctx, `
+		SELECT username, is_guest, created_at
+		FROM users
+		WHERE id = $1
+	`, id).Scan(&u.Username, &u.Guest, &u.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return User{}, ErrNotFound
+	}
+	if err != nil {
+		return User{}, err
+	}
+
+	rows, err := s.DB.QueryContext(ctx, `
+		SELECT issuer, subject, email, linked_at
+		FROM user_identities
+		WHERE user_id = $1
+		ORDER BY linked_at
+	`, id)
+	if err != nil {
+		return User{}, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var i Identity
+		if err := rows.Scan(&i.Issuer, &i.Subject, &i.Email, &i.LinkedAt); err != nil {
+			return User{}, err
+		}
+		u.Identities = append(u.Identities, i)
+	}
+	return u, rows.Err()
+}
+
+func (s *PostgresStore) FindByIdentity(ctx context.Context, issuer, subject string) (User, error) {
+	var id uuid.UUID
+	err := s.DB.QueryRowContext(ctx, `
+		SELECT user_id FROM user_identities WHERE issuer = $1 AND subject = $2
+	`, issuer, subject).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return User{}, ErrNotFound
+	}
+	if err != nil {
+		return User{}, err
+	}
+	return s.Get(ctx, id)
+}
+
+func (s *PostgresStore) Link(ctx context.Context, u User, identity Identity) (User, error) {
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return User{}, err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO users (id, username, is_guest, created_at)
+		VALUES ($1, $2, FALSE, $3)
+		ON CONFLICT (id) DO UPDATE SET is_guest = FALSE
+	`, u.ID, u.Username, u.CreatedAt); err != nil {
+		return User{}, err
+	}
+
+	// the primary key on (issuer, subject) decides concurrent links of the same identity
+	var owner uuid.UUID
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO user_identities (issuer, subject, user_id, email, linked_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (issuer, subject) DO UPDATE SET issuer = EXCLUDED.issuer
+		RETURNING user_id
+	`, identity.Issuer, identity.Subject, u.ID, identity.Email, identity.LinkedAt).Scan(&owner)
+	if err != nil {
+		return User{}, err
+	}
+	if owner != u.ID {
+		return User{}, ErrIdentityTaken
+	}
+	if err := tx.Commit(); err != nil {
+		return User{}, err
+	}
+	return s.Get(ctx, u.ID)
+}
@@ -0,0 +1,67 @@
+package users
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+)
+
+func newMockStore(t *testing.T) (*PostgresStore, sqlmock.Sqlmock) {
+	t.Helper()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return NewPostgres(db), mock
+}
+
+func TestPostgresLink(t *testing.T) {
+	store, mock := newMockStore(t)
+	id := uuid.New()
+	now := time.Date(2025, 1, 1, 20, 0, 0, 0, time.UTC)
+	identity := Identity{Issuer: "https://accounts.google.com", Subject: "1234", Email: "alice@example.com", LinkedAt: now}
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO users").WithArgs(id, "Alice", now).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery("INSERT INTO user_identities").
+		WithArgs(identity.Issuer, identity.Subject, id, identity.Email, now).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id"}).AddRow(id))
+	mock.ExpectCommit()
+	mock.ExpectQuery("SELECT username, is_guest, created_at FROM users").WithArgs(id).
+		WillReturnRows(sqlmock.NewRows([]string{"username", "is_guest", "created_at"}).AddRow("Alice", false, now))
+	mock.ExpectQuery("SELECT issuer, subject, email, linked_at FROM user_identities").WithArgs(id).
+		WillReturnRows(sqlmock.NewRows([]string{"issuer", "subject", "email", "linked_at"}).AddRow(identity.Issuer, identity.Subject, identity.Email, now))
+
+	u, err := store.Link(context.Background(), User{ID: id, Username: "Alice", CreatedAt: now}, identity)
+	if err != nil {
+		t.Fatalf("Link error: %v", err)
+	}
+	if u.Guest || len(u.Identities) != 1 || u.Identities[0].Subject != "1234" {
+		t.Fatalf("unexpected user %+v", u)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestPostgresLinkIdentityTaken(t *testing.T) {
+	store, mock := newMockStore(t)
+	id := uuid.New()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO users").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery("INSERT INTO user_identities").WillReturnRows(sqlmock.NewRows([]string{"user_id"}).AddRow(uuid.New()))
+	mock.ExpectRollback()
+
+	if _, err := store.Link(context.Background(), User{ID: id, Username: "Bob"}, Identity{Issuer: "iss", Subject: "sub"}); !errors.Is(err, ErrIdentityTaken) {
+		t.Fatalf("expected ErrIdentityTaken, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unfulfilled expectations: %v", err)
+	}
+}
@@ -0,0 +1,52 @@
+// Package users stores the user accounts of KnuffelGame.
+//
+// A user starts as guest, identified by nothing but the session cookie. Linking an external identity
+// (an OIDC issuer and subject) upgrades it to a registered account; the user id stays the same,
+// so everything the other services keyed by it stays attached.
+package users
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrNotFound is returned for unknown users and identities
+	ErrNotFound = errors.New("user not found")
+	// ErrIdentityTaken is returned when an identity is already linked to another user
+	ErrIdentityTaken = errors.New("identity already linked to another user")
+)
+
+// User is a stored account
+type User struct {
+	ID         uuid.UUID
+	Username   string
+	Guest      bool
+	Identities []Identity
+	CreatedAt  time.Time
+}
+
+// Identity is an external account linked to a user, identified by issuer and subject
+type Identity struct {
+	Issuer   string
+	Subject  string
+	Email    string
+	LinkedAt time.Time
+}
+
+// Store persists users and their identities.
+type Store interface {
+	// Create stores a new user without identities; creating an existing id is not an error
+	Create(ctx context.Context, u User) error
+	// Get returns the user with id
+	Get(ctx context.Context, id uuid.UUID) (User, error)
+	// FindByIdentity returns the user the identity is linked to
+	FindByIdentity(ctx context.Context, issuer, subject string) (User, error)
+	// Link links identity to the user u.ID and marks it registered, atomically. A user missing from the store
+	// (a guest from before the store existed) is created from u. Returns ErrIdentityTaken if the identity
+	// belongs to another user; linking an identity to its own user again is not an error.
+	Link(ctx context.Context, u User, identity Identity) (User, error)
+}
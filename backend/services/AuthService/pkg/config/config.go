@@ -1,22 +1,213 @@
 package config
 
-import "os"
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
 
 // Config holds runtime configuration loaded from environment variables.
 // PORT defaults to 8081 if unset.
-// JWT_SECRET must be set for token generation (warning logged otherwise).
+// JWT_SIGNING_ALG is RS256 (default), EdDSA or HS256. With HS256 tokens are signed with JWT_SECRET,
+// otherwise with rotating keys published at /.well-known/jwks.json.
+// JWT_SECRET is required for HS256; with an asymmetric algorithm a set secret keeps HS256 tokens verifying
+// during the migration, unset it once they have expired.
+// JWT_KEY_ROTATION_INTERVAL is how often a new signing key is created (default 24h).
+// SIGNING_KEY_STORE is "memory" (default, single replica, keys lost on restart) or "postgres".
+// ACCESS_TOKEN_TTL is the lifetime of a JWT as a Go duration (default 15m).
+// REFRESH_TOKEN_TTL is how long a session survives without a refresh (default 168h); every refresh extends it.
+// REFRESH_TOKEN_STORE is "memory" (default, lost on restart) or "postgres".
+// USER_STORE is "memory" (default, lost on restart) or "postgres" for the user accounts.
+// USERNAME_BLOCKLIST_FILE is a file of words that must not appear in usernames, one per line (default none).
+// REVOCATION_STORE is "memory" (default, lost on restart) or "postgres" to persist revoked tokens and share them between replicas.
+// COOKIE_SECURE (default "true"), COOKIE_DOMAIN (default host only) and COOKIE_SAMESITE
+// (strict, lax or none; default strict) set the attributes of the session cookies.
+// OIDC_ISSUER enables /internal/oidc-exchange for the provider named OIDC_PROVIDER (default "google").
+// OIDC_AUDIENCE is the client id registered with the provider. The provider keys are fetched from
+// OIDC_JWKS_URL or read from OIDC_JWKS_FILE, exactly one of them must be set.
+// DATABASE_* default to the docker compose Postgres instance and are only used by the postgres stores.
 // Extend here for future configuration values.
 
 type Config struct {
-	JWTSecret string
-	Port      string
+	JWTSecret         string
+	JWTSigningAlg     string
+	KeyRotation       time.Duration
+	SigningKeyStore   string
+	Port              string
+	AccessTokenTTL    time.Duration
+	RefreshTokenTTL   time.Duration
+	RefreshTokenStore string
+	RevocationStore   string
+	UserStore         string
+	UsernameBlocklist string
+	OIDCProvider      string
+	OIDCIssuer        string
+	OIDCAudience      string
+	OIDCJWKSURL       string
+	OIDCJWKSFile      string
+	CookieSecure      bool
+	CookieDomain      string
+	CookieSameSite    http.SameSite
+	DatabaseHost      string
+	DatabasePort      string
+	DatabaseUser      string
+	DatabasePassword  string
+	DatabaseName      string
+	DatabaseSSLMode   string
 }
 
-func Load() *Config {
-	secret := os.Getenv("JWT_SECRET")
-	port := os.Getenv("PORT")
-	if port == "" {
-		port = "8081"
+// Signing algorithms accepted by JWT_SIGNING_ALG
+const (
+	AlgHS256 = "HS256"
+	AlgRS256 = "RS256"
+	AlgEdDSA = "EdDSA"
+)
+
+// Stores accepted by the *_STORE variables
+const (
+	StoreMemory   = "memory"
+	StorePostgres = "postgres"
+)
+
+// Load reads the configuration from the environment and validates it.
+func Load() (*Config, error) {
+	keyRotation, err := duration("JWT_KEY_ROTATION_INTERVAL", "24h")
+	if err != nil {
+		return nil, err
+	}
+
+	accessTTL, err := duration("ACCESS_TOKEN_TTL", "15m")
+	if err != nil {
+		return nil, err
+	}
+
+	refreshTTL, err := duration("REFRESH_TOKEN_TTL", "168h")
+	if err != nil {
+		return nil, err
+	}
+
+	cookieSecure, err := strconv.ParseBool(env("COOKIE_SECURE", "true"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid COOKIE_SECURE %q", os.Getenv("COOKIE_SECURE"))
+	}
+
+	sameSite, err := parseSameSite(env("COOKIE_SAMESITE", "strict"))
+	if err != nil {
+		return nil, err
+	}
+
+	signingAlg := env("JWT_SIGNING_ALG", AlgRS256)
+	if strings.EqualFold(signingAlg, AlgHS256) {
+		signingAlg = AlgHS256
+	}
+
+	cfg := &Config{
+		JWTSecret:         os.Getenv("JWT_SECRET"),
+		JWTSigningAlg:     signingAlg,
+		KeyRotation:       keyRotation,
+		SigningKeyStore:   env("SIGNING_KEY_STORE", StoreMemory),
+		Port:              env("PORT", "8081"),
+		AccessTokenTTL:    accessTTL,
+		RefreshTokenTTL:   refreshTTL,
+		RefreshTokenStore: env("REFRESH_TOKEN_STORE", StoreMemory),
+		RevocationStore:   env("REVOCATION_STORE", StoreMemory),
+		UserStore:         env("USER_STORE", StoreMemory),
+		UsernameBlocklist: os.Getenv("USERNAME_BLOCKLIST_FILE"),
+		OIDCProvider:      env("OIDC_PROVIDER", "google"),
+		OIDCIssuer:        os.Getenv("OIDC_ISSUER"),
+		OIDCAudience:      os.Getenv("OIDC_AUDIENCE"),
+		OIDCJWKSURL:       os.Getenv("OIDC_JWKS_URL"),
+		OIDCJWKSFile:      os.Getenv("OIDC_JWKS_FILE"),
+		CookieSecure:      cookieSecure,
+		CookieDomain:      os.Getenv("COOKIE_DOMAIN"),
+		CookieSameSite:    sameSite,
+		DatabaseHost:      env("DATABASE_HOST", "Postgres"),
+		DatabasePort:      env("DATABASE_PORT", "5432"),
+		DatabaseUser:      env("DATABASE_USER", "auth"),
+		DatabasePassword:  env("DATABASE_PASSWORD", "secure"),
+		DatabaseName:      env("DATABASE_NAME", "auth"),
+		DatabaseSSLMode:   env("DATABASE_SSLMODE", "disable"),
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// Validate checks the values and the limits between them.
+func (c *Config) Validate() error {
+	if c.AccessTokenTTL <= 0 {
+		return errors.New("invalid ACCESS_TOKEN_TTL, must be positive")
+	}
+	if c.RefreshTokenTTL <= c.AccessTokenTTL {
+		return errors.New("invalid REFRESH_TOKEN_TTL, must be longer than ACCESS_TOKEN_TTL")
+	}
+	switch c.JWTSigningAlg {
+	case AlgHS256:
+	case AlgRS256, AlgEdDSA:
+		// retired keys verify for one more token lifetime, so rotating faster would pile them up
+		if c.KeyRotation < c.AccessTokenTTL {
+			return errors.New("invalid JWT_KEY_ROTATION_INTERVAL, must not be shorter than ACCESS_TOKEN_TTL")
+		}
+	default:
+		return fmt.Errorf("invalid JWT_SIGNING_ALG %q", c.JWTSigningAlg)
+	}
+	for name, store := range map[string]string{
+		"SIGNING_KEY_STORE":   c.SigningKeyStore,
+		"REFRESH_TOKEN_STORE": c.RefreshTokenStore,
+		"REVOCATION_STORE":    c.RevocationStore,
+		"USER_STORE":          c.UserStore,
+	} {
+		if store != StoreMemory && store != StorePostgres {
+			return fmt.Errorf("invalid %s %q", name, store)
+		}
+	}
+	// browsers drop SameSite=None cookies without Secure
+	if c.CookieSameSite == http.SameSiteNoneMode && !c.CookieSecure {
+		return errors.New("COOKIE_SAMESITE=none requires COOKIE_SECURE=true")
+	}
+	if c.OIDCIssuer != "" && (c.OIDCAudience == "" || (c.OIDCJWKSURL == "") == (c.OIDCJWKSFile == "")) {
+		return errors.New("OIDC_ISSUER requires OIDC_AUDIENCE and exactly one of OIDC_JWKS_URL and OIDC_JWKS_FILE")
+	}
+	return nil
+}
+
+// UsesPostgres reports whether any store lives in Postgres, so the database has to be opened.
+func (c *Config) UsesPostgres() bool {
+	signingKeys := c.JWTSigningAlg != AlgHS256 && c.SigningKeyStore == StorePostgres
+	return signingKeys || c.RefreshTokenStore == StorePostgres || c.RevocationStore == StorePostgres || c.UserStore == StorePostgres
+}
+
+// env returns the variable, or def if it is unset or empty
+func env(name, def string) string {
+	if v := os.Getenv(name); v != "" {
+		return v
+	}
+	return def
+}
+
+// duration parses the variable as a Go duration, falling back to def
+func duration(name, def string) (time.Duration, error) {
+	v := env(name, def)
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s %q", name, v)
+	}
+	return d, nil
+}
+
+func parseSameSite(s string) (http.SameSite, error) {
+	switch strings.ToLower(s) {
+	case "strict":
+		return http.SameSiteStrictMode, nil
+	case "lax":
+		return http.SameSiteLaxMode, nil
+	case "none":
+		return http.SameSiteNoneMode, nil
 	}
-	return &Config{JWTSecret: secret, Port: port}
+	return 0, fmt.Errorf("invalid COOKIE_SAMESITE %q", s)
 }
@@ -0,0 +1,87 @@
+package config
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLoadDefaults(t *testing.T) {
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.AccessTokenTTL != 15*time.Minute || cfg.RefreshTokenTTL != 168*time.Hour || cfg.KeyRotation != 24*time.Hour {
+		t.Fatalf("unexpected durations %v %v %v", cfg.AccessTokenTTL, cfg.RefreshTokenTTL, cfg.KeyRotation)
+	}
+	if !cfg.CookieSecure || cfg.CookieSameSite != http.SameSiteStrictMode || cfg.JWTSigningAlg != AlgRS256 {
+		t.Fatalf("unexpected defaults %+v", cfg)
+	}
+	if cfg.UsesPostgres() {
+		t.Fatal("expected the default stores to live in memory")
+	}
+}
+
+func TestLoadParses(t *testing.T) {
+	t.Setenv("JWT_SIGNING_ALG", "hs256")
+	t.Setenv("COOKIE_SAMESITE", "Lax")
+	t.Setenv("COOKIE_SECURE", "false")
+	t.Setenv("USER_STORE", StorePostgres)
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.JWTSigningAlg != AlgHS256 || cfg.CookieSameSite != http.SameSiteLaxMode || cfg.CookieSecure {
+		t.Fatalf("unexpected values %+v", cfg)
+	}
+	if !cfg.UsesPostgres() {
+		t.Fatal("expected the user store to need the database")
+	}
+}
+
+func TestLoadRejects(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		env  map[string]string
+		want string
+	}{
+		{"unparsable ttl", map[string]string{"ACCESS_TOKEN_TTL": "soon"}, "ACCESS_TOKEN_TTL"},
+		{"unparsable cookie flag", map[string]string{"COOKIE_SECURE": "maybe"}, "COOKIE_SECURE"},
+		{"unknown same site", map[string]string{"COOKIE_SAMESITE": "loose"}, "COOKIE_SAMESITE"},
+		{"refresh not longer than access", map[string]string{"ACCESS_TOKEN_TTL": "1h", "REFRESH_TOKEN_TTL": "1h"}, "REFRESH_TOKEN_TTL"},
+		{"rotation shorter than access", map[string]string{"JWT_KEY_ROTATION_INTERVAL": "1m"}, "JWT_KEY_ROTATION_INTERVAL"},
+		{"unknown algorithm", map[string]string{"JWT_SIGNING_ALG": "PS256"}, "JWT_SIGNING_ALG"},
+		{"unknown store", map[string]string{"REFRESH_TOKEN_STORE": "redis"}, "REFRESH_TOKEN_STORE"},
+		{"same site none without secure", map[string]string{"COOKIE_SAMESITE": "none", "COOKIE_SECURE": "false"}, "COOKIE_SECURE"},
+		{"oidc without keys", map[string]string{"OIDC_ISSUER": "https://accounts.google.com", "OIDC_AUDIENCE": "client"}, "OIDC_JWKS_URL"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			for k, v := range tc.env {
+				t.Setenv(k, v)
+			}
+			_, err := Load()
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("expected an error about %s, got %v", tc.want, err)
+			}
+		})
+	}
+}
+
+func TestValidateAllowsShortRotationWithHS256(t *testing.T) {
+	cfg := &Config{
+		JWTSigningAlg:     AlgHS256,
+		KeyRotation:       time.Second,
+		AccessTokenTTL:    time.Minute,
+		RefreshTokenTTL:   time.Hour,
+		SigningKeyStore:   StoreMemory,
+		RefreshTokenStore: StoreMemory,
+		RevocationStore:   StoreMemory,
+		UserStore:         StoreMemory,
+		CookieSameSite:    http.SameSiteStrictMode,
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate: %v", err)
+	}
+}
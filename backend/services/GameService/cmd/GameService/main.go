@@ -0,0 +1,96 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"os"
+
+	"github.com/KnuffelGame/KnuffelGame/backend/libs/logger"
+	router "github.com/KnuffelGame/KnuffelGame/backend/services/GameService/internal"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/GameService/internal/db"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/GameService/internal/events"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/GameService/internal/game"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/GameService/internal/lobby"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/GameService/internal/repository"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/GameService/internal/timeout"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/GameService/pkg/config"
+)
+
+func main() {
+	// ensure SERVICE_NAME env is present (fallback if empty)
+	if os.Getenv("SERVICE_NAME") == "" {
+		_ = os.Setenv("SERVICE_NAME", "GameService")
+	}
+	log := logger.FromEnv().With(slog.String("component", "bootstrap"))
+
+	cfg := config.Load()
+
+	// Initialize database connection
+	dbConfig := db.Config{
+		Host:     cfg.DatabaseHost,
+		Port:     cfg.DatabasePort,
+		User:     cfg.DatabaseUser,
+		Password: cfg.DatabasePassword,
+		Database: cfg.DatabaseName,
+		SSLMode:  cfg.DatabaseSSLMode,
+	}
+
+	dbConn, err := db.New(dbConfig)
+	if err != nil {
+		log.Error("failed to connect to database", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+	defer dbConn.Close()
+
+	// Run database migrations
+	if err := db.RunMigrations(dbConn.DB); err != nil {
+		log.Error("failed to run migrations", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+
+	repo := repository.New(dbConn.DB)
+
+	var pub events.Publisher = events.NopPublisher{}
+	if cfg.SSEServiceURL != "" {
+		pub = events.NewHTTPPublisher(cfg.SSEServiceURL)
+	} else {
+		log.Warn("SSE_SERVICE_URL is empty, events will not be published")
+	}
+
+	var lobbyClient lobby.Client = lobby.NopClient{}
+	if cfg.LobbyServiceURL != "" {
+		lobbyClient = lobby.NewHTTPClient(cfg.LobbyServiceURL)
+	} else {
+		log.Warn("LOBBY_SERVICE_URL is empty, timed out players will not be flagged inactive and lobbies not finished")
+	}
+
+	policy, ok := game.ParseTimeoutPolicy(cfg.TurnTimeoutPolicy)
+	if !ok {
+		log.Error("invalid TURN_TIMEOUT_POLICY", slog.String("policy", cfg.TurnTimeoutPolicy))
+		os.Exit(1)
+	}
+
+	// Turn timeout scheduler ends turns that exceed game.TurnTimeout
+	clock := timeout.RealClock{}
+	expirer := timeout.NewExpirer(repo, pub, lobbyClient, clock, policy, logger.Default())
+	timers := timeout.NewScheduler(clock, expirer.Expire)
+
+	// Restore turn timeouts of games that were running before a restart
+	running, err := repo.ListRunningGames(context.Background())
+	if err != nil {
+		log.Error("failed to load running games", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+	for _, g := range running {
+		timers.Schedule(g.ID, g.Deadline())
+	}
+	log.Info("restored turn timeouts", slog.Int("games", len(running)))
+
+	r := router.New(repo, game.RandomRoller{}, pub, lobbyClient, timers)
+	log.Info("listening", slog.String("port", cfg.Port))
+	if err := http.ListenAndServe(":"+cfg.Port, r); err != nil {
+		log.Error("server exited", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+}
@@ -1,3 +1,30 @@
 module github.com/KnuffelGame/KnuffelGame/backend/services/GameService
 
 go 1.25.3
+
+require (
+	github.com/DATA-DOG/go-sqlmock v1.5.2
+	github.com/KnuffelGame/KnuffelGame/backend/libs/auth v0.0.0
+	github.com/KnuffelGame/KnuffelGame/backend/libs/healthcheck v0.0.0
+	github.com/KnuffelGame/KnuffelGame/backend/libs/httpx v0.0.0
+	github.com/KnuffelGame/KnuffelGame/backend/libs/logger v0.0.0
+	github.com/go-chi/chi/v5 v5.2.3
+	github.com/google/uuid v1.6.0
+	github.com/lib/pq v1.10.9
+	github.com/pressly/goose/v3 v3.26.0
+)
+
+require (
+	github.com/mfridman/interpolate v0.0.2 // indirect
+	github.com/sethvargo/go-retry v0.3.0 // indirect
+	go.uber.org/multierr v1.11.0 // indirect
+	golang.org/x/sync v0.16.0 // indirect
+)
+
+replace github.com/KnuffelGame/KnuffelGame/backend/libs/healthcheck => ../../libs/healthcheck
+
+replace github.com/KnuffelGame/KnuffelGame/backend/libs/httpx => ../../libs/httpx
+
+replace github.com/KnuffelGame/KnuffelGame/backend/libs/logger => ../../libs/logger
+
+replace github.com/KnuffelGame/KnuffelGame/backend/libs/auth => ../../libs/auth
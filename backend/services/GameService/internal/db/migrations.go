@@ -0,0 +1,40 @@
+package db
+
+import (
+	"database/sql"
+	"embed"
+	"fmt"
+	"log/slog"
+
+	"github.com/pressly/goose/v3"
+)
+
+//go:embed migrations/*.sql
+var embedMigrations embed.FS
+
+// RunMigrations runs all pending database migrations from the embedded filesystem
+func RunMigrations(db *sql.DB) error {
+	if err := goose.SetDialect("postgres"); err != nil {
+		return fmt.Errorf("failed to set goose dialect: %w", err)
+	}
+
+	goose.SetBaseFS(embedMigrations)
+
+	if err := goose.Up(db, "migrations"); err != nil {
+		return fmt.Errorf("failed to run migrations: %w", err)
+	}
+
+	slog.Info("database migrations completed successfully")
+	return nil
+}
+
+// GetMigrationStatus returns the current migration status
+func GetMigrationStatus(db *sql.DB) error {
+	if err := goose.SetDialect("postgres"); err != nil {
+		return fmt.Errorf("failed to set goose dialect: %w", err)
+	}
+
+	goose.SetBaseFS(embedMigrations)
+
+	return goose.Status(db, "migrations")
+}
@@ -0,0 +1,64 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Create games table
+-- version is incremented on every update and used for optimistic concurrency
+CREATE TABLE IF NOT EXISTS games (
+    id UUID PRIMARY KEY,
+    lobby_id UUID NOT NULL,
+    leader_id UUID NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    current_index INT NOT NULL DEFAULT 0,
+    dice_values INT[] NOT NULL,
+    dice_locked BOOLEAN[] NOT NULL,
+    roll_count INT NOT NULL DEFAULT 0,
+    started_at TIMESTAMPTZ NOT NULL,
+    last_action_at TIMESTAMPTZ NOT NULL,
+    finished_at TIMESTAMPTZ NULL,
+    version INT NOT NULL DEFAULT 1
+);
+
+-- Create scores table (one scorecard per player, NULL fields are still open)
+CREATE TABLE IF NOT EXISTS scores (
+    game_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    username VARCHAR(20) NOT NULL,
+    turn_position INT NOT NULL,
+    is_active BOOLEAN NOT NULL DEFAULT true,
+    ones INT NULL,
+    twos INT NULL,
+    threes INT NULL,
+    fours INT NULL,
+    fives INT NULL,
+    sixes INT NULL,
+    three_of_a_kind INT NULL,
+    four_of_a_kind INT NULL,
+    full_house INT NULL,
+    small_straight INT NULL,
+    large_straight INT NULL,
+    kniffel INT NULL,
+    chance INT NULL,
+    kniffel_bonus_count INT NOT NULL DEFAULT 0,
+    PRIMARY KEY (game_id, user_id),
+    CONSTRAINT uq_scores_turn_position UNIQUE (game_id, turn_position),
+    CONSTRAINT fk_game FOREIGN KEY (game_id) REFERENCES games(id) ON DELETE CASCADE
+);
+
+-- Create indices for performance
+CREATE INDEX IF NOT EXISTS idx_games_lobby_id ON games(lobby_id);
+CREATE INDEX IF NOT EXISTS idx_games_status ON games(status);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+-- Drop indices
+DROP INDEX IF EXISTS idx_games_status;
+DROP INDEX IF EXISTS idx_games_lobby_id;
+
+-- Drop tables in reverse order (respecting foreign keys)
+DROP TABLE IF EXISTS scores;
+DROP TABLE IF EXISTS games;
+
+-- +goose StatementEnd
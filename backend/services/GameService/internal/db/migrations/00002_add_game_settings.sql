@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Settings chosen by the lobby; existing games keep the previous fixed rules
+ALTER TABLE games ADD COLUMN IF NOT EXISTS turn_timeout_seconds INT NOT NULL DEFAULT 40;
+ALTER TABLE games ADD COLUMN IF NOT EXISTS variant VARCHAR(20) NOT NULL DEFAULT 'classic';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE games DROP COLUMN IF EXISTS variant;
+ALTER TABLE games DROP COLUMN IF EXISTS turn_timeout_seconds;
+
+-- +goose StatementEnd
@@ -0,0 +1,73 @@
+// Package events publishes game events to the SSE Service.
+package events
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// TargetGame is the SSE target type for game events
+const TargetGame = "game"
+
+const publishTimeout = 3 * time.Second
+
+// Event matches the SSE Service PublishEventRequest schema.
+type Event struct {
+	TargetType   string      `json:"target_type"`
+	TargetID     string      `json:"target_id"`
+	EventType    string      `json:"event_type"`
+	TargetUserID string      `json:"target_user_id,omitempty"`
+	Data         interface{} `json:"data"`
+}
+
+// Publisher delivers events to connected clients.
+type Publisher interface {
+	Publish(ctx context.Context, e Event) error
+}
+
+// HTTPPublisher posts events to the SSE Service POST /internal/publish endpoint.
+type HTTPPublisher struct {
+	baseURL string
+	client  *http.Client
+}
+
+// NewHTTPPublisher creates a publisher for the SSE Service at baseURL (e.g. http://SSEService:8084).
+func NewHTTPPublisher(baseURL string) *HTTPPublisher {
+	return &HTTPPublisher{baseURL: baseURL, client: &http.Client{Timeout: publishTimeout}}
+}
+
+// Publish sends the event and returns an error for transport failures or non-2xx responses.
+// 404 means nobody is connected to the game, which is not an error.
+func (p *HTTPPublisher) Publish(ctx context.Context, e Event) error {
+	body, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("failed to encode event: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.baseURL+"/internal/publish", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to build publish request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to publish event: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("publish returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// NopPublisher discards all events. Used when no SSE Service is configured.
+type NopPublisher struct{}
+
+// Publish does nothing.
+func (NopPublisher) Publish(context.Context, Event) error { return nil }
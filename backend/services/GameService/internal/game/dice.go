@@ -0,0 +1,39 @@
+package game
+
+import "math/rand/v2"
+
+const (
+	// NumDice is the number of dice used in a Kniffel turn
+	NumDice = 5
+	// MaxRolls is the maximum number of rolls per turn
+	MaxRolls = 3
+)
+
+// Die is a single die. Value 0 means the die has not been rolled in this turn yet.
+type Die struct {
+	Value  int
+	Locked bool
+}
+
+// Roller produces die values in the range 1-6.
+// Inject a deterministic implementation in tests.
+type Roller interface {
+	Roll() int
+}
+
+// RandomRoller rolls dice using math/rand/v2.
+type RandomRoller struct{}
+
+// Roll returns a uniformly distributed value between 1 and 6.
+func (RandomRoller) Roll() int {
+	return rand.IntN(6) + 1
+}
+
+// diceValues returns the face values of the given dice.
+func diceValues(dice [NumDice]Die) []int {
+	values := make([]int, NumDice)
+	for i, d := range dice {
+		values[i] = d.Value
+	}
+	return values
+}
@@ -0,0 +1,591 @@
+// Package game implements the Kniffel rules engine: dice state, turns, scoring and rankings.
+// It has no knowledge of HTTP or persistence so it can be unit tested in isolation.
+package game
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// MinPlayers is the minimum number of players required to start a game
+	MinPlayers = 2
+	// MaxPlayers is the maximum number of players in a game; lobbies may choose a lower limit
+	MaxPlayers = 10
+	// TurnTimeout is the default time a player has before the turn times out; it resets on each interaction
+	TurnTimeout = 40 * time.Second
+	// MinTurnTimeout and MaxTurnTimeout bound the turn timeout a lobby may choose
+	MinTurnTimeout = 10 * time.Second
+	MaxTurnTimeout = 5 * time.Minute
+)
+
+// Status is the lifecycle state of a game.
+type Status string
+
+const (
+	StatusRunning  Status = "running"
+	StatusFinished Status = "finished"
+)
+
+// TimeoutPolicy decides what happens to the scorecard when a turn times out.
+type TimeoutPolicy string
+
+const (
+	// TimeoutSkip passes the turn on without touching the scorecard
+	TimeoutSkip TimeoutPolicy = "skip"
+	// TimeoutCrossOut scores 0 in the first open field (display order) before passing the turn on
+	TimeoutCrossOut TimeoutPolicy = "cross_out"
+)
+
+// ParseTimeoutPolicy converts a string to a TimeoutPolicy. The second return value is false for unknown names.
+func ParseTimeoutPolicy(s string) (TimeoutPolicy, bool) {
+	switch p := TimeoutPolicy(s); p {
+	case TimeoutSkip, TimeoutCrossOut:
+		return p, true
+	}
+	return "", false
+}
+
+// Variant selects a rule variant for the whole game.
+type Variant string
+
+const (
+	// VariantClassic applies the forced joker rules described on SelectField
+	VariantClassic Variant = "classic"
+	// VariantFreeJoker lets an additional Kniffel be scored in any open field, still counting as joker
+	VariantFreeJoker Variant = "free_joker"
+)
+
+// ParseVariant converts a string to a Variant. The second return value is false for unknown names.
+func ParseVariant(s string) (Variant, bool) {
+	switch v := Variant(s); v {
+	case VariantClassic, VariantFreeJoker:
+		return v, true
+	}
+	return "", false
+}
+
+// Bonus types reported when a field selection triggers a bonus.
+const (
+	BonusUpperSection    = "upper_section_bonus"
+	BonusMultipleKniffel = "multiple_kniffel"
+)
+
+var (
+	ErrNotEnoughPlayers = errors.New("not enough players")
+	ErrTooManyPlayers   = errors.New("too many players")
+	ErrDuplicatePlayer  = errors.New("duplicate player in turn order")
+	ErrGameFinished     = errors.New("game is already finished")
+	ErrNotAPlayer       = errors.New("user is not a player in this game")
+	ErrNotYourTurn      = errors.New("not your turn")
+	ErrNotLeader        = errors.New("only the lobby leader can end the game")
+	ErrMaxRollsReached  = errors.New("maximum rolls reached")
+	ErrNotRolledYet     = errors.New("dice not rolled yet")
+	ErrInvalidDieIndex  = errors.New("invalid dice index")
+	ErrInvalidField     = errors.New("invalid field")
+	ErrFieldFilled      = errors.New("field already filled")
+	ErrJokerField       = errors.New("field not allowed by joker rules")
+	ErrTurnNotExpired   = errors.New("turn has not timed out yet")
+	ErrInvalidTimeout   = errors.New("turn timeout out of range")
+)
+
+// Seat is a player entry in the turn order passed to New.
+type Seat struct {
+	UserID   uuid.UUID
+	Username string
+}
+
+// Player is a participant including their scorecard.
+// Inactive players are skipped when the turn advances.
+type Player struct {
+	UserID   uuid.UUID
+	Username string
+	Active   bool
+	Card     ScoreCard
+}
+
+// Game holds the complete state of a running or finished game.
+type Game struct {
+	ID           uuid.UUID
+	LobbyID      uuid.UUID
+	LeaderID     uuid.UUID
+	Status       Status
+	Players      []Player
+	CurrentIndex int
+	Dice         [NumDice]Die
+	RollCount    int
+	StartedAt    time.Time
+	LastActionAt time.Time
+	FinishedAt   *time.Time
+	// TurnTimeout and Variant are chosen by the lobby and fixed for the whole game
+	TurnTimeout time.Duration
+	Variant     Variant
+	// Version is managed by the repository for optimistic concurrency; the engine never changes it
+	Version int
+}
+
+// Bonus describes bonus points awarded by a field selection.
+type Bonus struct {
+	Type   string
+	Points int
+}
+
+// Ranking is a player's placement. Players with equal totals share a rank.
+type Ranking struct {
+	UserID     uuid.UUID
+	Username   string
+	TotalScore int
+	Rank       int
+}
+
+// TurnResult describes the outcome of SelectField.
+// Next is nil when the game finished with this selection.
+type TurnResult struct {
+	UserID   uuid.UUID
+	Username string
+	Field    Field
+	Points   int
+	Bonuses  []Bonus
+	NewTotal int
+	Next     *Player
+	Finished bool
+	Rankings []Ranking
+}
+
+// TimeoutResult describes the outcome of ExpireTurn.
+// Field is empty when the turn was skipped. Next is nil when the game finished.
+type TimeoutResult struct {
+	UserID   uuid.UUID
+	Username string
+	Field    Field
+	Next     *Player
+	Finished bool
+	Rankings []Ranking
+}
+
+// LeaveResult describes the outcome of Leave.
+// Next is set when the leaving player was on turn and the turn passed on; it is nil otherwise or when the game finished.
+type LeaveResult struct {
+	Next     *Player
+	Finished bool
+	Rankings []Ranking
+}
+
+// New creates a running game with the given turn order. The first seat starts.
+// The game uses the default TurnTimeout and VariantClassic; see Configure.
+func New(id, lobbyID, leaderID uuid.UUID, seats []Seat, now time.Time) (*Game, error) {
+	if len(seats) < MinPlayers {
+		return nil, ErrNotEnoughPlayers
+	}
+	if len(seats) > MaxPlayers {
+		return nil, ErrTooManyPlayers
+	}
+	seen := make(map[uuid.UUID]bool, len(seats))
+	players := make([]Player, 0, len(seats))
+	for _, s := range seats {
+		if seen[s.UserID] {
+			return nil, ErrDuplicatePlayer
+		}
+		seen[s.UserID] = true
+		players = append(players, Player{UserID: s.UserID, Username: s.Username, Active: true, Card: NewScoreCard()})
+	}
+	return &Game{
+		ID:           id,
+		LobbyID:      lobbyID,
+		LeaderID:     leaderID,
+		Status:       StatusRunning,
+		Players:      players,
+		StartedAt:    now,
+		LastActionAt: now,
+		TurnTimeout:  TurnTimeout,
+		Variant:      VariantClassic,
+	}, nil
+}
+
+// Configure sets the turn timeout and rule variant. Zero values keep the current setting.
+func (g *Game) Configure(turnTimeout time.Duration, variant Variant) error {
+	if turnTimeout != 0 {
+		if turnTimeout < MinTurnTimeout || turnTimeout > MaxTurnTimeout {
+			return ErrInvalidTimeout
+		}
+		g.TurnTimeout = turnTimeout
+	}
+	if variant != "" {
+		g.Variant = variant
+	}
+	return nil
+}
+
+// CurrentPlayer returns the player whose turn it is.
+func (g *Game) CurrentPlayer() *Player {
+	return &g.Players[g.CurrentIndex]
+}
+
+// Player looks up a participant by user id.
+func (g *Game) Player(userID uuid.UUID) (*Player, bool) {
+	for i := range g.Players {
+		if g.Players[i].UserID == userID {
+			return &g.Players[i], true
+		}
+	}
+	return nil, false
+}
+
+// TurnOrder returns the user ids in turn order.
+func (g *Game) TurnOrder() []uuid.UUID {
+	order := make([]uuid.UUID, len(g.Players))
+	for i, p := range g.Players {
+		order[i] = p.UserID
+	}
+	return order
+}
+
+// TimeoutRemaining returns how long the current player has left before the turn times out.
+func (g *Game) TimeoutRemaining(now time.Time) time.Duration {
+	if g.Status != StatusRunning {
+		return 0
+	}
+	remaining := g.Deadline().Sub(now)
+	if remaining < 0 {
+		return 0
+	}
+	return remaining
+}
+
+// Deadline returns the time at which the current turn times out.
+func (g *Game) Deadline() time.Time {
+	return g.LastActionAt.Add(g.TurnTimeout)
+}
+
+// Expired reports whether the current turn has timed out at now.
+func (g *Game) Expired(now time.Time) bool {
+	return g.Status == StatusRunning && !now.Before(g.Deadline())
+}
+
+// ExpireTurn ends a timed out turn. The current player is marked inactive so later turns skip them,
+// the scorecard is handled according to policy and the turn passes to the next active player.
+// The game finishes when no active player with open fields is left.
+func (g *Game) ExpireTurn(now time.Time, policy TimeoutPolicy) (*TimeoutResult, error) {
+	if g.Status != StatusRunning {
+		return nil, ErrGameFinished
+	}
+	if !g.Expired(now) {
+		return nil, ErrTurnNotExpired
+	}
+	p := g.CurrentPlayer()
+	result := &TimeoutResult{UserID: p.UserID, Username: p.Username}
+	if policy == TimeoutCrossOut {
+		if open := p.Card.OpenFields(); len(open) > 0 {
+			p.Card.Scores[open[0]] = 0
+			result.Field = open[0]
+		}
+	}
+	p.Active = false
+
+	g.advanceTurn(now)
+	if g.Status == StatusFinished {
+		result.Finished = true
+		result.Rankings = g.Rankings()
+	} else {
+		next := *g.CurrentPlayer()
+		result.Next = &next
+	}
+	return result, nil
+}
+
+// Roll rolls all unlocked dice for the current player.
+func (g *Game) Roll(userID uuid.UUID, roller Roller, now time.Time) error {
+	if err := g.checkTurn(userID); err != nil {
+		return err
+	}
+	if g.RollCount >= MaxRolls {
+		return ErrMaxRollsReached
+	}
+	for i := range g.Dice {
+		if !g.Dice[i].Locked {
+			g.Dice[i].Value = roller.Roll()
+		}
+	}
+	g.RollCount++
+	g.LastActionAt = now
+	return nil
+}
+
+// ToggleDice flips the lock state of the dice at the given indices (0-4).
+// Locking is only possible between the first and third roll.
+func (g *Game) ToggleDice(userID uuid.UUID, indices []int, now time.Time) error {
+	if err := g.checkTurn(userID); err != nil {
+		return err
+	}
+	if g.RollCount == 0 {
+		return ErrNotRolledYet
+	}
+	if g.RollCount >= MaxRolls {
+		return ErrMaxRollsReached
+	}
+	if len(indices) == 0 || len(InvalidDieIndices(indices)) > 0 {
+		return ErrInvalidDieIndex
+	}
+	for _, i := range indices {
+		g.Dice[i].Locked = !g.Dice[i].Locked
+	}
+	g.LastActionAt = now
+	return nil
+}
+
+// InvalidDieIndices returns all indices outside 0-4 and any duplicates.
+func InvalidDieIndices(indices []int) []int {
+	var invalid []int
+	seen := make(map[int]bool, len(indices))
+	for _, i := range indices {
+		if i < 0 || i >= NumDice || seen[i] {
+			invalid = append(invalid, i)
+		}
+		seen[i] = true
+	}
+	return invalid
+}
+
+// SelectField scores the current dice in field for the current player and ends the turn.
+//
+// Joker rules apply when a Kniffel is rolled while the kniffel field is already filled:
+// the matching upper field must be used if open, otherwise any open lower field (full house
+// and straights score their full value), otherwise any open upper field for 0 points.
+// If the kniffel field holds 50 points, a +50 bonus is awarded as well.
+// VariantFreeJoker drops the field restriction: any open field may be used, with the same joker scoring.
+func (g *Game) SelectField(userID uuid.UUID, field Field, now time.Time) (*TurnResult, error) {
+	if err := g.checkTurn(userID); err != nil {
+		return nil, err
+	}
+	if g.RollCount == 0 {
+		return nil, ErrNotRolledYet
+	}
+	if _, ok := ParseField(string(field)); !ok {
+		return nil, ErrInvalidField
+	}
+	p := g.CurrentPlayer()
+	if p.Card.IsFilled(field) {
+		return nil, ErrFieldFilled
+	}
+
+	values := diceValues(g.Dice)
+	var bonuses []Bonus
+	joker := false
+	if isKniffel(values) && p.Card.IsFilled(Kniffel) {
+		if g.Variant != VariantFreeJoker && !containsField(JokerFields(p.Card, values[0]), field) {
+			return nil, ErrJokerField
+		}
+		joker = true
+		if p.Card.Scores[Kniffel] == KniffelPoints {
+			p.Card.KniffelBonusCount++
+			bonuses = append(bonuses, Bonus{Type: BonusMultipleKniffel, Points: KniffelBonusPoints})
+		}
+	}
+
+	hadUpperBonus := p.Card.UpperBonus() > 0
+	points := score(field, values, joker)
+	p.Card.Scores[field] = points
+	if !hadUpperBonus && p.Card.UpperBonus() > 0 {
+		bonuses = append(bonuses, Bonus{Type: BonusUpperSection, Points: UpperBonusPoints})
+	}
+
+	result := &TurnResult{
+		UserID:   p.UserID,
+		Username: p.Username,
+		Field:    field,
+		Points:   points,
+		Bonuses:  bonuses,
+		NewTotal: p.Card.Total(),
+	}
+
+	g.advanceTurn(now)
+	if g.Status == StatusFinished {
+		result.Finished = true
+		result.Rankings = g.Rankings()
+	} else {
+		next := *g.CurrentPlayer()
+		result.Next = &next
+	}
+	return result, nil
+}
+
+// JokerFields returns the fields a player may use for an additional Kniffel of the given face.
+func JokerFields(card ScoreCard, face int) []Field {
+	upper := upperFieldFor(face)
+	if !card.IsFilled(upper) {
+		return []Field{upper}
+	}
+	var lower, openUpper []Field
+	for _, f := range card.OpenFields() {
+		if f.IsUpper() {
+			openUpper = append(openUpper, f)
+		} else {
+			lower = append(lower, f)
+		}
+	}
+	if len(lower) > 0 {
+		return lower
+	}
+	return openUpper
+}
+
+// End finishes the game prematurely. Only the lobby leader may do this.
+func (g *Game) End(userID uuid.UUID, now time.Time) ([]Ranking, error) {
+	if g.Status == StatusFinished {
+		return nil, ErrGameFinished
+	}
+	if userID != g.LeaderID {
+		return nil, ErrNotLeader
+	}
+	g.finish(now)
+	return g.Rankings(), nil
+}
+
+// SetLeader hands the right to end the game over to another player, following the lobby leader.
+func (g *Game) SetLeader(userID uuid.UUID) error {
+	if g.Status != StatusRunning {
+		return ErrGameFinished
+	}
+	if _, ok := g.Player(userID); !ok {
+		return ErrNotAPlayer
+	}
+	g.LeaderID = userID
+	return nil
+}
+
+// Abort finishes the game on behalf of the Lobby Service, e.g. when the lobby failed to start or was closed.
+func (g *Game) Abort(now time.Time) ([]Ranking, error) {
+	if g.Status == StatusFinished {
+		return nil, ErrGameFinished
+	}
+	g.finish(now)
+	return g.Rankings(), nil
+}
+
+// Leave marks a player that left the lobby inactive so later turns skip them.
+// If it was their turn, the turn passes to the next active player; the game finishes when none is left.
+func (g *Game) Leave(userID uuid.UUID, now time.Time) (*LeaveResult, error) {
+	if g.Status != StatusRunning {
+		return nil, ErrGameFinished
+	}
+	p, ok := g.Player(userID)
+	if !ok {
+		return nil, ErrNotAPlayer
+	}
+	p.Active = false
+
+	result := &LeaveResult{}
+	if g.CurrentPlayer().UserID != userID {
+		return result, nil
+	}
+	g.advanceTurn(now)
+	if g.Status == StatusFinished {
+		result.Finished = true
+		result.Rankings = g.Rankings()
+	} else {
+		next := *g.CurrentPlayer()
+		result.Next = &next
+	}
+	return result, nil
+}
+
+// Activate marks an inactive player, e.g. one whose turn timed out, active again so later turns include them.
+func (g *Game) Activate(userID uuid.UUID) error {
+	if g.Status != StatusRunning {
+		return ErrGameFinished
+	}
+	p, ok := g.Player(userID)
+	if !ok {
+		return ErrNotAPlayer
+	}
+	p.Active = true
+	return nil
+}
+
+// Rankings returns all players ordered by total score (highest first).
+func (g *Game) Rankings() []Ranking {
+	rankings := make([]Ranking, len(g.Players))
+	for i, p := range g.Players {
+		rankings[i] = Ranking{UserID: p.UserID, Username: p.Username, TotalScore: p.Card.Total()}
+	}
+	sort.SliceStable(rankings, func(i, j int) bool {
+		return rankings[i].TotalScore > rankings[j].TotalScore
+	})
+	for i := range rankings {
+		if i > 0 && rankings[i].TotalScore == rankings[i-1].TotalScore {
+			rankings[i].Rank = rankings[i-1].Rank
+		} else {
+			rankings[i].Rank = i + 1
+		}
+	}
+	return rankings
+}
+
+// Clone returns a deep copy of the game.
+func (g *Game) Clone() *Game {
+	c := *g
+	c.Players = make([]Player, len(g.Players))
+	for i, p := range g.Players {
+		p.Card = p.Card.clone()
+		c.Players[i] = p
+	}
+	if g.FinishedAt != nil {
+		t := *g.FinishedAt
+		c.FinishedAt = &t
+	}
+	return &c
+}
+
+// checkTurn validates that the game is running and userID is the current player.
+func (g *Game) checkTurn(userID uuid.UUID) error {
+	if g.Status != StatusRunning {
+		return ErrGameFinished
+	}
+	if _, ok := g.Player(userID); !ok {
+		return ErrNotAPlayer
+	}
+	if g.CurrentPlayer().UserID != userID {
+		return ErrNotYourTurn
+	}
+	return nil
+}
+
+// advanceTurn resets the dice and moves to the next active player with open fields.
+// The game finishes when no such player is left.
+func (g *Game) advanceTurn(now time.Time) {
+	g.resetDice()
+	g.LastActionAt = now
+	n := len(g.Players)
+	for step := 1; step <= n; step++ {
+		idx := (g.CurrentIndex + step) % n
+		p := g.Players[idx]
+		if p.Active && !p.Card.Complete() {
+			g.CurrentIndex = idx
+			return
+		}
+	}
+	g.finish(now)
+}
+
+func (g *Game) finish(now time.Time) {
+	g.resetDice()
+	g.Status = StatusFinished
+	g.FinishedAt = &now
+}
+
+func (g *Game) resetDice() {
+	g.Dice = [NumDice]Die{}
+	g.RollCount = 0
+}
+
+func containsField(fields []Field, f Field) bool {
+	for _, x := range fields {
+		if x == f {
+			return true
+		}
+	}
+	return false
+}
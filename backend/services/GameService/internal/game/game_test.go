@@ -0,0 +1,512 @@
+package game
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// seqRoller returns the given values in order, wrapping around at the end.
+type seqRoller struct {
+	values []int
+	next   int
+}
+
+func (r *seqRoller) Roll() int {
+	v := r.values[r.next%len(r.values)]
+	r.next++
+	return v
+}
+
+func rollerOf(values ...int) *seqRoller { return &seqRoller{values: values} }
+
+var t0 = time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+func newTestGame(t *testing.T, players int) *Game {
+	t.Helper()
+	seats := make([]Seat, players)
+	for i := range seats {
+		seats[i] = Seat{UserID: uuid.New(), Username: string(rune('A' + i))}
+	}
+	g, err := New(uuid.New(), uuid.New(), seats[0].UserID, seats, t0)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	return g
+}
+
+func TestNew_ValidatesPlayers(t *testing.T) {
+	a := Seat{UserID: uuid.New(), Username: "A"}
+	if _, err := New(uuid.New(), uuid.New(), a.UserID, []Seat{a}, t0); !errors.Is(err, ErrNotEnoughPlayers) {
+		t.Fatalf("expected ErrNotEnoughPlayers, got %v", err)
+	}
+	if _, err := New(uuid.New(), uuid.New(), a.UserID, []Seat{a, a}, t0); !errors.Is(err, ErrDuplicatePlayer) {
+		t.Fatalf("expected ErrDuplicatePlayer, got %v", err)
+	}
+	seats := make([]Seat, MaxPlayers+1)
+	for i := range seats {
+		seats[i] = Seat{UserID: uuid.New(), Username: "P"}
+	}
+	if _, err := New(uuid.New(), uuid.New(), a.UserID, seats, t0); !errors.Is(err, ErrTooManyPlayers) {
+		t.Fatalf("expected ErrTooManyPlayers, got %v", err)
+	}
+}
+
+func TestRoll_KeepsLockedDiceAndLimitsRolls(t *testing.T) {
+	g := newTestGame(t, 2)
+	p := g.CurrentPlayer().UserID
+
+	if err := g.Roll(p, rollerOf(1, 2, 3, 4, 5), t0); err != nil {
+		t.Fatalf("first roll: %v", err)
+	}
+	if err := g.ToggleDice(p, []int{0, 4}, t0); err != nil {
+		t.Fatalf("toggle: %v", err)
+	}
+	if err := g.Roll(p, rollerOf(6), t0); err != nil {
+		t.Fatalf("second roll: %v", err)
+	}
+	want := []int{1, 6, 6, 6, 5}
+	for i, v := range diceValues(g.Dice) {
+		if v != want[i] {
+			t.Fatalf("expected dice %v, got %v", want, diceValues(g.Dice))
+		}
+	}
+	if err := g.Roll(p, rollerOf(6), t0); err != nil {
+		t.Fatalf("third roll: %v", err)
+	}
+	if err := g.Roll(p, rollerOf(6), t0); !errors.Is(err, ErrMaxRollsReached) {
+		t.Fatalf("expected ErrMaxRollsReached, got %v", err)
+	}
+	if err := g.ToggleDice(p, []int{1}, t0); !errors.Is(err, ErrMaxRollsReached) {
+		t.Fatalf("expected ErrMaxRollsReached on toggle after third roll, got %v", err)
+	}
+}
+
+func TestTurnChecks(t *testing.T) {
+	g := newTestGame(t, 2)
+	other := g.Players[1].UserID
+
+	if err := g.Roll(other, rollerOf(1), t0); !errors.Is(err, ErrNotYourTurn) {
+		t.Fatalf("expected ErrNotYourTurn, got %v", err)
+	}
+	if err := g.Roll(uuid.New(), rollerOf(1), t0); !errors.Is(err, ErrNotAPlayer) {
+		t.Fatalf("expected ErrNotAPlayer, got %v", err)
+	}
+	p := g.CurrentPlayer().UserID
+	if err := g.ToggleDice(p, []int{0}, t0); !errors.Is(err, ErrNotRolledYet) {
+		t.Fatalf("expected ErrNotRolledYet, got %v", err)
+	}
+	if _, err := g.SelectField(p, Chance, t0); !errors.Is(err, ErrNotRolledYet) {
+		t.Fatalf("expected ErrNotRolledYet, got %v", err)
+	}
+}
+
+func TestToggleDice_InvalidIndices(t *testing.T) {
+	g := newTestGame(t, 2)
+	p := g.CurrentPlayer().UserID
+	_ = g.Roll(p, rollerOf(1, 2, 3, 4, 5), t0)
+
+	if err := g.ToggleDice(p, []int{0, 5}, t0); !errors.Is(err, ErrInvalidDieIndex) {
+		t.Fatalf("expected ErrInvalidDieIndex, got %v", err)
+	}
+	if got := InvalidDieIndices([]int{-1, 2, 2, 7}); len(got) != 3 {
+		t.Fatalf("expected 3 invalid indices, got %v", got)
+	}
+	if g.Dice[0].Locked {
+		t.Fatal("dice must not change when the request is rejected")
+	}
+}
+
+func TestSelectField_AdvancesTurn(t *testing.T) {
+	g := newTestGame(t, 2)
+	p := g.CurrentPlayer().UserID
+	_ = g.Roll(p, rollerOf(2, 2, 3, 3, 3), t0)
+
+	later := t0.Add(10 * time.Second)
+	res, err := g.SelectField(p, FullHouse, later)
+	if err != nil {
+		t.Fatalf("select: %v", err)
+	}
+	if res.Points != FullHousePoints || res.NewTotal != FullHousePoints {
+		t.Fatalf("unexpected result %+v", res)
+	}
+	if res.Next == nil || res.Next.UserID != g.Players[1].UserID {
+		t.Fatalf("expected turn to pass to second player, got %+v", res.Next)
+	}
+	if g.RollCount != 0 || g.Dice[0].Value != 0 {
+		t.Fatal("expected dice to reset for the next turn")
+	}
+	if g.LastActionAt != later {
+		t.Fatal("expected timeout to reset on turn change")
+	}
+
+	// first player can no longer use full house
+	_ = g.Roll(g.CurrentPlayer().UserID, rollerOf(1), t0)
+	_, _ = g.SelectField(g.CurrentPlayer().UserID, Ones, t0)
+	_ = g.Roll(p, rollerOf(2, 2, 3, 3, 3), t0)
+	if _, err := g.SelectField(p, FullHouse, t0); !errors.Is(err, ErrFieldFilled) {
+		t.Fatalf("expected ErrFieldFilled, got %v", err)
+	}
+	if _, err := g.SelectField(p, "bogus", t0); !errors.Is(err, ErrInvalidField) {
+		t.Fatalf("expected ErrInvalidField, got %v", err)
+	}
+}
+
+func TestSelectField_SkipsInactivePlayers(t *testing.T) {
+	g := newTestGame(t, 3)
+	g.Players[1].Active = false
+	p := g.CurrentPlayer().UserID
+	_ = g.Roll(p, rollerOf(1), t0)
+	res, err := g.SelectField(p, Ones, t0)
+	if err != nil {
+		t.Fatalf("select: %v", err)
+	}
+	if res.Next.UserID != g.Players[2].UserID {
+		t.Fatalf("expected inactive player to be skipped")
+	}
+}
+
+func TestSelectField_UpperBonusReported(t *testing.T) {
+	g := newTestGame(t, 2)
+	p := g.CurrentPlayer()
+	p.Card.Scores[Fives] = 25
+	p.Card.Scores[Fours] = 20
+	_ = g.Roll(p.UserID, rollerOf(6, 6, 6, 1, 2), t0)
+
+	res, err := g.SelectField(p.UserID, Sixes, t0)
+	if err != nil {
+		t.Fatalf("select: %v", err)
+	}
+	if len(res.Bonuses) != 1 || res.Bonuses[0].Type != BonusUpperSection {
+		t.Fatalf("expected upper section bonus, got %+v", res.Bonuses)
+	}
+	if res.NewTotal != 63+UpperBonusPoints {
+		t.Fatalf("expected total %d, got %d", 63+UpperBonusPoints, res.NewTotal)
+	}
+}
+
+func TestSelectField_JokerRules(t *testing.T) {
+	g := newTestGame(t, 2)
+	p := g.CurrentPlayer()
+	p.Card.Scores[Kniffel] = KniffelPoints
+	_ = g.Roll(p.UserID, rollerOf(4), t0)
+
+	// matching upper field is open and therefore mandatory
+	if _, err := g.SelectField(p.UserID, FullHouse, t0); !errors.Is(err, ErrJokerField) {
+		t.Fatalf("expected ErrJokerField, got %v", err)
+	}
+	res, err := g.SelectField(p.UserID, Fours, t0)
+	if err != nil {
+		t.Fatalf("select: %v", err)
+	}
+	if res.Points != 20 || len(res.Bonuses) != 1 || res.Bonuses[0].Type != BonusMultipleKniffel {
+		t.Fatalf("unexpected result %+v", res)
+	}
+	if p := g.Players[0]; p.Card.KniffelBonusCount != 1 || p.Card.Total() != 50+20+KniffelBonusPoints {
+		t.Fatalf("unexpected card %+v total %d", p.Card, p.Card.Total())
+	}
+
+	// with the upper field filled, a lower field scores its full value
+	_ = g.Roll(g.CurrentPlayer().UserID, rollerOf(1), t0)
+	_, _ = g.SelectField(g.CurrentPlayer().UserID, Ones, t0)
+	_ = g.Roll(p.UserID, rollerOf(4), t0)
+	res, err = g.SelectField(p.UserID, LargeStraight, t0)
+	if err != nil {
+		t.Fatalf("select: %v", err)
+	}
+	if res.Points != LargeStraightPoints {
+		t.Fatalf("expected joker large straight %d, got %d", LargeStraightPoints, res.Points)
+	}
+}
+
+func TestSelectField_FreeJokerVariant(t *testing.T) {
+	g := newTestGame(t, 2)
+	if err := g.Configure(0, VariantFreeJoker); err != nil {
+		t.Fatalf("configure: %v", err)
+	}
+	p := g.CurrentPlayer()
+	p.Card.Scores[Kniffel] = KniffelPoints
+	_ = g.Roll(p.UserID, rollerOf(4), t0)
+
+	// the open upper field is not mandatory, the lower field still scores as joker
+	res, err := g.SelectField(p.UserID, FullHouse, t0)
+	if err != nil {
+		t.Fatalf("select: %v", err)
+	}
+	if res.Points != FullHousePoints || len(res.Bonuses) != 1 || res.Bonuses[0].Type != BonusMultipleKniffel {
+		t.Fatalf("unexpected result %+v", res)
+	}
+}
+
+func TestConfigure(t *testing.T) {
+	g := newTestGame(t, 2)
+	if g.TurnTimeout != TurnTimeout || g.Variant != VariantClassic {
+		t.Fatalf("expected defaults, got %v %q", g.TurnTimeout, g.Variant)
+	}
+	if err := g.Configure(MaxTurnTimeout+time.Second, ""); !errors.Is(err, ErrInvalidTimeout) {
+		t.Fatalf("expected ErrInvalidTimeout, got %v", err)
+	}
+	if err := g.Configure(time.Minute, ""); err != nil {
+		t.Fatalf("configure: %v", err)
+	}
+	if g.Deadline() != g.LastActionAt.Add(time.Minute) || g.Variant != VariantClassic {
+		t.Fatalf("unexpected deadline %v or variant %q", g.Deadline(), g.Variant)
+	}
+}
+
+func TestSelectField_NoKniffelBonusWhenCrossedOut(t *testing.T) {
+	g := newTestGame(t, 2)
+	p := g.CurrentPlayer()
+	p.Card.Scores[Kniffel] = 0
+	_ = g.Roll(p.UserID, rollerOf(2), t0)
+
+	res, err := g.SelectField(p.UserID, Twos, t0)
+	if err != nil {
+		t.Fatalf("select: %v", err)
+	}
+	if len(res.Bonuses) != 0 {
+		t.Fatalf("expected no bonus, got %+v", res.Bonuses)
+	}
+}
+
+func TestGameFinishesWhenAllCardsComplete(t *testing.T) {
+	g := newTestGame(t, 2)
+	roller := rollerOf(1, 2, 3, 4, 5)
+	var res *TurnResult
+	for round := 0; round < len(Fields); round++ {
+		for range g.Players {
+			p := g.CurrentPlayer().UserID
+			if err := g.Roll(p, roller, t0); err != nil {
+				t.Fatalf("roll: %v", err)
+			}
+			var err error
+			res, err = g.SelectField(p, Fields[round], t0)
+			if err != nil {
+				t.Fatalf("select %s: %v", Fields[round], err)
+			}
+		}
+	}
+	if !res.Finished || g.Status != StatusFinished || g.FinishedAt == nil {
+		t.Fatalf("expected game to finish, status %s", g.Status)
+	}
+	if len(res.Rankings) != 2 || res.Rankings[0].Rank != 1 || res.Rankings[1].Rank != 1 {
+		t.Fatalf("expected shared first place for equal scores, got %+v", res.Rankings)
+	}
+	if err := g.Roll(g.Players[0].UserID, roller, t0); !errors.Is(err, ErrGameFinished) {
+		t.Fatalf("expected ErrGameFinished, got %v", err)
+	}
+}
+
+func TestEnd(t *testing.T) {
+	g := newTestGame(t, 3)
+	g.Players[2].Card.Scores[Chance] = 20
+	g.Players[1].Card.Scores[Chance] = 10
+
+	if _, err := g.End(g.Players[1].UserID, t0); !errors.Is(err, ErrNotLeader) {
+		t.Fatalf("expected ErrNotLeader, got %v", err)
+	}
+	rankings, err := g.End(g.LeaderID, t0)
+	if err != nil {
+		t.Fatalf("End: %v", err)
+	}
+	if rankings[0].UserID != g.Players[2].UserID || rankings[2].UserID != g.Players[0].UserID || rankings[2].Rank != 3 {
+		t.Fatalf("unexpected rankings %+v", rankings)
+	}
+	if _, err := g.End(g.LeaderID, t0); !errors.Is(err, ErrGameFinished) {
+		t.Fatalf("expected ErrGameFinished, got %v", err)
+	}
+}
+
+func TestAbort(t *testing.T) {
+	g := newTestGame(t, 2)
+
+	if _, err := g.Abort(t0); err != nil {
+		t.Fatalf("Abort: %v", err)
+	}
+	if g.Status != StatusFinished || g.FinishedAt == nil {
+		t.Fatalf("expected a finished game, got %s", g.Status)
+	}
+	if _, err := g.Abort(t0); !errors.Is(err, ErrGameFinished) {
+		t.Fatalf("expected ErrGameFinished, got %v", err)
+	}
+}
+
+func TestLeave(t *testing.T) {
+	g := newTestGame(t, 3)
+
+	// not on turn: only flagged inactive
+	res, err := g.Leave(g.Players[1].UserID, t0)
+	if err != nil {
+		t.Fatalf("Leave: %v", err)
+	}
+	if res.Next != nil || res.Finished || g.Players[1].Active || g.CurrentIndex != 0 {
+		t.Fatalf("unexpected result %+v, current %d", res, g.CurrentIndex)
+	}
+
+	// on turn: the turn skips the inactive player
+	res, err = g.Leave(g.Players[0].UserID, t0)
+	if err != nil {
+		t.Fatalf("Leave: %v", err)
+	}
+	if res.Next == nil || res.Next.UserID != g.Players[2].UserID {
+		t.Fatalf("expected the turn to pass to the last active player, got %+v", res)
+	}
+
+	// nobody active is left
+	res, err = g.Leave(g.Players[2].UserID, t0)
+	if err != nil {
+		t.Fatalf("Leave: %v", err)
+	}
+	if !res.Finished || len(res.Rankings) != 3 || g.Status != StatusFinished {
+		t.Fatalf("expected the game to finish, got %+v", res)
+	}
+	if _, err := g.Leave(g.Players[2].UserID, t0); !errors.Is(err, ErrGameFinished) {
+		t.Fatalf("expected ErrGameFinished, got %v", err)
+	}
+}
+
+func TestLeave_NotAPlayer(t *testing.T) {
+	g := newTestGame(t, 2)
+	if _, err := g.Leave(uuid.New(), t0); !errors.Is(err, ErrNotAPlayer) {
+		t.Fatalf("expected ErrNotAPlayer, got %v", err)
+	}
+}
+
+func TestSetLeader(t *testing.T) {
+	g := newTestGame(t, 2)
+	oldLeader, newLeader := g.LeaderID, g.Players[1].UserID
+	if newLeader == oldLeader {
+		newLeader = g.Players[0].UserID
+	}
+
+	if err := g.SetLeader(uuid.New()); !errors.Is(err, ErrNotAPlayer) {
+		t.Fatalf("expected ErrNotAPlayer, got %v", err)
+	}
+	if err := g.SetLeader(newLeader); err != nil {
+		t.Fatalf("SetLeader: %v", err)
+	}
+	if _, err := g.End(oldLeader, t0); !errors.Is(err, ErrNotLeader) {
+		t.Fatalf("expected the old leader to be rejected, got %v", err)
+	}
+	if _, err := g.End(newLeader, t0); err != nil {
+		t.Fatalf("expected the new leader to end the game, got %v", err)
+	}
+	if err := g.SetLeader(oldLeader); !errors.Is(err, ErrGameFinished) {
+		t.Fatalf("expected ErrGameFinished, got %v", err)
+	}
+}
+
+func TestActivate(t *testing.T) {
+	g := newTestGame(t, 3)
+	p := g.CurrentPlayer().UserID
+	if _, err := g.ExpireTurn(t0.Add(TurnTimeout), TimeoutSkip); err != nil {
+		t.Fatalf("ExpireTurn: %v", err)
+	}
+
+	// the timed out player gets turns again
+	if err := g.Activate(p); err != nil {
+		t.Fatalf("Activate: %v", err)
+	}
+	if pl, _ := g.Player(p); !pl.Active {
+		t.Fatal("expected the player to be active")
+	}
+	next := g.CurrentPlayer().UserID
+	_ = g.Roll(next, rollerOf(1), t0)
+	if _, err := g.SelectField(next, Ones, t0); err != nil {
+		t.Fatalf("select: %v", err)
+	}
+	_ = g.Roll(g.CurrentPlayer().UserID, rollerOf(1), t0)
+	res, err := g.SelectField(g.CurrentPlayer().UserID, Ones, t0)
+	if err != nil {
+		t.Fatalf("select: %v", err)
+	}
+	if res.Next == nil || res.Next.UserID != p {
+		t.Fatalf("expected the turn to return to the activated player, got %+v", res.Next)
+	}
+
+	if err := g.Activate(uuid.New()); !errors.Is(err, ErrNotAPlayer) {
+		t.Fatalf("expected ErrNotAPlayer, got %v", err)
+	}
+	g.finish(t0)
+	if err := g.Activate(p); !errors.Is(err, ErrGameFinished) {
+		t.Fatalf("expected ErrGameFinished, got %v", err)
+	}
+}
+
+func TestTimeoutRemaining(t *testing.T) {
+	g := newTestGame(t, 2)
+	if got := g.TimeoutRemaining(t0.Add(15 * time.Second)); got != 25*time.Second {
+		t.Fatalf("expected 25s remaining, got %s", got)
+	}
+	if got := g.TimeoutRemaining(t0.Add(time.Minute)); got != 0 {
+		t.Fatalf("expected 0 remaining, got %s", got)
+	}
+}
+
+func TestClone_IsDeep(t *testing.T) {
+	g := newTestGame(t, 2)
+	c := g.Clone()
+	c.Players[0].Card.Scores[Chance] = 30
+	c.Players[1].Username = "changed"
+	if g.Players[0].Card.IsFilled(Chance) || g.Players[1].Username == "changed" {
+		t.Fatal("clone shares state with original")
+	}
+}
+
+func TestExpireTurn_Skip(t *testing.T) {
+	g := newTestGame(t, 3)
+	p := g.CurrentPlayer().UserID
+	_ = g.Roll(p, rollerOf(6), t0)
+
+	if _, err := g.ExpireTurn(t0.Add(TurnTimeout-time.Second), TimeoutSkip); !errors.Is(err, ErrTurnNotExpired) {
+		t.Fatalf("expected ErrTurnNotExpired, got %v", err)
+	}
+	now := t0.Add(TurnTimeout)
+	res, err := g.ExpireTurn(now, TimeoutSkip)
+	if err != nil {
+		t.Fatalf("ExpireTurn: %v", err)
+	}
+	if res.UserID != p || res.Field != "" || res.Next.UserID != g.Players[1].UserID {
+		t.Fatalf("unexpected result %+v", res)
+	}
+	if g.Players[0].Active || len(g.Players[0].Card.Scores) != 0 {
+		t.Fatalf("expected timed out player to be inactive with untouched card, got %+v", g.Players[0])
+	}
+	if g.RollCount != 0 || g.Deadline() != now.Add(TurnTimeout) {
+		t.Fatal("expected next turn to start with fresh dice and deadline")
+	}
+}
+
+func TestExpireTurn_CrossOut(t *testing.T) {
+	g := newTestGame(t, 2)
+	p := g.CurrentPlayer()
+	p.Card.Scores[Ones] = 3
+
+	res, err := g.ExpireTurn(t0.Add(TurnTimeout), TimeoutCrossOut)
+	if err != nil {
+		t.Fatalf("ExpireTurn: %v", err)
+	}
+	if res.Field != Twos {
+		t.Fatalf("expected first open field twos to be crossed out, got %q", res.Field)
+	}
+	if v, ok := g.Players[0].Card.Value(Twos); !ok || v != 0 {
+		t.Fatalf("expected twos to hold 0, got %d %v", v, ok)
+	}
+}
+
+func TestExpireTurn_LastActivePlayerFinishesGame(t *testing.T) {
+	g := newTestGame(t, 2)
+	now := t0.Add(TurnTimeout)
+	if _, err := g.ExpireTurn(now, TimeoutSkip); err != nil {
+		t.Fatalf("first ExpireTurn: %v", err)
+	}
+	res, err := g.ExpireTurn(now.Add(TurnTimeout), TimeoutSkip)
+	if err != nil {
+		t.Fatalf("second ExpireTurn: %v", err)
+	}
+	if !res.Finished || g.Status != StatusFinished || len(res.Rankings) != 2 {
+		t.Fatalf("expected game to finish when nobody is active, got %+v", res)
+	}
+}
@@ -0,0 +1,260 @@
+package game
+
+// Field is a scorecard field name as used in the API.
+type Field string
+
+// Scorecard fields in display order.
+const (
+	Ones          Field = "ones"
+	Twos          Field = "twos"
+	Threes        Field = "threes"
+	Fours         Field = "fours"
+	Fives         Field = "fives"
+	Sixes         Field = "sixes"
+	ThreeOfAKind  Field = "three_of_a_kind"
+	FourOfAKind   Field = "four_of_a_kind"
+	FullHouse     Field = "full_house"
+	SmallStraight Field = "small_straight"
+	LargeStraight Field = "large_straight"
+	Kniffel       Field = "kniffel"
+	Chance        Field = "chance"
+)
+
+// Fixed point values and bonus rules.
+const (
+	FullHousePoints     = 25
+	SmallStraightPoints = 30
+	LargeStraightPoints = 40
+	KniffelPoints       = 50
+
+	UpperBonusThreshold = 63
+	UpperBonusPoints    = 35
+	KniffelBonusPoints  = 50
+)
+
+// Fields lists all 13 scorecard fields in display order.
+var Fields = []Field{
+	Ones, Twos, Threes, Fours, Fives, Sixes,
+	ThreeOfAKind, FourOfAKind, FullHouse, SmallStraight, LargeStraight, Kniffel, Chance,
+}
+
+// upperFace maps upper section fields to the die face they count.
+var upperFace = map[Field]int{Ones: 1, Twos: 2, Threes: 3, Fours: 4, Fives: 5, Sixes: 6}
+
+// ParseField converts a string to a Field. The second return value is false for unknown names.
+func ParseField(s string) (Field, bool) {
+	for _, f := range Fields {
+		if string(f) == s {
+			return f, true
+		}
+	}
+	return "", false
+}
+
+// IsUpper reports whether the field belongs to the upper section.
+func (f Field) IsUpper() bool {
+	_, ok := upperFace[f]
+	return ok
+}
+
+// upperFieldFor returns the upper section field counting the given face.
+func upperFieldFor(face int) Field {
+	for f, v := range upperFace {
+		if v == face {
+			return f
+		}
+	}
+	return ""
+}
+
+// Score calculates the points the given dice values are worth in field.
+// Dice that do not qualify for a field score 0 (crossing out).
+func Score(field Field, values []int) int {
+	return score(field, values, false)
+}
+
+// score calculates points; joker lets a Kniffel count as full house or straight.
+func score(field Field, values []int, joker bool) int {
+	counts := faceCounts(values)
+	sum := 0
+	for _, v := range values {
+		sum += v
+	}
+
+	if face, ok := upperFace[field]; ok {
+		return counts[face] * face
+	}
+
+	switch field {
+	case ThreeOfAKind:
+		if maxCount(counts) >= 3 {
+			return sum
+		}
+	case FourOfAKind:
+		if maxCount(counts) >= 4 {
+			return sum
+		}
+	case FullHouse:
+		if joker || isFullHouse(counts) {
+			return FullHousePoints
+		}
+	case SmallStraight:
+		if joker || longestRun(counts) >= 4 {
+			return SmallStraightPoints
+		}
+	case LargeStraight:
+		if joker || longestRun(counts) >= 5 {
+			return LargeStraightPoints
+		}
+	case Kniffel:
+		if maxCount(counts) == NumDice {
+			return KniffelPoints
+		}
+	case Chance:
+		return sum
+	}
+	return 0
+}
+
+// isKniffel reports whether all dice show the same rolled face.
+func isKniffel(values []int) bool {
+	if len(values) != NumDice || values[0] == 0 {
+		return false
+	}
+	for _, v := range values[1:] {
+		if v != values[0] {
+			return false
+		}
+	}
+	return true
+}
+
+func faceCounts(values []int) [7]int {
+	var counts [7]int
+	for _, v := range values {
+		if v >= 1 && v <= 6 {
+			counts[v]++
+		}
+	}
+	return counts
+}
+
+func maxCount(counts [7]int) int {
+	m := 0
+	for _, c := range counts[1:] {
+		if c > m {
+			m = c
+		}
+	}
+	return m
+}
+
+func isFullHouse(counts [7]int) bool {
+	hasThree, hasTwo := false, false
+	for _, c := range counts[1:] {
+		switch c {
+		case 3:
+			hasThree = true
+		case 2:
+			hasTwo = true
+		}
+	}
+	return hasThree && hasTwo
+}
+
+func longestRun(counts [7]int) int {
+	longest, run := 0, 0
+	for face := 1; face <= 6; face++ {
+		if counts[face] > 0 {
+			run++
+			if run > longest {
+				longest = run
+			}
+		} else {
+			run = 0
+		}
+	}
+	return longest
+}
+
+// ScoreCard holds the filled fields of a single player.
+// Fields absent from Scores are still open.
+type ScoreCard struct {
+	Scores            map[Field]int
+	KniffelBonusCount int
+}
+
+// NewScoreCard returns an empty scorecard.
+func NewScoreCard() ScoreCard {
+	return ScoreCard{Scores: make(map[Field]int, len(Fields))}
+}
+
+// IsFilled reports whether the field already holds a value (including a crossed out 0).
+func (c ScoreCard) IsFilled(f Field) bool {
+	_, ok := c.Scores[f]
+	return ok
+}
+
+// Value returns the score of a field and whether it is filled.
+func (c ScoreCard) Value(f Field) (int, bool) {
+	v, ok := c.Scores[f]
+	return v, ok
+}
+
+// Complete reports whether all 13 fields are filled.
+func (c ScoreCard) Complete() bool {
+	return len(c.Scores) == len(Fields)
+}
+
+// OpenFields returns all unfilled fields in display order.
+func (c ScoreCard) OpenFields() []Field {
+	open := make([]Field, 0, len(Fields))
+	for _, f := range Fields {
+		if !c.IsFilled(f) {
+			open = append(open, f)
+		}
+	}
+	return open
+}
+
+// UpperSum returns the sum of the upper section without bonus.
+func (c ScoreCard) UpperSum() int {
+	sum := 0
+	for f := range upperFace {
+		sum += c.Scores[f]
+	}
+	return sum
+}
+
+// UpperBonus returns 35 once the upper section reaches 63 points, otherwise 0.
+func (c ScoreCard) UpperBonus() int {
+	if c.UpperSum() >= UpperBonusThreshold {
+		return UpperBonusPoints
+	}
+	return 0
+}
+
+// LowerSum returns the sum of the lower section including multiple Kniffel bonuses.
+func (c ScoreCard) LowerSum() int {
+	sum := 0
+	for _, f := range Fields {
+		if !f.IsUpper() {
+			sum += c.Scores[f]
+		}
+	}
+	return sum + c.KniffelBonusCount*KniffelBonusPoints
+}
+
+// Total returns upper sum, upper bonus and lower sum combined.
+func (c ScoreCard) Total() int {
+	return c.UpperSum() + c.UpperBonus() + c.LowerSum()
+}
+
+// clone returns a deep copy of the scorecard.
+func (c ScoreCard) clone() ScoreCard {
+	scores := make(map[Field]int, len(c.Scores))
+	for f, v := range c.Scores {
+		scores[f] = v
+	}
+	return ScoreCard{Scores: scores, KniffelBonusCount: c.KniffelBonusCount}
+}
@@ -0,0 +1,97 @@
+package game
+
+import "testing"
+
+func TestScore(t *testing.T) {
+	tests := []struct {
+		name   string
+		field  Field
+		values []int
+		want   int
+	}{
+		{"ones counts only ones", Ones, []int{1, 1, 3, 4, 1}, 3},
+		{"sixes none", Sixes, []int{1, 2, 3, 4, 5}, 0},
+		{"fives", Fives, []int{5, 5, 5, 2, 1}, 15},
+		{"three of a kind", ThreeOfAKind, []int{3, 3, 3, 4, 5}, 18},
+		{"three of a kind missing", ThreeOfAKind, []int{3, 3, 2, 4, 5}, 0},
+		{"four of a kind", FourOfAKind, []int{6, 6, 6, 6, 2}, 26},
+		{"four of a kind with kniffel", FourOfAKind, []int{2, 2, 2, 2, 2}, 10},
+		{"full house", FullHouse, []int{2, 2, 3, 3, 3}, FullHousePoints},
+		{"full house kniffel is not full house", FullHouse, []int{4, 4, 4, 4, 4}, 0},
+		{"small straight", SmallStraight, []int{1, 2, 3, 4, 6}, SmallStraightPoints},
+		{"small straight with duplicate", SmallStraight, []int{3, 4, 5, 6, 3}, SmallStraightPoints},
+		{"small straight missing", SmallStraight, []int{1, 2, 3, 5, 6}, 0},
+		{"large straight", LargeStraight, []int{2, 3, 4, 5, 6}, LargeStraightPoints},
+		{"large straight missing", LargeStraight, []int{1, 2, 3, 4, 6}, 0},
+		{"kniffel", Kniffel, []int{5, 5, 5, 5, 5}, KniffelPoints},
+		{"kniffel missing", Kniffel, []int{5, 5, 5, 5, 4}, 0},
+		{"chance", Chance, []int{1, 2, 3, 4, 6}, 16},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Score(tt.field, tt.values); got != tt.want {
+				t.Fatalf("Score(%s, %v) = %d, want %d", tt.field, tt.values, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScore_JokerCountsFixedFields(t *testing.T) {
+	values := []int{3, 3, 3, 3, 3}
+	for field, want := range map[Field]int{FullHouse: FullHousePoints, SmallStraight: SmallStraightPoints, LargeStraight: LargeStraightPoints} {
+		if got := score(field, values, true); got != want {
+			t.Fatalf("joker score(%s) = %d, want %d", field, got, want)
+		}
+	}
+}
+
+func TestParseField(t *testing.T) {
+	if f, ok := ParseField("full_house"); !ok || f != FullHouse {
+		t.Fatalf("expected full_house to parse, got %q %v", f, ok)
+	}
+	if _, ok := ParseField("yahtzee"); ok {
+		t.Fatal("expected unknown field to be rejected")
+	}
+}
+
+func TestScoreCard_Totals(t *testing.T) {
+	c := NewScoreCard()
+	c.Scores[Ones] = 3
+	c.Scores[Twos] = 6
+	c.Scores[Threes] = 9
+	c.Scores[Fours] = 12
+	c.Scores[Fives] = 15
+	c.Scores[Sixes] = 18
+	c.Scores[Kniffel] = KniffelPoints
+	c.Scores[Chance] = 20
+	c.KniffelBonusCount = 1
+
+	if got := c.UpperSum(); got != 63 {
+		t.Fatalf("expected upper sum 63, got %d", got)
+	}
+	if got := c.UpperBonus(); got != UpperBonusPoints {
+		t.Fatalf("expected upper bonus %d, got %d", UpperBonusPoints, got)
+	}
+	if got := c.LowerSum(); got != 120 {
+		t.Fatalf("expected lower sum 120, got %d", got)
+	}
+	if got := c.Total(); got != 63+35+120 {
+		t.Fatalf("expected total %d, got %d", 63+35+120, got)
+	}
+	if c.Complete() {
+		t.Fatal("expected scorecard to be incomplete")
+	}
+	if got := len(c.OpenFields()); got != 5 {
+		t.Fatalf("expected 5 open fields, got %d", got)
+	}
+}
+
+func TestScoreCard_NoBonusBelowThreshold(t *testing.T) {
+	c := NewScoreCard()
+	c.Scores[Sixes] = 30
+	c.Scores[Fives] = 25
+	c.Scores[Fours] = 4
+	if got := c.UpperBonus(); got != 0 {
+		t.Fatalf("expected no bonus at 59 points, got %d", got)
+	}
+}
@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/KnuffelGame/KnuffelGame/backend/libs/httpx"
+	"github.com/KnuffelGame/KnuffelGame/backend/libs/logger"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/GameService/internal/events"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/GameService/internal/models"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/GameService/internal/repository"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/GameService/internal/timeout"
+)
+
+// AbortGameHandler returns an http.HandlerFunc that finishes a game on behalf of the Lobby Service
+// Internal endpoint called when a lobby fails to start after the game was created, or when the lobby is closed
+// Stops the turn timeout so no player is flagged inactive afterwards
+// Path parameter: game_id (UUID)
+// Publishes: game_ended
+// Returns: 200 OK with EndGameResponse, 409 if the game is already finished
+func AbortGameHandler(repo repository.Repository, pub events.Publisher, timers *timeout.Scheduler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log := logger.Logger(r.Context()).WithGroup("handler").With(slog.String("action", "abort_game"))
+
+		gameID, ok := parseGameID(w, r, log)
+		if !ok {
+			return
+		}
+
+		g, ok := loadGame(w, r, repo, gameID, log)
+		if !ok {
+			return
+		}
+
+		rankings, err := g.Abort(timers.Now())
+		if err != nil {
+			writeGameError(w, err, nil, log)
+			return
+		}
+
+		if !saveGame(w, r, repo, g, log) {
+			return
+		}
+
+		scheduleTurn(timers, g)
+
+		final := toRankings(rankings)
+		publish(r.Context(), pub, g.ID, models.EventGameEnded, models.GameEndedEvent{GameID: g.ID, Rankings: final}, log)
+
+		log.Info("game aborted", slog.String("game_id", g.ID.String()), slog.String("lobby_id", g.LobbyID.String()))
+
+		httpx.WriteJSON(w, http.StatusOK, models.EndGameResponse{
+			GameID:           g.ID,
+			Status:           string(g.Status),
+			EndedPrematurely: true,
+			FinalRankings:    final,
+			EndedAt:          *g.FinishedAt,
+		}, log)
+	}
+}
@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/KnuffelGame/KnuffelGame/backend/services/GameService/internal/game"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/GameService/internal/models"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/GameService/internal/repository"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// serveInternalRequest runs h without auth headers and with the game_id path parameter set
+func serveInternalRequest(h http.HandlerFunc, gameID uuid.UUID) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/internal/games/"+gameID.String()+"/abort", nil)
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, &chi.Context{
+		URLParams: chi.RouteParams{Keys: []string{"game_id"}, Values: []string{gameID.String()}},
+	}))
+	rec := httptest.NewRecorder()
+	h(rec, req)
+	return rec
+}
+
+func TestAbortGame_Success(t *testing.T) {
+	repo := repository.NewMemory()
+	pub := &recordingPublisher{}
+	g := seedGame(t, repo)
+	timers := newTestTimers()
+	timers.Schedule(g.ID, g.Deadline())
+
+	rec := serveInternalRequest(AbortGameHandler(repo, pub, timers), g.ID)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if timers.Pending() != 0 {
+		t.Fatalf("expected turn timeout to be cancelled for an aborted game")
+	}
+	stored, err := repo.GetGame(context.Background(), g.ID)
+	if err != nil || stored.Status != game.StatusFinished {
+		t.Fatalf("expected the stored game to be finished, got %+v, %v", stored, err)
+	}
+	if types := pub.types(); len(types) != 1 || types[0] != models.EventGameEnded {
+		t.Fatalf("expected game_ended event, got %v", types)
+	}
+
+	rec = serveInternalRequest(AbortGameHandler(repo, pub, newTestTimers()), g.ID)
+	if rec.Code != http.StatusConflict {
+		t.Fatalf("expected 409 for finished game, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestAbortGame_NotFound(t *testing.T) {
+	rec := serveInternalRequest(AbortGameHandler(repository.NewMemory(), &recordingPublisher{}, newTestTimers()), uuid.New())
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
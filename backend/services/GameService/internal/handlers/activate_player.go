@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/KnuffelGame/KnuffelGame/backend/libs/httpx"
+	"github.com/KnuffelGame/KnuffelGame/backend/libs/logger"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/GameService/internal/repository"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// ActivatePlayerHandler returns an http.HandlerFunc that takes an inactive player back into the turn order
+// Internal endpoint called by the Lobby Service when a player of a running lobby is flagged active again,
+// e.g. after their turn timed out
+// Path parameters: game_id (UUID), user_id (UUID)
+// Returns: 204 No Content, 403 if the user is not a player, 409 if the game is already finished
+func ActivatePlayerHandler(repo repository.Repository) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log := logger.Logger(r.Context()).WithGroup("handler").With(slog.String("action", "activate_player"))
+
+		gameID, ok := parseGameID(w, r, log)
+		if !ok {
+			return
+		}
+
+		userIDStr := chi.URLParam(r, "user_id")
+		userID, err := uuid.Parse(userIDStr)
+		if err != nil {
+			log.Warn("invalid user_id format", slog.String("user_id", userIDStr), slog.String("error", err.Error()))
+			httpx.WriteBadRequest(w, "Invalid user ID format", map[string]interface{}{"detail": err.Error()}, log)
+			return
+		}
+
+		g, ok := loadGame(w, r, repo, gameID, log)
+		if !ok {
+			return
+		}
+
+		if err := g.Activate(userID); err != nil {
+			writeGameError(w, err, nil, log)
+			return
+		}
+
+		if !saveGame(w, r, repo, g, log) {
+			return
+		}
+
+		log.Info("player activated",
+			slog.String("game_id", g.ID.String()),
+			slog.String("user_id", userID.String()))
+
+		httpx.WriteNoContent(w)
+	}
+}
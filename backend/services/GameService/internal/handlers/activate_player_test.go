@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/KnuffelGame/KnuffelGame/backend/services/GameService/internal/repository"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+func activatePlayer(h http.HandlerFunc, gameID, userID uuid.UUID) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/internal/games/"+gameID.String()+"/players/"+userID.String()+"/activate", nil)
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, &chi.Context{
+		URLParams: chi.RouteParams{Keys: []string{"game_id", "user_id"}, Values: []string{gameID.String(), userID.String()}},
+	}))
+	rec := httptest.NewRecorder()
+	h(rec, req)
+	return rec
+}
+
+func TestActivatePlayer(t *testing.T) {
+	repo := repository.NewMemory()
+	g := seedGame(t, repo)
+	g.Players[1].Active = false
+	if err := repo.UpdateGame(context.Background(), g); err != nil {
+		t.Fatalf("failed to save game: %v", err)
+	}
+
+	rec := activatePlayer(ActivatePlayerHandler(repo), g.ID, g.Players[1].UserID)
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d: %s", rec.Code, rec.Body.String())
+	}
+	stored, err := repo.GetGame(context.Background(), g.ID)
+	if err != nil {
+		t.Fatalf("failed to load game: %v", err)
+	}
+	if !stored.Players[1].Active {
+		t.Fatal("expected the player to be active again")
+	}
+}
+
+func TestActivatePlayer_NotAPlayer(t *testing.T) {
+	repo := repository.NewMemory()
+	g := seedGame(t, repo)
+
+	rec := activatePlayer(ActivatePlayerHandler(repo), g.ID, uuid.New())
+	if rec.Code != http.StatusForbidden {
+		t.Fatalf("expected 403, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestActivatePlayer_UnknownGame(t *testing.T) {
+	rec := activatePlayer(ActivatePlayerHandler(repository.NewMemory()), uuid.New(), uuid.New())
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
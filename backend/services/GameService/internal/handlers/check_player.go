@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/KnuffelGame/KnuffelGame/backend/libs/httpx"
+	"github.com/KnuffelGame/KnuffelGame/backend/libs/logger"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/GameService/internal/game"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/GameService/internal/repository"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// CheckPlayerHandler returns an http.HandlerFunc that tells whether a user is a player of a game
+// Internal endpoint called by the SSE Service before it opens the event stream of a game
+// Path parameters: game_id (UUID), user_id (UUID)
+// Returns: 204 No Content for players, including those who left or timed out, 403 if the user is not a player
+func CheckPlayerHandler(repo repository.Repository) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log := logger.Logger(r.Context()).WithGroup("handler").With(slog.String("action", "check_player"))
+
+		gameID, ok := parseGameID(w, r, log)
+		if !ok {
+			return
+		}
+
+		userIDStr := chi.URLParam(r, "user_id")
+		userID, err := uuid.Parse(userIDStr)
+		if err != nil {
+			log.Warn("invalid user_id format", slog.String("user_id", userIDStr), slog.String("error", err.Error()))
+			httpx.WriteBadRequest(w, "Invalid user ID format", map[string]interface{}{"detail": err.Error()}, log)
+			return
+		}
+
+		g, ok := loadGame(w, r, repo, gameID, log)
+		if !ok {
+			return
+		}
+
+		if _, ok := g.Player(userID); !ok {
+			writeGameError(w, game.ErrNotAPlayer, nil, log)
+			return
+		}
+
+		httpx.WriteNoContent(w)
+	}
+}
@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/KnuffelGame/KnuffelGame/backend/services/GameService/internal/repository"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+func checkPlayer(h http.HandlerFunc, gameID, userID uuid.UUID) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/internal/games/"+gameID.String()+"/players/"+userID.String(), nil)
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, &chi.Context{
+		URLParams: chi.RouteParams{Keys: []string{"game_id", "user_id"}, Values: []string{gameID.String(), userID.String()}},
+	}))
+	rec := httptest.NewRecorder()
+	h(rec, req)
+	return rec
+}
+
+func TestCheckPlayer(t *testing.T) {
+	repo := repository.NewMemory()
+	g := seedGame(t, repo)
+
+	rec := checkPlayer(CheckPlayerHandler(repo), g.ID, g.Players[1].UserID)
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestCheckPlayer_NotAPlayer(t *testing.T) {
+	repo := repository.NewMemory()
+	g := seedGame(t, repo)
+
+	rec := checkPlayer(CheckPlayerHandler(repo), g.ID, uuid.New())
+	if rec.Code != http.StatusForbidden {
+		t.Fatalf("expected 403, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestCheckPlayer_UnknownGame(t *testing.T) {
+	rec := checkPlayer(CheckPlayerHandler(repository.NewMemory()), uuid.New(), uuid.New())
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
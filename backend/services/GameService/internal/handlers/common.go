@@ -0,0 +1,220 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/KnuffelGame/KnuffelGame/backend/libs/httpx"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/GameService/internal/events"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/GameService/internal/game"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/GameService/internal/lobby"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/GameService/internal/models"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/GameService/internal/repository"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/GameService/internal/timeout"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// parseGameID extracts and parses the game_id path parameter, writing a 400 response on failure
+func parseGameID(w http.ResponseWriter, r *http.Request, log *slog.Logger) (uuid.UUID, bool) {
+	gameIDStr := chi.URLParam(r, "game_id")
+	if gameIDStr == "" {
+		log.Warn("missing game_id parameter")
+		httpx.WriteBadRequest(w, "Missing game_id parameter", nil, log)
+		return uuid.Nil, false
+	}
+	gameID, err := uuid.Parse(gameIDStr)
+	if err != nil {
+		log.Warn("invalid game_id format", slog.String("game_id", gameIDStr), slog.String("error", err.Error()))
+		httpx.WriteBadRequest(w, "Invalid game ID format", map[string]interface{}{"detail": err.Error()}, log)
+		return uuid.Nil, false
+	}
+	return gameID, true
+}
+
+// loadGame fetches a game from the repository, writing a 404 or 500 response on failure
+func loadGame(w http.ResponseWriter, r *http.Request, repo repository.Repository, gameID uuid.UUID, log *slog.Logger) (*game.Game, bool) {
+	g, err := repo.GetGame(r.Context(), gameID)
+	if err != nil {
+		if errors.Is(err, repository.ErrGameNotFound) {
+			log.Warn("game not found", slog.String("game_id", gameID.String()))
+			httpx.WriteError(w, http.StatusNotFound, "game_not_found", "Game not found", nil, log)
+			return nil, false
+		}
+		log.Error("failed to load game", slog.String("error", err.Error()), slog.String("game_id", gameID.String()))
+		httpx.WriteInternalError(w, "Database error", nil, log)
+		return nil, false
+	}
+	return g, true
+}
+
+// saveGame persists a game, writing a 409 response if the game was modified concurrently and 500 on other failures
+func saveGame(w http.ResponseWriter, r *http.Request, repo repository.Repository, g *game.Game, log *slog.Logger) bool {
+	if err := repo.UpdateGame(r.Context(), g); err != nil {
+		if errors.Is(err, repository.ErrVersionConflict) {
+			log.Warn("concurrent game modification", slog.String("game_id", g.ID.String()), slog.Int("version", g.Version))
+			httpx.WriteError(w, http.StatusConflict, "conflict", "Game was modified concurrently, please retry", nil, log)
+			return false
+		}
+		log.Error("failed to update game", slog.String("error", err.Error()), slog.String("game_id", g.ID.String()))
+		httpx.WriteInternalError(w, "Database error", nil, log)
+		return false
+	}
+	return true
+}
+
+// writeGameError maps engine errors to API error responses.
+// details are attached to the response where the error benefits from context (e.g. the offending field).
+func writeGameError(w http.ResponseWriter, err error, details map[string]interface{}, log *slog.Logger) {
+	log.Warn("game action rejected", slog.String("error", err.Error()))
+	switch {
+	case errors.Is(err, game.ErrNotYourTurn):
+		httpx.WriteError(w, http.StatusForbidden, "forbidden", "It's not your turn", details, log)
+	case errors.Is(err, game.ErrNotAPlayer):
+		httpx.WriteError(w, http.StatusForbidden, "forbidden", "You are not a player in this game", details, log)
+	case errors.Is(err, game.ErrNotLeader):
+		httpx.WriteError(w, http.StatusForbidden, "forbidden", "Only the lobby leader can end the game prematurely", details, log)
+	case errors.Is(err, game.ErrMaxRollsReached):
+		httpx.WriteError(w, http.StatusForbidden, "forbidden", "Maximum rolls (3) reached - must select a field", details, log)
+	case errors.Is(err, game.ErrNotRolledYet):
+		httpx.WriteError(w, http.StatusBadRequest, "invalid_request", "Must roll dice first", details, log)
+	case errors.Is(err, game.ErrInvalidDieIndex):
+		httpx.WriteError(w, http.StatusBadRequest, "invalid_request", "Invalid dice index (must be 0-4)", details, log)
+	case errors.Is(err, game.ErrInvalidField):
+		httpx.WriteError(w, http.StatusBadRequest, "invalid_request", "Invalid field name", details, log)
+	case errors.Is(err, game.ErrFieldFilled):
+		httpx.WriteError(w, http.StatusBadRequest, "invalid_request", "Field already filled", details, log)
+	case errors.Is(err, game.ErrJokerField):
+		httpx.WriteError(w, http.StatusBadRequest, "invalid_request", "Field not allowed by joker rules", details, log)
+	case errors.Is(err, game.ErrGameFinished):
+		httpx.WriteError(w, http.StatusConflict, "conflict", "Game is already finished", details, log)
+	default:
+		log.Error("unexpected game error", slog.String("error", err.Error()))
+		httpx.WriteInternalError(w, "Internal error", nil, log)
+	}
+}
+
+// publish sends an event for the game to the SSE Service.
+// Failures are logged only; the game state change has already been persisted.
+func publish(ctx context.Context, pub events.Publisher, gameID uuid.UUID, eventType string, data interface{}, log *slog.Logger) {
+	e := events.Event{
+		TargetType: events.TargetGame,
+		TargetID:   gameID.String(),
+		EventType:  eventType,
+		Data:       data,
+	}
+	if err := pub.Publish(ctx, e); err != nil {
+		log.Warn("failed to publish event", slog.String("event_type", eventType), slog.String("error", err.Error()))
+	}
+}
+
+// finishLobby tells the Lobby Service that the game has ended, so the lobby frees its join code.
+// Failures are logged only; the game has already been persisted as finished.
+func finishLobby(ctx context.Context, lobbies lobby.Client, g *game.Game, log *slog.Logger) {
+	if err := lobbies.FinishLobby(ctx, g.LobbyID, g.ID); err != nil {
+		log.Warn("failed to finish lobby", slog.String("lobby_id", g.LobbyID.String()), slog.String("error", err.Error()))
+	}
+}
+
+// scheduleTurn resets the turn timeout after an interaction, or cancels it once the game has finished
+func scheduleTurn(timers *timeout.Scheduler, g *game.Game) {
+	if g.Status == game.StatusRunning {
+		timers.Schedule(g.ID, g.Deadline())
+		return
+	}
+	timers.Cancel(g.ID)
+}
+
+func toDice(dice [game.NumDice]game.Die) []models.Die {
+	out := make([]models.Die, len(dice))
+	for i, d := range dice {
+		out[i] = models.Die{Locked: d.Locked}
+		if d.Value != 0 {
+			v := d.Value
+			out[i].Value = &v
+		}
+	}
+	return out
+}
+
+func toScoreCard(c game.ScoreCard) models.ScoreCard {
+	value := func(f game.Field) *int {
+		v, ok := c.Value(f)
+		if !ok {
+			return nil
+		}
+		return &v
+	}
+	sc := models.ScoreCard{
+		Ones:              value(game.Ones),
+		Twos:              value(game.Twos),
+		Threes:            value(game.Threes),
+		Fours:             value(game.Fours),
+		Fives:             value(game.Fives),
+		Sixes:             value(game.Sixes),
+		UpperSum:          c.UpperSum(),
+		ThreeOfAKind:      value(game.ThreeOfAKind),
+		FourOfAKind:       value(game.FourOfAKind),
+		FullHouse:         value(game.FullHouse),
+		SmallStraight:     value(game.SmallStraight),
+		LargeStraight:     value(game.LargeStraight),
+		Kniffel:           value(game.Kniffel),
+		Chance:            value(game.Chance),
+		LowerSum:          c.LowerSum(),
+		Total:             c.Total(),
+		KniffelBonusCount: c.KniffelBonusCount,
+	}
+	// bonus stays null until it is reached or can no longer be reached
+	if bonus := c.UpperBonus(); bonus > 0 || upperComplete(c) {
+		sc.Bonus = &bonus
+	}
+	return sc
+}
+
+func upperComplete(c game.ScoreCard) bool {
+	for _, f := range game.Fields {
+		if f.IsUpper() && !c.IsFilled(f) {
+			return false
+		}
+	}
+	return true
+}
+
+func toRankings(rankings []game.Ranking) []models.PlayerRanking {
+	out := make([]models.PlayerRanking, len(rankings))
+	for i, rk := range rankings {
+		out[i] = models.PlayerRanking{UserID: rk.UserID, Username: rk.Username, TotalScore: rk.TotalScore, Rank: rk.Rank}
+	}
+	return out
+}
+
+func toGameState(g *game.Game, now time.Time) models.GameStateResponse {
+	current := g.CurrentPlayer()
+	board := make([]models.PlayerScores, len(g.Players))
+	for i, p := range g.Players {
+		status := models.PlayerStatusActive
+		if !p.Active {
+			status = models.PlayerStatusInactive
+		}
+		board[i] = models.PlayerScores{UserID: p.UserID, Username: p.Username, Status: status, Scores: toScoreCard(p.Card)}
+	}
+	return models.GameStateResponse{
+		GameID:                  g.ID,
+		LobbyID:                 g.LobbyID,
+		Status:                  string(g.Status),
+		CurrentPlayerID:         current.UserID,
+		CurrentPlayerUsername:   current.Username,
+		RollCount:               g.RollCount,
+		Dice:                    toDice(g.Dice),
+		TimeoutRemainingSeconds: int(g.TimeoutRemaining(now).Seconds()),
+		TurnTimeoutSeconds:      int(g.TurnTimeout.Seconds()),
+		Variant:                 string(g.Variant),
+		TurnOrder:               g.TurnOrder(),
+		ScoreBoard:              board,
+		StartedAt:               g.StartedAt,
+		FinishedAt:              g.FinishedAt,
+	}
+}
@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/KnuffelGame/KnuffelGame/backend/libs/httpx"
+	"github.com/KnuffelGame/KnuffelGame/backend/libs/logger"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/GameService/internal/game"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/GameService/internal/models"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/GameService/internal/repository"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/GameService/internal/timeout"
+	"github.com/google/uuid"
+)
+
+// CreateGameHandler returns an http.HandlerFunc that creates a new game
+// Internal endpoint called by the Lobby Service when a game starts; does not publish events
+// Starts the turn timeout of the first player
+// Request body: CreateGameRequest with lobby_id, optional leader_id, turn_order (2-10 players) and optional settings
+// If leader_id is omitted the first player in turn_order is treated as leader
+// Returns: 201 Created with CreateGameResponse
+func CreateGameHandler(repo repository.Repository, timers *timeout.Scheduler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log := logger.Logger(r.Context()).WithGroup("handler").With(slog.String("action", "create_game"))
+
+		var req models.CreateGameRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			log.Warn("failed to decode request body", slog.String("error", err.Error()))
+			httpx.WriteBadRequest(w, "Invalid request body", map[string]interface{}{"detail": err.Error()}, log)
+			return
+		}
+
+		if req.LobbyID == uuid.Nil {
+			log.Warn("missing lobby_id")
+			httpx.WriteBadRequest(w, "lobby_id is required", nil, log)
+			return
+		}
+
+		var variant game.Variant
+		if req.Variant != "" {
+			v, ok := game.ParseVariant(req.Variant)
+			if !ok {
+				log.Warn("unknown variant", slog.String("variant", req.Variant))
+				httpx.WriteBadRequest(w, "Unknown variant", map[string]interface{}{"variant": req.Variant}, log)
+				return
+			}
+			variant = v
+		}
+
+		seats := make([]game.Seat, 0, len(req.TurnOrder))
+		for _, p := range req.TurnOrder {
+			if p.UserID == uuid.Nil || p.Username == "" {
+				log.Warn("invalid turn_order entry", slog.String("user_id", p.UserID.String()), slog.String("username", p.Username))
+				httpx.WriteBadRequest(w, "Each turn_order entry requires user_id and username", nil, log)
+				return
+			}
+			seats = append(seats, game.Seat{UserID: p.UserID, Username: p.Username})
+		}
+
+		leaderID := req.LeaderID
+		if leaderID == uuid.Nil && len(seats) > 0 {
+			leaderID = seats[0].UserID
+		}
+
+		g, err := game.New(uuid.New(), req.LobbyID, leaderID, seats, timers.Now())
+		if err != nil {
+			log.Warn("invalid turn order", slog.String("error", err.Error()), slog.Int("players", len(seats)))
+			switch {
+			case errors.Is(err, game.ErrNotEnoughPlayers), errors.Is(err, game.ErrTooManyPlayers):
+				httpx.WriteBadRequest(w, fmt.Sprintf("turn_order must contain between %d and %d players", game.MinPlayers, game.MaxPlayers),
+					map[string]interface{}{"players": len(seats)}, log)
+			case errors.Is(err, game.ErrDuplicatePlayer):
+				httpx.WriteBadRequest(w, "turn_order must not contain duplicate players", nil, log)
+			default:
+				httpx.WriteBadRequest(w, "Invalid turn order", nil, log)
+			}
+			return
+		}
+		if err := g.Configure(time.Duration(req.TurnTimeoutSeconds)*time.Second, variant); err != nil {
+			log.Warn("invalid turn timeout", slog.Int("turn_timeout_seconds", req.TurnTimeoutSeconds))
+			httpx.WriteBadRequest(w, fmt.Sprintf("turn_timeout_seconds must be between %d and %d",
+				int(game.MinTurnTimeout.Seconds()), int(game.MaxTurnTimeout.Seconds())), nil, log)
+			return
+		}
+
+		if err := repo.CreateGame(r.Context(), g); err != nil {
+			log.Error("failed to create game", slog.String("error", err.Error()), slog.String("lobby_id", req.LobbyID.String()))
+			httpx.WriteInternalError(w, "Failed to create game", nil, log)
+			return
+		}
+
+		// start the timeout of the first turn
+		scheduleTurn(timers, g)
+
+		log.Info("game created",
+			slog.String("game_id", g.ID.String()),
+			slog.String("lobby_id", g.LobbyID.String()),
+			slog.Int("players", len(g.Players)),
+			slog.String("variant", string(g.Variant)))
+
+		httpx.WriteJSON(w, http.StatusCreated, models.CreateGameResponse{
+			GameID:          g.ID,
+			LobbyID:         g.LobbyID,
+			CurrentPlayerID: g.CurrentPlayer().UserID,
+			TurnOrder:       g.TurnOrder(),
+		}, log)
+	}
+}
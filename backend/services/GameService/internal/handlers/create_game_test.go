@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/KnuffelGame/KnuffelGame/backend/services/GameService/internal/game"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/GameService/internal/models"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/GameService/internal/repository"
+	"github.com/google/uuid"
+)
+
+func TestCreateGame_Success(t *testing.T) {
+	repo := repository.NewMemory()
+	h := CreateGameHandler(repo, newTestTimers())
+
+	reqBody := models.CreateGameRequest{
+		LobbyID: uuid.New(),
+		TurnOrder: []models.PlayerInfo{
+			{UserID: uuid.New(), Username: "Charlie"},
+			{UserID: uuid.New(), Username: "Alice"},
+		},
+	}
+	bodyBytes, _ := json.Marshal(reqBody)
+	req := httptest.NewRequest(http.MethodPost, "/internal/create", bytes.NewReader(bodyBytes))
+	rec := httptest.NewRecorder()
+	h(rec, req)
+
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	var resp models.CreateGameResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.LobbyID != reqBody.LobbyID || resp.CurrentPlayerID != reqBody.TurnOrder[0].UserID || len(resp.TurnOrder) != 2 {
+		t.Fatalf("unexpected response %+v", resp)
+	}
+
+	g, err := repo.GetGame(context.Background(), resp.GameID)
+	if err != nil {
+		t.Fatalf("game not stored: %v", err)
+	}
+	if g.LeaderID != reqBody.TurnOrder[0].UserID {
+		t.Fatalf("expected leader to default to first player, got %s", g.LeaderID)
+	}
+}
+
+func TestCreateGame_Settings(t *testing.T) {
+	repo := repository.NewMemory()
+	players := []models.PlayerInfo{{UserID: uuid.New(), Username: "A"}, {UserID: uuid.New(), Username: "B"}}
+
+	bodyBytes, _ := json.Marshal(models.CreateGameRequest{LobbyID: uuid.New(), TurnOrder: players, TurnTimeoutSeconds: 90, Variant: "free_joker"})
+	rec := httptest.NewRecorder()
+	CreateGameHandler(repo, newTestTimers())(rec, httptest.NewRequest(http.MethodPost, "/internal/create", bytes.NewReader(bodyBytes)))
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var resp models.CreateGameResponse
+	_ = json.Unmarshal(rec.Body.Bytes(), &resp)
+	g, err := repo.GetGame(context.Background(), resp.GameID)
+	if err != nil {
+		t.Fatalf("game not stored: %v", err)
+	}
+	if g.TurnTimeout != 90*time.Second || g.Variant != game.VariantFreeJoker {
+		t.Fatalf("settings not applied: %v %q", g.TurnTimeout, g.Variant)
+	}
+
+	for _, body := range []models.CreateGameRequest{
+		{LobbyID: uuid.New(), TurnOrder: players, TurnTimeoutSeconds: 5},
+		{LobbyID: uuid.New(), TurnOrder: players, Variant: "yahtzee"},
+	} {
+		bodyBytes, _ := json.Marshal(body)
+		rec := httptest.NewRecorder()
+		CreateGameHandler(repo, newTestTimers())(rec, httptest.NewRequest(http.MethodPost, "/internal/create", bytes.NewReader(bodyBytes)))
+		if rec.Code != http.StatusBadRequest {
+			t.Fatalf("expected 400 for %+v, got %d", body, rec.Code)
+		}
+	}
+}
+
+func TestCreateGame_InvalidTurnOrder(t *testing.T) {
+	tests := []struct {
+		name string
+		body models.CreateGameRequest
+	}{
+		{"missing lobby", models.CreateGameRequest{TurnOrder: []models.PlayerInfo{{UserID: uuid.New(), Username: "A"}, {UserID: uuid.New(), Username: "B"}}}},
+		{"single player", models.CreateGameRequest{LobbyID: uuid.New(), TurnOrder: []models.PlayerInfo{{UserID: uuid.New(), Username: "A"}}}},
+		{"missing username", models.CreateGameRequest{LobbyID: uuid.New(), TurnOrder: []models.PlayerInfo{{UserID: uuid.New()}, {UserID: uuid.New(), Username: "B"}}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bodyBytes, _ := json.Marshal(tt.body)
+			req := httptest.NewRequest(http.MethodPost, "/internal/create", bytes.NewReader(bodyBytes))
+			rec := httptest.NewRecorder()
+			CreateGameHandler(repository.NewMemory(), newTestTimers())(rec, req)
+			if rec.Code != http.StatusBadRequest {
+				t.Fatalf("expected 400, got %d: %s", rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/KnuffelGame/KnuffelGame/backend/libs/auth"
+	"github.com/KnuffelGame/KnuffelGame/backend/libs/httpx"
+	"github.com/KnuffelGame/KnuffelGame/backend/libs/logger"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/GameService/internal/events"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/GameService/internal/lobby"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/GameService/internal/models"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/GameService/internal/repository"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/GameService/internal/timeout"
+)
+
+// EndGameHandler returns an http.HandlerFunc that ends a game prematurely
+// Requires AuthMiddleware; only the lobby leader may end the game
+// Path parameter: game_id (UUID)
+// Publishes: game_ended; the Lobby Service is told that the game ended
+// Returns: 200 OK with EndGameResponse containing rankings based on current scores
+func EndGameHandler(repo repository.Repository, pub events.Publisher, lobbies lobby.Client, timers *timeout.Scheduler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log := logger.Logger(r.Context()).WithGroup("handler").With(slog.String("action", "end_game"))
+
+		user, ok := auth.FromContext(r.Context())
+		if !ok {
+			log.Warn("user missing from context")
+			httpx.WriteUnauthorized(w, "Missing authentication headers", log)
+			return
+		}
+
+		gameID, ok := parseGameID(w, r, log)
+		if !ok {
+			return
+		}
+
+		g, ok := loadGame(w, r, repo, gameID, log)
+		if !ok {
+			return
+		}
+
+		rankings, err := g.End(user.ID, timers.Now())
+		if err != nil {
+			writeGameError(w, err, nil, log)
+			return
+		}
+
+		if !saveGame(w, r, repo, g, log) {
+			return
+		}
+
+		scheduleTurn(timers, g)
+
+		final := toRankings(rankings)
+		publish(r.Context(), pub, g.ID, models.EventGameEnded, models.GameEndedEvent{GameID: g.ID, Rankings: final}, log)
+		finishLobby(r.Context(), lobbies, g, log)
+
+		log.Info("game ended prematurely", slog.String("game_id", g.ID.String()), slog.String("user_id", user.ID.String()))
+
+		httpx.WriteJSON(w, http.StatusOK, models.EndGameResponse{
+			GameID:           g.ID,
+			Status:           string(g.Status),
+			EndedPrematurely: true,
+			FinalRankings:    final,
+			EndedAt:          *g.FinishedAt,
+		}, log)
+	}
+}
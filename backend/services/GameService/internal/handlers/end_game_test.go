@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/KnuffelGame/KnuffelGame/backend/services/GameService/internal/game"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/GameService/internal/models"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/GameService/internal/repository"
+)
+
+func TestEndGame_Success(t *testing.T) {
+	repo := repository.NewMemory()
+	pub := &recordingPublisher{}
+	g := seedGame(t, repo)
+	timers := newTestTimers()
+	timers.Schedule(g.ID, g.Deadline())
+
+	lobbies := &recordingLobby{}
+	rec := serveGameRequest(EndGameHandler(repo, pub, lobbies, timers), http.MethodPost, g.ID, g.Players[0], nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if timers.Pending() != 0 {
+		t.Fatalf("expected turn timeout to be cancelled for a finished game")
+	}
+
+	var resp models.EndGameResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Status != string(game.StatusFinished) || !resp.EndedPrematurely || len(resp.FinalRankings) != 2 {
+		t.Fatalf("unexpected response %+v", resp)
+	}
+	if types := pub.types(); len(types) != 1 || types[0] != models.EventGameEnded {
+		t.Fatalf("expected game_ended event, got %v", types)
+	}
+	if len(lobbies.finished) != 1 || lobbies.finished[0] != g.ID {
+		t.Fatalf("expected the lobby to be finished, got %v", lobbies.finished)
+	}
+
+	rec = serveGameRequest(EndGameHandler(repo, pub, lobbies, newTestTimers()), http.MethodPost, g.ID, g.Players[0], nil)
+	if rec.Code != http.StatusConflict {
+		t.Fatalf("expected 409 for finished game, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestEndGame_NotLeader(t *testing.T) {
+	repo := repository.NewMemory()
+	g := seedGame(t, repo)
+
+	rec := serveGameRequest(EndGameHandler(repo, &recordingPublisher{}, &recordingLobby{}, newTestTimers()), http.MethodPost, g.ID, g.Players[1], nil)
+	if rec.Code != http.StatusForbidden {
+		t.Fatalf("expected 403, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
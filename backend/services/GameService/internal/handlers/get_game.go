@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/KnuffelGame/KnuffelGame/backend/libs/auth"
+	"github.com/KnuffelGame/KnuffelGame/backend/libs/httpx"
+	"github.com/KnuffelGame/KnuffelGame/backend/libs/logger"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/GameService/internal/game"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/GameService/internal/repository"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/GameService/internal/timeout"
+)
+
+// GetGameHandler returns an http.HandlerFunc that returns the complete game state
+// Requires AuthMiddleware; only players of the game may view it
+// Path parameter: game_id (UUID)
+// Returns: 200 OK with GameStateResponse
+func GetGameHandler(repo repository.Repository, timers *timeout.Scheduler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log := logger.Logger(r.Context()).WithGroup("handler").With(slog.String("action", "get_game"))
+
+		user, ok := auth.FromContext(r.Context())
+		if !ok {
+			log.Warn("user missing from context")
+			httpx.WriteUnauthorized(w, "Missing authentication headers", log)
+			return
+		}
+
+		gameID, ok := parseGameID(w, r, log)
+		if !ok {
+			return
+		}
+
+		g, ok := loadGame(w, r, repo, gameID, log)
+		if !ok {
+			return
+		}
+
+		if _, ok := g.Player(user.ID); !ok {
+			writeGameError(w, game.ErrNotAPlayer, nil, log)
+			return
+		}
+
+		httpx.WriteJSON(w, http.StatusOK, toGameState(g, timers.Now()), log)
+	}
+}
@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/KnuffelGame/KnuffelGame/backend/services/GameService/internal/game"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/GameService/internal/models"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/GameService/internal/repository"
+	"github.com/google/uuid"
+)
+
+func TestGetGame_Success(t *testing.T) {
+	repo := repository.NewMemory()
+	g := seedGame(t, repo)
+
+	rec := serveGameRequest(GetGameHandler(repo, newTestTimers()), http.MethodGet, g.ID, g.Players[1], nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	var resp models.GameStateResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Status != string(game.StatusRunning) || resp.CurrentPlayerID != g.Players[0].UserID {
+		t.Fatalf("unexpected state %+v", resp)
+	}
+	if len(resp.Dice) != game.NumDice || resp.Dice[0].Value != nil {
+		t.Fatalf("expected unrolled dice, got %+v", resp.Dice)
+	}
+	if len(resp.ScoreBoard) != 2 || resp.ScoreBoard[0].Scores.Chance != nil || resp.ScoreBoard[0].Scores.Bonus != nil {
+		t.Fatalf("expected empty score board, got %+v", resp.ScoreBoard)
+	}
+	if resp.TimeoutRemainingSeconds <= 0 {
+		t.Fatalf("expected remaining timeout, got %d", resp.TimeoutRemainingSeconds)
+	}
+}
+
+func TestGetGame_NotFound(t *testing.T) {
+	repo := repository.NewMemory()
+	user := game.Player{UserID: uuid.New(), Username: "Alice"}
+
+	rec := serveGameRequest(GetGameHandler(repo, newTestTimers()), http.MethodGet, uuid.New(), user, nil)
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if payload := decodeError(t, rec); payload["error"] != "game_not_found" {
+		t.Fatalf("expected game_not_found, got %v", payload["error"])
+	}
+}
+
+func TestGetGame_NotAPlayer(t *testing.T) {
+	repo := repository.NewMemory()
+	g := seedGame(t, repo)
+	outsider := game.Player{UserID: uuid.New(), Username: "Eve"}
+
+	rec := serveGameRequest(GetGameHandler(repo, newTestTimers()), http.MethodGet, g.ID, outsider, nil)
+	if rec.Code != http.StatusForbidden {
+		t.Fatalf("expected 403, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/KnuffelGame/KnuffelGame/backend/libs/auth"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/GameService/internal/events"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/GameService/internal/game"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/GameService/internal/lobby"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/GameService/internal/repository"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/GameService/internal/timeout"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/GameService/internal/timeout/timeouttest"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// recordingPublisher records published events for assertions
+type recordingPublisher struct {
+	mu     sync.Mutex
+	events []events.Event
+}
+
+func (p *recordingPublisher) Publish(_ context.Context, e events.Event) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.events = append(p.events, e)
+	return nil
+}
+
+func (p *recordingPublisher) types() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	out := make([]string, len(p.events))
+	for i, e := range p.events {
+		out[i] = e.EventType
+	}
+	return out
+}
+
+// recordingLobby records the games reported as ended to the Lobby Service
+type recordingLobby struct {
+	lobby.NopClient
+	finished []uuid.UUID
+}
+
+func (l *recordingLobby) FinishLobby(_ context.Context, _, gameID uuid.UUID) error {
+	l.finished = append(l.finished, gameID)
+	return nil
+}
+
+// fixedRoller always rolls the same value
+type fixedRoller int
+
+func (f fixedRoller) Roll() int { return int(f) }
+
+// newTestTimers returns a scheduler on a fake clock whose timers never fire unless the clock is advanced
+func newTestTimers() *timeout.Scheduler {
+	return timeout.NewScheduler(timeouttest.NewClock(time.Now()), func(uuid.UUID) (time.Time, bool) {
+		return time.Time{}, false
+	})
+}
+
+// seedGame stores a running two-player game where the first player is leader and current player
+func seedGame(t *testing.T, repo repository.Repository) *game.Game {
+	t.Helper()
+	seats := []game.Seat{
+		{UserID: uuid.New(), Username: "Alice"},
+		{UserID: uuid.New(), Username: "Bob"},
+	}
+	g, err := game.New(uuid.New(), uuid.New(), seats[0].UserID, seats, time.Now())
+	if err != nil {
+		t.Fatalf("failed to create game: %v", err)
+	}
+	if err := repo.CreateGame(context.Background(), g); err != nil {
+		t.Fatalf("failed to store game: %v", err)
+	}
+	return g
+}
+
+// serveGameRequest runs h behind the auth middleware with the game_id path parameter set
+func serveGameRequest(h http.HandlerFunc, method string, gameID uuid.UUID, user game.Player, body interface{}) *httptest.ResponseRecorder {
+	var buf bytes.Buffer
+	if body != nil {
+		_ = json.NewEncoder(&buf).Encode(body)
+	}
+	req := httptest.NewRequest(method, "/games/"+gameID.String(), &buf)
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"game_id"},
+			Values: []string{gameID.String()},
+		},
+	}))
+	req.Header.Set(auth.DefaultHeaderUserID, user.UserID.String())
+	req.Header.Set(auth.DefaultHeaderUsername, user.Username)
+	req.Header.Set("Content-Type", "application/json")
+
+	rec := httptest.NewRecorder()
+	auth.AuthMiddleware(h).ServeHTTP(rec, req)
+	return rec
+}
+
+func decodeError(t *testing.T, rec *httptest.ResponseRecorder) map[string]interface{} {
+	t.Helper()
+	var payload map[string]interface{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &payload); err != nil {
+		t.Fatalf("failed to decode error response: %v", err)
+	}
+	return payload
+}
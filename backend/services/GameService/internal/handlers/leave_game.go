@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/KnuffelGame/KnuffelGame/backend/libs/httpx"
+	"github.com/KnuffelGame/KnuffelGame/backend/libs/logger"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/GameService/internal/events"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/GameService/internal/lobby"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/GameService/internal/models"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/GameService/internal/repository"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/GameService/internal/timeout"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// LeaveGameHandler returns an http.HandlerFunc that removes a player who left the lobby from the turn order
+// Internal endpoint called by the Lobby Service when a player leaves a running lobby
+// The player is marked inactive; if it was their turn, the turn passes on or the game ends when nobody active is left
+// Path parameters: game_id (UUID), user_id (UUID)
+// Publishes: turn_changed or game_ended when the turn passed on; the Lobby Service is told when the game ended
+// Returns: 204 No Content, 403 if the user is not a player, 409 if the game is already finished
+func LeaveGameHandler(repo repository.Repository, pub events.Publisher, lobbies lobby.Client, timers *timeout.Scheduler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log := logger.Logger(r.Context()).WithGroup("handler").With(slog.String("action", "leave_game"))
+
+		gameID, ok := parseGameID(w, r, log)
+		if !ok {
+			return
+		}
+
+		userIDStr := chi.URLParam(r, "user_id")
+		userID, err := uuid.Parse(userIDStr)
+		if err != nil {
+			log.Warn("invalid user_id format", slog.String("user_id", userIDStr), slog.String("error", err.Error()))
+			httpx.WriteBadRequest(w, "Invalid user ID format", map[string]interface{}{"detail": err.Error()}, log)
+			return
+		}
+
+		g, ok := loadGame(w, r, repo, gameID, log)
+		if !ok {
+			return
+		}
+
+		result, err := g.Leave(userID, timers.Now())
+		if err != nil {
+			writeGameError(w, err, nil, log)
+			return
+		}
+
+		if !saveGame(w, r, repo, g, log) {
+			return
+		}
+
+		scheduleTurn(timers, g)
+
+		switch {
+		case result.Finished:
+			publish(r.Context(), pub, g.ID, models.EventGameEnded, models.GameEndedEvent{GameID: g.ID, Rankings: toRankings(result.Rankings)}, log)
+			finishLobby(r.Context(), lobbies, g, log)
+		case result.Next != nil:
+			publish(r.Context(), pub, g.ID, models.EventTurnChanged, models.TurnChangedEvent{
+				CurrentPlayerID:       result.Next.UserID,
+				CurrentPlayerUsername: result.Next.Username,
+			}, log)
+		}
+
+		log.Info("player left game",
+			slog.String("game_id", g.ID.String()),
+			slog.String("user_id", userID.String()),
+			slog.Bool("game_finished", result.Finished))
+
+		httpx.WriteNoContent(w)
+	}
+}
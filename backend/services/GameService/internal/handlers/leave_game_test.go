@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/KnuffelGame/KnuffelGame/backend/services/GameService/internal/game"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/GameService/internal/models"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/GameService/internal/repository"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+func leaveGame(h http.HandlerFunc, gameID, userID uuid.UUID) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/internal/games/"+gameID.String()+"/players/"+userID.String()+"/leave", nil)
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, &chi.Context{
+		URLParams: chi.RouteParams{Keys: []string{"game_id", "user_id"}, Values: []string{gameID.String(), userID.String()}},
+	}))
+	rec := httptest.NewRecorder()
+	h(rec, req)
+	return rec
+}
+
+func TestLeaveGame_CurrentPlayer(t *testing.T) {
+	repo := repository.NewMemory()
+	pub := &recordingPublisher{}
+	g := seedGame(t, repo)
+	timers := newTestTimers()
+	lobbies := &recordingLobby{}
+
+	rec := leaveGame(LeaveGameHandler(repo, pub, lobbies, timers), g.ID, g.Players[0].UserID)
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d: %s", rec.Code, rec.Body.String())
+	}
+	stored, err := repo.GetGame(context.Background(), g.ID)
+	if err != nil {
+		t.Fatalf("failed to load game: %v", err)
+	}
+	if stored.Players[0].Active || stored.CurrentPlayer().UserID != g.Players[1].UserID {
+		t.Fatalf("expected the turn to pass to Bob, got current %s", stored.CurrentPlayer().Username)
+	}
+	if types := pub.types(); len(types) != 1 || types[0] != models.EventTurnChanged {
+		t.Fatalf("expected turn_changed event, got %v", types)
+	}
+	if timers.Pending() != 1 {
+		t.Fatalf("expected the next turn to be scheduled")
+	}
+
+	// the last active player leaving ends the game
+	if len(lobbies.finished) != 0 {
+		t.Fatalf("expected the lobby to keep running, got %v", lobbies.finished)
+	}
+	rec = leaveGame(LeaveGameHandler(repo, pub, lobbies, timers), g.ID, g.Players[1].UserID)
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if stored, _ = repo.GetGame(context.Background(), g.ID); stored.Status != game.StatusFinished {
+		t.Fatalf("expected a finished game, got %s", stored.Status)
+	}
+	if types := pub.types(); len(types) != 2 || types[1] != models.EventGameEnded {
+		t.Fatalf("expected game_ended event, got %v", types)
+	}
+	if timers.Pending() != 0 {
+		t.Fatalf("expected turn timeout to be cancelled for a finished game")
+	}
+	if len(lobbies.finished) != 1 || lobbies.finished[0] != g.ID {
+		t.Fatalf("expected the lobby to be finished, got %v", lobbies.finished)
+	}
+}
+
+func TestLeaveGame_NotOnTurn(t *testing.T) {
+	repo := repository.NewMemory()
+	pub := &recordingPublisher{}
+	g := seedGame(t, repo)
+
+	rec := leaveGame(LeaveGameHandler(repo, pub, &recordingLobby{}, newTestTimers()), g.ID, g.Players[1].UserID)
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if types := pub.types(); len(types) != 0 {
+		t.Fatalf("expected no event, got %v", types)
+	}
+}
+
+func TestLeaveGame_NotAPlayer(t *testing.T) {
+	repo := repository.NewMemory()
+	g := seedGame(t, repo)
+
+	rec := leaveGame(LeaveGameHandler(repo, &recordingPublisher{}, &recordingLobby{}, newTestTimers()), g.ID, uuid.New())
+	if rec.Code != http.StatusForbidden {
+		t.Fatalf("expected 403, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
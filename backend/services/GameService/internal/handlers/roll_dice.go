@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/KnuffelGame/KnuffelGame/backend/libs/auth"
+	"github.com/KnuffelGame/KnuffelGame/backend/libs/httpx"
+	"github.com/KnuffelGame/KnuffelGame/backend/libs/logger"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/GameService/internal/events"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/GameService/internal/game"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/GameService/internal/models"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/GameService/internal/repository"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/GameService/internal/timeout"
+)
+
+// RollDiceHandler returns an http.HandlerFunc that rolls all unlocked dice for the current player
+// Requires AuthMiddleware; only the current player may roll, at most 3 times per turn
+// Resets the turn timeout
+// Path parameter: game_id (UUID)
+// Publishes: dice_rolled
+// Returns: 200 OK with RollDiceResponse
+func RollDiceHandler(repo repository.Repository, roller game.Roller, pub events.Publisher, timers *timeout.Scheduler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log := logger.Logger(r.Context()).WithGroup("handler").With(slog.String("action", "roll_dice"))
+
+		user, ok := auth.FromContext(r.Context())
+		if !ok {
+			log.Warn("user missing from context")
+			httpx.WriteUnauthorized(w, "Missing authentication headers", log)
+			return
+		}
+
+		gameID, ok := parseGameID(w, r, log)
+		if !ok {
+			return
+		}
+
+		g, ok := loadGame(w, r, repo, gameID, log)
+		if !ok {
+			return
+		}
+
+		if err := g.Roll(user.ID, roller, timers.Now()); err != nil {
+			writeGameError(w, err, map[string]interface{}{"roll_count": g.RollCount}, log)
+			return
+		}
+
+		if !saveGame(w, r, repo, g, log) {
+			return
+		}
+
+		scheduleTurn(timers, g)
+
+		dice := toDice(g.Dice)
+		publish(r.Context(), pub, g.ID, models.EventDiceRolled, models.DiceRolledEvent{
+			UserID:    user.ID,
+			Username:  user.Username,
+			RollCount: g.RollCount,
+			Dice:      dice,
+		}, log)
+
+		log.Info("dice rolled", slog.String("game_id", g.ID.String()), slog.String("user_id", user.ID.String()), slog.Int("roll_count", g.RollCount))
+
+		httpx.WriteJSON(w, http.StatusOK, models.RollDiceResponse{
+			GameID:          g.ID,
+			RollCount:       g.RollCount,
+			Dice:            dice,
+			CanRollAgain:    g.RollCount < game.MaxRolls,
+			MustSelectField: g.RollCount >= game.MaxRolls,
+		}, log)
+	}
+}
@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/KnuffelGame/KnuffelGame/backend/services/GameService/internal/models"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/GameService/internal/repository"
+)
+
+func TestRollDice_Success(t *testing.T) {
+	repo := repository.NewMemory()
+	pub := &recordingPublisher{}
+	g := seedGame(t, repo)
+	timers := newTestTimers()
+	h := RollDiceHandler(repo, fixedRoller(4), pub, timers)
+
+	rec := serveGameRequest(h, http.MethodPost, g.ID, g.Players[0], nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if timers.Pending() != 1 {
+		t.Fatalf("expected roll to reset the turn timeout")
+	}
+
+	var resp models.RollDiceResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.RollCount != 1 || !resp.CanRollAgain || resp.MustSelectField {
+		t.Fatalf("unexpected response %+v", resp)
+	}
+	if resp.Dice[0].Value == nil || *resp.Dice[0].Value != 4 {
+		t.Fatalf("expected rolled value 4, got %+v", resp.Dice[0])
+	}
+	if types := pub.types(); len(types) != 1 || types[0] != models.EventDiceRolled {
+		t.Fatalf("expected dice_rolled event, got %v", types)
+	}
+}
+
+func TestRollDice_MaxRolls(t *testing.T) {
+	repo := repository.NewMemory()
+	g := seedGame(t, repo)
+	h := RollDiceHandler(repo, fixedRoller(4), &recordingPublisher{}, newTestTimers())
+
+	for i := 0; i < 3; i++ {
+		if rec := serveGameRequest(h, http.MethodPost, g.ID, g.Players[0], nil); rec.Code != http.StatusOK {
+			t.Fatalf("roll %d: expected 200, got %d", i+1, rec.Code)
+		}
+	}
+	rec := serveGameRequest(h, http.MethodPost, g.ID, g.Players[0], nil)
+	if rec.Code != http.StatusForbidden {
+		t.Fatalf("expected 403, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestRollDice_NotYourTurn(t *testing.T) {
+	repo := repository.NewMemory()
+	pub := &recordingPublisher{}
+	g := seedGame(t, repo)
+
+	rec := serveGameRequest(RollDiceHandler(repo, fixedRoller(4), pub, newTestTimers()), http.MethodPost, g.ID, g.Players[1], nil)
+	if rec.Code != http.StatusForbidden {
+		t.Fatalf("expected 403, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if len(pub.types()) != 0 {
+		t.Fatal("expected no events for rejected roll")
+	}
+}
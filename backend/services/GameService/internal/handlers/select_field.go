@@ -0,0 +1,128 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/KnuffelGame/KnuffelGame/backend/libs/auth"
+	"github.com/KnuffelGame/KnuffelGame/backend/libs/httpx"
+	"github.com/KnuffelGame/KnuffelGame/backend/libs/logger"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/GameService/internal/events"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/GameService/internal/game"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/GameService/internal/lobby"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/GameService/internal/models"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/GameService/internal/repository"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/GameService/internal/timeout"
+)
+
+// SelectFieldHandler returns an http.HandlerFunc that scores the current dice in a field and ends the turn
+// Requires AuthMiddleware; only the current player may select, after at least one roll
+// Starts the turn timeout of the next player
+// Path parameter: game_id (UUID)
+// Request body: SelectFieldRequest with field name
+// Publishes: field_selected, then turn_changed or game_ended; the Lobby Service is told when the game ended
+// Returns: 200 OK with SelectFieldResponse
+func SelectFieldHandler(repo repository.Repository, pub events.Publisher, lobbies lobby.Client, timers *timeout.Scheduler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log := logger.Logger(r.Context()).WithGroup("handler").With(slog.String("action", "select_field"))
+
+		user, ok := auth.FromContext(r.Context())
+		if !ok {
+			log.Warn("user missing from context")
+			httpx.WriteUnauthorized(w, "Missing authentication headers", log)
+			return
+		}
+
+		gameID, ok := parseGameID(w, r, log)
+		if !ok {
+			return
+		}
+
+		var req models.SelectFieldRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			log.Warn("failed to decode request body", slog.String("error", err.Error()))
+			httpx.WriteBadRequest(w, "Invalid request body", map[string]interface{}{"detail": err.Error()}, log)
+			return
+		}
+
+		g, ok := loadGame(w, r, repo, gameID, log)
+		if !ok {
+			return
+		}
+
+		field := game.Field(req.Field)
+		result, err := g.SelectField(user.ID, field, timers.Now())
+		if err != nil {
+			switch {
+			case errors.Is(err, game.ErrNotRolledYet):
+				log.Warn("select before first roll", slog.String("game_id", g.ID.String()))
+				httpx.WriteError(w, http.StatusBadRequest, "invalid_request", "Must roll dice before selecting a field", nil, log)
+			case errors.Is(err, game.ErrInvalidField):
+				writeGameError(w, err, map[string]interface{}{"field": req.Field, "valid_fields": game.Fields}, log)
+			case errors.Is(err, game.ErrFieldFilled):
+				current, _ := g.CurrentPlayer().Card.Value(field)
+				writeGameError(w, err, map[string]interface{}{"field": req.Field, "current_value": current}, log)
+			case errors.Is(err, game.ErrJokerField):
+				allowed := game.JokerFields(g.CurrentPlayer().Card, g.Dice[0].Value)
+				writeGameError(w, err, map[string]interface{}{"field": req.Field, "allowed_fields": allowed}, log)
+			default:
+				writeGameError(w, err, nil, log)
+			}
+			return
+		}
+
+		if !saveGame(w, r, repo, g, log) {
+			return
+		}
+
+		scheduleTurn(timers, g)
+
+		resp := models.SelectFieldResponse{
+			GameID:         g.ID,
+			Field:          string(result.Field),
+			PointsEarned:   result.Points,
+			NewTotal:       result.NewTotal,
+			GameFinished:   result.Finished,
+			BonusesApplied: make([]models.BonusApplied, len(result.Bonuses)),
+		}
+		// a joker kniffel in the upper section can earn the kniffel and the upper section bonus at once
+		for i, b := range result.Bonuses {
+			resp.BonusesApplied[i] = models.BonusApplied{Type: b.Type, Points: b.Points}
+		}
+		if len(resp.BonusesApplied) > 0 {
+			resp.BonusApplied = &resp.BonusesApplied[0]
+		}
+
+		publish(r.Context(), pub, g.ID, models.EventFieldSelected, models.FieldSelectedEvent{
+			UserID:   result.UserID,
+			Username: result.Username,
+			Field:    string(result.Field),
+			Points:   result.Points,
+			NewTotal: result.NewTotal,
+		}, log)
+
+		if result.Finished {
+			resp.FinalRankings = toRankings(result.Rankings)
+			publish(r.Context(), pub, g.ID, models.EventGameEnded, models.GameEndedEvent{GameID: g.ID, Rankings: resp.FinalRankings}, log)
+			finishLobby(r.Context(), lobbies, g, log)
+		} else {
+			resp.NextPlayerID = &result.Next.UserID
+			resp.NextPlayerUsername = &result.Next.Username
+			publish(r.Context(), pub, g.ID, models.EventTurnChanged, models.TurnChangedEvent{
+				CurrentPlayerID:       result.Next.UserID,
+				CurrentPlayerUsername: result.Next.Username,
+			}, log)
+		}
+
+		log.Info("field selected",
+			slog.String("game_id", g.ID.String()),
+			slog.String("user_id", user.ID.String()),
+			slog.String("field", req.Field),
+			slog.Int("points", result.Points),
+			slog.Bool("game_finished", result.Finished))
+
+		httpx.WriteJSON(w, http.StatusOK, resp, log)
+	}
+}
@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/KnuffelGame/KnuffelGame/backend/services/GameService/internal/game"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/GameService/internal/models"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/GameService/internal/repository"
+)
+
+func TestSelectField_Success(t *testing.T) {
+	repo := repository.NewMemory()
+	pub := &recordingPublisher{}
+	g := seedGame(t, repo)
+	serveGameRequest(RollDiceHandler(repo, fixedRoller(6), pub, newTestTimers()), http.MethodPost, g.ID, g.Players[0], nil)
+
+	rec := serveGameRequest(SelectFieldHandler(repo, pub, &recordingLobby{}, newTestTimers()), http.MethodPost, g.ID, g.Players[0], models.SelectFieldRequest{Field: "kniffel"})
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	var resp models.SelectFieldResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.PointsEarned != 50 || resp.NewTotal != 50 || resp.GameFinished {
+		t.Fatalf("unexpected response %+v", resp)
+	}
+	if resp.NextPlayerID == nil || *resp.NextPlayerID != g.Players[1].UserID {
+		t.Fatalf("expected next player %s, got %v", g.Players[1].UserID, resp.NextPlayerID)
+	}
+	if resp.BonusApplied != nil || resp.BonusesApplied == nil || len(resp.BonusesApplied) != 0 {
+		t.Fatalf("expected no bonus, got %v %v", resp.BonusApplied, resp.BonusesApplied)
+	}
+
+	types := pub.types()
+	if len(types) != 3 || types[1] != models.EventFieldSelected || types[2] != models.EventTurnChanged {
+		t.Fatalf("expected field_selected and turn_changed events, got %v", types)
+	}
+}
+
+func TestSelectField_ReturnsEveryBonus(t *testing.T) {
+	repo := repository.NewMemory()
+	pub := &recordingPublisher{}
+	g := seedGame(t, repo)
+	// 45 points in the upper section and a scored kniffel: a second kniffel of sixes earns both bonuses
+	card := g.Players[0].Card
+	card.Scores[game.Kniffel] = game.KniffelPoints
+	card.Scores[game.Ones], card.Scores[game.Twos], card.Scores[game.Threes] = 3, 6, 9
+	card.Scores[game.Fours], card.Scores[game.Fives] = 12, 15
+	if err := repo.UpdateGame(context.Background(), g); err != nil {
+		t.Fatalf("failed to save game: %v", err)
+	}
+	serveGameRequest(RollDiceHandler(repo, fixedRoller(6), pub, newTestTimers()), http.MethodPost, g.ID, g.Players[0], nil)
+
+	rec := serveGameRequest(SelectFieldHandler(repo, pub, &recordingLobby{}, newTestTimers()), http.MethodPost, g.ID, g.Players[0], models.SelectFieldRequest{Field: "sixes"})
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	var resp models.SelectFieldResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	want := []models.BonusApplied{
+		{Type: game.BonusMultipleKniffel, Points: game.KniffelBonusPoints},
+		{Type: game.BonusUpperSection, Points: game.UpperBonusPoints},
+	}
+	if len(resp.BonusesApplied) != len(want) || resp.BonusesApplied[0] != want[0] || resp.BonusesApplied[1] != want[1] {
+		t.Fatalf("expected bonuses %v, got %v", want, resp.BonusesApplied)
+	}
+	if resp.BonusApplied == nil || *resp.BonusApplied != want[0] {
+		t.Fatalf("expected bonus_applied to be the first bonus, got %v", resp.BonusApplied)
+	}
+}
+
+func TestSelectField_InvalidField(t *testing.T) {
+	repo := repository.NewMemory()
+	g := seedGame(t, repo)
+	serveGameRequest(RollDiceHandler(repo, fixedRoller(6), &recordingPublisher{}, newTestTimers()), http.MethodPost, g.ID, g.Players[0], nil)
+
+	rec := serveGameRequest(SelectFieldHandler(repo, &recordingPublisher{}, &recordingLobby{}, newTestTimers()), http.MethodPost, g.ID, g.Players[0], models.SelectFieldRequest{Field: "yahtzee"})
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d: %s", rec.Code, rec.Body.String())
+	}
+	details, _ := decodeError(t, rec)["details"].(map[string]interface{})
+	if fields, _ := details["valid_fields"].([]interface{}); len(fields) != 13 {
+		t.Fatalf("expected 13 valid_fields, got %v", details)
+	}
+}
+
+func TestSelectField_BeforeRoll(t *testing.T) {
+	repo := repository.NewMemory()
+	g := seedGame(t, repo)
+
+	rec := serveGameRequest(SelectFieldHandler(repo, &recordingPublisher{}, &recordingLobby{}, newTestTimers()), http.MethodPost, g.ID, g.Players[0], models.SelectFieldRequest{Field: "chance"})
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
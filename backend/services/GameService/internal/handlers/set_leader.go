@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/KnuffelGame/KnuffelGame/backend/libs/httpx"
+	"github.com/KnuffelGame/KnuffelGame/backend/libs/logger"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/GameService/internal/models"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/GameService/internal/repository"
+	"github.com/google/uuid"
+)
+
+// SetLeaderHandler returns an http.HandlerFunc that hands the game over to the new lobby leader
+// Internal endpoint called by the Lobby Service when leadership is transferred or the leader leaves a running lobby
+// Only the leader may end the game prematurely
+// Path parameters: game_id (UUID)
+// Request body: SetLeaderRequest with leader_id
+// Returns: 204 No Content, 403 if the new leader is not a player, 409 if the game is already finished
+func SetLeaderHandler(repo repository.Repository) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log := logger.Logger(r.Context()).WithGroup("handler").With(slog.String("action", "set_leader"))
+
+		gameID, ok := parseGameID(w, r, log)
+		if !ok {
+			return
+		}
+
+		var req models.SetLeaderRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			log.Warn("failed to decode request body", slog.String("error", err.Error()))
+			httpx.WriteBadRequest(w, "Invalid request body", map[string]interface{}{"detail": err.Error()}, log)
+			return
+		}
+		if req.LeaderID == uuid.Nil {
+			log.Warn("missing leader_id")
+			httpx.WriteBadRequest(w, "leader_id is required", nil, log)
+			return
+		}
+
+		g, ok := loadGame(w, r, repo, gameID, log)
+		if !ok {
+			return
+		}
+
+		if err := g.SetLeader(req.LeaderID); err != nil {
+			writeGameError(w, err, nil, log)
+			return
+		}
+
+		if !saveGame(w, r, repo, g, log) {
+			return
+		}
+
+		log.Info("game leader changed",
+			slog.String("game_id", g.ID.String()),
+			slog.String("leader_id", req.LeaderID.String()))
+
+		httpx.WriteNoContent(w)
+	}
+}
@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/KnuffelGame/KnuffelGame/backend/services/GameService/internal/repository"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+func setLeader(h http.HandlerFunc, gameID uuid.UUID, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPut, "/internal/games/"+gameID.String()+"/leader", strings.NewReader(body))
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, &chi.Context{
+		URLParams: chi.RouteParams{Keys: []string{"game_id"}, Values: []string{gameID.String()}},
+	}))
+	rec := httptest.NewRecorder()
+	h(rec, req)
+	return rec
+}
+
+func TestSetLeader(t *testing.T) {
+	repo := repository.NewMemory()
+	g := seedGame(t, repo)
+	bob := g.Players[1].UserID
+
+	rec := setLeader(SetLeaderHandler(repo), g.ID, `{"leader_id":"`+bob.String()+`"}`)
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d: %s", rec.Code, rec.Body.String())
+	}
+	stored, err := repo.GetGame(context.Background(), g.ID)
+	if err != nil {
+		t.Fatalf("failed to load game: %v", err)
+	}
+	if stored.LeaderID != bob {
+		t.Fatalf("expected Bob to lead the game, got %s", stored.LeaderID)
+	}
+}
+
+func TestSetLeader_Invalid(t *testing.T) {
+	repo := repository.NewMemory()
+	g := seedGame(t, repo)
+
+	tests := []struct {
+		name string
+		body string
+		want int
+	}{
+		{"invalid body", `{`, http.StatusBadRequest},
+		{"missing leader", `{}`, http.StatusBadRequest},
+		{"not a player", `{"leader_id":"` + uuid.NewString() + `"}`, http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if rec := setLeader(SetLeaderHandler(repo), g.ID, tt.body); rec.Code != tt.want {
+				t.Fatalf("expected %d, got %d: %s", tt.want, rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/KnuffelGame/KnuffelGame/backend/libs/auth"
+	"github.com/KnuffelGame/KnuffelGame/backend/libs/httpx"
+	"github.com/KnuffelGame/KnuffelGame/backend/libs/logger"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/GameService/internal/events"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/GameService/internal/game"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/GameService/internal/models"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/GameService/internal/repository"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/GameService/internal/timeout"
+)
+
+// ToggleDiceHandler returns an http.HandlerFunc that locks/unlocks dice for the current player
+// Requires AuthMiddleware; only allowed after the first and before the third roll
+// Resets the turn timeout
+// Path parameter: game_id (UUID)
+// Request body: ToggleDiceRequest with dice_indices (0-4)
+// Publishes: dice_toggled
+// Returns: 200 OK with ToggleDiceResponse
+func ToggleDiceHandler(repo repository.Repository, pub events.Publisher, timers *timeout.Scheduler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log := logger.Logger(r.Context()).WithGroup("handler").With(slog.String("action", "toggle_dice"))
+
+		user, ok := auth.FromContext(r.Context())
+		if !ok {
+			log.Warn("user missing from context")
+			httpx.WriteUnauthorized(w, "Missing authentication headers", log)
+			return
+		}
+
+		gameID, ok := parseGameID(w, r, log)
+		if !ok {
+			return
+		}
+
+		var req models.ToggleDiceRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			log.Warn("failed to decode request body", slog.String("error", err.Error()))
+			httpx.WriteBadRequest(w, "Invalid request body", map[string]interface{}{"detail": err.Error()}, log)
+			return
+		}
+
+		g, ok := loadGame(w, r, repo, gameID, log)
+		if !ok {
+			return
+		}
+
+		if err := g.ToggleDice(user.ID, req.DiceIndices, timers.Now()); err != nil {
+			switch {
+			case errors.Is(err, game.ErrNotRolledYet):
+				log.Warn("toggle before first roll", slog.String("game_id", g.ID.String()))
+				httpx.WriteError(w, http.StatusBadRequest, "invalid_request", "Cannot lock dice before first roll", nil, log)
+			case errors.Is(err, game.ErrInvalidDieIndex):
+				writeGameError(w, err, map[string]interface{}{"invalid_indices": game.InvalidDieIndices(req.DiceIndices)}, log)
+			default:
+				writeGameError(w, err, nil, log)
+			}
+			return
+		}
+
+		if !saveGame(w, r, repo, g, log) {
+			return
+		}
+
+		scheduleTurn(timers, g)
+
+		dice := toDice(g.Dice)
+		publish(r.Context(), pub, g.ID, models.EventDiceToggled, models.DiceToggledEvent{
+			UserID:   user.ID,
+			Username: user.Username,
+			Dice:     dice,
+		}, log)
+
+		log.Info("dice toggled", slog.String("game_id", g.ID.String()), slog.String("user_id", user.ID.String()))
+
+		httpx.WriteJSON(w, http.StatusOK, models.ToggleDiceResponse{GameID: g.ID, Dice: dice}, log)
+	}
+}
@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/KnuffelGame/KnuffelGame/backend/services/GameService/internal/models"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/GameService/internal/repository"
+)
+
+func TestToggleDice_Success(t *testing.T) {
+	repo := repository.NewMemory()
+	pub := &recordingPublisher{}
+	g := seedGame(t, repo)
+	serveGameRequest(RollDiceHandler(repo, fixedRoller(3), pub, newTestTimers()), http.MethodPost, g.ID, g.Players[0], nil)
+
+	rec := serveGameRequest(ToggleDiceHandler(repo, pub, newTestTimers()), http.MethodPost, g.ID, g.Players[0], models.ToggleDiceRequest{DiceIndices: []int{0, 2}})
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	var resp models.ToggleDiceResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if !resp.Dice[0].Locked || resp.Dice[1].Locked || !resp.Dice[2].Locked {
+		t.Fatalf("unexpected lock state %+v", resp.Dice)
+	}
+	if types := pub.types(); len(types) != 2 || types[1] != models.EventDiceToggled {
+		t.Fatalf("expected dice_toggled event, got %v", types)
+	}
+}
+
+func TestToggleDice_BeforeFirstRoll(t *testing.T) {
+	repo := repository.NewMemory()
+	g := seedGame(t, repo)
+
+	rec := serveGameRequest(ToggleDiceHandler(repo, &recordingPublisher{}, newTestTimers()), http.MethodPost, g.ID, g.Players[0], models.ToggleDiceRequest{DiceIndices: []int{0}})
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if payload := decodeError(t, rec); payload["error"] != "invalid_request" {
+		t.Fatalf("expected invalid_request, got %v", payload["error"])
+	}
+}
+
+func TestToggleDice_InvalidIndex(t *testing.T) {
+	repo := repository.NewMemory()
+	g := seedGame(t, repo)
+	serveGameRequest(RollDiceHandler(repo, fixedRoller(3), &recordingPublisher{}, newTestTimers()), http.MethodPost, g.ID, g.Players[0], nil)
+
+	rec := serveGameRequest(ToggleDiceHandler(repo, &recordingPublisher{}, newTestTimers()), http.MethodPost, g.ID, g.Players[0], models.ToggleDiceRequest{DiceIndices: []int{1, 5}})
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d: %s", rec.Code, rec.Body.String())
+	}
+	details, _ := decodeError(t, rec)["details"].(map[string]interface{})
+	if invalid, _ := details["invalid_indices"].([]interface{}); len(invalid) != 1 || invalid[0] != float64(5) {
+		t.Fatalf("expected invalid_indices [5], got %v", details)
+	}
+}
@@ -0,0 +1,88 @@
+// Package lobby calls internal endpoints of the Lobby Service.
+package lobby
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const requestTimeout = 3 * time.Second
+
+// Client is the subset of the Lobby Service API used by the Game Service.
+type Client interface {
+	SetPlayerActive(ctx context.Context, lobbyID, userID uuid.UUID, active bool) error
+	FinishLobby(ctx context.Context, lobbyID, gameID uuid.UUID) error
+}
+
+// HTTPClient talks to the Lobby Service over HTTP.
+type HTTPClient struct {
+	baseURL string
+	client  *http.Client
+}
+
+// NewHTTPClient creates a client for the Lobby Service at baseURL (e.g. http://LobbyService:8083).
+func NewHTTPClient(baseURL string) *HTTPClient {
+	return &HTTPClient{baseURL: baseURL, client: &http.Client{Timeout: requestTimeout}}
+}
+
+// SetPlayerActive calls PUT /internal/lobbies/{lobby_id}/players/{player_id}/active.
+func (c *HTTPClient) SetPlayerActive(ctx context.Context, lobbyID, userID uuid.UUID, active bool) error {
+	body, err := json.Marshal(map[string]bool{"is_active": active})
+	if err != nil {
+		return fmt.Errorf("failed to encode request: %w", err)
+	}
+	url := fmt.Sprintf("%s/internal/lobbies/%s/players/%s/active", c.baseURL, lobbyID, userID)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to update player active status: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("update player active status returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// FinishLobby calls POST /internal/lobbies/{lobby_id}/finish once the game has ended, so the lobby frees its join code.
+// A lobby that no longer exists needs no finishing and is not an error.
+func (c *HTTPClient) FinishLobby(ctx context.Context, lobbyID, gameID uuid.UUID) error {
+	body, err := json.Marshal(map[string]uuid.UUID{"game_id": gameID})
+	if err != nil {
+		return fmt.Errorf("failed to encode request: %w", err)
+	}
+	url := fmt.Sprintf("%s/internal/lobbies/%s/finish", c.baseURL, lobbyID)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to finish lobby: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusNotFound {
+		return fmt.Errorf("finish lobby returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// NopClient ignores all calls. Used when no Lobby Service is configured.
+type NopClient struct{}
+
+// SetPlayerActive does nothing.
+func (NopClient) SetPlayerActive(context.Context, uuid.UUID, uuid.UUID, bool) error { return nil }
+
+// FinishLobby does nothing.
+func (NopClient) FinishLobby(context.Context, uuid.UUID, uuid.UUID) error { return nil }
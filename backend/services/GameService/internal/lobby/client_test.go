@@ -0,0 +1,73 @@
+package lobby
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestHTTPClient_SetPlayerActive(t *testing.T) {
+	lobbyID, userID := uuid.New(), uuid.New()
+	var gotPath, gotMethod string
+	var gotBody map[string]bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath, gotMethod = r.URL.Path, r.Method
+		_ = json.NewDecoder(r.Body).Decode(&gotBody)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	if err := NewHTTPClient(srv.URL).SetPlayerActive(context.Background(), lobbyID, userID, false); err != nil {
+		t.Fatalf("SetPlayerActive: %v", err)
+	}
+	if want := "/internal/lobbies/" + lobbyID.String() + "/players/" + userID.String() + "/active"; gotPath != want {
+		t.Fatalf("expected path %s, got %s", want, gotPath)
+	}
+	if gotMethod != http.MethodPut || gotBody["is_active"] {
+		t.Fatalf("unexpected request %s %v", gotMethod, gotBody)
+	}
+}
+
+func TestHTTPClient_SetPlayerActive_ErrorStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	if err := NewHTTPClient(srv.URL).SetPlayerActive(context.Background(), uuid.New(), uuid.New(), false); err == nil {
+		t.Fatal("expected error for 404 response")
+	}
+}
+
+func TestHTTPClient_FinishLobby(t *testing.T) {
+	lobbyID, gameID := uuid.New(), uuid.New()
+	var gotPath, gotMethod string
+	var gotBody map[string]uuid.UUID
+	status := http.StatusNoContent
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath, gotMethod = r.URL.Path, r.Method
+		_ = json.NewDecoder(r.Body).Decode(&gotBody)
+		w.WriteHeader(status)
+	}))
+	defer srv.Close()
+
+	if err := NewHTTPClient(srv.URL).FinishLobby(context.Background(), lobbyID, gameID); err != nil {
+		t.Fatalf("FinishLobby: %v", err)
+	}
+	if want := "/internal/lobbies/" + lobbyID.String() + "/finish"; gotPath != want || gotMethod != http.MethodPost {
+		t.Fatalf("expected POST %s, got %s %s", want, gotMethod, gotPath)
+	}
+	if gotBody["game_id"] != gameID {
+		t.Fatalf("expected game_id %s, got %v", gameID, gotBody)
+	}
+
+	// the lobby belongs to another game
+	status = http.StatusConflict
+	if err := NewHTTPClient(srv.URL).FinishLobby(context.Background(), lobbyID, gameID); err == nil {
+		t.Fatal("expected an error")
+	}
+}
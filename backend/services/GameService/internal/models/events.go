@@ -0,0 +1,64 @@
+package models
+
+import "github.com/google/uuid"
+
+// Event types published to the SSE Service
+const (
+	EventDiceRolled     = "dice_rolled"
+	EventDiceToggled    = "dice_toggled"
+	EventFieldSelected  = "field_selected"
+	EventTurnChanged    = "turn_changed"
+	EventGameEnded      = "game_ended"
+	EventPlayerTimedOut = "player_timed_out"
+)
+
+// Actions reported in PlayerTimedOutEvent
+const (
+	TimedOutActionSkipped    = "skipped"
+	TimedOutActionCrossedOut = "crossed_out"
+)
+
+// DiceRolledEvent is the payload of a dice_rolled event
+type DiceRolledEvent struct {
+	UserID    uuid.UUID `json:"user_id"`
+	Username  string    `json:"username"`
+	RollCount int       `json:"roll_count"`
+	Dice      []Die     `json:"dice"`
+}
+
+// DiceToggledEvent is the payload of a dice_toggled event
+type DiceToggledEvent struct {
+	UserID   uuid.UUID `json:"user_id"`
+	Username string    `json:"username"`
+	Dice     []Die     `json:"dice"`
+}
+
+// FieldSelectedEvent is the payload of a field_selected event
+type FieldSelectedEvent struct {
+	UserID   uuid.UUID `json:"user_id"`
+	Username string    `json:"username"`
+	Field    string    `json:"field"`
+	Points   int       `json:"points"`
+	NewTotal int       `json:"new_total"`
+}
+
+// TurnChangedEvent is the payload of a turn_changed event
+type TurnChangedEvent struct {
+	CurrentPlayerID       uuid.UUID `json:"current_player_id"`
+	CurrentPlayerUsername string    `json:"current_player_username"`
+}
+
+// GameEndedEvent is the payload of a game_ended event
+type GameEndedEvent struct {
+	GameID   uuid.UUID       `json:"game_id"`
+	Rankings []PlayerRanking `json:"rankings"`
+}
+
+// PlayerTimedOutEvent is the payload of a player_timed_out event
+// Field is set when the timeout crossed out a field
+type PlayerTimedOutEvent struct {
+	UserID   uuid.UUID `json:"user_id"`
+	Username string    `json:"username"`
+	Action   string    `json:"action"`
+	Field    string    `json:"field,omitempty"`
+}
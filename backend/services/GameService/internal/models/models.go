@@ -0,0 +1,159 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PlayerInfo is a player entry in the turn order
+type PlayerInfo struct {
+	UserID   uuid.UUID `json:"user_id"`
+	Username string    `json:"username"`
+}
+
+// CreateGameRequest is sent by the Lobby Service when a game starts
+// LeaderID is the lobby leader, the only user allowed to end the game prematurely
+// TurnTimeoutSeconds and Variant are the lobby settings; zero values use the defaults
+type CreateGameRequest struct {
+	LobbyID            uuid.UUID    `json:"lobby_id"`
+	LeaderID           uuid.UUID    `json:"leader_id"`
+	TurnOrder          []PlayerInfo `json:"turn_order"`
+	TurnTimeoutSeconds int          `json:"turn_timeout_seconds,omitempty"`
+	Variant            string       `json:"variant,omitempty"`
+}
+
+// CreateGameResponse represents the response when creating a game
+type CreateGameResponse struct {
+	GameID          uuid.UUID   `json:"game_id"`
+	LobbyID         uuid.UUID   `json:"lobby_id"`
+	CurrentPlayerID uuid.UUID   `json:"current_player_id"`
+	TurnOrder       []uuid.UUID `json:"turn_order"`
+}
+
+// SetLeaderRequest is sent by the Lobby Service when the lobby leader changes during the game
+type SetLeaderRequest struct {
+	LeaderID uuid.UUID `json:"leader_id"`
+}
+
+// Die represents a single die; Value is null until the die has been rolled in the current turn
+type Die struct {
+	Value  *int `json:"value"`
+	Locked bool `json:"locked"`
+}
+
+// ScoreCard represents a player's scorecard; unfilled fields are null
+type ScoreCard struct {
+	Ones              *int `json:"ones"`
+	Twos              *int `json:"twos"`
+	Threes            *int `json:"threes"`
+	Fours             *int `json:"fours"`
+	Fives             *int `json:"fives"`
+	Sixes             *int `json:"sixes"`
+	UpperSum          int  `json:"upper_sum"`
+	Bonus             *int `json:"bonus"`
+	ThreeOfAKind      *int `json:"three_of_a_kind"`
+	FourOfAKind       *int `json:"four_of_a_kind"`
+	FullHouse         *int `json:"full_house"`
+	SmallStraight     *int `json:"small_straight"`
+	LargeStraight     *int `json:"large_straight"`
+	Kniffel           *int `json:"kniffel"`
+	Chance            *int `json:"chance"`
+	LowerSum          int  `json:"lower_sum"`
+	Total             int  `json:"total"`
+	KniffelBonusCount int  `json:"kniffel_bonus_count"`
+}
+
+// Player status values used in PlayerScores
+const (
+	PlayerStatusActive   = "active"
+	PlayerStatusInactive = "inactive"
+)
+
+// PlayerScores represents a player's entry on the score board
+type PlayerScores struct {
+	UserID   uuid.UUID `json:"user_id"`
+	Username string    `json:"username"`
+	Status   string    `json:"status"`
+	Scores   ScoreCard `json:"scores"`
+}
+
+// GameStateResponse represents the complete state of a game
+type GameStateResponse struct {
+	GameID                  uuid.UUID      `json:"game_id"`
+	LobbyID                 uuid.UUID      `json:"lobby_id"`
+	Status                  string         `json:"status"`
+	CurrentPlayerID         uuid.UUID      `json:"current_player_id"`
+	CurrentPlayerUsername   string         `json:"current_player_username"`
+	RollCount               int            `json:"roll_count"`
+	Dice                    []Die          `json:"dice"`
+	TimeoutRemainingSeconds int            `json:"timeout_remaining_seconds"`
+	TurnTimeoutSeconds      int            `json:"turn_timeout_seconds"`
+	Variant                 string         `json:"variant"`
+	TurnOrder               []uuid.UUID    `json:"turn_order"`
+	ScoreBoard              []PlayerScores `json:"score_board"`
+	StartedAt               time.Time      `json:"started_at"`
+	FinishedAt              *time.Time     `json:"finished_at,omitempty"`
+}
+
+// RollDiceResponse represents the response after rolling dice
+type RollDiceResponse struct {
+	GameID          uuid.UUID `json:"game_id"`
+	RollCount       int       `json:"roll_count"`
+	Dice            []Die     `json:"dice"`
+	CanRollAgain    bool      `json:"can_roll_again"`
+	MustSelectField bool      `json:"must_select_field"`
+}
+
+// ToggleDiceRequest represents the request to lock/unlock dice
+type ToggleDiceRequest struct {
+	DiceIndices []int `json:"dice_indices"`
+}
+
+// ToggleDiceResponse represents the response after toggling dice
+type ToggleDiceResponse struct {
+	GameID uuid.UUID `json:"game_id"`
+	Dice   []Die     `json:"dice"`
+}
+
+// SelectFieldRequest represents the request to fill a scorecard field
+type SelectFieldRequest struct {
+	Field string `json:"field"`
+}
+
+// BonusApplied describes a bonus awarded with a field selection
+type BonusApplied struct {
+	Type   string `json:"type"`
+	Points int    `json:"points"`
+}
+
+// PlayerRanking represents a player's final placement
+type PlayerRanking struct {
+	UserID     uuid.UUID `json:"user_id"`
+	Username   string    `json:"username"`
+	TotalScore int       `json:"total_score"`
+	Rank       int       `json:"rank"`
+}
+
+// SelectFieldResponse represents the response after selecting a field
+type SelectFieldResponse struct {
+	GameID             uuid.UUID       `json:"game_id"`
+	Field              string          `json:"field"`
+	PointsEarned       int             `json:"points_earned"`
+	BonusApplied       *BonusApplied   `json:"bonus_applied"` // first of BonusesApplied, kept for older clients
+	BonusesApplied     []BonusApplied  `json:"bonuses_applied"`
+	NewTotal           int             `json:"new_total"`
+	NextPlayerID       *uuid.UUID      `json:"next_player_id"`
+	NextPlayerUsername *string         `json:"next_player_username"`
+	GameFinished       bool            `json:"game_finished"`
+	FinalRankings      []PlayerRanking `json:"final_rankings,omitempty"`
+}
+
+// EndGameResponse represents the response after ending a game prematurely
+type EndGameResponse struct {
+	GameID           uuid.UUID       `json:"game_id"`
+	Status           string          `json:"status"`
+	EndedPrematurely bool            `json:"ended_prematurely"`
+	FinalRankings    []PlayerRanking `json:"final_rankings"`
+	EndedAt          time.Time       `json:"ended_at"`
+}
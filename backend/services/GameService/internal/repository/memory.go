@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"context"
+	"sync"
+
+	"github.com/KnuffelGame/KnuffelGame/backend/services/GameService/internal/game"
+	"github.com/google/uuid"
+)
+
+// MemoryRepository implements Repository with an in-process map.
+// Games are cloned on the way in and out so callers never share state.
+// Used in tests and for running without a database.
+type MemoryRepository struct {
+	mu    sync.RWMutex
+	games map[uuid.UUID]*game.Game
+}
+
+// NewMemory creates an empty MemoryRepository
+func NewMemory() *MemoryRepository {
+	return &MemoryRepository{games: make(map[uuid.UUID]*game.Game)}
+}
+
+func (r *MemoryRepository) CreateGame(_ context.Context, g *game.Game) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	g.Version = 1
+	r.games[g.ID] = g.Clone()
+	return nil
+}
+
+func (r *MemoryRepository) GetGame(_ context.Context, gameID uuid.UUID) (*game.Game, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	g, ok := r.games[gameID]
+	if !ok {
+		return nil, ErrGameNotFound
+	}
+	return g.Clone(), nil
+}
+
+func (r *MemoryRepository) UpdateGame(_ context.Context, g *game.Game) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored, ok := r.games[g.ID]
+	if !ok {
+		return ErrGameNotFound
+	}
+	if stored.Version != g.Version {
+		return ErrVersionConflict
+	}
+	g.Version++
+	r.games[g.ID] = g.Clone()
+	return nil
+}
+
+func (r *MemoryRepository) ListRunningGames(_ context.Context) ([]*game.Game, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var running []*game.Game
+	for _, g := range r.games {
+		if g.Status == game.StatusRunning {
+			running = append(running, g.Clone())
+		}
+	}
+	return running, nil
+}
@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestMemoryUpdateGameVersionConflict(t *testing.T) {
+	repo := NewMemory()
+	ctx := context.Background()
+	g := newTestGame(t)
+	if err := repo.CreateGame(ctx, g); err != nil {
+		t.Fatalf("CreateGame error: %v", err)
+	}
+
+	first, _ := repo.GetGame(ctx, g.ID)
+	second, _ := repo.GetGame(ctx, g.ID)
+
+	if err := repo.UpdateGame(ctx, first); err != nil {
+		t.Fatalf("first UpdateGame error: %v", err)
+	}
+	if first.Version != 2 {
+		t.Fatalf("expected version 2, got %d", first.Version)
+	}
+	if err := repo.UpdateGame(ctx, second); !errors.Is(err, ErrVersionConflict) {
+		t.Fatalf("expected ErrVersionConflict, got %v", err)
+	}
+}
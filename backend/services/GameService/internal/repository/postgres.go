@@ -0,0 +1,269 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/KnuffelGame/KnuffelGame/backend/services/GameService/internal/game"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// scoreColumns are the scorecard columns of the scores table; they are named after the game fields
+var scoreColumns = func() []string {
+	cols := make([]string, len(game.Fields))
+	for i, f := range game.Fields {
+		cols[i] = string(f)
+	}
+	return cols
+}()
+
+// PostgresRepository implements Repository using a *sql.DB
+type PostgresRepository struct {
+	DB *sql.DB
+}
+
+// New creates a new PostgresRepository
+func New(db *sql.DB) *PostgresRepository {
+	return &PostgresRepository{DB: db}
+}
+
+func (r *PostgresRepository) CreateGame(ctx context.Context, g *game.Game) error {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	values, locked := diceArrays(g.Dice)
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO games (id, lobby_id, leader_id, status, current_index, dice_values, dice_locked, roll_count, started_at, last_action_at, finished_at,
+			turn_timeout_seconds, variant, version)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, 1)
+	`, g.ID, g.LobbyID, g.LeaderID, string(g.Status), g.CurrentIndex, pq.Array(values), pq.Array(locked), g.RollCount,
+		g.StartedAt, g.LastActionAt, nullTime(g.FinishedAt), int(g.TurnTimeout/time.Second), string(g.Variant)); err != nil {
+		return err
+	}
+
+	insertScore := fmt.Sprintf(`
+		INSERT INTO scores (game_id, user_id, username, turn_position, is_active, %s, kniffel_bonus_count)
+		VALUES (%s)
+	`, strings.Join(scoreColumns, ", "), placeholders(1, 5+len(scoreColumns)+1))
+	for i, p := range g.Players {
+		args := []interface{}{g.ID, p.UserID, p.Username, i, p.Active}
+		args = append(args, scoreArgs(p.Card)...)
+		args = append(args, p.Card.KniffelBonusCount)
+		if _, err := tx.ExecContext(ctx, insertScore, args...); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	g.Version = 1
+	return nil
+}
+
+func (r *PostgresRepository) GetGame(ctx context.Context, gameID uuid.UUID) (*game.Game, error) {
+	g := &game.Game{ID: gameID}
+	var status string
+	var values []int64
+	var locked []bool
+	var finishedAt sql.NullTime
+	var timeoutSeconds int
+	var variant string
+	err := r.DB.QueryRowContext(ctx, `
+		SELECT lobby_id, leader_id, status, current_index, dice_values, dice_locked, roll_count, started_at, last_action_at, finished_at,
+			turn_timeout_seconds, variant, version
+		FROM games
+		WHERE id = $1
+	`, gameID).Scan(&g.LobbyID, &g.LeaderID, &status, &g.CurrentIndex, pq.Array(&values), pq.Array(&locked), &g.RollCount,
+		&g.StartedAt, &g.LastActionAt, &finishedAt, &timeoutSeconds, &variant, &g.Version)
+	if err == sql.ErrNoRows {
+		return nil, ErrGameNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	g.Status = game.Status(status)
+	g.TurnTimeout = time.Duration(timeoutSeconds) * time.Second
+	g.Variant = game.Variant(variant)
+	for i := 0; i < game.NumDice && i < len(values) && i < len(locked); i++ {
+		g.Dice[i] = game.Die{Value: int(values[i]), Locked: locked[i]}
+	}
+	if finishedAt.Valid {
+		t := finishedAt.Time
+		g.FinishedAt = &t
+	}
+
+	players, err := r.loadPlayers(ctx, gameID)
+	if err != nil {
+		return nil, err
+	}
+	g.Players = players
+	return g, nil
+}
+
+func (r *PostgresRepository) loadPlayers(ctx context.Context, gameID uuid.UUID) ([]game.Player, error) {
+	rows, err := r.DB.QueryContext(ctx, fmt.Sprintf(`
+		SELECT user_id, username, is_active, %s, kniffel_bonus_count
+		FROM scores
+		WHERE game_id = $1
+		ORDER BY turn_position ASC
+	`, strings.Join(scoreColumns, ", ")), gameID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var players []game.Player
+	for rows.Next() {
+		p := game.Player{Card: game.NewScoreCard()}
+		scores := make([]sql.NullInt64, len(scoreColumns))
+		dest := []interface{}{&p.UserID, &p.Username, &p.Active}
+		for i := range scores {
+			dest = append(dest, &scores[i])
+		}
+		dest = append(dest, &p.Card.KniffelBonusCount)
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		for i, f := range game.Fields {
+			if scores[i].Valid {
+				p.Card.Scores[f] = int(scores[i].Int64)
+			}
+		}
+		players = append(players, p)
+	}
+	return players, rows.Err()
+}
+
+func (r *PostgresRepository) UpdateGame(ctx context.Context, g *game.Game) error {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	values, locked := diceArrays(g.Dice)
+	result, err := tx.ExecContext(ctx, `
+		UPDATE games
+		SET status = $2, current_index = $3, dice_values = $4, dice_locked = $5, roll_count = $6,
+			last_action_at = $7, finished_at = $8, version = version + 1
+		WHERE id = $1 AND version = $9
+	`, g.ID, string(g.Status), g.CurrentIndex, pq.Array(values), pq.Array(locked), g.RollCount,
+		g.LastActionAt, nullTime(g.FinishedAt), g.Version)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		var exists bool
+		if err := tx.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM games WHERE id = $1)`, g.ID).Scan(&exists); err != nil {
+			return err
+		}
+		if !exists {
+			return ErrGameNotFound
+		}
+		return ErrVersionConflict
+	}
+
+	sets := make([]string, len(scoreColumns))
+	for i, col := range scoreColumns {
+		sets[i] = fmt.Sprintf("%s = $%d", col, i+4)
+	}
+	updateScore := fmt.Sprintf(`
+		UPDATE scores
+		SET is_active = $3, %s, kniffel_bonus_count = $%d
+		WHERE game_id = $1 AND user_id = $2
+	`, strings.Join(sets, ", "), len(scoreColumns)+4)
+	for _, p := range g.Players {
+		args := []interface{}{g.ID, p.UserID, p.Active}
+		args = append(args, scoreArgs(p.Card)...)
+		args = append(args, p.Card.KniffelBonusCount)
+		if _, err := tx.ExecContext(ctx, updateScore, args...); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	g.Version++
+	return nil
+}
+
+func (r *PostgresRepository) ListRunningGames(ctx context.Context) ([]*game.Game, error) {
+	rows, err := r.DB.QueryContext(ctx, `SELECT id FROM games WHERE status = $1`, string(game.StatusRunning))
+	if err != nil {
+		return nil, err
+	}
+	var ids []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	games := make([]*game.Game, 0, len(ids))
+	for _, id := range ids {
+		g, err := r.GetGame(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		games = append(games, g)
+	}
+	return games, nil
+}
+
+// diceArrays splits the dice into value and lock arrays for the INT[] and BOOLEAN[] columns
+func diceArrays(dice [game.NumDice]game.Die) ([]int64, []bool) {
+	values := make([]int64, len(dice))
+	locked := make([]bool, len(dice))
+	for i, d := range dice {
+		values[i] = int64(d.Value)
+		locked[i] = d.Locked
+	}
+	return values, locked
+}
+
+// scoreArgs returns the scorecard values in scoreColumns order; open fields are NULL
+func scoreArgs(c game.ScoreCard) []interface{} {
+	args := make([]interface{}, len(game.Fields))
+	for i, f := range game.Fields {
+		if v, ok := c.Value(f); ok {
+			args[i] = v
+		}
+	}
+	return args
+}
+
+func nullTime(t *time.Time) sql.NullTime {
+	if t == nil {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: *t, Valid: true}
+}
+
+// placeholders returns "$from, ..., $to"
+func placeholders(from, to int) string {
+	ps := make([]string, 0, to-from+1)
+	for i := from; i <= to; i++ {
+		ps = append(ps, fmt.Sprintf("$%d", i))
+	}
+	return strings.Join(ps, ", ")
+}
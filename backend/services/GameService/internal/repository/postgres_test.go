@@ -0,0 +1,212 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/GameService/internal/game"
+	"github.com/google/uuid"
+)
+
+func newTestGame(t *testing.T) *game.Game {
+	t.Helper()
+	seats := []game.Seat{{UserID: uuid.New(), Username: "Alice"}, {UserID: uuid.New(), Username: "Bob"}}
+	g, err := game.New(uuid.New(), uuid.New(), seats[0].UserID, seats, time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("failed to create game: %v", err)
+	}
+	return g
+}
+
+func TestCreateGame(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	repo := New(db)
+	g := newTestGame(t)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO games").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("INSERT INTO scores").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("INSERT INTO scores").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	if err := repo.CreateGame(context.Background(), g); err != nil {
+		t.Fatalf("CreateGame error: %v", err)
+	}
+	if g.Version != 1 {
+		t.Fatalf("expected version 1, got %d", g.Version)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestGetGameNotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	repo := New(db)
+	gameID := uuid.New()
+
+	mock.ExpectQuery("SELECT (.+) FROM games").WithArgs(gameID).WillReturnError(sql.ErrNoRows)
+
+	if _, err := repo.GetGame(context.Background(), gameID); !errors.Is(err, ErrGameNotFound) {
+		t.Fatalf("expected ErrGameNotFound, got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestGetGame(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	repo := New(db)
+	gameID, lobbyID, leaderID := uuid.New(), uuid.New(), uuid.New()
+	bobID := uuid.New()
+	startedAt := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery("SELECT (.+) FROM games").WithArgs(gameID).WillReturnRows(sqlmock.NewRows([]string{
+		"lobby_id", "leader_id", "status", "current_index", "dice_values", "dice_locked", "roll_count", "started_at", "last_action_at", "finished_at",
+		"turn_timeout_seconds", "variant", "version",
+	}).AddRow(lobbyID.String(), leaderID.String(), "running", 1, "{3,3,3,5,6}", "{t,t,t,f,f}", 2, startedAt, startedAt, nil, 60, "free_joker", 4))
+
+	scoreCols := append([]string{"user_id", "username", "is_active"}, scoreColumns...)
+	scoreCols = append(scoreCols, "kniffel_bonus_count")
+	alice := []driver.Value{leaderID.String(), "Alice", true, 3}
+	bob := []driver.Value{bobID.String(), "Bob", false, nil}
+	for i := 1; i < len(scoreColumns); i++ {
+		alice = append(alice, nil)
+		bob = append(bob, nil)
+	}
+	alice = append(alice, 0)
+	bob = append(bob, 0)
+	rows := sqlmock.NewRows(scoreCols).AddRow(alice...).AddRow(bob...)
+	mock.ExpectQuery("SELECT (.+) FROM scores").WithArgs(gameID).WillReturnRows(rows)
+
+	g, err := repo.GetGame(context.Background(), gameID)
+	if err != nil {
+		t.Fatalf("GetGame error: %v", err)
+	}
+	if g.Version != 4 || g.CurrentIndex != 1 || g.RollCount != 2 || g.Status != game.StatusRunning {
+		t.Fatalf("unexpected game state: %+v", g)
+	}
+	if g.TurnTimeout != time.Minute || g.Variant != game.VariantFreeJoker {
+		t.Fatalf("unexpected settings: %v %q", g.TurnTimeout, g.Variant)
+	}
+	if g.Dice[0] != (game.Die{Value: 3, Locked: true}) || g.Dice[4] != (game.Die{Value: 6, Locked: false}) {
+		t.Fatalf("unexpected dice: %+v", g.Dice)
+	}
+	if len(g.Players) != 2 || g.Players[1].UserID != bobID || g.Players[1].Active {
+		t.Fatalf("unexpected players: %+v", g.Players)
+	}
+	if v, ok := g.Players[0].Card.Value(game.Fields[0]); !ok || v != 3 {
+		t.Fatalf("expected %s to be 3, got %d (filled=%v)", game.Fields[0], v, ok)
+	}
+	if g.Players[1].Card.IsFilled(game.Fields[0]) {
+		t.Fatalf("expected %s to be open for Bob", game.Fields[0])
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestUpdateGameIncrementsVersion(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	repo := New(db)
+	g := newTestGame(t)
+	g.Version = 3
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE games").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("UPDATE scores").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("UPDATE scores").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	if err := repo.UpdateGame(context.Background(), g); err != nil {
+		t.Fatalf("UpdateGame error: %v", err)
+	}
+	if g.Version != 4 {
+		t.Fatalf("expected version 4, got %d", g.Version)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestUpdateGameVersionConflict(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	repo := New(db)
+	g := newTestGame(t)
+	g.Version = 3
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE games").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("SELECT EXISTS").WithArgs(g.ID).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	mock.ExpectRollback()
+
+	if err := repo.UpdateGame(context.Background(), g); !errors.Is(err, ErrVersionConflict) {
+		t.Fatalf("expected ErrVersionConflict, got %v", err)
+	}
+	if g.Version != 3 {
+		t.Fatalf("expected version to stay 3, got %d", g.Version)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestUpdateGameNotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	repo := New(db)
+	g := newTestGame(t)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE games").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("SELECT EXISTS").WithArgs(g.ID).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+	mock.ExpectRollback()
+
+	if err := repo.UpdateGame(context.Background(), g); !errors.Is(err, ErrGameNotFound) {
+		t.Fatalf("expected ErrGameNotFound, got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unfulfilled expectations: %v", err)
+	}
+}
@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/KnuffelGame/KnuffelGame/backend/services/GameService/internal/game"
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrGameNotFound is returned when no game exists for the given id
+	ErrGameNotFound = errors.New("game not found")
+	// ErrVersionConflict is returned by UpdateGame when the game was changed since it was loaded
+	ErrVersionConflict = errors.New("game was modified concurrently")
+)
+
+// Repository defines storage operations required by the Game service.
+// UpdateGame only succeeds if the stored version still matches g.Version and increments it on success,
+// so two requests that loaded the same game cannot both apply their changes.
+type Repository interface {
+	CreateGame(ctx context.Context, g *game.Game) error
+	GetGame(ctx context.Context, gameID uuid.UUID) (*game.Game, error)
+	UpdateGame(ctx context.Context, g *game.Game) error
+	// ListRunningGames returns all games that are still running, e.g. to restore turn timeouts after a restart
+	ListRunningGames(ctx context.Context) ([]*game.Game, error)
+}
@@ -0,0 +1,51 @@
+package router
+
+import (
+	"net/http"
+
+	"github.com/KnuffelGame/KnuffelGame/backend/libs/auth"
+	"github.com/KnuffelGame/KnuffelGame/backend/libs/healthcheck"
+	"github.com/KnuffelGame/KnuffelGame/backend/libs/logger"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/GameService/internal/events"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/GameService/internal/game"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/GameService/internal/handlers"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/GameService/internal/lobby"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/GameService/internal/repository"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/GameService/internal/timeout"
+	"github.com/go-chi/chi/v5"
+)
+
+// New constructs the HTTP router with repository, dice roller, event publisher, Lobby Service client and turn timeout scheduler dependencies
+func New(repo repository.Repository, roller game.Roller, pub events.Publisher, lobbies lobby.Client, timers *timeout.Scheduler) http.Handler {
+	r := chi.NewRouter()
+	// replace chi default logger with structured slog based middleware
+	l := logger.Default()
+	r.Use(logger.ChiMiddleware(l))
+
+	// Healthcheck
+	healthcheck.Mount(r)
+
+	// Internal endpoints (no auth required)
+	r.Route("/internal", func(r chi.Router) {
+		r.Post("/create", handlers.CreateGameHandler(repo, timers))
+		r.Post("/games/{game_id}/abort", handlers.AbortGameHandler(repo, pub, timers))
+		r.Get("/games/{game_id}/players/{user_id}", handlers.CheckPlayerHandler(repo))
+		r.Post("/games/{game_id}/players/{user_id}/leave", handlers.LeaveGameHandler(repo, pub, lobbies, timers))
+		r.Post("/games/{game_id}/players/{user_id}/activate", handlers.ActivatePlayerHandler(repo))
+		r.Put("/games/{game_id}/leader", handlers.SetLeaderHandler(repo))
+	})
+
+	// Game endpoints grouped under auth middleware
+	r.Route("/games", func(r chi.Router) {
+		// Authentication middleware (reads X-User-ID / X-Username and injects user into context)
+		r.Use(auth.AuthMiddleware)
+
+		r.Get("/{game_id}", handlers.GetGameHandler(repo, timers))
+		r.Post("/{game_id}/roll", handlers.RollDiceHandler(repo, roller, pub, timers))
+		r.Post("/{game_id}/toggle-dice", handlers.ToggleDiceHandler(repo, pub, timers))
+		r.Post("/{game_id}/select-field", handlers.SelectFieldHandler(repo, pub, lobbies, timers))
+		r.Post("/{game_id}/end", handlers.EndGameHandler(repo, pub, lobbies, timers))
+	})
+
+	return r
+}
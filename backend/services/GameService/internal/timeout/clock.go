@@ -0,0 +1,25 @@
+// Package timeout enforces the turn timeout of running games.
+// A Scheduler keeps one timer per game; when it fires, an Expirer ends the timed out turn.
+package timeout
+
+import "time"
+
+// Clock abstracts time so tests can fast-forward instead of sleeping (see timeouttest.Clock).
+type Clock interface {
+	Now() time.Time
+	AfterFunc(d time.Duration, f func()) Timer
+}
+
+// Timer is a pending call created by Clock.AfterFunc.
+type Timer interface {
+	Stop() bool
+}
+
+// RealClock uses the time package.
+type RealClock struct{}
+
+// Now returns the current time.
+func (RealClock) Now() time.Time { return time.Now() }
+
+// AfterFunc calls f in its own goroutine after d.
+func (RealClock) AfterFunc(d time.Duration, f func()) Timer { return time.AfterFunc(d, f) }
@@ -0,0 +1,128 @@
+package timeout
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/KnuffelGame/KnuffelGame/backend/services/GameService/internal/events"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/GameService/internal/game"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/GameService/internal/lobby"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/GameService/internal/models"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/GameService/internal/repository"
+	"github.com/google/uuid"
+)
+
+// retryDelay is used when a timed out turn could not be processed, e.g. because storage was unavailable
+const retryDelay = 5 * time.Second
+
+// expireTimeout bounds the storage and downstream calls made for a single expiry
+const expireTimeout = 10 * time.Second
+
+// Expirer ends timed out turns. Its Expire method is used as the Scheduler's ExpireFunc.
+type Expirer struct {
+	repo   repository.Repository
+	pub    events.Publisher
+	lobby  lobby.Client
+	clock  Clock
+	policy game.TimeoutPolicy
+	log    *slog.Logger
+}
+
+// NewExpirer creates an Expirer applying policy to timed out turns.
+func NewExpirer(repo repository.Repository, pub events.Publisher, lobbyClient lobby.Client, clock Clock, policy game.TimeoutPolicy, log *slog.Logger) *Expirer {
+	return &Expirer{repo: repo, pub: pub, lobby: lobbyClient, clock: clock, policy: policy, log: log.With(slog.String("component", "timeout"))}
+}
+
+// Expire ends the current turn of the game if its deadline has passed.
+// The timed out player is flagged inactive in the Lobby Service and the turn passes on.
+// It returns the next deadline and whether the game still needs to be scheduled.
+func (e *Expirer) Expire(gameID uuid.UUID) (time.Time, bool) {
+	ctx, cancel := context.WithTimeout(context.Background(), expireTimeout)
+	defer cancel()
+	log := e.log.With(slog.String("game_id", gameID.String()))
+
+	g, err := e.repo.GetGame(ctx, gameID)
+	if err != nil {
+		if errors.Is(err, repository.ErrGameNotFound) {
+			log.Warn("timed out game no longer exists")
+			return time.Time{}, false
+		}
+		log.Error("failed to load game", slog.String("error", err.Error()))
+		return e.clock.Now().Add(retryDelay), true
+	}
+	if g.Status != game.StatusRunning {
+		return time.Time{}, false
+	}
+
+	now := e.clock.Now()
+	// the deadline may have moved since the timer was set
+	if !g.Expired(now) {
+		return g.Deadline(), true
+	}
+
+	result, err := g.ExpireTurn(now, e.policy)
+	if err != nil {
+		log.Error("failed to expire turn", slog.String("error", err.Error()))
+		return e.clock.Now().Add(retryDelay), true
+	}
+	if err := e.repo.UpdateGame(ctx, g); err != nil {
+		if errors.Is(err, repository.ErrVersionConflict) {
+			// a player acted in the meantime; re-evaluate against the fresh state right away
+			log.Info("game changed while expiring turn, re-evaluating")
+			return e.clock.Now(), true
+		}
+		log.Error("failed to update game", slog.String("error", err.Error()))
+		return e.clock.Now().Add(retryDelay), true
+	}
+
+	log.Info("turn timed out",
+		slog.String("user_id", result.UserID.String()),
+		slog.String("policy", string(e.policy)),
+		slog.String("field", string(result.Field)),
+		slog.Bool("game_finished", result.Finished))
+
+	e.publish(ctx, g.ID, models.EventPlayerTimedOut, models.PlayerTimedOutEvent{
+		UserID:   result.UserID,
+		Username: result.Username,
+		Action:   timedOutAction(result),
+		Field:    string(result.Field),
+	}, log)
+
+	if err := e.lobby.SetPlayerActive(ctx, g.LobbyID, result.UserID, false); err != nil {
+		log.Warn("failed to flag player inactive in lobby", slog.String("user_id", result.UserID.String()), slog.String("error", err.Error()))
+	}
+
+	if result.Finished {
+		rankings := make([]models.PlayerRanking, len(result.Rankings))
+		for i, rk := range result.Rankings {
+			rankings[i] = models.PlayerRanking{UserID: rk.UserID, Username: rk.Username, TotalScore: rk.TotalScore, Rank: rk.Rank}
+		}
+		e.publish(ctx, g.ID, models.EventGameEnded, models.GameEndedEvent{GameID: g.ID, Rankings: rankings}, log)
+		if err := e.lobby.FinishLobby(ctx, g.LobbyID, g.ID); err != nil {
+			log.Warn("failed to finish lobby", slog.String("lobby_id", g.LobbyID.String()), slog.String("error", err.Error()))
+		}
+		return time.Time{}, false
+	}
+
+	e.publish(ctx, g.ID, models.EventTurnChanged, models.TurnChangedEvent{
+		CurrentPlayerID:       result.Next.UserID,
+		CurrentPlayerUsername: result.Next.Username,
+	}, log)
+	return g.Deadline(), true
+}
+
+func (e *Expirer) publish(ctx context.Context, gameID uuid.UUID, eventType string, data interface{}, log *slog.Logger) {
+	ev := events.Event{TargetType: events.TargetGame, TargetID: gameID.String(), EventType: eventType, Data: data}
+	if err := e.pub.Publish(ctx, ev); err != nil {
+		log.Warn("failed to publish event", slog.String("event_type", eventType), slog.String("error", err.Error()))
+	}
+}
+
+func timedOutAction(result *game.TimeoutResult) string {
+	if result.Field != "" {
+		return models.TimedOutActionCrossedOut
+	}
+	return models.TimedOutActionSkipped
+}
@@ -0,0 +1,184 @@
+package timeout_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/KnuffelGame/KnuffelGame/backend/services/GameService/internal/events"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/GameService/internal/game"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/GameService/internal/models"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/GameService/internal/repository"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/GameService/internal/timeout"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/GameService/internal/timeout/timeouttest"
+	"github.com/google/uuid"
+)
+
+type recordingPublisher struct {
+	mu     sync.Mutex
+	events []events.Event
+}
+
+func (p *recordingPublisher) Publish(_ context.Context, e events.Event) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.events = append(p.events, e)
+	return nil
+}
+
+type inactiveCall struct {
+	lobbyID, userID uuid.UUID
+	active          bool
+}
+
+type fakeLobby struct {
+	calls    []inactiveCall
+	finished []uuid.UUID
+}
+
+func (f *fakeLobby) SetPlayerActive(_ context.Context, lobbyID, userID uuid.UUID, active bool) error {
+	f.calls = append(f.calls, inactiveCall{lobbyID, userID, active})
+	return nil
+}
+
+func (f *fakeLobby) FinishLobby(_ context.Context, _, gameID uuid.UUID) error {
+	f.finished = append(f.finished, gameID)
+	return nil
+}
+
+type fixture struct {
+	clock  *timeouttest.Clock
+	repo   *repository.MemoryRepository
+	pub    *recordingPublisher
+	lobby  *fakeLobby
+	timers *timeout.Scheduler
+	game   *game.Game
+}
+
+func newFixture(t *testing.T, policy game.TimeoutPolicy) *fixture {
+	t.Helper()
+	f := &fixture{clock: timeouttest.NewClock(t0), repo: repository.NewMemory(), pub: &recordingPublisher{}, lobby: &fakeLobby{}}
+	expirer := timeout.NewExpirer(f.repo, f.pub, f.lobby, f.clock, policy, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	f.timers = timeout.NewScheduler(f.clock, expirer.Expire)
+
+	seats := []game.Seat{{UserID: uuid.New(), Username: "Alice"}, {UserID: uuid.New(), Username: "Bob"}, {UserID: uuid.New(), Username: "Carol"}}
+	g, err := game.New(uuid.New(), uuid.New(), seats[0].UserID, seats, t0)
+	if err != nil {
+		t.Fatalf("failed to create game: %v", err)
+	}
+	if err := f.repo.CreateGame(context.Background(), g); err != nil {
+		t.Fatalf("failed to store game: %v", err)
+	}
+	f.game = g
+	f.timers.Schedule(g.ID, g.Deadline())
+	return f
+}
+
+func (f *fixture) load(t *testing.T) *game.Game {
+	t.Helper()
+	g, err := f.repo.GetGame(context.Background(), f.game.ID)
+	if err != nil {
+		t.Fatalf("failed to load game: %v", err)
+	}
+	return g
+}
+
+func (f *fixture) eventTypes() []string {
+	f.pub.mu.Lock()
+	defer f.pub.mu.Unlock()
+	out := make([]string, len(f.pub.events))
+	for i, e := range f.pub.events {
+		out[i] = e.EventType
+	}
+	return out
+}
+
+func TestExpirer_SkipsTimedOutTurn(t *testing.T) {
+	f := newFixture(t, game.TimeoutSkip)
+
+	f.clock.Advance(game.TurnTimeout)
+
+	g := f.load(t)
+	if g.CurrentPlayer().UserID != f.game.Players[1].UserID {
+		t.Fatalf("expected turn to pass to Bob, current is %s", g.CurrentPlayer().Username)
+	}
+	if g.Players[0].Active {
+		t.Fatal("expected Alice to be inactive")
+	}
+	types := f.eventTypes()
+	if len(types) != 2 || types[0] != models.EventPlayerTimedOut || types[1] != models.EventTurnChanged {
+		t.Fatalf("expected player_timed_out and turn_changed, got %v", types)
+	}
+	data := f.pub.events[0].Data.(models.PlayerTimedOutEvent)
+	if data.Action != models.TimedOutActionSkipped || data.UserID != f.game.Players[0].UserID {
+		t.Fatalf("unexpected event payload %+v", data)
+	}
+	if len(f.lobby.calls) != 1 || f.lobby.calls[0] != (inactiveCall{f.game.LobbyID, f.game.Players[0].UserID, false}) {
+		t.Fatalf("expected lobby to flag Alice inactive, got %+v", f.lobby.calls)
+	}
+	if f.timers.Pending() != 1 {
+		t.Fatal("expected Bob's turn to be scheduled")
+	}
+}
+
+func TestExpirer_CrossOut(t *testing.T) {
+	f := newFixture(t, game.TimeoutCrossOut)
+
+	f.clock.Advance(game.TurnTimeout)
+
+	g := f.load(t)
+	if v, ok := g.Players[0].Card.Value(game.Ones); !ok || v != 0 {
+		t.Fatalf("expected ones to be crossed out, got %d %v", v, ok)
+	}
+	data := f.pub.events[0].Data.(models.PlayerTimedOutEvent)
+	if data.Action != models.TimedOutActionCrossedOut || data.Field != string(game.Ones) {
+		t.Fatalf("unexpected event payload %+v", data)
+	}
+}
+
+func TestExpirer_InteractionMovesDeadline(t *testing.T) {
+	f := newFixture(t, game.TimeoutSkip)
+
+	// Alice rolls 30s in, without the handler resetting the timer the original timer fires at 40s
+	f.clock.Advance(30 * time.Second)
+	g := f.load(t)
+	if err := g.Roll(g.Players[0].UserID, game.RandomRoller{}, f.clock.Now()); err != nil {
+		t.Fatalf("roll: %v", err)
+	}
+	if err := f.repo.UpdateGame(context.Background(), g); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+
+	f.clock.Advance(10 * time.Second)
+	if len(f.eventTypes()) != 0 {
+		t.Fatalf("expected no timeout after interaction, got %v", f.eventTypes())
+	}
+	f.clock.Advance(30 * time.Second)
+	if types := f.eventTypes(); len(types) == 0 || types[0] != models.EventPlayerTimedOut {
+		t.Fatalf("expected timeout 40s after the roll, got %v", types)
+	}
+}
+
+func TestExpirer_EveryoneTimesOut(t *testing.T) {
+	f := newFixture(t, game.TimeoutSkip)
+
+	f.clock.Advance(3 * game.TurnTimeout)
+
+	g := f.load(t)
+	if g.Status != game.StatusFinished {
+		t.Fatalf("expected game to finish, status %s", g.Status)
+	}
+	types := f.eventTypes()
+	if types[len(types)-1] != models.EventGameEnded {
+		t.Fatalf("expected game_ended as last event, got %v", types)
+	}
+	if len(f.lobby.calls) != 3 || f.timers.Pending() != 0 {
+		t.Fatalf("expected 3 inactive calls and no pending timers, got %d calls, %d pending", len(f.lobby.calls), f.timers.Pending())
+	}
+	if len(f.lobby.finished) != 1 || f.lobby.finished[0] != g.ID {
+		t.Fatalf("expected the lobby to be finished, got %v", f.lobby.finished)
+	}
+}
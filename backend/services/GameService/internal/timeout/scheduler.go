@@ -0,0 +1,96 @@
+package timeout
+
+import (
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ExpireFunc is called when a game's deadline passes.
+// It returns the next deadline for the game and true to keep the game scheduled.
+type ExpireFunc func(gameID uuid.UUID) (time.Time, bool)
+
+// Scheduler keeps one pending timer per running game.
+// Scheduling a game again replaces its previous timer, so interactions simply reset the deadline.
+type Scheduler struct {
+	clock  Clock
+	expire ExpireFunc
+
+	mu     sync.Mutex
+	seq    uint64
+	timers map[uuid.UUID]*entry
+}
+
+type entry struct {
+	timer Timer
+	seq   uint64
+}
+
+// NewScheduler creates a scheduler that calls expire when a game's deadline passes.
+func NewScheduler(clock Clock, expire ExpireFunc) *Scheduler {
+	return &Scheduler{clock: clock, expire: expire, timers: make(map[uuid.UUID]*entry)}
+}
+
+// Now returns the current time of the scheduler's clock.
+func (s *Scheduler) Now() time.Time {
+	return s.clock.Now()
+}
+
+// Schedule sets the deadline for a game, replacing any pending one.
+func (s *Scheduler) Schedule(gameID uuid.UUID, deadline time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.scheduleLocked(gameID, deadline)
+}
+
+func (s *Scheduler) scheduleLocked(gameID uuid.UUID, deadline time.Time) {
+	if e, ok := s.timers[gameID]; ok {
+		e.timer.Stop()
+	}
+	s.seq++
+	seq := s.seq
+	d := deadline.Sub(s.clock.Now())
+	if d < 0 {
+		d = 0
+	}
+	s.timers[gameID] = &entry{seq: seq, timer: s.clock.AfterFunc(d, func() { s.fire(gameID, seq) })}
+}
+
+// Cancel removes the pending deadline of a game, e.g. once it has finished.
+func (s *Scheduler) Cancel(gameID uuid.UUID) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if e, ok := s.timers[gameID]; ok {
+		e.timer.Stop()
+		delete(s.timers, gameID)
+	}
+}
+
+// Pending returns the number of games with a pending deadline.
+func (s *Scheduler) Pending() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.timers)
+}
+
+// fire runs the expire callback unless the timer was replaced or cancelled in the meantime.
+func (s *Scheduler) fire(gameID uuid.UUID, seq uint64) {
+	s.mu.Lock()
+	e, ok := s.timers[gameID]
+	if !ok || e.seq != seq {
+		s.mu.Unlock()
+		return
+	}
+	delete(s.timers, gameID)
+	s.mu.Unlock()
+
+	if next, ok := s.expire(gameID); ok {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		// an interaction during expire may already have set a newer deadline
+		if _, rescheduled := s.timers[gameID]; !rescheduled {
+			s.scheduleLocked(gameID, next)
+		}
+	}
+}
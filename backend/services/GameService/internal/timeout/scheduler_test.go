@@ -0,0 +1,91 @@
+package timeout_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/KnuffelGame/KnuffelGame/backend/services/GameService/internal/timeout"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/GameService/internal/timeout/timeouttest"
+	"github.com/google/uuid"
+)
+
+var t0 = time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+func TestScheduler_FiresAtDeadline(t *testing.T) {
+	clock := timeouttest.NewClock(t0)
+	var fired []uuid.UUID
+	s := timeout.NewScheduler(clock, func(id uuid.UUID) (time.Time, bool) {
+		fired = append(fired, id)
+		return time.Time{}, false
+	})
+
+	a, b := uuid.New(), uuid.New()
+	s.Schedule(a, t0.Add(40*time.Second))
+	s.Schedule(b, t0.Add(20*time.Second))
+
+	clock.Advance(39 * time.Second)
+	if len(fired) != 1 || fired[0] != b {
+		t.Fatalf("expected only b to fire, got %v", fired)
+	}
+	clock.Advance(time.Second)
+	if len(fired) != 2 || fired[1] != a {
+		t.Fatalf("expected a to fire at its deadline, got %v", fired)
+	}
+	if s.Pending() != 0 {
+		t.Fatalf("expected no pending timers, got %d", s.Pending())
+	}
+}
+
+func TestScheduler_ScheduleResetsDeadline(t *testing.T) {
+	clock := timeouttest.NewClock(t0)
+	fired := 0
+	s := timeout.NewScheduler(clock, func(uuid.UUID) (time.Time, bool) {
+		fired++
+		return time.Time{}, false
+	})
+
+	id := uuid.New()
+	s.Schedule(id, t0.Add(40*time.Second))
+	clock.Advance(30 * time.Second)
+	s.Schedule(id, clock.Now().Add(40*time.Second))
+	clock.Advance(30 * time.Second)
+	if fired != 0 {
+		t.Fatal("expected reset deadline not to fire yet")
+	}
+	clock.Advance(10 * time.Second)
+	if fired != 1 {
+		t.Fatalf("expected exactly one expiry, got %d", fired)
+	}
+}
+
+func TestScheduler_Cancel(t *testing.T) {
+	clock := timeouttest.NewClock(t0)
+	fired := 0
+	s := timeout.NewScheduler(clock, func(uuid.UUID) (time.Time, bool) {
+		fired++
+		return time.Time{}, false
+	})
+
+	id := uuid.New()
+	s.Schedule(id, t0.Add(time.Second))
+	s.Cancel(id)
+	clock.Advance(time.Minute)
+	if fired != 0 || s.Pending() != 0 {
+		t.Fatalf("expected cancelled timer not to fire, fired %d", fired)
+	}
+}
+
+func TestScheduler_ReschedulesFromExpire(t *testing.T) {
+	clock := timeouttest.NewClock(t0)
+	fired := 0
+	s := timeout.NewScheduler(clock, func(uuid.UUID) (time.Time, bool) {
+		fired++
+		return clock.Now().Add(40 * time.Second), fired < 3
+	})
+
+	s.Schedule(uuid.New(), t0.Add(40*time.Second))
+	clock.Advance(10 * time.Minute)
+	if fired != 3 {
+		t.Fatalf("expected 3 expiries before expire stopped rescheduling, got %d", fired)
+	}
+}
@@ -0,0 +1,83 @@
+// Package timeouttest provides a manually advanced clock for tests of the turn timeout.
+package timeouttest
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/KnuffelGame/KnuffelGame/backend/services/GameService/internal/timeout"
+)
+
+// Clock is a manually advanced timeout.Clock for tests.
+// Due timers run synchronously inside Advance, in deadline order.
+type Clock struct {
+	mu     sync.Mutex
+	now    time.Time
+	timers []*fakeTimer
+}
+
+type fakeTimer struct {
+	clock    *Clock
+	deadline time.Time
+	f        func()
+	stopped  bool
+}
+
+// NewClock creates a Clock starting at now.
+func NewClock(now time.Time) *Clock {
+	return &Clock{now: now}
+}
+
+// Now returns the fake current time.
+func (c *Clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// AfterFunc registers f to run once the clock has been advanced by d.
+func (c *Clock) AfterFunc(d time.Duration, f func()) timeout.Timer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	t := &fakeTimer{clock: c, deadline: c.now.Add(d), f: f}
+	c.timers = append(c.timers, t)
+	return t
+}
+
+// Advance moves the clock forward by d and runs all timers that became due.
+func (c *Clock) Advance(d time.Duration) {
+	c.mu.Lock()
+	target := c.now.Add(d)
+	c.mu.Unlock()
+	for {
+		c.mu.Lock()
+		sort.SliceStable(c.timers, func(i, j int) bool { return c.timers[i].deadline.Before(c.timers[j].deadline) })
+		if len(c.timers) == 0 || c.timers[0].deadline.After(target) {
+			c.now = target
+			c.mu.Unlock()
+			return
+		}
+		t := c.timers[0]
+		c.timers = c.timers[1:]
+		c.now = t.deadline
+		c.mu.Unlock()
+		if !t.stopped {
+			t.f()
+		}
+	}
+}
+
+func (t *fakeTimer) Stop() bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	wasActive := !t.stopped
+	t.stopped = true
+	for i, other := range t.clock.timers {
+		if other == t {
+			t.clock.timers = append(t.clock.timers[:i], t.clock.timers[i+1:]...)
+			return wasActive
+		}
+	}
+	return false
+}
@@ -0,0 +1,91 @@
+package config
+
+import "os"
+
+// Config holds runtime configuration loaded from environment variables.
+// PORT defaults to 8082 if unset.
+// SSE_SERVICE_URL defaults to the docker compose address of the SSE Service;
+// set it to an empty value to disable event publishing.
+// LOBBY_SERVICE_URL defaults to the docker compose address of the Lobby Service;
+// set it to an empty value to skip flagging timed out players inactive.
+// TURN_TIMEOUT_POLICY decides what happens to a timed out turn: "skip" (default) or "cross_out".
+// DATABASE_* default to the docker compose Postgres instance.
+// Extend here for future configuration values.
+
+type Config struct {
+	Port              string
+	SSEServiceURL     string
+	LobbyServiceURL   string
+	TurnTimeoutPolicy string
+	DatabaseHost      string
+	DatabasePort      string
+	DatabaseUser      string
+	DatabasePassword  string
+	DatabaseName      string
+	DatabaseSSLMode   string
+}
+
+func Load() *Config {
+	port := os.Getenv("PORT")
+	if port == "" {
+		port = "8082"
+	}
+
+	sseURL, ok := os.LookupEnv("SSE_SERVICE_URL")
+	if !ok {
+		sseURL = "http://SSEService:8084"
+	}
+
+	lobbyURL, ok := os.LookupEnv("LOBBY_SERVICE_URL")
+	if !ok {
+		lobbyURL = "http://LobbyService:8083"
+	}
+
+	timeoutPolicy := os.Getenv("TURN_TIMEOUT_POLICY")
+	if timeoutPolicy == "" {
+		timeoutPolicy = "skip"
+	}
+
+	dbHost := os.Getenv("DATABASE_HOST")
+	if dbHost == "" {
+		dbHost = "Postgres"
+	}
+
+	dbPort := os.Getenv("DATABASE_PORT")
+	if dbPort == "" {
+		dbPort = "5432"
+	}
+
+	dbUser := os.Getenv("DATABASE_USER")
+	if dbUser == "" {
+		dbUser = "game"
+	}
+
+	dbPassword := os.Getenv("DATABASE_PASSWORD")
+	if dbPassword == "" {
+		dbPassword = "secure"
+	}
+
+	dbName := os.Getenv("DATABASE_NAME")
+	if dbName == "" {
+		dbName = "game"
+	}
+
+	dbSSLMode := os.Getenv("DATABASE_SSLMODE")
+	if dbSSLMode == "" {
+		dbSSLMode = "disable"
+	}
+
+	return &Config{
+		Port:              port,
+		SSEServiceURL:     sseURL,
+		LobbyServiceURL:   lobbyURL,
+		TurnTimeoutPolicy: timeoutPolicy,
+		DatabaseHost:      dbHost,
+		DatabasePort:      dbPort,
+		DatabaseUser:      dbUser,
+		DatabasePassword:  dbPassword,
+		DatabaseName:      dbName,
+		DatabaseSSLMode:   dbSSLMode,
+	}
+}
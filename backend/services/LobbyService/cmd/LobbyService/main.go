@@ -1,15 +1,13 @@
 package main
 
 import (
+	"context"
 	"log/slog"
 	"net/http"
 	"os"
 
 	"github.com/KnuffelGame/KnuffelGame/backend/libs/logger"
-	router "github.com/KnuffelGame/KnuffelGame/backend/services/LobbyService/internal"
-	"github.com/KnuffelGame/KnuffelGame/backend/services/LobbyService/internal/db"
-	"github.com/KnuffelGame/KnuffelGame/backend/services/LobbyService/internal/joincode"
-	"github.com/KnuffelGame/KnuffelGame/backend/services/LobbyService/internal/repository"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/LobbyService/internal/app"
 	"github.com/KnuffelGame/KnuffelGame/backend/services/LobbyService/pkg/config"
 )
 
@@ -20,40 +18,21 @@ func main() {
 	}
 	log := logger.FromEnv().With(slog.String("component", "bootstrap"))
 
-	cfg := config.Load()
-
-	// Initialize database connection
-	dbConfig := db.Config{
-		Host:     cfg.DatabaseHost,
-		Port:     cfg.DatabasePort,
-		User:     cfg.DatabaseUser,
-		Password: cfg.DatabasePassword,
-		Database: cfg.DatabaseName,
-		SSLMode:  cfg.DatabaseSSLMode,
-	}
-
-	dbConn, err := db.New(dbConfig)
+	cfg, err := config.Load()
 	if err != nil {
-		log.Error("failed to connect to database", slog.String("error", err.Error()))
+		log.Error("invalid configuration", slog.String("error", err.Error()))
 		os.Exit(1)
 	}
-	defer dbConn.Close()
 
-	// Run database migrations
-	if err := db.RunMigrations(dbConn.DB); err != nil {
-		log.Error("failed to run migrations", slog.String("error", err.Error()))
+	a, err := app.New(context.Background(), cfg, log)
+	if err != nil {
+		log.Error("failed to start", slog.String("error", err.Error()))
 		os.Exit(1)
 	}
+	defer a.Close()
 
-	// Initialize join code generator
-	codeGen := joincode.NewGenerator(dbConn.DB)
-
-	// Construct repository and pass it into router
-	repo := repository.New(dbConn.DB)
-
-	r := router.New(repo, codeGen)
 	log.Info("listening", slog.String("port", cfg.Port))
-	if err := http.ListenAndServe(":"+cfg.Port, r); err != nil {
+	if err := http.ListenAndServe(":"+cfg.Port, a.Handler); err != nil {
 		log.Error("server exited", slog.String("error", err.Error()))
 		os.Exit(1)
 	}
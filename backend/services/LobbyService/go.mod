@@ -8,6 +8,7 @@ require (
 	github.com/KnuffelGame/KnuffelGame/backend/libs/healthcheck v0.0.0
 	github.com/KnuffelGame/KnuffelGame/backend/libs/httpx v0.0.0
 	github.com/KnuffelGame/KnuffelGame/backend/libs/logger v0.0.0
+	github.com/KnuffelGame/KnuffelGame/backend/libs/username v0.0.0
 	github.com/go-chi/chi/v5 v5.2.3
 	github.com/google/uuid v1.6.0
 	github.com/lib/pq v1.10.9
@@ -19,6 +20,7 @@ require (
 	github.com/sethvargo/go-retry v0.3.0 // indirect
 	go.uber.org/multierr v1.11.0 // indirect
 	golang.org/x/sync v0.16.0 // indirect
+	golang.org/x/text v0.27.0 // indirect
 )
 
 replace github.com/KnuffelGame/KnuffelGame/backend/libs/healthcheck => ../../libs/healthcheck
@@ -28,3 +30,5 @@ replace github.com/KnuffelGame/KnuffelGame/backend/libs/httpx => ../../libs/http
 replace github.com/KnuffelGame/KnuffelGame/backend/libs/logger => ../../libs/logger
 
 replace github.com/KnuffelGame/KnuffelGame/backend/libs/auth => ../../libs/auth
+
+replace github.com/KnuffelGame/KnuffelGame/backend/libs/username => ../../libs/username
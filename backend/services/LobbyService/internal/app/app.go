@@ -0,0 +1,86 @@
+// Package app builds the Lobby Service from its configuration: database, service clients, background workers and the HTTP router.
+package app
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/KnuffelGame/KnuffelGame/backend/libs/logger"
+	router "github.com/KnuffelGame/KnuffelGame/backend/services/LobbyService/internal"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/LobbyService/internal/db"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/LobbyService/internal/events"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/LobbyService/internal/game"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/LobbyService/internal/janitor"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/LobbyService/internal/joincode"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/LobbyService/internal/models"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/LobbyService/internal/outbox"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/LobbyService/internal/repository"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/LobbyService/pkg/config"
+)
+
+// App is the assembled Lobby Service
+type App struct {
+	Handler http.Handler
+	conn    *db.Connection
+}
+
+// New builds the service described by cfg and starts the outbox dispatcher and the janitor, which stop with ctx.
+func New(ctx context.Context, cfg *config.Config, log *slog.Logger) (*App, error) {
+	codes, err := joincode.NewGenerator(cfg.JoinCodeAlphabet, cfg.JoinCodeLength)
+	if err != nil {
+		return nil, fmt.Errorf("invalid join code configuration: %w", err)
+	}
+
+	conn, err := openDatabase(cfg)
+	if err != nil {
+		return nil, err
+	}
+	repo := repository.New(conn.DB)
+	pub := newPublisher(cfg, log)
+	games := game.NewHTTPClient(cfg.GameServiceURL)
+
+	// Deliver the events handlers wrote to the outbox; without an SSE Service they are discarded
+	go outbox.NewDispatcher(repo, pub, logger.Default()).Run(ctx)
+
+	// Close expired lobbies; safe on every replica since each lobby is claimed by one of them
+	expiry := models.LobbyExpiry{IdleTimeout: cfg.IdleTimeout, InactiveTimeout: cfg.InactiveTimeout, FinishedRetention: cfg.FinishedRetention}
+	go janitor.NewJanitor(repo, games, expiry, cfg.JanitorInterval, logger.Default()).Run(ctx)
+
+	return &App{Handler: router.New(repo, codes, games, pub), conn: conn}, nil
+}
+
+// Close releases the database connection
+func (a *App) Close() {
+	a.conn.Close()
+}
+
+// openDatabase connects to Postgres and runs the migrations
+func openDatabase(cfg *config.Config) (*db.Connection, error) {
+	conn, err := db.New(db.Config{
+		Host:     cfg.DatabaseHost,
+		Port:     cfg.DatabasePort,
+		User:     cfg.DatabaseUser,
+		Password: cfg.DatabasePassword,
+		Database: cfg.DatabaseName,
+		SSLMode:  cfg.DatabaseSSLMode,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+	if err := db.RunMigrations(conn.DB); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to run migrations: %w", err)
+	}
+	return conn, nil
+}
+
+// newPublisher creates the SSE Service publisher; without SSE_SERVICE_URL events are dropped
+func newPublisher(cfg *config.Config, log *slog.Logger) events.Publisher {
+	if cfg.SSEServiceURL == "" {
+		log.Warn("SSE_SERVICE_URL is empty, events will not be published")
+		return events.NopPublisher{}
+	}
+	return events.NewHTTPPublisher(cfg.SSEServiceURL)
+}
@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Game created when the lobby was started, NULL while waiting
+ALTER TABLE lobbies ADD COLUMN IF NOT EXISTS game_id UUID NULL;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE lobbies DROP COLUMN IF EXISTS game_id;
+-- +goose StatementEnd
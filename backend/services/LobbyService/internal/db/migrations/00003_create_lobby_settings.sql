@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Settings chosen by the leader, one row per lobby
+CREATE TABLE IF NOT EXISTS lobby_settings (
+    lobby_id UUID PRIMARY KEY,
+    max_players INT NOT NULL DEFAULT 6,
+    turn_timeout_seconds INT NOT NULL DEFAULT 40,
+    is_private BOOLEAN NOT NULL DEFAULT true,
+    variant VARCHAR(20) NOT NULL DEFAULT 'classic',
+    CONSTRAINT fk_settings_lobby FOREIGN KEY (lobby_id) REFERENCES lobbies(id) ON DELETE CASCADE,
+    CONSTRAINT chk_max_players CHECK (max_players BETWEEN 2 AND 10),
+    CONSTRAINT chk_turn_timeout CHECK (turn_timeout_seconds BETWEEN 10 AND 300)
+);
+
+-- Existing lobbies keep the previous fixed rules
+INSERT INTO lobby_settings (lobby_id)
+SELECT id FROM lobbies
+ON CONFLICT (lobby_id) DO NOTHING;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS lobby_settings;
+-- +goose StatementEnd
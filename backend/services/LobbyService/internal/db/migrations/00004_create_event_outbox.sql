@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Events written in the transaction of the state change they describe.
+-- The outbox dispatcher publishes them to the SSE Service and deletes delivered rows.
+CREATE TABLE IF NOT EXISTS event_outbox (
+    id BIGSERIAL PRIMARY KEY,
+    target_type VARCHAR(10) NOT NULL,
+    target_id VARCHAR(64) NOT NULL,
+    event_type VARCHAR(50) NOT NULL,
+    target_user_id UUID NULL,
+    payload JSONB NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    last_error TEXT NULL,
+    next_attempt_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Events of one target are delivered in id order
+CREATE INDEX IF NOT EXISTS idx_event_outbox_target ON event_outbox(target_type, target_id, id);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_event_outbox_target;
+DROP TABLE IF EXISTS event_outbox;
+-- +goose StatementEnd
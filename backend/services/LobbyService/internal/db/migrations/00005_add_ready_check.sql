@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Ready toggle of each player, reset by joining again
+ALTER TABLE players ADD COLUMN IF NOT EXISTS is_ready BOOLEAN NOT NULL DEFAULT false;
+
+-- Start is refused until every player is ready
+ALTER TABLE lobby_settings ADD COLUMN IF NOT EXISTS require_ready BOOLEAN NOT NULL DEFAULT false;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE lobby_settings DROP COLUMN IF EXISTS require_ready;
+ALTER TABLE players DROP COLUMN IF EXISTS is_ready;
+-- +goose StatementEnd
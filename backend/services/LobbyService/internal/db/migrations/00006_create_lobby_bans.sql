@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Users banned by the leader when kicking them; they cannot join the lobby again
+CREATE TABLE IF NOT EXISTS lobby_bans (
+    lobby_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    banned_by UUID NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (lobby_id, user_id),
+    CONSTRAINT fk_bans_lobby FOREIGN KEY (lobby_id) REFERENCES lobbies(id) ON DELETE CASCADE,
+    CONSTRAINT fk_bans_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS lobby_bans;
+-- +goose StatementEnd
@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Join code length is configurable; existing 6-character codes are kept as they are
+ALTER TABLE lobbies ALTER COLUMN join_code TYPE VARCHAR(12);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM lobbies WHERE length(join_code) > 6) THEN
+        RAISE EXCEPTION 'cannot roll back: join codes longer than 6 characters exist';
+    END IF;
+END $$;
+
+ALTER TABLE lobbies ALTER COLUMN join_code TYPE CHAR(6);
+-- +goose StatementEnd
@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Keep only the latest row per player; earlier rejoins inserted a second one
+DELETE FROM players p
+USING players q
+WHERE p.lobby_id = q.lobby_id
+  AND p.user_id = q.user_id
+  AND (p.joined_at, p.id) < (q.joined_at, q.id);
+
+-- A player that leaves and rejoins reuses its row
+ALTER TABLE players ADD CONSTRAINT uq_players_lobby_user UNIQUE (lobby_id, user_id);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE players DROP CONSTRAINT IF EXISTS uq_players_lobby_user;
+-- +goose StatementEnd
@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- A dispatcher leases the events it claims until locked_until, so it can publish them without holding row locks.
+-- Events whose lease ran out, e.g. because the dispatcher crashed, are claimed again.
+ALTER TABLE event_outbox ADD COLUMN IF NOT EXISTS locked_until TIMESTAMP NULL;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE event_outbox DROP COLUMN IF EXISTS locked_until;
+-- +goose StatementEnd
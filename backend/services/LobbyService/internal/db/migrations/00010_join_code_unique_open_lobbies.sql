@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Finished lobbies are kept for their retention; their codes can be drawn again for new lobbies
+ALTER TABLE lobbies DROP CONSTRAINT IF EXISTS lobbies_join_code_key;
+CREATE UNIQUE INDEX IF NOT EXISTS uq_lobbies_join_code_open ON lobbies(join_code) WHERE status <> 'finished';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM lobbies GROUP BY join_code HAVING COUNT(*) > 1) THEN
+        RAISE EXCEPTION 'cannot roll back: join codes are shared by finished lobbies';
+    END IF;
+END $$;
+
+DROP INDEX IF EXISTS uq_lobbies_join_code_open;
+ALTER TABLE lobbies ADD CONSTRAINT lobbies_join_code_key UNIQUE (join_code);
+-- +goose StatementEnd
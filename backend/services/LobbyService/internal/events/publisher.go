@@ -0,0 +1,141 @@
+// Package events registers targets with and publishes lobby events to the SSE Service.
+package events
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// SSE target types
+const (
+	TargetLobby = "lobby"
+	TargetGame  = "game"
+)
+
+// Unregister reasons sent to connected clients
+const (
+	ReasonLobbyDeleted = "lobby_deleted"
+	ReasonCleanup      = "cleanup"
+)
+
+// EventUnregister only exists in the event outbox: the dispatcher unregisters the target instead of publishing,
+// after all earlier events of the target were delivered. The payload is UnregisterData.
+const EventUnregister = "unregister"
+
+// UnregisterData is the payload of an EventUnregister outbox entry
+type UnregisterData struct {
+	Reason string `json:"reason"`
+}
+
+const publishTimeout = 3 * time.Second
+
+// Event matches the SSE Service PublishEventRequest schema.
+type Event struct {
+	TargetType   string      `json:"target_type"`
+	TargetID     string      `json:"target_id"`
+	EventType    string      `json:"event_type"`
+	TargetUserID string      `json:"target_user_id,omitempty"`
+	Data         interface{} `json:"data"`
+}
+
+// Publisher delivers events to connected clients.
+type Publisher interface {
+	// Register announces a lobby or game before its first connection
+	Register(ctx context.Context, targetType, targetID string) error
+	Publish(ctx context.Context, e Event) error
+	// Unregister closes all connections to a lobby or game
+	Unregister(ctx context.Context, targetType, targetID, reason string) error
+}
+
+// HTTPPublisher calls the SSE Service POST /internal/register and /internal/publish endpoints.
+type HTTPPublisher struct {
+	baseURL string
+	client  *http.Client
+}
+
+// NewHTTPPublisher creates a publisher for the SSE Service at baseURL (e.g. http://SSEService:8084).
+func NewHTTPPublisher(baseURL string) *HTTPPublisher {
+	return &HTTPPublisher{baseURL: baseURL, client: &http.Client{Timeout: publishTimeout}}
+}
+
+// Register returns an error for transport failures or non-2xx responses.
+// 409 means the target is registered already, which is not an error.
+func (p *HTTPPublisher) Register(ctx context.Context, targetType, targetID string) error {
+	status, err := p.post(ctx, "/internal/register", map[string]string{"target_type": targetType, "target_id": targetID})
+	if err != nil {
+		return fmt.Errorf("failed to register target: %w", err)
+	}
+	if status == http.StatusConflict {
+		return nil
+	}
+	if status < 200 || status >= 300 {
+		return fmt.Errorf("register returned status %d", status)
+	}
+	return nil
+}
+
+// Publish sends the event and returns an error for transport failures or non-2xx responses.
+// 404 means nobody is connected to the target, which is not an error.
+func (p *HTTPPublisher) Publish(ctx context.Context, e Event) error {
+	status, err := p.post(ctx, "/internal/publish", e)
+	if err != nil {
+		return fmt.Errorf("failed to publish event: %w", err)
+	}
+	if status == http.StatusNotFound {
+		return nil
+	}
+	if status < 200 || status >= 300 {
+		return fmt.Errorf("publish returned status %d", status)
+	}
+	return nil
+}
+
+// Unregister returns an error for transport failures or non-2xx responses.
+// 404 means the target is not registered, which is not an error.
+func (p *HTTPPublisher) Unregister(ctx context.Context, targetType, targetID, reason string) error {
+	status, err := p.post(ctx, "/internal/unregister", map[string]string{"target_type": targetType, "target_id": targetID, "reason": reason})
+	if err != nil {
+		return fmt.Errorf("failed to unregister target: %w", err)
+	}
+	if status == http.StatusNotFound {
+		return nil
+	}
+	if status < 200 || status >= 300 {
+		return fmt.Errorf("unregister returned status %d", status)
+	}
+	return nil
+}
+
+func (p *HTTPPublisher) post(ctx context.Context, path string, v interface{}) (int, error) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		return 0, fmt.Errorf("failed to encode request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.baseURL+path, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("failed to build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	resp.Body.Close()
+	return resp.StatusCode, nil
+}
+
+// NopPublisher discards all events. Used when no SSE Service is configured.
+type NopPublisher struct{}
+
+// Register does nothing.
+func (NopPublisher) Register(context.Context, string, string) error { return nil }
+
+// Publish does nothing.
+func (NopPublisher) Publish(context.Context, Event) error { return nil }
+
+// Unregister does nothing.
+func (NopPublisher) Unregister(context.Context, string, string, string) error { return nil }
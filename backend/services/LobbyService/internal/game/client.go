@@ -0,0 +1,181 @@
+// Package game calls internal endpoints of the Game Service.
+package game
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const requestTimeout = 3 * time.Second
+
+// Player is a seat in the turn order
+type Player struct {
+	UserID   uuid.UUID `json:"user_id"`
+	Username string    `json:"username"`
+}
+
+// CreateGameRequest matches the Game Service CreateGameRequest schema
+type CreateGameRequest struct {
+	LobbyID            uuid.UUID `json:"lobby_id"`
+	LeaderID           uuid.UUID `json:"leader_id"`
+	TurnOrder          []Player  `json:"turn_order"`
+	TurnTimeoutSeconds int       `json:"turn_timeout_seconds,omitempty"`
+	Variant            string    `json:"variant,omitempty"`
+}
+
+// CreateGameResponse matches the Game Service CreateGameResponse schema
+type CreateGameResponse struct {
+	GameID          uuid.UUID   `json:"game_id"`
+	LobbyID         uuid.UUID   `json:"lobby_id"`
+	CurrentPlayerID uuid.UUID   `json:"current_player_id"`
+	TurnOrder       []uuid.UUID `json:"turn_order"`
+}
+
+// SetLeaderRequest matches the Game Service SetLeaderRequest schema
+type SetLeaderRequest struct {
+	LeaderID uuid.UUID `json:"leader_id"`
+}
+
+// Client is the subset of the Game Service API used by the Lobby Service.
+type Client interface {
+	CreateGame(ctx context.Context, req CreateGameRequest) (CreateGameResponse, error)
+	AbortGame(ctx context.Context, gameID uuid.UUID) error
+	LeaveGame(ctx context.Context, gameID, userID uuid.UUID) error
+	ActivatePlayer(ctx context.Context, gameID, userID uuid.UUID) error
+	SetLeader(ctx context.Context, gameID, leaderID uuid.UUID) error
+}
+
+// HTTPClient talks to the Game Service over HTTP.
+type HTTPClient struct {
+	baseURL string
+	client  *http.Client
+}
+
+// NewHTTPClient creates a client for the Game Service at baseURL (e.g. http://GameService:8082).
+func NewHTTPClient(baseURL string) *HTTPClient {
+	return &HTTPClient{baseURL: baseURL, client: &http.Client{Timeout: requestTimeout}}
+}
+
+// CreateGame calls POST /internal/create and returns the created game.
+func (c *HTTPClient) CreateGame(ctx context.Context, in CreateGameRequest) (CreateGameResponse, error) {
+	body, err := json.Marshal(in)
+	if err != nil {
+		return CreateGameResponse{}, fmt.Errorf("failed to encode request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/internal/create", bytes.NewReader(body))
+	if err != nil {
+		return CreateGameResponse{}, fmt.Errorf("failed to build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return CreateGameResponse{}, fmt.Errorf("failed to create game: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		return CreateGameResponse{}, fmt.Errorf("create game returned status %d", resp.StatusCode)
+	}
+
+	var out CreateGameResponse
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return CreateGameResponse{}, fmt.Errorf("failed to decode response: %w", err)
+	}
+	if out.GameID == uuid.Nil || len(out.TurnOrder) == 0 {
+		return CreateGameResponse{}, fmt.Errorf("create game returned an incomplete game")
+	}
+	return out, nil
+}
+
+// AbortGame calls POST /internal/games/{game_id}/abort.
+// A game that is unknown or already finished needs no abort and is not an error.
+func (c *HTTPClient) AbortGame(ctx context.Context, gameID uuid.UUID) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/internal/games/"+gameID.String()+"/abort", nil)
+	if err != nil {
+		return fmt.Errorf("failed to build request: %w", err)
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to abort game: %w", err)
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusNotFound, http.StatusConflict:
+		return nil
+	default:
+		return fmt.Errorf("abort game returned status %d", resp.StatusCode)
+	}
+}
+
+// LeaveGame calls POST /internal/games/{game_id}/players/{user_id}/leave so the game skips a player who left the lobby.
+// A game that is unknown or already finished has no turns left to skip and is not an error.
+func (c *HTTPClient) LeaveGame(ctx context.Context, gameID, userID uuid.UUID) error {
+	url := c.baseURL + "/internal/games/" + gameID.String() + "/players/" + userID.String() + "/leave"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to build request: %w", err)
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to leave game: %w", err)
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusNoContent, http.StatusNotFound, http.StatusConflict:
+		return nil
+	default:
+		return fmt.Errorf("leave game returned status %d", resp.StatusCode)
+	}
+}
+
+// ActivatePlayer calls POST /internal/games/{game_id}/players/{user_id}/activate so later turns include a player
+// that was flagged inactive, e.g. after a turn timeout.
+// A game that is unknown or already finished has no turns left and is not an error.
+func (c *HTTPClient) ActivatePlayer(ctx context.Context, gameID, userID uuid.UUID) error {
+	url := c.baseURL + "/internal/games/" + gameID.String() + "/players/" + userID.String() + "/activate"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to build request: %w", err)
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to activate player: %w", err)
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusNoContent, http.StatusNotFound, http.StatusConflict:
+		return nil
+	default:
+		return fmt.Errorf("activate player returned status %d", resp.StatusCode)
+	}
+}
+
+// SetLeader calls PUT /internal/games/{game_id}/leader so the new lobby leader may end the game.
+// A game that is unknown or already finished has nobody left to end it and is not an error.
+func (c *HTTPClient) SetLeader(ctx context.Context, gameID, leaderID uuid.UUID) error {
+	body, err := json.Marshal(SetLeaderRequest{LeaderID: leaderID})
+	if err != nil {
+		return fmt.Errorf("failed to encode request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, c.baseURL+"/internal/games/"+gameID.String()+"/leader", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to set game leader: %w", err)
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusNoContent, http.StatusNotFound, http.StatusConflict:
+		return nil
+	default:
+		return fmt.Errorf("set game leader returned status %d", resp.StatusCode)
+	}
+}
@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"database/sql"
+	"log/slog"
+	"net/http"
+
+	"github.com/KnuffelGame/KnuffelGame/backend/libs/httpx"
+	"github.com/KnuffelGame/KnuffelGame/backend/libs/logger"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/LobbyService/internal/repository"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// CheckMemberHandler returns an http.HandlerFunc that tells whether a user is a member of a lobby
+// Internal endpoint called by the SSE Service before it opens the event stream of a lobby
+// Path parameters: lobby_id (UUID), player_id (UUID)
+// Returns: 204 No Content for members that have not left, 403 if the user is not a member, 404 if the lobby does not exist
+func CheckMemberHandler(repo repository.Repository) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log := logger.Logger(r.Context()).WithGroup("handler").With(slog.String("action", "check_member"))
+
+		lobbyIDStr := chi.URLParam(r, "lobby_id")
+		lobbyID, err := uuid.Parse(lobbyIDStr)
+		if err != nil {
+			log.Warn("invalid lobby_id format", slog.String("lobby_id", lobbyIDStr))
+			httpx.WriteBadRequest(w, "Invalid lobby ID format", nil, log)
+			return
+		}
+
+		playerIDStr := chi.URLParam(r, "player_id")
+		playerID, err := uuid.Parse(playerIDStr)
+		if err != nil {
+			log.Warn("invalid player_id format", slog.String("player_id", playerIDStr))
+			httpx.WriteBadRequest(w, "Invalid player ID format", nil, log)
+			return
+		}
+
+		// 1. Distinguish unknown lobbies from non-members
+		if _, err := repo.GetLobbyLeaderID(r.Context(), lobbyID); err != nil {
+			if err == sql.ErrNoRows {
+				log.Info("lobby not found", slog.String("lobby_id", lobbyIDStr))
+				httpx.WriteNotFound(w, "Lobby not found", log)
+				return
+			}
+			log.Error("failed to query lobby", slog.String("error", err.Error()))
+			httpx.WriteInternalError(w, "Database error", nil, log)
+			return
+		}
+
+		// 2. Check membership
+		exists, err := repo.IsMember(r.Context(), lobbyID, playerID)
+		if err != nil {
+			log.Error("failed to query membership", slog.String("error", err.Error()))
+			httpx.WriteInternalError(w, "Database error", nil, log)
+			return
+		}
+		if !exists {
+			log.Info("user is not a member of lobby", slog.String("lobby_id", lobbyIDStr), slog.String("player_id", playerIDStr))
+			httpx.WriteForbidden(w, "User is not a member of the lobby", log)
+			return
+		}
+
+		httpx.WriteNoContent(w)
+	}
+}
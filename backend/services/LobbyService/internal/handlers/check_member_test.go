@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/LobbyService/internal/repository"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+func checkMember(h http.HandlerFunc, lobbyID, playerID uuid.UUID) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/internal/lobbies/"+lobbyID.String()+"/players/"+playerID.String(), nil)
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, &chi.Context{
+		URLParams: chi.RouteParams{Keys: []string{"lobby_id", "player_id"}, Values: []string{lobbyID.String(), playerID.String()}},
+	}))
+	rec := httptest.NewRecorder()
+	h(rec, req)
+	return rec
+}
+
+func TestCheckMember(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		member bool
+		want   int
+	}{
+		{"member", true, http.StatusNoContent},
+		{"not a member", false, http.StatusForbidden},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("failed to create mock DB: %v", err)
+			}
+			defer db.Close()
+
+			lobbyID, playerID := uuid.New(), uuid.New()
+			mock.ExpectQuery("SELECT leader_id::text FROM lobbies WHERE id =").
+				WithArgs(lobbyID).
+				WillReturnRows(sqlmock.NewRows([]string{"leader_id"}).AddRow(uuid.New().String()))
+			mock.ExpectQuery("SELECT EXISTS").
+				WithArgs(lobbyID, playerID).
+				WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(tc.member))
+
+			rec := checkMember(CheckMemberHandler(repository.New(db)), lobbyID, playerID)
+			if rec.Code != tc.want {
+				t.Fatalf("expected %d, got %d: %s", tc.want, rec.Code, rec.Body.String())
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Fatalf("unfulfilled expectations: %v", err)
+			}
+		})
+	}
+}
+
+func TestCheckMember_UnknownLobby(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock DB: %v", err)
+	}
+	defer db.Close()
+
+	lobbyID := uuid.New()
+	mock.ExpectQuery("SELECT leader_id::text FROM lobbies WHERE id =").
+		WithArgs(lobbyID).
+		WillReturnError(sql.ErrNoRows)
+
+	rec := checkMember(CheckMemberHandler(repository.New(db)), lobbyID, uuid.New())
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unfulfilled expectations: %v", err)
+	}
+}
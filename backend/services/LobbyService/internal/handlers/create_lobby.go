@@ -1,6 +1,10 @@
 package handlers
 
 import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"io"
 	"log/slog"
 	"net/http"
 
@@ -19,8 +23,9 @@ const (
 
 // CreateLobbyHandler returns an http.HandlerFunc that creates a new lobby
 // Headers required: X-User-ID, X-Username (from Gateway)
-// Creates user if not exists, generates join code, sets user as leader, adds user as first player
-func CreateLobbyHandler(repo repository.Repository, codeGen *joincode.Generator) http.HandlerFunc {
+// Optional request body: CreateLobbyRequest with settings; omitted settings use DefaultLobbySettings
+// Creates user if not exists, inserts the lobby with a join code from codes, sets user as leader, adds user as first player
+func CreateLobbyHandler(repo repository.Repository, codes joincode.Source) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		log := logger.Logger(r.Context()).WithGroup("handler").With(slog.String("action", "create_lobby"))
 
@@ -41,6 +46,23 @@ func CreateLobbyHandler(repo repository.Repository, codeGen *joincode.Generator)
 			return
 		}
 
+		// Parse the optional settings body
+		var req models.CreateLobbyRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+			log.Warn("failed to decode request body", slog.String("error", err.Error()))
+			httpx.WriteBadRequest(w, "Invalid request body", map[string]interface{}{"detail": err.Error()}, log)
+			return
+		}
+		settings := models.DefaultLobbySettings()
+		if req.Settings != nil {
+			var invalid map[string]interface{}
+			if settings, invalid = applySettings(settings, *req.Settings); invalid != nil {
+				log.Info("invalid settings")
+				httpx.WriteError(w, http.StatusBadRequest, "invalid_settings", "Invalid lobby settings", invalid, log)
+				return
+			}
+		}
+
 		// Begin transaction via repository
 		tx, err := repo.BeginTx(r.Context())
 		if err != nil {
@@ -57,23 +79,39 @@ func CreateLobbyHandler(repo repository.Repository, codeGen *joincode.Generator)
 			return
 		}
 
-		// 2. Generate unique join code
-		joinCode, err := codeGen.GenerateJoinCode()
-		if err != nil {
-			log.Error("failed to generate join code", slog.String("error", err.Error()))
-			httpx.WriteInternalError(w, "Failed to generate join code", nil, log)
-			return
+		// 2. Create lobby with user as leader; a taken join code is retried with the next one
+		var lobbyID uuid.UUID
+		var joinCode string
+		for attempt := 1; ; attempt++ {
+			if joinCode, err = codes.NextCode(); err != nil {
+				log.Error("failed to generate join code", slog.String("error", err.Error()))
+				httpx.WriteInternalError(w, "Failed to generate join code", nil, log)
+				return
+			}
+			lobbyID, err = repo.CreateLobbyTx(tx, joinCode, userID)
+			if err == nil {
+				break
+			}
+			if err != sql.ErrNoRows {
+				log.Error("failed to create lobby", slog.String("error", err.Error()))
+				httpx.WriteInternalError(w, "Failed to create lobby", nil, log)
+				return
+			}
+			if attempt == joincode.MaxRetries {
+				log.Error("failed to generate join code", slog.String("error", joincode.ErrMaxRetriesExceeded.Error()))
+				httpx.WriteInternalError(w, "Failed to generate join code", nil, log)
+				return
+			}
+			log.Info("join code taken, retrying", slog.Int("attempt", attempt))
 		}
 
-		// 3. Create lobby with user as leader
-		lobbyID, err := repo.CreateLobbyTx(tx, joinCode, userID)
-		if err != nil {
-			log.Error("failed to create lobby", slog.String("error", err.Error()))
+		if err := repo.CreateLobbySettingsTx(tx, lobbyID, settings); err != nil {
+			log.Error("failed to store lobby settings", slog.String("error", err.Error()))
 			httpx.WriteInternalError(w, "Failed to create lobby", nil, log)
 			return
 		}
 
-		// 4. Add user as first player in the lobby
+		// 3. Add user as first player in the lobby
 		playerID, joinedAt, err := repo.AddPlayerTx(tx, lobbyID, userID)
 		if err != nil {
 			log.Error("failed to add player to lobby", slog.String("error", err.Error()))
@@ -88,7 +126,7 @@ func CreateLobbyHandler(repo repository.Repository, codeGen *joincode.Generator)
 			return
 		}
 
-		// 5. Build response
+		// 4. Build response
 		response := models.CreateLobbyResponse{
 			LobbyID:  lobbyID,
 			JoinCode: joinCode,
@@ -103,6 +141,7 @@ func CreateLobbyHandler(repo repository.Repository, codeGen *joincode.Generator)
 					IsActive: true,
 				},
 			},
+			Settings: settings,
 		}
 
 		log.Info("lobby created successfully",
@@ -2,8 +2,10 @@ package handlers
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -25,15 +27,13 @@ func TestCreateLobby_Success(t *testing.T) {
 	userID := uuid.New()
 	username := "TestUser"
 
-	// Expectations: transaction begin, insert user (exec), joincode existence check, insert lobby returning id, insert player returning id and joined_at, commit
+	// Expectations: transaction begin, insert user (exec), insert lobby with the join code returning id, insert player returning id and joined_at, commit
 	mock.ExpectBegin()
 	mock.ExpectExec("INSERT INTO users").WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(1, 1))
 
-	// joincode generator checks for existence (use permissive regex)
-	mock.ExpectQuery("SELECT EXISTS").WithArgs(sqlmock.AnyArg()).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
-
 	lobbyID := uuid.New()
-	mock.ExpectQuery("INSERT INTO lobbies").WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(lobbyID.String()))
+	mock.ExpectQuery("INSERT INTO lobbies").WithArgs("ABC234", userID, models.LobbyStatusWaiting).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(lobbyID.String()))
+	mock.ExpectExec("INSERT INTO lobby_settings").WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(1, 1))
 
 	playerID := uuid.New()
 	joinedAt := time.Now()
@@ -42,7 +42,7 @@ func TestCreateLobby_Success(t *testing.T) {
 	)
 	mock.ExpectCommit()
 
-	codeGen := joincode.NewGenerator(db)
+	codeGen := joincode.Fixed("ABC234")
 	h := CreateLobbyHandler(repository.New(db), codeGen)
 
 	req := httptest.NewRequest(http.MethodPost, "/lobbies", nil)
@@ -68,8 +68,8 @@ func TestCreateLobby_Success(t *testing.T) {
 	if resp.JoinCode == "" {
 		t.Error("expected non-empty join_code")
 	}
-	if len(resp.JoinCode) != 6 {
-		t.Errorf("expected join_code length 6, got %d", len(resp.JoinCode))
+	if resp.JoinCode != "ABC234" {
+		t.Errorf("expected join_code ABC234, got %s", resp.JoinCode)
 	}
 	if resp.LeaderID != userID {
 		t.Errorf("expected leader_id %s, got %s", userID, resp.LeaderID)
@@ -113,7 +113,7 @@ func TestCreateLobby_MissingUserID(t *testing.T) {
 	}
 	defer db.Close()
 
-	codeGen := joincode.NewGenerator(db)
+	codeGen := joincode.Fixed()
 	h := CreateLobbyHandler(repository.New(db), codeGen)
 
 	req := httptest.NewRequest(http.MethodPost, "/lobbies", nil)
@@ -135,7 +135,7 @@ func TestCreateLobby_MissingUsername(t *testing.T) {
 	}
 	defer db.Close()
 
-	codeGen := joincode.NewGenerator(db)
+	codeGen := joincode.Fixed()
 	h := CreateLobbyHandler(repository.New(db), codeGen)
 
 	userID := uuid.New()
@@ -159,7 +159,7 @@ func TestCreateLobby_InvalidUserID(t *testing.T) {
 	}
 	defer db.Close()
 
-	codeGen := joincode.NewGenerator(db)
+	codeGen := joincode.Fixed()
 	h := CreateLobbyHandler(repository.New(db), codeGen)
 
 	req := httptest.NewRequest(http.MethodPost, "/lobbies", nil)
@@ -188,9 +188,9 @@ func TestCreateLobby_IdempotentUser(t *testing.T) {
 	// First lobby expectations
 	mock.ExpectBegin()
 	mock.ExpectExec("INSERT INTO users").WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(1, 1))
-	mock.ExpectQuery("SELECT EXISTS").WithArgs(sqlmock.AnyArg()).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
 	lobby1 := uuid.New()
 	mock.ExpectQuery("INSERT INTO lobbies").WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(lobby1.String()))
+	mock.ExpectExec("INSERT INTO lobby_settings").WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(1, 1))
 	player1 := uuid.New()
 	mock.ExpectQuery("INSERT INTO players").WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg()).WillReturnRows(sqlmock.NewRows([]string{"id", "joined_at"}).AddRow(player1.String(), time.Now()))
 	mock.ExpectCommit()
@@ -198,14 +198,14 @@ func TestCreateLobby_IdempotentUser(t *testing.T) {
 	// Second lobby expectations
 	mock.ExpectBegin()
 	mock.ExpectExec("INSERT INTO users").WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(1, 1))
-	mock.ExpectQuery("SELECT EXISTS").WithArgs(sqlmock.AnyArg()).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
 	lobby2 := uuid.New()
 	mock.ExpectQuery("INSERT INTO lobbies").WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(lobby2.String()))
+	mock.ExpectExec("INSERT INTO lobby_settings").WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(1, 1))
 	player2 := uuid.New()
 	mock.ExpectQuery("INSERT INTO players").WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg()).WillReturnRows(sqlmock.NewRows([]string{"id", "joined_at"}).AddRow(player2.String(), time.Now()))
 	mock.ExpectCommit()
 
-	codeGen := joincode.NewGenerator(db)
+	codeGen := joincode.Fixed("ABC234", "XYZ789")
 	h := CreateLobbyHandler(repository.New(db), codeGen)
 
 	// Create first lobby
@@ -251,3 +251,163 @@ func TestCreateLobby_IdempotentUser(t *testing.T) {
 		t.Fatalf("unfulfilled expectations: %v", err)
 	}
 }
+
+func TestCreateLobby_WithSettings(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock DB: %v", err)
+	}
+	defer db.Close()
+
+	userID := uuid.New()
+	lobbyID := uuid.New()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO users").WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectQuery("INSERT INTO lobbies").WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(lobbyID.String()))
+	// omitted fields keep their defaults
+	mock.ExpectExec("INSERT INTO lobby_settings").WithArgs(lobbyID, 4, 40, true, models.VariantFreeJoker, false).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectQuery("INSERT INTO players").WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg()).WillReturnRows(
+		sqlmock.NewRows([]string{"id", "joined_at"}).AddRow(uuid.New().String(), time.Now()),
+	)
+	mock.ExpectCommit()
+
+	h := CreateLobbyHandler(repository.New(db), joincode.Fixed("ABC234"))
+
+	req := httptest.NewRequest(http.MethodPost, "/lobbies", strings.NewReader(`{"settings":{"max_players":4,"variant":"free_joker"}}`))
+	req.Header.Set(headerUserID, userID.String())
+	req.Header.Set(headerUsername, "TestUser")
+	req.Header.Set("Content-Type", "application/json")
+
+	rec := httptest.NewRecorder()
+	h(rec, req)
+
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var resp models.CreateLobbyResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	want := models.LobbySettings{MaxPlayers: 4, TurnTimeoutSeconds: 40, IsPrivate: true, Variant: models.VariantFreeJoker}
+	if resp.Settings != want {
+		t.Errorf("expected settings %+v, got %+v", want, resp.Settings)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestCreateLobby_InvalidSettings(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock DB: %v", err)
+	}
+	defer db.Close()
+
+	h := CreateLobbyHandler(repository.New(db), joincode.Fixed())
+
+	req := httptest.NewRequest(http.MethodPost, "/lobbies", strings.NewReader(`{"settings":{"max_players":11,"turn_timeout_seconds":5}}`))
+	req.Header.Set(headerUserID, uuid.New().String())
+	req.Header.Set(headerUsername, "TestUser")
+	req.Header.Set("Content-Type", "application/json")
+
+	rec := httptest.NewRecorder()
+	h(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var resp map[string]interface{}
+	_ = json.Unmarshal(rec.Body.Bytes(), &resp)
+	details, _ := resp["details"].(map[string]interface{})
+	if resp["error"] != "invalid_settings" || details["max_players"] == nil || details["turn_timeout_seconds"] == nil {
+		t.Fatalf("expected invalid_settings for both fields, got %+v", resp)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestCreateLobby_JoinCodeTaken(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock DB: %v", err)
+	}
+	defer db.Close()
+
+	userID := uuid.New()
+	lobbyID := uuid.New()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO users").WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(1, 1))
+	// the first code is taken, the insert returns no row
+	mock.ExpectQuery("INSERT INTO lobbies .* ON CONFLICT \\(join_code\\) WHERE status <> 'finished' DO NOTHING").
+		WithArgs("ABC234", userID, models.LobbyStatusWaiting).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectQuery("INSERT INTO lobbies").WithArgs("XYZ789", userID, models.LobbyStatusWaiting).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(lobbyID.String()))
+	mock.ExpectExec("INSERT INTO lobby_settings").WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectQuery("INSERT INTO players").WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg()).WillReturnRows(
+		sqlmock.NewRows([]string{"id", "joined_at"}).AddRow(uuid.New().String(), time.Now()),
+	)
+	mock.ExpectCommit()
+
+	h := CreateLobbyHandler(repository.New(db), joincode.Fixed("ABC234", "XYZ789"))
+
+	req := httptest.NewRequest(http.MethodPost, "/lobbies", nil)
+	req.Header.Set(headerUserID, userID.String())
+	req.Header.Set(headerUsername, "TestUser")
+
+	rec := httptest.NewRecorder()
+	h(rec, req)
+
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var resp models.CreateLobbyResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	if resp.JoinCode != "XYZ789" || resp.LobbyID != lobbyID {
+		t.Errorf("expected lobby %s with join_code XYZ789, got %s with %s", lobbyID, resp.LobbyID, resp.JoinCode)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestCreateLobby_JoinCodeRetriesExceeded(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock DB: %v", err)
+	}
+	defer db.Close()
+
+	// every code is taken; the source would have one more
+	codes := make([]string, joincode.MaxRetries+1)
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO users").WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(1, 1))
+	for i := range codes {
+		codes[i] = fmt.Sprintf("CODE%02d", i)
+	}
+	for _, code := range codes[:joincode.MaxRetries] {
+		mock.ExpectQuery("INSERT INTO lobbies").WithArgs(code, sqlmock.AnyArg(), sqlmock.AnyArg()).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	}
+	mock.ExpectRollback()
+
+	h := CreateLobbyHandler(repository.New(db), joincode.Fixed(codes...))
+
+	req := httptest.NewRequest(http.MethodPost, "/lobbies", nil)
+	req.Header.Set(headerUserID, uuid.New().String())
+	req.Header.Set(headerUsername, "TestUser")
+
+	rec := httptest.NewRecorder()
+	h(rec, req)
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("expected 500, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unfulfilled expectations: %v", err)
+	}
+}
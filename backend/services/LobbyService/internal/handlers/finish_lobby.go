@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/KnuffelGame/KnuffelGame/backend/libs/httpx"
+	"github.com/KnuffelGame/KnuffelGame/backend/libs/logger"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/LobbyService/internal/models"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/LobbyService/internal/repository"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// FinishLobbyHandler returns an http.HandlerFunc that finishes a running lobby once its game has ended
+// Internal endpoint called by the Game Service; the join code is free again and the janitor deletes the lobby
+// after its retention. Clients learn about the end through game_ended on the game stream.
+// Path parameter: lobby_id (UUID)
+// Request body: FinishLobbyRequest with game_id
+// Returns: 204 No Content (also if the lobby is already finished), 404 if the lobby does not exist,
+// 409 if game_id is not the game of the lobby
+func FinishLobbyHandler(repo repository.Repository) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log := logger.Logger(r.Context()).WithGroup("handler").With(slog.String("action", "finish_lobby"))
+
+		lobbyIDStr := chi.URLParam(r, "lobby_id")
+		lobbyID, err := uuid.Parse(lobbyIDStr)
+		if err != nil {
+			log.Warn("invalid lobby_id format", slog.String("lobby_id", lobbyIDStr))
+			httpx.WriteBadRequest(w, "Invalid lobby ID format", nil, log)
+			return
+		}
+
+		var req models.FinishLobbyRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			log.Warn("failed to decode request body", slog.String("error", err.Error()))
+			httpx.WriteBadRequest(w, "Invalid request body", map[string]interface{}{"detail": err.Error()}, log)
+			return
+		}
+		if req.GameID == uuid.Nil {
+			log.Warn("missing game_id")
+			httpx.WriteBadRequest(w, "game_id is required", nil, log)
+			return
+		}
+
+		tx, err := repo.BeginTx(r.Context())
+		if err != nil {
+			log.Error("failed to begin transaction", slog.String("error", err.Error()))
+			httpx.WriteInternalError(w, "Database error", nil, log)
+			return
+		}
+		defer tx.Rollback()
+
+		// 1. Lock the lobby and check that the game is its own
+		lobby, err := repo.GetLobbyForUpdateTx(tx, lobbyID)
+		if err == sql.ErrNoRows {
+			log.Info("lobby not found", slog.String("lobby_id", lobbyID.String()))
+			httpx.WriteNotFound(w, "Lobby not found", log)
+			return
+		}
+		if err != nil {
+			log.Error("failed to get lobby", slog.String("error", err.Error()), slog.String("lobby_id", lobbyID.String()))
+			httpx.WriteInternalError(w, "Database error", nil, log)
+			return
+		}
+		if lobby.GameID == nil || *lobby.GameID != req.GameID {
+			log.Warn("game does not belong to lobby", slog.String("lobby_id", lobbyID.String()), slog.String("game_id", req.GameID.String()))
+			httpx.WriteError(w, http.StatusConflict, "game_mismatch", "Game is not the current game of the lobby", nil, log)
+			return
+		}
+		if lobby.Status == models.LobbyStatusFinished {
+			httpx.WriteNoContent(w)
+			return
+		}
+
+		// 2. Finish the lobby
+		if err := repo.SetLobbyStatusTx(tx, lobbyID, models.LobbyStatusFinished); err != nil {
+			log.Error("failed to finish lobby", slog.String("error", err.Error()), slog.String("lobby_id", lobbyID.String()))
+			httpx.WriteInternalError(w, "Database error", nil, log)
+			return
+		}
+
+		if err := tx.Commit(); err != nil {
+			log.Error("failed to commit transaction", slog.String("error", err.Error()))
+			httpx.WriteInternalError(w, "Database error", nil, log)
+			return
+		}
+
+		log.Info("lobby finished", slog.String("lobby_id", lobbyID.String()), slog.String("game_id", req.GameID.String()))
+
+		httpx.WriteNoContent(w)
+	}
+}
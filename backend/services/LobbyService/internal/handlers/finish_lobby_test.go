@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/LobbyService/internal/models"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/LobbyService/internal/repository"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+func finishLobby(h http.HandlerFunc, lobbyID, gameID uuid.UUID) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/internal/lobbies/"+lobbyID.String()+"/finish", strings.NewReader(`{"game_id":"`+gameID.String()+`"}`))
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, &chi.Context{
+		URLParams: chi.RouteParams{Keys: []string{"lobby_id"}, Values: []string{lobbyID.String()}},
+	}))
+	rec := httptest.NewRecorder()
+	h(rec, req)
+	return rec
+}
+
+func expectFinishLobby(mock sqlmock.Sqlmock, lobbyID uuid.UUID, status string, gameID interface{}) {
+	now := time.Now()
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT id, join_code, leader_id, status, game_id, created_at, updated_at FROM lobbies WHERE id = \\$1 FOR UPDATE").
+		WithArgs(lobbyID).
+		WillReturnRows(sqlmock.NewRows(lobbyColumns).AddRow(lobbyID, "ABC123", uuid.New(), status, gameID, now, now))
+}
+
+func TestFinishLobby(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock DB: %v", err)
+	}
+	defer db.Close()
+
+	lobbyID, gameID := uuid.New(), uuid.New()
+	expectFinishLobby(mock, lobbyID, models.LobbyStatusInGame, gameID)
+	mock.ExpectExec("UPDATE lobbies").WithArgs(models.LobbyStatusFinished, lobbyID).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	rec := finishLobby(FinishLobbyHandler(repository.New(db)), lobbyID, gameID)
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestFinishLobby_AlreadyFinished(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock DB: %v", err)
+	}
+	defer db.Close()
+
+	lobbyID, gameID := uuid.New(), uuid.New()
+	expectFinishLobby(mock, lobbyID, models.LobbyStatusFinished, gameID)
+	mock.ExpectRollback()
+
+	rec := finishLobby(FinishLobbyHandler(repository.New(db)), lobbyID, gameID)
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestFinishLobby_OtherGame(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock DB: %v", err)
+	}
+	defer db.Close()
+
+	lobbyID := uuid.New()
+	expectFinishLobby(mock, lobbyID, models.LobbyStatusInGame, uuid.New())
+	mock.ExpectRollback()
+
+	rec := finishLobby(FinishLobbyHandler(repository.New(db)), lobbyID, uuid.New())
+	if rec.Code != http.StatusConflict {
+		t.Fatalf("expected 409, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unfulfilled expectations: %v", err)
+	}
+}
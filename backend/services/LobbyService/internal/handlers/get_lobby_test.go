@@ -35,8 +35,8 @@ func TestGetLobby_Success(t *testing.T) {
 	joinedAt := time.Now()
 
 	// Expect query and return one row
-	columns := []string{"lobby_id", "join_code", "status", "leader_id", "player_id", "user_id", "username", "joined_at", "is_active"}
-	rows := sqlmock.NewRows(columns).AddRow(lobbyID.String(), joinCode, models.LobbyStatusWaiting, userID.String(), playerID.String(), userID.String(), username, joinedAt, true)
+	columns := []string{"lobby_id", "join_code", "status", "leader_id", "player_id", "user_id", "username", "joined_at", "is_active", "left_at", "is_ready", "max_players", "turn_timeout_seconds", "is_private", "variant", "require_ready"}
+	rows := sqlmock.NewRows(columns).AddRow(lobbyID.String(), joinCode, models.LobbyStatusWaiting, userID.String(), playerID.String(), userID.String(), username, joinedAt, true, nil, false, 6, 40, true, "classic", false)
 	mock.ExpectQuery("SELECT").WithArgs(lobbyID.String()).WillReturnRows(rows)
 
 	// Create request
@@ -117,11 +117,11 @@ func TestGetLobby_MultiplePlayersLeaderIdentified(t *testing.T) {
 	joinedAt2 := time.Now().Add(-2 * time.Minute)
 	joinedAt3 := time.Now().Add(-1 * time.Minute)
 
-	columns := []string{"lobby_id", "join_code", "status", "leader_id", "player_id", "user_id", "username", "joined_at", "is_active"}
+	columns := []string{"lobby_id", "join_code", "status", "leader_id", "player_id", "user_id", "username", "joined_at", "is_active", "left_at", "is_ready", "max_players", "turn_timeout_seconds", "is_private", "variant", "require_ready"}
 	rows := sqlmock.NewRows(columns).
-		AddRow(lobbyID.String(), joinCode, models.LobbyStatusWaiting, leaderID.String(), uuid.New().String(), leaderID.String(), leaderName, joinedAt1, true).
-		AddRow(lobbyID.String(), joinCode, models.LobbyStatusWaiting, leaderID.String(), uuid.New().String(), player2ID.String(), player2Name, joinedAt2, true).
-		AddRow(lobbyID.String(), joinCode, models.LobbyStatusWaiting, leaderID.String(), uuid.New().String(), player3ID.String(), player3Name, joinedAt3, true)
+		AddRow(lobbyID.String(), joinCode, models.LobbyStatusWaiting, leaderID.String(), uuid.New().String(), leaderID.String(), leaderName, joinedAt1, true, nil, false, 6, 40, true, "classic", false).
+		AddRow(lobbyID.String(), joinCode, models.LobbyStatusWaiting, leaderID.String(), uuid.New().String(), player2ID.String(), player2Name, joinedAt2, true, nil, false, 6, 40, true, "classic", false).
+		AddRow(lobbyID.String(), joinCode, models.LobbyStatusWaiting, leaderID.String(), uuid.New().String(), player3ID.String(), player3Name, joinedAt3, true, nil, false, 6, 40, true, "classic", false)
 
 	mock.ExpectQuery("SELECT").WithArgs(lobbyID.String()).WillReturnRows(rows)
 
@@ -184,10 +184,10 @@ func TestGetLobby_WithInactivePlayers(t *testing.T) {
 	joinedAt1 := time.Now().Add(-10 * time.Minute)
 	joinedAt2 := time.Now().Add(-5 * time.Minute)
 
-	columns := []string{"lobby_id", "join_code", "status", "leader_id", "player_id", "user_id", "username", "joined_at", "is_active"}
+	columns := []string{"lobby_id", "join_code", "status", "leader_id", "player_id", "user_id", "username", "joined_at", "is_active", "left_at", "is_ready", "max_players", "turn_timeout_seconds", "is_private", "variant", "require_ready"}
 	rows := sqlmock.NewRows(columns).
-		AddRow(lobbyID.String(), joinCode, models.LobbyStatusInGame, leaderID.String(), uuid.New().String(), leaderID.String(), "Leader", joinedAt1, true).
-		AddRow(lobbyID.String(), joinCode, models.LobbyStatusInGame, leaderID.String(), uuid.New().String(), inactivePlayerID.String(), "InactivePlayer", joinedAt2, false)
+		AddRow(lobbyID.String(), joinCode, models.LobbyStatusInGame, leaderID.String(), uuid.New().String(), leaderID.String(), "Leader", joinedAt1, true, nil, false, 6, 40, true, "classic", false).
+		AddRow(lobbyID.String(), joinCode, models.LobbyStatusInGame, leaderID.String(), uuid.New().String(), inactivePlayerID.String(), "InactivePlayer", joinedAt2, false, nil, false, 6, 40, true, "classic", false)
 
 	mock.ExpectQuery("SELECT").WithArgs(lobbyID.String()).WillReturnRows(rows)
 
@@ -256,7 +256,7 @@ func TestGetLobby_NotFound(t *testing.T) {
 	nonExistentLobbyID := uuid.New()
 
 	// Expect query but return no rows
-	columns := []string{"lobby_id", "join_code", "status", "leader_id", "player_id", "user_id", "username", "joined_at", "is_active"}
+	columns := []string{"lobby_id", "join_code", "status", "leader_id", "player_id", "user_id", "username", "joined_at", "is_active", "left_at", "is_ready", "max_players", "turn_timeout_seconds", "is_private", "variant", "require_ready"}
 	mock.ExpectQuery("SELECT").WithArgs(nonExistentLobbyID.String()).WillReturnRows(sqlmock.NewRows(columns))
 
 	req := httptest.NewRequest(http.MethodGet, "/lobbies/"+nonExistentLobbyID.String(), nil)
@@ -374,9 +374,9 @@ func TestGetLobby_ForbiddenForNonMember(t *testing.T) {
 	lobbyID := uuid.New()
 
 	// Return rows showing only member is in lobby
-	columns := []string{"lobby_id", "join_code", "status", "leader_id", "player_id", "user_id", "username", "joined_at", "is_active"}
+	columns := []string{"lobby_id", "join_code", "status", "leader_id", "player_id", "user_id", "username", "joined_at", "is_active", "left_at", "is_ready", "max_players", "turn_timeout_seconds", "is_private", "variant", "require_ready"}
 	rows := sqlmock.NewRows(columns).
-		AddRow(lobbyID.String(), "FORBID", models.LobbyStatusWaiting, memberID.String(), uuid.New().String(), memberID.String(), "Member", time.Now(), true)
+		AddRow(lobbyID.String(), "FORBID", models.LobbyStatusWaiting, memberID.String(), uuid.New().String(), memberID.String(), "Member", time.Now(), true, nil, false, 6, 40, true, "classic", false)
 
 	mock.ExpectQuery("SELECT").WithArgs(lobbyID.String()).WillReturnRows(rows)
 
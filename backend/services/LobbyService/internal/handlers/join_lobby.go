@@ -3,24 +3,25 @@ package handlers
 import (
 	"database/sql"
 	"encoding/json"
+	"fmt"
 	"log/slog"
 	"net/http"
+	"strings"
 
 	"github.com/KnuffelGame/KnuffelGame/backend/libs/httpx"
 	"github.com/KnuffelGame/KnuffelGame/backend/libs/logger"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/LobbyService/internal/events"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/LobbyService/internal/joincode"
 	"github.com/KnuffelGame/KnuffelGame/backend/services/LobbyService/internal/models"
 	"github.com/KnuffelGame/KnuffelGame/backend/services/LobbyService/internal/repository"
 	"github.com/google/uuid"
 )
 
-const (
-	maxPlayers = 6
-)
-
 // JoinLobbyHandler returns an http.HandlerFunc that joins an existing lobby by join code
 // Headers required: X-User-ID, X-Username (from Gateway)
 // Request body: JoinLobbyRequest with join_code field
-// Returns: LobbyDetailResponse on success, various error responses on failure
+// player_joined is written to the event outbox in the same transaction.
+// Returns: LobbyDetailResponse on success, 403 banned_from_lobby for banned users, various error responses on failure
 func JoinLobbyHandler(repo repository.Repository) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		log := logger.Logger(r.Context()).WithGroup("handler").With(slog.String("action", "join_lobby"))
@@ -50,10 +51,11 @@ func JoinLobbyHandler(repo repository.Repository) http.HandlerFunc {
 			return
 		}
 
-		// Validate join code format (6 characters, alphanumeric)
-		if len(req.JoinCode) != 6 {
+		// Validate join code format; codes are stored uppercase and their length is configurable
+		req.JoinCode = strings.ToUpper(strings.TrimSpace(req.JoinCode))
+		if len(req.JoinCode) < joincode.MinLength || len(req.JoinCode) > joincode.MaxLength {
 			log.Warn("invalid join code format", slog.String("join_code", req.JoinCode))
-			httpx.WriteBadRequest(w, "Join code must be exactly 6 characters", nil, log)
+			httpx.WriteBadRequest(w, fmt.Sprintf("Join code must be %d to %d characters", joincode.MinLength, joincode.MaxLength), nil, log)
 			return
 		}
 
@@ -79,14 +81,47 @@ func JoinLobbyHandler(repo repository.Repository) http.HandlerFunc {
 			return
 		}
 
-		// 2. Validate lobby status is "waiting"
+		// 2. Lock the lobby, so concurrent joins cannot both take its last seat
+		lobby, err = repo.GetLobbyForUpdateTx(tx, lobby.ID)
+		if err == sql.ErrNoRows {
+			log.Info("lobby deleted while joining", slog.String("join_code", req.JoinCode))
+			httpx.WriteNotFound(w, "No lobby found with join code: "+req.JoinCode, log)
+			return
+		}
+		if err != nil {
+			log.Error("failed to lock lobby", slog.String("error", err.Error()), slog.String("join_code", req.JoinCode))
+			httpx.WriteInternalError(w, "Database error", nil, log)
+			return
+		}
+
+		// 3. Validate lobby status is "waiting"
 		if lobby.Status != models.LobbyStatusWaiting {
 			log.Info("lobby not joinable", slog.String("lobby_id", lobby.ID.String()), slog.String("status", lobby.Status))
 			httpx.WriteError(w, http.StatusConflict, "lobby_not_joinable", "Cannot join lobby - game already started", nil, log)
 			return
 		}
 
-		// 3. Check player count is less than max
+		// 4. Banned users cannot join again
+		banned, err := repo.IsBannedTx(tx, lobby.ID, userID)
+		if err != nil {
+			log.Error("failed to check ban", slog.String("error", err.Error()), slog.String("lobby_id", lobby.ID.String()))
+			httpx.WriteInternalError(w, "Database error", nil, log)
+			return
+		}
+		if banned {
+			log.Info("user banned from lobby", slog.String("lobby_id", lobby.ID.String()), slog.String("user_id", userID.String()))
+			httpx.WriteError(w, http.StatusForbidden, "banned_from_lobby", "You are banned from this lobby", nil, log)
+			return
+		}
+
+		// 5. Check player count is less than the lobby's max_players; players that left do not count, inactive ones do
+		settings, err := repo.GetLobbySettingsTx(tx, lobby.ID)
+		if err != nil {
+			log.Error("failed to get settings", slog.String("error", err.Error()), slog.String("lobby_id", lobby.ID.String()))
+			httpx.WriteInternalError(w, "Database error", nil, log)
+			return
+		}
+
 		playerCount, err := repo.GetLobbyPlayerCountTx(tx, lobby.ID)
 		if err != nil {
 			log.Error("failed to get player count", slog.String("error", err.Error()), slog.String("lobby_id", lobby.ID.String()))
@@ -94,13 +129,13 @@ func JoinLobbyHandler(repo repository.Repository) http.HandlerFunc {
 			return
 		}
 
-		if playerCount >= maxPlayers {
+		if playerCount >= settings.MaxPlayers {
 			log.Info("lobby is full", slog.String("lobby_id", lobby.ID.String()), slog.Int("player_count", playerCount))
-			httpx.WriteError(w, http.StatusConflict, "lobby_full", "Lobby has reached maximum capacity (6 players)", nil, log)
+			httpx.WriteError(w, http.StatusConflict, "lobby_full", fmt.Sprintf("Lobby has reached maximum capacity (%d players)", settings.MaxPlayers), nil, log)
 			return
 		}
 
-		// 4. Check if user is already in lobby
+		// 6. Check if user is already in lobby
 		isMember, err := repo.IsMemberTx(tx, lobby.ID, userID)
 		if err != nil {
 			log.Error("failed to check membership", slog.String("error", err.Error()), slog.String("lobby_id", lobby.ID.String()))
@@ -114,20 +149,32 @@ func JoinLobbyHandler(repo repository.Repository) http.HandlerFunc {
 			return
 		}
 
-		// 5. Create user entry if not exists
+		// 7. Create user entry if not exists
 		if err := repo.CreateUserIfNotExistsTx(tx, userID, username); err != nil {
 			log.Error("failed to insert user", slog.String("error", err.Error()), slog.String("user_id", userID.String()))
 			httpx.WriteInternalError(w, "Failed to create user", nil, log)
 			return
 		}
 
-		// 6. Add user as player to lobby
+		// 8. Add user as player to lobby
 		if _, _, err := repo.AddPlayerTx(tx, lobby.ID, userID); err != nil {
 			log.Error("failed to add player to lobby", slog.String("error", err.Error()))
 			httpx.WriteInternalError(w, "Failed to add player to lobby", nil, log)
 			return
 		}
 
+		// 9. Announce the new player; the outbox delivers the event once the transaction commits
+		if err := repo.EnqueueEventTx(tx, events.Event{
+			TargetType: events.TargetLobby,
+			TargetID:   lobby.ID.String(),
+			EventType:  models.EventPlayerJoined,
+			Data:       models.PlayerJoinedEvent{UserID: userID, Username: username, PlayerCount: playerCount + 1},
+		}); err != nil {
+			log.Error("failed to enqueue event", slog.String("error", err.Error()), slog.String("lobby_id", lobby.ID.String()))
+			httpx.WriteInternalError(w, "Database error", nil, log)
+			return
+		}
+
 		// Commit transaction
 		if err := tx.Commit(); err != nil {
 			log.Error("failed to commit transaction", slog.String("error", err.Error()))
@@ -135,7 +182,7 @@ func JoinLobbyHandler(repo repository.Repository) http.HandlerFunc {
 			return
 		}
 
-		// 7. Get updated lobby details to return
+		// 10. Get updated lobby details to return
 		lobbyDetail, err := repo.GetLobbyDetail(r.Context(), lobby.ID)
 		if err != nil {
 			log.Error("failed to get lobby details after joining", slog.String("error", err.Error()))
@@ -41,12 +41,16 @@ func TestJoinLobby_Success(t *testing.T) {
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
-	mock.ExpectQuery("SELECT id, join_code, leader_id, status, created_at, updated_at FROM lobbies WHERE join_code =").
-		WithArgs(joinCode).
+	mock.ExpectQuery("SELECT id, join_code, leader_id, status, created_at, updated_at FROM lobbies WHERE join_code = \\$1 AND status <> \\$2").
+		WithArgs(joinCode, models.LobbyStatusFinished).
 		WillReturnRows(sqlmock.NewRows([]string{"id", "join_code", "leader_id", "status", "created_at", "updated_at"}).
 			AddRow(lobby.ID, lobby.JoinCode, lobby.LeaderID, lobby.Status, lobby.CreatedAt, lobby.UpdatedAt))
+	expectLockLobby(mock, lobby.ID, lobby.Status)
 
 	// Get player count (currently 2 players in lobby)
+	expectNotBanned(mock, lobbyID, userID)
+	expectSettings(mock, lobbyID, 6)
+
 	mock.ExpectQuery("SELECT COUNT").
 		WithArgs(lobbyID).
 		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
@@ -66,12 +70,15 @@ func TestJoinLobby_Success(t *testing.T) {
 		WithArgs(lobbyID, userID).
 		WillReturnRows(sqlmock.NewRows([]string{"id", "joined_at"}).AddRow(playerID.String(), joinedAt))
 
+	// Announce the new player in the same transaction
+	expectEnqueue(mock, lobbyID, models.EventPlayerJoined, nil, payloadContains(`"player_count":3`))
+
 	mock.ExpectCommit()
 
 	// Get lobby detail after commit
 	mock.ExpectQuery("SELECT.*lobbies l.*").WithArgs(lobbyID).WillReturnRows(
-		sqlmock.NewRows([]string{"lobby_id", "join_code", "status", "leader_id", "player_id", "user_id", "username", "joined_at", "is_active"}).
-			AddRow(lobbyID, joinCode, models.LobbyStatusWaiting, lobby.LeaderID, playerID.String(), userID.String(), username, joinedAt, true),
+		sqlmock.NewRows([]string{"lobby_id", "join_code", "status", "leader_id", "player_id", "user_id", "username", "joined_at", "is_active", "left_at", "is_ready", "max_players", "turn_timeout_seconds", "is_private", "variant", "require_ready"}).
+			AddRow(lobbyID, joinCode, models.LobbyStatusWaiting, lobby.LeaderID, playerID.String(), userID.String(), username, joinedAt, true, nil, false, 6, 40, true, "classic", false),
 	)
 
 	h := JoinLobbyHandler(repository.New(db))
@@ -223,9 +230,12 @@ func TestJoinLobby_InvalidJoinCodeFormat(t *testing.T) {
 	}
 
 	// Join code too long
-	reqBody2 := models.JoinLobbyRequest{JoinCode: "ABC1234"}
+	reqBody2 := models.JoinLobbyRequest{JoinCode: "ABCDEFGHJKLMN"}
 	bodyBytes2, _ := json.Marshal(reqBody2)
 	req2 := httptest.NewRequest(http.MethodPost, "/lobbies/join", bytes.NewReader(bodyBytes2))
+	req2.Header.Set(headerUserID, userID.String())
+	req2.Header.Set(headerUsername, "TestUser")
+	req2.Header.Set("Content-Type", "application/json")
 
 	rec2 := httptest.NewRecorder()
 	h(rec2, req2)
@@ -247,14 +257,15 @@ func TestJoinLobby_LobbyNotFound(t *testing.T) {
 	joinCode := "INVALD"
 
 	mock.ExpectBegin()
-	mock.ExpectQuery("SELECT id, join_code, leader_id, status, created_at, updated_at FROM lobbies WHERE join_code =").
-		WithArgs(joinCode).
+	mock.ExpectQuery("SELECT id, join_code, leader_id, status, created_at, updated_at FROM lobbies WHERE join_code = \\$1 AND status <> \\$2").
+		WithArgs(joinCode, models.LobbyStatusFinished).
 		WillReturnError(sql.ErrNoRows)
 	mock.ExpectRollback()
 
 	h := JoinLobbyHandler(repository.New(db))
 
-	reqBody := models.JoinLobbyRequest{JoinCode: joinCode}
+	// codes are trimmed and matched in uppercase
+	reqBody := models.JoinLobbyRequest{JoinCode: " invald "}
 	bodyBytes, _ := json.Marshal(reqBody)
 	req := httptest.NewRequest(http.MethodPost, "/lobbies/join", bytes.NewReader(bodyBytes))
 	req.Header.Set(headerUserID, userID.String())
@@ -296,10 +307,11 @@ func TestJoinLobby_LobbyNotJoinable(t *testing.T) {
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
-	mock.ExpectQuery("SELECT id, join_code, leader_id, status, created_at, updated_at FROM lobbies WHERE join_code =").
-		WithArgs(joinCode).
+	mock.ExpectQuery("SELECT id, join_code, leader_id, status, created_at, updated_at FROM lobbies WHERE join_code = \\$1 AND status <> \\$2").
+		WithArgs(joinCode, models.LobbyStatusFinished).
 		WillReturnRows(sqlmock.NewRows([]string{"id", "join_code", "leader_id", "status", "created_at", "updated_at"}).
 			AddRow(lobby.ID, lobby.JoinCode, lobby.LeaderID, lobby.Status, lobby.CreatedAt, lobby.UpdatedAt))
+	expectLockLobby(mock, lobby.ID, lobby.Status)
 
 	mock.ExpectRollback()
 
@@ -324,6 +336,43 @@ func TestJoinLobby_LobbyNotJoinable(t *testing.T) {
 	}
 }
 
+func TestJoinLobby_LobbyStartedWhileJoining(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock DB: %v", err)
+	}
+	defer db.Close()
+
+	userID := uuid.New()
+	lobbyID := uuid.New()
+	joinCode := "ABC123"
+
+	// the lookup still sees the lobby waiting, the locked row shows the started game
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT id, join_code, leader_id, status, created_at, updated_at FROM lobbies WHERE join_code = \\$1 AND status <> \\$2").
+		WithArgs(joinCode, models.LobbyStatusFinished).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "join_code", "leader_id", "status", "created_at", "updated_at"}).
+			AddRow(lobbyID, joinCode, uuid.New(), models.LobbyStatusWaiting, time.Now(), time.Now()))
+	expectLockLobby(mock, lobbyID, models.LobbyStatusInGame)
+	mock.ExpectRollback()
+
+	bodyBytes, _ := json.Marshal(models.JoinLobbyRequest{JoinCode: joinCode})
+	req := httptest.NewRequest(http.MethodPost, "/lobbies/join", bytes.NewReader(bodyBytes))
+	req.Header.Set(headerUserID, userID.String())
+	req.Header.Set(headerUsername, "TestUser")
+	req.Header.Set("Content-Type", "application/json")
+
+	rec := httptest.NewRecorder()
+	JoinLobbyHandler(repository.New(db))(rec, req)
+
+	if rec.Code != http.StatusConflict {
+		t.Fatalf("expected 409, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unfulfilled expectations: %v", err)
+	}
+}
+
 func TestJoinLobby_LobbyFull(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	if err != nil {
@@ -347,12 +396,16 @@ func TestJoinLobby_LobbyFull(t *testing.T) {
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
-	mock.ExpectQuery("SELECT id, join_code, leader_id, status, created_at, updated_at FROM lobbies WHERE join_code =").
-		WithArgs(joinCode).
+	mock.ExpectQuery("SELECT id, join_code, leader_id, status, created_at, updated_at FROM lobbies WHERE join_code = \\$1 AND status <> \\$2").
+		WithArgs(joinCode, models.LobbyStatusFinished).
 		WillReturnRows(sqlmock.NewRows([]string{"id", "join_code", "leader_id", "status", "created_at", "updated_at"}).
 			AddRow(lobby.ID, lobby.JoinCode, lobby.LeaderID, lobby.Status, lobby.CreatedAt, lobby.UpdatedAt))
+	expectLockLobby(mock, lobby.ID, lobby.Status)
 
 	// Get player count (6 players - full)
+	expectNotBanned(mock, lobbyID, userID)
+	expectSettings(mock, lobbyID, 6)
+
 	mock.ExpectQuery("SELECT COUNT").
 		WithArgs(lobbyID).
 		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(6))
@@ -403,12 +456,16 @@ func TestJoinLobby_UserAlreadyInLobby(t *testing.T) {
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
-	mock.ExpectQuery("SELECT id, join_code, leader_id, status, created_at, updated_at FROM lobbies WHERE join_code =").
-		WithArgs(joinCode).
+	mock.ExpectQuery("SELECT id, join_code, leader_id, status, created_at, updated_at FROM lobbies WHERE join_code = \\$1 AND status <> \\$2").
+		WithArgs(joinCode, models.LobbyStatusFinished).
 		WillReturnRows(sqlmock.NewRows([]string{"id", "join_code", "leader_id", "status", "created_at", "updated_at"}).
 			AddRow(lobby.ID, lobby.JoinCode, lobby.LeaderID, lobby.Status, lobby.CreatedAt, lobby.UpdatedAt))
+	expectLockLobby(mock, lobby.ID, lobby.Status)
 
 	// Get player count (2 players)
+	expectNotBanned(mock, lobbyID, userID)
+	expectSettings(mock, lobbyID, 6)
+
 	mock.ExpectQuery("SELECT COUNT").
 		WithArgs(lobbyID).
 		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
@@ -453,8 +510,8 @@ func TestJoinLobby_DatabaseError(t *testing.T) {
 	joinCode := "ERRORR"
 
 	mock.ExpectBegin()
-	mock.ExpectQuery("SELECT id, join_code, leader_id, status, created_at, updated_at FROM lobbies WHERE join_code =").
-		WithArgs(joinCode).
+	mock.ExpectQuery("SELECT id, join_code, leader_id, status, created_at, updated_at FROM lobbies WHERE join_code = \\$1 AND status <> \\$2").
+		WithArgs(joinCode, models.LobbyStatusFinished).
 		WillReturnError(sql.ErrConnDone)
 	mock.ExpectRollback()
 
@@ -478,3 +535,64 @@ func TestJoinLobby_DatabaseError(t *testing.T) {
 		t.Fatalf("unfulfilled expectations: %v", err)
 	}
 }
+
+// expectLockLobby expects the joined lobby to be locked with the given status
+func expectLockLobby(mock sqlmock.Sqlmock, lobbyID uuid.UUID, status string) {
+	mock.ExpectQuery("SELECT id, join_code, leader_id, status, game_id, created_at, updated_at FROM lobbies WHERE id = \\$1 FOR UPDATE").
+		WithArgs(lobbyID).
+		WillReturnRows(sqlmock.NewRows(lobbyColumns).AddRow(lobbyID, "ABC123", uuid.New(), status, nil, time.Now(), time.Now()))
+}
+
+// expectNotBanned expects the ban check of the joining user to find no ban
+func expectNotBanned(mock sqlmock.Sqlmock, lobbyID, userID uuid.UUID) {
+	mock.ExpectQuery("SELECT EXISTS\\(SELECT 1 FROM lobby_bans").
+		WithArgs(lobbyID, userID).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+}
+
+func TestJoinLobby_Banned(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock DB: %v", err)
+	}
+	defer db.Close()
+
+	userID := uuid.New()
+	lobbyID := uuid.New()
+	joinCode := "BANNED"
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT id, join_code, leader_id, status, created_at, updated_at FROM lobbies WHERE join_code = \\$1 AND status <> \\$2").
+		WithArgs(joinCode, models.LobbyStatusFinished).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "join_code", "leader_id", "status", "created_at", "updated_at"}).
+			AddRow(lobbyID, joinCode, uuid.New(), models.LobbyStatusWaiting, time.Now(), time.Now()))
+	expectLockLobby(mock, lobbyID, models.LobbyStatusWaiting)
+	mock.ExpectQuery("SELECT EXISTS\\(SELECT 1 FROM lobby_bans").
+		WithArgs(lobbyID, userID).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	mock.ExpectRollback()
+
+	h := JoinLobbyHandler(repository.New(db))
+
+	bodyBytes, _ := json.Marshal(models.JoinLobbyRequest{JoinCode: joinCode})
+	req := httptest.NewRequest(http.MethodPost, "/lobbies/join", bytes.NewReader(bodyBytes))
+	req.Header.Set(headerUserID, userID.String())
+	req.Header.Set(headerUsername, "TestUser")
+	req.Header.Set("Content-Type", "application/json")
+
+	rec := httptest.NewRecorder()
+	h(rec, req)
+
+	if rec.Code != http.StatusForbidden {
+		t.Fatalf("expected 403, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var resp map[string]interface{}
+	_ = json.Unmarshal(rec.Body.Bytes(), &resp)
+	if resp["error"] != "banned_from_lobby" {
+		t.Fatalf("expected banned_from_lobby, got %+v", resp)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unfulfilled expectations: %v", err)
+	}
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/KnuffelGame/KnuffelGame/backend/libs/httpx"
 	"github.com/KnuffelGame/KnuffelGame/backend/libs/logger"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/LobbyService/internal/events"
 	"github.com/KnuffelGame/KnuffelGame/backend/services/LobbyService/internal/models"
 	"github.com/KnuffelGame/KnuffelGame/backend/services/LobbyService/internal/repository"
 	"github.com/go-chi/chi/v5"
@@ -17,7 +18,9 @@ import (
 // KickPlayerHandler returns an http.HandlerFunc that kicks a player from a lobby
 // Headers required: X-User-ID, X-Username (from Gateway)
 // Path parameter: lobby_id (UUID)
-// Request body: KickPlayerRequest with target_user_id field
+// Request body: KickPlayerRequest with target_user_id field and optional ban flag
+// A banned player cannot join the lobby again until the leader lifts the ban.
+// player_kicked and the targeted you_were_kicked are written to the event outbox in the same transaction.
 // Returns: 204 No Content on success, various error responses on failure
 func KickPlayerHandler(repo repository.Repository) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -119,6 +122,13 @@ func KickPlayerHandler(repo repository.Repository) http.HandlerFunc {
 			return
 		}
 
+		targetUsername, err := repo.GetUsernameTx(tx, targetUserID)
+		if err != nil {
+			log.Error("failed to get target username", slog.String("error", err.Error()), slog.String("target_user_id", targetUserID.String()))
+			httpx.WriteInternalError(w, "Database error", nil, log)
+			return
+		}
+
 		// 3. Delete the player record from the database
 		if err := repo.DeletePlayerTx(tx, lobbyID, targetUserID); err != nil {
 			log.Error("failed to kick player", slog.String("error", err.Error()), slog.String("lobby_id", lobbyID.String()), slog.String("target_user_id", targetUserID.String()))
@@ -126,6 +136,40 @@ func KickPlayerHandler(repo repository.Repository) http.HandlerFunc {
 			return
 		}
 
+		// 4. Record the ban
+		message := "You were removed from the lobby"
+		if req.Ban {
+			if err := repo.BanUserTx(tx, lobbyID, targetUserID, userID); err != nil {
+				log.Error("failed to ban player", slog.String("error", err.Error()), slog.String("lobby_id", lobbyID.String()), slog.String("target_user_id", targetUserID.String()))
+				httpx.WriteInternalError(w, "Failed to ban player", nil, log)
+				return
+			}
+			message = "You were banned from the lobby"
+		}
+
+		// 5. Tell the lobby and the kicked player; the outbox delivers the events once the transaction commits
+		for _, e := range []events.Event{
+			{
+				TargetType: events.TargetLobby,
+				TargetID:   lobbyID.String(),
+				EventType:  models.EventPlayerKicked,
+				Data:       models.PlayerKickedEvent{UserID: targetUserID, Username: targetUsername, KickedBy: userID, Banned: req.Ban},
+			},
+			{
+				TargetType:   events.TargetLobby,
+				TargetID:     lobbyID.String(),
+				EventType:    models.EventYouWereKicked,
+				TargetUserID: targetUserID.String(),
+				Data:         models.YouWereKickedEvent{Message: message, KickedBy: userID, Banned: req.Ban},
+			},
+		} {
+			if err := repo.EnqueueEventTx(tx, e); err != nil {
+				log.Error("failed to enqueue event", slog.String("error", err.Error()), slog.String("lobby_id", lobbyID.String()))
+				httpx.WriteInternalError(w, "Database error", nil, log)
+				return
+			}
+		}
+
 		// Commit transaction
 		if err := tx.Commit(); err != nil {
 			log.Error("failed to commit transaction", slog.String("error", err.Error()))
@@ -136,7 +180,8 @@ func KickPlayerHandler(repo repository.Repository) http.HandlerFunc {
 		log.Info("player kicked successfully",
 			slog.String("lobby_id", lobbyID.String()),
 			slog.String("user_id", userID.String()),
-			slog.String("target_user_id", targetUserID.String()))
+			slog.String("target_user_id", targetUserID.String()),
+			slog.Bool("banned", req.Ban))
 
 		httpx.WriteNoContent(w)
 	}
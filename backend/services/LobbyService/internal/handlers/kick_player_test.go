@@ -37,15 +37,23 @@ func TestKickPlayer_Success(t *testing.T) {
 		WillReturnRows(sqlmock.NewRows([]string{"leader_id"}).AddRow(userID.String()))
 
 	// Check if target user is member
-	mock.ExpectQuery("SELECT EXISTS\\(SELECT 1 FROM players WHERE lobby_id = \\$1 AND user_id = \\$2\\)").
+	mock.ExpectQuery("SELECT EXISTS\\(SELECT 1 FROM players WHERE lobby_id = \\$1 AND user_id = \\$2 AND left_at IS NULL\\)").
 		WithArgs(lobbyID, targetUserID).
 		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
 
+	mock.ExpectQuery("SELECT username FROM users WHERE id = \\$1").
+		WithArgs(targetUserID).
+		WillReturnRows(sqlmock.NewRows([]string{"username"}).AddRow("Bob"))
+
 	// Delete player
 	mock.ExpectExec("DELETE FROM players WHERE lobby_id = \\$1 AND user_id = \\$2").
 		WithArgs(lobbyID, targetUserID).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
+	// Events for the lobby and the kicked player in the same transaction
+	expectEnqueue(mock, lobbyID, models.EventPlayerKicked, nil, payloadContains(`"username":"Bob"`))
+	expectEnqueue(mock, lobbyID, models.EventYouWereKicked, targetUserID.String(), payloadContains(`"kicked_by":"`+userID.String()+`"`))
+
 	mock.ExpectCommit()
 
 	h := KickPlayerHandler(repository.New(db))
@@ -75,6 +83,65 @@ func TestKickPlayer_Success(t *testing.T) {
 	}
 }
 
+func TestKickPlayer_WithBan(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock DB: %v", err)
+	}
+	defer db.Close()
+
+	userID := uuid.New()
+	lobbyID := uuid.New()
+	targetUserID := uuid.New()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT leader_id::text FROM lobbies WHERE id =").
+		WithArgs(lobbyID).
+		WillReturnRows(sqlmock.NewRows([]string{"leader_id"}).AddRow(userID.String()))
+	mock.ExpectQuery("SELECT EXISTS\\(SELECT 1 FROM players").
+		WithArgs(lobbyID, targetUserID).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	mock.ExpectQuery("SELECT username FROM users WHERE id = \\$1").
+		WithArgs(targetUserID).
+		WillReturnRows(sqlmock.NewRows([]string{"username"}).AddRow("Bob"))
+	mock.ExpectExec("DELETE FROM players").
+		WithArgs(lobbyID, targetUserID).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	// The ban is recorded with the kick
+	mock.ExpectExec("INSERT INTO lobby_bans \\(lobby_id, user_id, banned_by\\)").
+		WithArgs(lobbyID, targetUserID, userID).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	expectEnqueue(mock, lobbyID, models.EventPlayerKicked, nil, payloadContains(`"banned":true`))
+	expectEnqueue(mock, lobbyID, models.EventYouWereKicked, targetUserID.String(), payloadContains(`"message":"You were banned from the lobby"`))
+	mock.ExpectCommit()
+
+	h := KickPlayerHandler(repository.New(db))
+
+	bodyBytes, _ := json.Marshal(models.KickPlayerRequest{TargetUserID: targetUserID.String(), Ban: true})
+	req := httptest.NewRequest(http.MethodPost, "/lobbies/"+lobbyID.String()+"/kick", bytes.NewReader(bodyBytes))
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"lobby_id"},
+			Values: []string{lobbyID.String()},
+		},
+	}))
+	req.Header.Set(headerUserID, userID.String())
+	req.Header.Set(headerUsername, "LeaderUser")
+	req.Header.Set("Content-Type", "application/json")
+
+	rec := httptest.NewRecorder()
+	h(rec, req)
+
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unfulfilled expectations: %v", err)
+	}
+}
+
 func TestKickPlayer_MissingHeaders(t *testing.T) {
 	db, _, err := sqlmock.New()
 	if err != nil {
@@ -313,7 +380,7 @@ func TestKickPlayer_TargetNotInLobby(t *testing.T) {
 	mock.ExpectQuery("SELECT leader_id::text FROM lobbies WHERE id =").
 		WithArgs(lobbyID).
 		WillReturnRows(sqlmock.NewRows([]string{"leader_id"}).AddRow(userID.String()))
-	mock.ExpectQuery("SELECT EXISTS\\(SELECT 1 FROM players WHERE lobby_id = \\$1 AND user_id = \\$2\\)").
+	mock.ExpectQuery("SELECT EXISTS\\(SELECT 1 FROM players WHERE lobby_id = \\$1 AND user_id = \\$2 AND left_at IS NULL\\)").
 		WithArgs(lobbyID, targetUserID).
 		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
 	mock.ExpectRollback()
@@ -393,9 +460,12 @@ func TestKickPlayer_DatabaseError(t *testing.T) {
 	mock.ExpectQuery("SELECT leader_id::text FROM lobbies WHERE id =").
 		WithArgs(lobbyID).
 		WillReturnRows(sqlmock.NewRows([]string{"leader_id"}).AddRow(userID.String()))
-	mock.ExpectQuery("SELECT EXISTS\\(SELECT 1 FROM players WHERE lobby_id = \\$1 AND user_id = \\$2\\)").
+	mock.ExpectQuery("SELECT EXISTS\\(SELECT 1 FROM players WHERE lobby_id = \\$1 AND user_id = \\$2 AND left_at IS NULL\\)").
 		WithArgs(lobbyID, targetUserID).
 		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	mock.ExpectQuery("SELECT username FROM users WHERE id = \\$1").
+		WithArgs(targetUserID).
+		WillReturnRows(sqlmock.NewRows([]string{"username"}).AddRow("Bob"))
 	mock.ExpectExec("DELETE FROM players WHERE lobby_id = \\$1 AND user_id = \\$2").
 		WithArgs(lobbyID, targetUserID).
 		WillReturnError(sql.ErrConnDone)
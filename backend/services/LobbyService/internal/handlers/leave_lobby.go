@@ -0,0 +1,213 @@
+package handlers
+
+import (
+	"database/sql"
+	"log/slog"
+	"net/http"
+
+	"github.com/KnuffelGame/KnuffelGame/backend/libs/httpx"
+	"github.com/KnuffelGame/KnuffelGame/backend/libs/logger"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/LobbyService/internal/events"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/LobbyService/internal/game"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/LobbyService/internal/models"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/LobbyService/internal/repository"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// LeaveLobbyHandler returns an http.HandlerFunc that removes the requesting user from a lobby
+// Headers required: X-User-ID, X-Username (from Gateway)
+// Path parameter: lobby_id (UUID)
+// The player is marked as left (players.left_at); a leaving leader hands over to the longest-joined active player.
+// The last player leaving deletes a waiting lobby and finishes a running one.
+// player_left and leader_changed are written to the event outbox in the same transaction; if nobody is left
+// the lobby stream is closed after commit instead.
+// In a running lobby the Game Service is told to skip the player, or to abort the game once nobody is left;
+// if that call fails the turn timeout flags the player inactive instead. A new leader also takes over the game.
+// Returns: 200 OK with SuccessResponse, 404 if the lobby does not exist or the user is not in it
+func LeaveLobbyHandler(repo repository.Repository, games game.Client, pub events.Publisher) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log := logger.Logger(r.Context()).WithGroup("handler").With(slog.String("action", "leave_lobby"))
+
+		// Extract user context from headers
+		userIDStr := r.Header.Get(headerUserID)
+		username := r.Header.Get(headerUsername)
+
+		if userIDStr == "" || username == "" {
+			log.Warn("missing required headers", slog.String("user_id", userIDStr), slog.String("username", username))
+			httpx.WriteBadRequest(w, "Missing required headers: X-User-ID and X-Username", nil, log)
+			return
+		}
+
+		userID, err := uuid.Parse(userIDStr)
+		if err != nil {
+			log.Warn("invalid user_id format", slog.String("user_id", userIDStr), slog.String("error", err.Error()))
+			httpx.WriteBadRequest(w, "Invalid user ID format", map[string]interface{}{"detail": err.Error()}, log)
+			return
+		}
+
+		lobbyIDStr := chi.URLParam(r, "lobby_id")
+		lobbyID, err := uuid.Parse(lobbyIDStr)
+		if err != nil {
+			log.Warn("invalid lobby_id format", slog.String("lobby_id", lobbyIDStr))
+			httpx.WriteBadRequest(w, "Invalid lobby ID format", nil, log)
+			return
+		}
+
+		tx, err := repo.BeginTx(r.Context())
+		if err != nil {
+			log.Error("failed to begin transaction", slog.String("error", err.Error()))
+			httpx.WriteInternalError(w, "Database error", nil, log)
+			return
+		}
+		defer tx.Rollback()
+
+		// 1. Lock the lobby so concurrent leaves agree on the next leader
+		lobby, err := repo.GetLobbyForUpdateTx(tx, lobbyID)
+		if err == sql.ErrNoRows {
+			log.Info("lobby not found", slog.String("lobby_id", lobbyID.String()))
+			httpx.WriteNotFound(w, "Lobby not found", log)
+			return
+		}
+		if err != nil {
+			log.Error("failed to get lobby", slog.String("error", err.Error()), slog.String("lobby_id", lobbyID.String()))
+			httpx.WriteInternalError(w, "Database error", nil, log)
+			return
+		}
+
+		// 2. Split the players into the leaving one and the remaining ones
+		players, err := repo.GetPlayersTx(tx, lobbyID)
+		if err != nil {
+			log.Error("failed to get players", slog.String("error", err.Error()), slog.String("lobby_id", lobbyID.String()))
+			httpx.WriteInternalError(w, "Database error", nil, log)
+			return
+		}
+		var leaving *models.PlayerInfo
+		remaining := make([]models.PlayerInfo, 0, len(players))
+		for i := range players {
+			if players[i].UserID == userID {
+				leaving = &players[i]
+				continue
+			}
+			remaining = append(remaining, players[i])
+		}
+		if leaving == nil {
+			log.Info("user not in lobby", slog.String("lobby_id", lobbyID.String()), slog.String("user_id", userID.String()))
+			httpx.WriteError(w, http.StatusNotFound, "not_in_lobby", "You are not in this lobby", nil, log)
+			return
+		}
+
+		// 3. Mark the player as left
+		player, err := repo.LeavePlayerTx(tx, lobbyID, userID)
+		if err != nil {
+			log.Error("failed to leave lobby", slog.String("error", err.Error()), slog.String("lobby_id", lobbyID.String()))
+			httpx.WriteInternalError(w, "Database error", nil, log)
+			return
+		}
+
+		// 4. Hand over leadership, or end the lobby if nobody is left
+		var newLeader *models.PlayerInfo
+		switch {
+		case len(remaining) == 0 && lobby.Status == models.LobbyStatusWaiting:
+			err = repo.DeleteLobbyTx(tx, lobbyID)
+		case len(remaining) == 0:
+			err = repo.SetLobbyStatusTx(tx, lobbyID, models.LobbyStatusFinished)
+		case lobby.LeaderID == userID:
+			newLeader = nextLeader(remaining)
+			err = repo.SetLobbyLeaderTx(tx, lobbyID, newLeader.UserID)
+		}
+		if err != nil {
+			log.Error("failed to update lobby", slog.String("error", err.Error()), slog.String("lobby_id", lobbyID.String()))
+			httpx.WriteInternalError(w, "Database error", nil, log)
+			return
+		}
+
+		// 5. Announce the change to the remaining players; the outbox delivers the events once the transaction commits
+		var changes []events.Event
+		if len(remaining) > 0 {
+			changes = append(changes, events.Event{
+				TargetType: events.TargetLobby,
+				TargetID:   lobbyID.String(),
+				EventType:  models.EventPlayerLeft,
+				Data:       models.PlayerLeftEvent{UserID: userID, Username: leaving.Username, PlayerCount: len(remaining)},
+			})
+		}
+		if newLeader != nil {
+			changes = append(changes, events.Event{
+				TargetType: events.TargetLobby,
+				TargetID:   lobbyID.String(),
+				EventType:  models.EventLeaderChanged,
+				Data: models.LeaderChangedEvent{
+					OldLeaderID:       userID,
+					NewLeaderID:       newLeader.UserID,
+					NewLeaderUsername: newLeader.Username,
+				},
+			})
+		}
+		for _, e := range changes {
+			if err := repo.EnqueueEventTx(tx, e); err != nil {
+				log.Error("failed to enqueue event", slog.String("error", err.Error()), slog.String("lobby_id", lobbyID.String()))
+				httpx.WriteInternalError(w, "Database error", nil, log)
+				return
+			}
+		}
+
+		if err := tx.Commit(); err != nil {
+			log.Error("failed to commit transaction", slog.String("error", err.Error()))
+			httpx.WriteInternalError(w, "Database error", nil, log)
+			return
+		}
+
+		// 6. Take the player out of the running game
+		if lobby.Status == models.LobbyStatusInGame && lobby.GameID != nil {
+			var err error
+			if len(remaining) == 0 {
+				err = games.AbortGame(r.Context(), *lobby.GameID)
+			} else {
+				err = games.LeaveGame(r.Context(), *lobby.GameID, userID)
+			}
+			if err != nil {
+				log.Warn("failed to update game", slog.String("game_id", lobby.GameID.String()), slog.String("error", err.Error()))
+			}
+			if newLeader != nil {
+				if err := games.SetLeader(r.Context(), *lobby.GameID, newLeader.UserID); err != nil {
+					log.Warn("failed to set game leader", slog.String("game_id", lobby.GameID.String()), slog.String("error", err.Error()))
+				}
+			}
+		}
+
+		// 7. Close the lobby stream if nobody is left
+		if len(remaining) == 0 {
+			reason := events.ReasonCleanup
+			if lobby.Status == models.LobbyStatusWaiting {
+				reason = events.ReasonLobbyDeleted
+			}
+			if err := pub.Unregister(r.Context(), events.TargetLobby, lobbyID.String(), reason); err != nil {
+				log.Warn("failed to unregister lobby", slog.String("lobby_id", lobbyID.String()), slog.String("error", err.Error()))
+			}
+		}
+
+		log.Info("player left lobby",
+			slog.String("lobby_id", lobbyID.String()),
+			slog.String("user_id", userID.String()),
+			slog.Time("left_at", *player.LeftAt),
+			slog.Int("remaining", len(remaining)))
+
+		message := "You have left the lobby"
+		if lobby.Status != models.LobbyStatusWaiting {
+			message = "You have left the game - you cannot rejoin"
+		}
+		httpx.WriteJSON(w, http.StatusOK, models.SuccessResponse{Success: true, Message: message}, log)
+	}
+}
+
+// nextLeader picks the longest-joined active player, or the longest-joined player if none is active.
+// players must be in join order and not empty.
+func nextLeader(players []models.PlayerInfo) *models.PlayerInfo {
+	for i := range players {
+		if players[i].IsActive {
+			return &players[i]
+		}
+	}
+	return &players[0]
+}
@@ -0,0 +1,262 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/LobbyService/internal/models"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/LobbyService/internal/repository"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+type testPlayer struct {
+	userID uuid.UUID
+	name   string
+	active bool
+}
+
+func leaveLobby(h http.HandlerFunc, lobbyID, userID uuid.UUID) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/lobbies/"+lobbyID.String()+"/leave", nil)
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, &chi.Context{
+		URLParams: chi.RouteParams{Keys: []string{"lobby_id"}, Values: []string{lobbyID.String()}},
+	}))
+	req.Header.Set(headerUserID, userID.String())
+	req.Header.Set(headerUsername, "Player")
+	rec := httptest.NewRecorder()
+	h(rec, req)
+	return rec
+}
+
+// expectLeave expects the lobby lock, the player list in join order and, if userID is listed, the left_at update.
+// A running lobby gets gameID as its game.
+func expectLeave(mock sqlmock.Sqlmock, lobbyID, leaderID, userID uuid.UUID, status string, players []testPlayer, gameID uuid.UUID) {
+	now := time.Now()
+	var gameCol interface{}
+	if status == models.LobbyStatusInGame {
+		gameCol = gameID
+	}
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT id, join_code, leader_id, status, game_id, created_at, updated_at FROM lobbies WHERE id = \\$1 FOR UPDATE").
+		WithArgs(lobbyID).
+		WillReturnRows(sqlmock.NewRows(lobbyColumns).AddRow(lobbyID, "ABC123", leaderID, status, gameCol, now, now))
+	rows := sqlmock.NewRows(playerColumns)
+	member := false
+	for i, p := range players {
+		rows.AddRow(uuid.New(), p.userID, p.name, now.Add(time.Duration(i)*time.Second), p.active, false)
+		member = member || p.userID == userID
+	}
+	mock.ExpectQuery("SELECT p.id, p.user_id, u.username, p.joined_at, p.is_active, p.is_ready FROM players p").
+		WithArgs(lobbyID).
+		WillReturnRows(rows)
+	if member {
+		mock.ExpectQuery("UPDATE players SET left_at = CURRENT_TIMESTAMP, is_active = false").
+			WithArgs(lobbyID, userID).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "lobby_id", "user_id", "joined_at", "is_active", "left_at"}).
+				AddRow(uuid.New(), lobbyID, userID, now, false, now))
+	}
+}
+
+func TestLeaveLobby_LeaderHandsOverToLongestJoinedActivePlayer(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock DB: %v", err)
+	}
+	defer db.Close()
+
+	lobbyID, leaderID, awayID, bobID := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	expectLeave(mock, lobbyID, leaderID, leaderID, models.LobbyStatusWaiting, []testPlayer{
+		{leaderID, "Alice", true},
+		{awayID, "Away", false},
+		{bobID, "Bob", true},
+	}, uuid.Nil)
+	mock.ExpectExec("UPDATE lobbies SET leader_id = \\$1").
+		WithArgs(bobID, lobbyID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	expectEnqueue(mock, lobbyID, models.EventPlayerLeft, nil,
+		payloadContains(`"user_id":"`+leaderID.String()+`","username":"Alice","player_count":2`))
+	expectEnqueue(mock, lobbyID, models.EventLeaderChanged, nil,
+		payloadContains(`"new_leader_id":"`+bobID.String()+`","new_leader_username":"Bob"`))
+	mock.ExpectCommit()
+
+	pub := &fakePublisher{}
+	rec := leaveLobby(LeaveLobbyHandler(repository.New(db), &fakeGames{}, pub), lobbyID, leaderID)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var resp models.SuccessResponse
+	_ = json.Unmarshal(rec.Body.Bytes(), &resp)
+	if !resp.Success || resp.Message != "You have left the lobby" {
+		t.Fatalf("unexpected response %+v", resp)
+	}
+
+	if len(pub.unregistered) != 0 {
+		t.Fatalf("expected the lobby stream to stay open, got %v", pub.unregistered)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestLeaveLobby_PlayerLeavesRunningGame(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock DB: %v", err)
+	}
+	defer db.Close()
+
+	lobbyID, leaderID, bobID, gameID := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	expectLeave(mock, lobbyID, leaderID, bobID, models.LobbyStatusInGame, []testPlayer{
+		{leaderID, "Alice", true},
+		{bobID, "Bob", true},
+	}, gameID)
+	// no leader change, the leader stays
+	expectEnqueue(mock, lobbyID, models.EventPlayerLeft, nil, payloadContains(`"username":"Bob","player_count":1`))
+	mock.ExpectCommit()
+
+	games, pub := &fakeGames{}, &fakePublisher{}
+	rec := leaveLobby(LeaveLobbyHandler(repository.New(db), games, pub), lobbyID, bobID)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var resp models.SuccessResponse
+	_ = json.Unmarshal(rec.Body.Bytes(), &resp)
+	if resp.Message != "You have left the game - you cannot rejoin" {
+		t.Fatalf("unexpected message %q", resp.Message)
+	}
+	if len(games.left) != 1 || games.left[0] != gameID.String()+":"+bobID.String() || len(games.aborted) != 0 {
+		t.Fatalf("expected Bob to leave the game, got left %v, aborted %v", games.left, games.aborted)
+	}
+	if len(games.leaders) != 0 {
+		t.Fatalf("expected the game leader to stay, got %v", games.leaders)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestLeaveLobby_LeaderLeavesRunningGame(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock DB: %v", err)
+	}
+	defer db.Close()
+
+	lobbyID, leaderID, bobID, gameID := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	expectLeave(mock, lobbyID, leaderID, leaderID, models.LobbyStatusInGame, []testPlayer{
+		{leaderID, "Alice", true},
+		{bobID, "Bob", true},
+	}, gameID)
+	mock.ExpectExec("UPDATE lobbies SET leader_id = \\$1").
+		WithArgs(bobID, lobbyID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	expectEnqueue(mock, lobbyID, models.EventPlayerLeft, nil, payloadContains(`"username":"Alice","player_count":1`))
+	expectEnqueue(mock, lobbyID, models.EventLeaderChanged, nil, payloadContains(`"new_leader_id":"`+bobID.String()+`"`))
+	mock.ExpectCommit()
+
+	games := &fakeGames{}
+	rec := leaveLobby(LeaveLobbyHandler(repository.New(db), games, &fakePublisher{}), lobbyID, leaderID)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	// Bob may now end the game
+	if len(games.leaders) != 1 || games.leaders[0] != gameID.String()+":"+bobID.String() {
+		t.Fatalf("expected Bob to lead the game, got %v", games.leaders)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestLeaveLobby_LastPlayer(t *testing.T) {
+	tests := []struct {
+		name   string
+		status string
+		expect func(mock sqlmock.Sqlmock, lobbyID uuid.UUID)
+		reason string
+	}{
+		{
+			name:   "waiting lobby is deleted",
+			status: models.LobbyStatusWaiting,
+			expect: func(mock sqlmock.Sqlmock, lobbyID uuid.UUID) {
+				mock.ExpectExec("DELETE FROM lobbies WHERE id = \\$1").
+					WithArgs(lobbyID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			reason: "lobby_deleted",
+		},
+		{
+			name:   "running lobby is finished",
+			status: models.LobbyStatusInGame,
+			expect: func(mock sqlmock.Sqlmock, lobbyID uuid.UUID) {
+				mock.ExpectExec("UPDATE lobbies SET status = \\$1").
+					WithArgs(models.LobbyStatusFinished, lobbyID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			reason: "cleanup",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("failed to create mock DB: %v", err)
+			}
+			defer db.Close()
+
+			lobbyID, leaderID, gameID := uuid.New(), uuid.New(), uuid.New()
+			expectLeave(mock, lobbyID, leaderID, leaderID, tt.status, []testPlayer{{leaderID, "Alice", true}}, gameID)
+			tt.expect(mock, lobbyID)
+			mock.ExpectCommit()
+
+			games, pub := &fakeGames{}, &fakePublisher{}
+			rec := leaveLobby(LeaveLobbyHandler(repository.New(db), games, pub), lobbyID, leaderID)
+			if rec.Code != http.StatusOK {
+				t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+			}
+			if len(pub.unregistered) != 1 || pub.unregistered[0] != "lobby:"+lobbyID.String()+":"+tt.reason {
+				t.Fatalf("expected the lobby to be unregistered, got %v", pub.unregistered)
+			}
+			// the game of a running lobby ends with its last player
+			if tt.status == models.LobbyStatusInGame && (len(games.aborted) != 1 || games.aborted[0] != gameID) {
+				t.Fatalf("expected the game to be aborted, got %v", games.aborted)
+			}
+			if tt.status == models.LobbyStatusWaiting && len(games.aborted) != 0 {
+				t.Fatalf("expected no game to abort, got %v", games.aborted)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Fatalf("unfulfilled expectations: %v", err)
+			}
+		})
+	}
+}
+
+func TestLeaveLobby_NotInLobby(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock DB: %v", err)
+	}
+	defer db.Close()
+
+	lobbyID, leaderID, strangerID := uuid.New(), uuid.New(), uuid.New()
+	expectLeave(mock, lobbyID, leaderID, strangerID, models.LobbyStatusWaiting, []testPlayer{{leaderID, "Alice", true}}, uuid.Nil)
+	mock.ExpectRollback()
+
+	rec := leaveLobby(LeaveLobbyHandler(repository.New(db), &fakeGames{}, &fakePublisher{}), lobbyID, strangerID)
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d", rec.Code)
+	}
+	var resp map[string]interface{}
+	_ = json.Unmarshal(rec.Body.Bytes(), &resp)
+	if resp["error"] != "not_in_lobby" {
+		t.Fatalf("expected not_in_lobby, got %+v", resp)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unfulfilled expectations: %v", err)
+	}
+}
@@ -0,0 +1,146 @@
+package handlers
+
+import (
+	"encoding/base64"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/KnuffelGame/KnuffelGame/backend/libs/httpx"
+	"github.com/KnuffelGame/KnuffelGame/backend/libs/logger"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/LobbyService/internal/models"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/LobbyService/internal/repository"
+	"github.com/google/uuid"
+)
+
+// Page sizes of the lobby browser
+const (
+	defaultLobbyPageSize = 20
+	maxLobbyPageSize     = 50
+)
+
+var errInvalidCursor = errors.New("invalid cursor")
+
+// ListLobbiesHandler returns an http.HandlerFunc that lists public lobbies for the lobby browser
+// Query parameters (all optional):
+//   - status: waiting (default) or running
+//   - free_seats: true to only list lobbies below their max_players
+//   - order: desc (default, newest first) or asc by created_at
+//   - limit: page size, 1-50 (default 20)
+//   - cursor: next_cursor of the previous page
+//
+// Private lobbies are never listed.
+// Returns: 200 OK with LobbyListResponse, 400 for invalid query parameters
+func ListLobbiesHandler(repo repository.Repository) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log := logger.Logger(r.Context()).WithGroup("handler").With(slog.String("action", "list_lobbies"))
+
+		filter, invalid := parseLobbyListFilter(r)
+		if invalid != nil {
+			log.Info("invalid query parameters")
+			httpx.WriteBadRequest(w, "Invalid query parameters", invalid, log)
+			return
+		}
+
+		// Fetch one extra lobby to know whether another page follows
+		limit := filter.Limit
+		filter.Limit++
+		lobbies, err := repo.ListPublicLobbies(r.Context(), filter)
+		if err != nil {
+			log.Error("failed to list lobbies", slog.String("error", err.Error()))
+			httpx.WriteInternalError(w, "Database error", nil, log)
+			return
+		}
+
+		resp := models.LobbyListResponse{Lobbies: lobbies}
+		if len(lobbies) > limit {
+			resp.Lobbies = lobbies[:limit]
+			last := resp.Lobbies[limit-1]
+			resp.NextCursor = encodeCursor(models.LobbyCursor{CreatedAt: last.CreatedAt, ID: last.LobbyID})
+		}
+
+		log.Info("lobbies listed", slog.Int("count", len(resp.Lobbies)), slog.Bool("more", resp.NextCursor != ""))
+		httpx.WriteJSON(w, http.StatusOK, resp, log)
+	}
+}
+
+// parseLobbyListFilter reads the query parameters of the lobby browser.
+// The second return value maps each invalid parameter to a message and is nil if all are valid.
+func parseLobbyListFilter(r *http.Request) (models.LobbyListFilter, map[string]interface{}) {
+	q := r.URL.Query()
+	filter := models.LobbyListFilter{Status: models.LobbyStatusWaiting, Descending: true, Limit: defaultLobbyPageSize}
+	invalid := map[string]interface{}{}
+
+	switch status := q.Get("status"); status {
+	case "":
+	case models.LobbyStatusWaiting, models.LobbyStatusInGame:
+		filter.Status = status
+	default:
+		invalid["status"] = "must be " + models.LobbyStatusWaiting + " or " + models.LobbyStatusInGame
+	}
+
+	if v := q.Get("free_seats"); v != "" {
+		freeSeats, err := strconv.ParseBool(v)
+		if err != nil {
+			invalid["free_seats"] = "must be true or false"
+		}
+		filter.FreeSeats = freeSeats
+	}
+
+	switch order := q.Get("order"); order {
+	case "", "desc":
+	case "asc":
+		filter.Descending = false
+	default:
+		invalid["order"] = "must be asc or desc"
+	}
+
+	if v := q.Get("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil || limit < 1 || limit > maxLobbyPageSize {
+			invalid["limit"] = "must be between 1 and " + strconv.Itoa(maxLobbyPageSize)
+		}
+		filter.Limit = limit
+	}
+
+	if v := q.Get("cursor"); v != "" {
+		cursor, err := decodeCursor(v)
+		if err != nil {
+			invalid["cursor"] = "must be the next_cursor of a previous page"
+		}
+		filter.After = cursor
+	}
+
+	if len(invalid) > 0 {
+		return filter, invalid
+	}
+	return filter, nil
+}
+
+// encodeCursor returns the opaque form of c handed to clients
+func encodeCursor(c models.LobbyCursor) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(c.CreatedAt.UTC().Format(time.RFC3339Nano) + "|" + c.ID.String()))
+}
+
+// decodeCursor parses a cursor produced by encodeCursor
+func decodeCursor(s string) (*models.LobbyCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, errInvalidCursor
+	}
+	createdAt, id, ok := strings.Cut(string(raw), "|")
+	if !ok {
+		return nil, errInvalidCursor
+	}
+	var c models.LobbyCursor
+	if c.CreatedAt, err = time.Parse(time.RFC3339Nano, createdAt); err != nil {
+		return nil, errInvalidCursor
+	}
+	if c.ID, err = uuid.Parse(id); err != nil {
+		return nil, errInvalidCursor
+	}
+	return &c, nil
+}
@@ -0,0 +1,139 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/LobbyService/internal/models"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/LobbyService/internal/repository"
+	"github.com/google/uuid"
+)
+
+var summaryColumns = []string{"id", "join_code", "status", "username", "player_count", "max_players", "turn_timeout_seconds", "is_private", "variant", "require_ready", "created_at"}
+
+func listLobbies(h http.HandlerFunc, query string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/lobbies"+query, nil)
+	req.Header.Set(headerUserID, uuid.New().String())
+	req.Header.Set(headerUsername, "Alice")
+	rec := httptest.NewRecorder()
+	h(rec, req)
+	return rec
+}
+
+func TestListLobbies_Pagination(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock DB: %v", err)
+	}
+	defer db.Close()
+
+	now := time.Now().UTC()
+	ids := []uuid.UUID{uuid.New(), uuid.New(), uuid.New()}
+
+	// first page of 2: the third row only signals that another page follows
+	rows := sqlmock.NewRows(summaryColumns)
+	for i, id := range ids {
+		rows.AddRow(id, "ABC12"+string(rune('0'+i)), models.LobbyStatusWaiting, "Leader", 1, 6, 40, false, "classic", false, now.Add(-time.Duration(i)*time.Minute))
+	}
+	mock.ExpectQuery("NOT s.is_private").
+		WithArgs(models.LobbyStatusWaiting, 3).
+		WillReturnRows(rows)
+
+	h := ListLobbiesHandler(repository.New(db))
+	rec := listLobbies(h, "?limit=2&free_seats=true")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var page models.LobbyListResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &page); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(page.Lobbies) != 2 || page.Lobbies[1].LobbyID != ids[1] || page.NextCursor == "" {
+		t.Fatalf("unexpected first page %+v", page)
+	}
+
+	// second page continues after the last lobby of the first one
+	mock.ExpectQuery("\\(l.created_at, l.id\\) < \\(\\$2, \\$3\\)").
+		WithArgs(models.LobbyStatusWaiting, now.Add(-time.Minute), ids[1], 3).
+		WillReturnRows(sqlmock.NewRows(summaryColumns).
+			AddRow(ids[2], "ABC122", models.LobbyStatusWaiting, "Leader", 1, 6, 40, false, "classic", false, now.Add(-2*time.Minute)))
+
+	rec = listLobbies(h, "?limit=2&free_seats=true&cursor="+page.NextCursor)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	page = models.LobbyListResponse{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &page); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(page.Lobbies) != 1 || page.Lobbies[0].LobbyID != ids[2] || page.NextCursor != "" {
+		t.Fatalf("unexpected last page %+v", page)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestListLobbies_Empty(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock DB: %v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery("ORDER BY l.created_at ASC").
+		WithArgs(models.LobbyStatusInGame, defaultLobbyPageSize+1).
+		WillReturnRows(sqlmock.NewRows(summaryColumns))
+
+	rec := listLobbies(ListLobbiesHandler(repository.New(db)), "?status=running&order=asc")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if body := rec.Body.String(); body != "{\"lobbies\":[]}\n" {
+		t.Fatalf("expected an empty list, got %s", body)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestListLobbies_InvalidQuery(t *testing.T) {
+	tests := []struct {
+		query string
+		param string
+	}{
+		{"?status=finished", "status"},
+		{"?free_seats=maybe", "free_seats"},
+		{"?order=random", "order"},
+		{"?limit=0", "limit"},
+		{"?limit=51", "limit"},
+		{"?cursor=not-a-cursor", "cursor"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("failed to create mock DB: %v", err)
+			}
+			defer db.Close()
+
+			rec := listLobbies(ListLobbiesHandler(repository.New(db)), tt.query)
+			if rec.Code != http.StatusBadRequest {
+				t.Fatalf("expected 400, got %d: %s", rec.Code, rec.Body.String())
+			}
+			var resp map[string]interface{}
+			_ = json.Unmarshal(rec.Body.Bytes(), &resp)
+			details, _ := resp["details"].(map[string]interface{})
+			if details[tt.param] == nil {
+				t.Fatalf("expected %s in details, got %+v", tt.param, resp)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Fatalf("unfulfilled expectations: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"database/sql"
+	"log/slog"
+	"net/http"
+
+	"github.com/KnuffelGame/KnuffelGame/backend/libs/httpx"
+	"github.com/KnuffelGame/KnuffelGame/backend/libs/logger"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/LobbyService/internal/models"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/LobbyService/internal/repository"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// ListBansHandler returns an http.HandlerFunc that lists the users banned from a lobby
+// Must be mounted behind RequireLobbyLeader
+// Path parameter: lobby_id (UUID)
+// Returns: 200 OK with LobbyBansResponse, newest ban first
+func ListBansHandler(repo repository.Repository) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log := logger.Logger(r.Context()).WithGroup("handler").With(slog.String("action", "list_bans"))
+
+		lobbyIDStr := chi.URLParam(r, "lobby_id")
+		lobbyID, err := uuid.Parse(lobbyIDStr)
+		if err != nil {
+			log.Warn("invalid lobby_id format", slog.String("lobby_id", lobbyIDStr))
+			httpx.WriteBadRequest(w, "Invalid lobby ID format", nil, log)
+			return
+		}
+
+		bans, err := repo.ListBans(r.Context(), lobbyID)
+		if err != nil {
+			log.Error("failed to list bans", slog.String("error", err.Error()), slog.String("lobby_id", lobbyID.String()))
+			httpx.WriteInternalError(w, "Database error", nil, log)
+			return
+		}
+
+		log.Info("bans listed", slog.String("lobby_id", lobbyID.String()), slog.Int("count", len(bans)))
+		httpx.WriteJSON(w, http.StatusOK, models.LobbyBansResponse{Bans: bans}, log)
+	}
+}
+
+// LiftBanHandler returns an http.HandlerFunc that lifts the ban of a user, who may then join the lobby again
+// Must be mounted behind RequireLobbyLeader
+// Path parameters: lobby_id (UUID), user_id (UUID)
+// Returns: 204 No Content on success, 404 ban_not_found if the user is not banned
+func LiftBanHandler(repo repository.Repository) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log := logger.Logger(r.Context()).WithGroup("handler").With(slog.String("action", "lift_ban"))
+
+		lobbyIDStr := chi.URLParam(r, "lobby_id")
+		lobbyID, err := uuid.Parse(lobbyIDStr)
+		if err != nil {
+			log.Warn("invalid lobby_id format", slog.String("lobby_id", lobbyIDStr))
+			httpx.WriteBadRequest(w, "Invalid lobby ID format", nil, log)
+			return
+		}
+
+		userIDStr := chi.URLParam(r, "user_id")
+		userID, err := uuid.Parse(userIDStr)
+		if err != nil {
+			log.Warn("invalid user_id format", slog.String("user_id", userIDStr))
+			httpx.WriteBadRequest(w, "Invalid user ID format", nil, log)
+			return
+		}
+
+		if err := repo.DeleteBan(r.Context(), lobbyID, userID); err != nil {
+			if err == sql.ErrNoRows {
+				log.Info("ban not found", slog.String("lobby_id", lobbyID.String()), slog.String("user_id", userID.String()))
+				httpx.WriteError(w, http.StatusNotFound, "ban_not_found", "User is not banned from the lobby", nil, log)
+				return
+			}
+			log.Error("failed to lift ban", slog.String("error", err.Error()), slog.String("lobby_id", lobbyID.String()))
+			httpx.WriteInternalError(w, "Database error", nil, log)
+			return
+		}
+
+		log.Info("ban lifted", slog.String("lobby_id", lobbyID.String()), slog.String("user_id", userID.String()))
+		httpx.WriteNoContent(w)
+	}
+}
@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/LobbyService/internal/models"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/LobbyService/internal/repository"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+func banRequest(h http.HandlerFunc, method string, lobbyID uuid.UUID, userID string) *httptest.ResponseRecorder {
+	keys, values := []string{"lobby_id"}, []string{lobbyID.String()}
+	path := "/lobbies/" + lobbyID.String() + "/bans"
+	if userID != "" {
+		keys, values = append(keys, "user_id"), append(values, userID)
+		path += "/" + userID
+	}
+	req := httptest.NewRequest(method, path, nil)
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, &chi.Context{
+		URLParams: chi.RouteParams{Keys: keys, Values: values},
+	}))
+	rec := httptest.NewRecorder()
+	h(rec, req)
+	return rec
+}
+
+func TestListBans(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock DB: %v", err)
+	}
+	defer db.Close()
+
+	lobbyID, bobID, leaderID := uuid.New(), uuid.New(), uuid.New()
+	bannedAt := time.Now().UTC().Truncate(time.Second)
+	mock.ExpectQuery("FROM lobby_bans b JOIN users u ON u.id = b.user_id WHERE b.lobby_id = \\$1 ORDER BY b.created_at DESC").
+		WithArgs(lobbyID).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "username", "banned_by", "created_at"}).
+			AddRow(bobID, "Bob", leaderID, bannedAt))
+
+	rec := banRequest(ListBansHandler(repository.New(db)), http.MethodGet, lobbyID, "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var resp models.LobbyBansResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(resp.Bans) != 1 || resp.Bans[0].UserID != bobID || resp.Bans[0].Username != "Bob" || resp.Bans[0].BannedBy != leaderID {
+		t.Fatalf("unexpected bans %+v", resp.Bans)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestLiftBan(t *testing.T) {
+	tests := []struct {
+		name         string
+		rowsAffected int64
+		wantStatus   int
+	}{
+		{"lifted", 1, http.StatusNoContent},
+		{"not banned", 0, http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("failed to create mock DB: %v", err)
+			}
+			defer db.Close()
+
+			lobbyID, userID := uuid.New(), uuid.New()
+			mock.ExpectExec("DELETE FROM lobby_bans WHERE lobby_id = \\$1 AND user_id = \\$2").
+				WithArgs(lobbyID, userID).
+				WillReturnResult(sqlmock.NewResult(0, tt.rowsAffected))
+
+			rec := banRequest(LiftBanHandler(repository.New(db)), http.MethodDelete, lobbyID, userID.String())
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("expected %d, got %d: %s", tt.wantStatus, rec.Code, rec.Body.String())
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Fatalf("unfulfilled expectations: %v", err)
+			}
+		})
+	}
+}
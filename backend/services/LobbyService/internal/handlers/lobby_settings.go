@@ -0,0 +1,174 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/KnuffelGame/KnuffelGame/backend/libs/httpx"
+	"github.com/KnuffelGame/KnuffelGame/backend/libs/logger"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/LobbyService/internal/events"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/LobbyService/internal/models"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/LobbyService/internal/repository"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// Bounds of the lobby settings; the Game Service enforces the same limits
+const (
+	minPlayers            = 2
+	maxPlayers            = 10
+	minTurnTimeoutSeconds = 10
+	maxTurnTimeoutSeconds = 300
+)
+
+// applySettings returns s with the fields of req applied.
+// The second return value maps each invalid field to a message and is nil if all fields are valid.
+func applySettings(s models.LobbySettings, req models.LobbySettingsRequest) (models.LobbySettings, map[string]interface{}) {
+	invalid := map[string]interface{}{}
+	if req.MaxPlayers != nil {
+		if *req.MaxPlayers < minPlayers || *req.MaxPlayers > maxPlayers {
+			invalid["max_players"] = fmt.Sprintf("must be between %d and %d", minPlayers, maxPlayers)
+		}
+		s.MaxPlayers = *req.MaxPlayers
+	}
+	if req.TurnTimeoutSeconds != nil {
+		if *req.TurnTimeoutSeconds < minTurnTimeoutSeconds || *req.TurnTimeoutSeconds > maxTurnTimeoutSeconds {
+			invalid["turn_timeout_seconds"] = fmt.Sprintf("must be between %d and %d", minTurnTimeoutSeconds, maxTurnTimeoutSeconds)
+		}
+		s.TurnTimeoutSeconds = *req.TurnTimeoutSeconds
+	}
+	if req.IsPrivate != nil {
+		s.IsPrivate = *req.IsPrivate
+	}
+	if req.Variant != nil {
+		if *req.Variant != models.VariantClassic && *req.Variant != models.VariantFreeJoker {
+			invalid["variant"] = fmt.Sprintf("must be %s or %s", models.VariantClassic, models.VariantFreeJoker)
+		}
+		s.Variant = *req.Variant
+	}
+	if req.RequireReady != nil {
+		s.RequireReady = *req.RequireReady
+	}
+	if len(invalid) > 0 {
+		return s, invalid
+	}
+	return s, nil
+}
+
+// UpdateLobbySettingsHandler returns an http.HandlerFunc that edits the settings of a waiting lobby
+// Must be mounted behind RequireLobbyLeader
+// Path parameter: lobby_id (UUID)
+// Request body: LobbySettingsRequest; omitted fields keep their value
+// max_players cannot drop below the current number of players.
+// settings_changed is written to the event outbox in the same transaction.
+// Returns: 200 OK with LobbySettings, 400 for invalid settings, 409 if the game already started
+func UpdateLobbySettingsHandler(repo repository.Repository) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log := logger.Logger(r.Context()).WithGroup("handler").With(slog.String("action", "update_lobby_settings"))
+
+		lobbyIDStr := chi.URLParam(r, "lobby_id")
+		lobbyID, err := uuid.Parse(lobbyIDStr)
+		if err != nil {
+			log.Warn("invalid lobby_id format", slog.String("lobby_id", lobbyIDStr))
+			httpx.WriteBadRequest(w, "Invalid lobby ID format", nil, log)
+			return
+		}
+
+		var req models.LobbySettingsRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			log.Warn("failed to decode request body", slog.String("error", err.Error()))
+			httpx.WriteBadRequest(w, "Invalid request body", map[string]interface{}{"detail": err.Error()}, log)
+			return
+		}
+
+		tx, err := repo.BeginTx(r.Context())
+		if err != nil {
+			log.Error("failed to begin transaction", slog.String("error", err.Error()))
+			httpx.WriteInternalError(w, "Database error", nil, log)
+			return
+		}
+		defer tx.Rollback()
+
+		// 1. Lock the lobby; settings are frozen once the game started
+		lobby, err := repo.GetLobbyForUpdateTx(tx, lobbyID)
+		if err == sql.ErrNoRows {
+			log.Info("lobby not found", slog.String("lobby_id", lobbyID.String()))
+			httpx.WriteNotFound(w, "Lobby not found", log)
+			return
+		}
+		if err != nil {
+			log.Error("failed to get lobby", slog.String("error", err.Error()), slog.String("lobby_id", lobbyID.String()))
+			httpx.WriteInternalError(w, "Database error", nil, log)
+			return
+		}
+		if lobby.Status != models.LobbyStatusWaiting {
+			log.Info("lobby not waiting", slog.String("lobby_id", lobbyID.String()), slog.String("status", lobby.Status))
+			httpx.WriteError(w, http.StatusConflict, "lobby_not_waiting", "Settings can only be changed before the game starts", nil, log)
+			return
+		}
+
+		// 2. Apply and validate the changes
+		current, err := repo.GetLobbySettingsTx(tx, lobbyID)
+		if err != nil {
+			log.Error("failed to get settings", slog.String("error", err.Error()), slog.String("lobby_id", lobbyID.String()))
+			httpx.WriteInternalError(w, "Database error", nil, log)
+			return
+		}
+		settings, invalid := applySettings(*current, req)
+		if invalid != nil {
+			log.Info("invalid settings", slog.String("lobby_id", lobbyID.String()))
+			httpx.WriteError(w, http.StatusBadRequest, "invalid_settings", "Invalid lobby settings", invalid, log)
+			return
+		}
+		players, err := repo.GetPlayersTx(tx, lobbyID)
+		if err != nil {
+			log.Error("failed to get players", slog.String("error", err.Error()), slog.String("lobby_id", lobbyID.String()))
+			httpx.WriteInternalError(w, "Database error", nil, log)
+			return
+		}
+		if settings.MaxPlayers < len(players) {
+			log.Info("max_players below player count", slog.String("lobby_id", lobbyID.String()), slog.Int("players", len(players)))
+			httpx.WriteError(w, http.StatusBadRequest, "invalid_settings", "Invalid lobby settings",
+				map[string]interface{}{"max_players": fmt.Sprintf("must be at least the current player count (%d)", len(players))}, log)
+			return
+		}
+
+		// 3. Store
+		if err := repo.UpdateLobbySettingsTx(tx, lobbyID, settings); err != nil {
+			log.Error("failed to update settings", slog.String("error", err.Error()), slog.String("lobby_id", lobbyID.String()))
+			httpx.WriteInternalError(w, "Database error", nil, log)
+			return
+		}
+
+		// 4. Announce the new settings; the outbox delivers the event once the transaction commits
+		if err := repo.EnqueueEventTx(tx, events.Event{
+			TargetType: events.TargetLobby,
+			TargetID:   lobbyID.String(),
+			EventType:  models.EventSettingsChanged,
+			Data:       settings,
+		}); err != nil {
+			log.Error("failed to enqueue event", slog.String("error", err.Error()), slog.String("lobby_id", lobbyID.String()))
+			httpx.WriteInternalError(w, "Database error", nil, log)
+			return
+		}
+
+		if err := tx.Commit(); err != nil {
+			log.Error("failed to commit transaction", slog.String("error", err.Error()))
+			httpx.WriteInternalError(w, "Database error", nil, log)
+			return
+		}
+
+		log.Info("lobby settings updated",
+			slog.String("lobby_id", lobbyID.String()),
+			slog.Int("max_players", settings.MaxPlayers),
+			slog.Int("turn_timeout_seconds", settings.TurnTimeoutSeconds),
+			slog.Bool("is_private", settings.IsPrivate),
+			slog.String("variant", settings.Variant),
+			slog.Bool("require_ready", settings.RequireReady))
+
+		httpx.WriteJSON(w, http.StatusOK, settings, log)
+	}
+}
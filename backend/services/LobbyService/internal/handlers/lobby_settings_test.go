@@ -0,0 +1,148 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/LobbyService/internal/models"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/LobbyService/internal/repository"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+func updateSettings(h http.HandlerFunc, lobbyID uuid.UUID, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPatch, "/lobbies/"+lobbyID.String()+"/settings", strings.NewReader(body))
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, &chi.Context{
+		URLParams: chi.RouteParams{Keys: []string{"lobby_id"}, Values: []string{lobbyID.String()}},
+	}))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	h(rec, req)
+	return rec
+}
+
+// expectSettingsLobby expects the lobby lock, the current settings and, unless playerCount is negative, the player list
+func expectSettingsLobby(mock sqlmock.Sqlmock, lobbyID uuid.UUID, playerCount int) {
+	now := time.Now()
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT id, join_code, leader_id, status, game_id, created_at, updated_at FROM lobbies WHERE id = \\$1 FOR UPDATE").
+		WithArgs(lobbyID).
+		WillReturnRows(sqlmock.NewRows(lobbyColumns).AddRow(lobbyID, "ABC123", uuid.New(), models.LobbyStatusWaiting, nil, now, now))
+	expectSettings(mock, lobbyID, 6)
+	if playerCount < 0 {
+		return
+	}
+	rows := sqlmock.NewRows(playerColumns)
+	for i := 0; i < playerCount; i++ {
+		rows.AddRow(uuid.New(), uuid.New(), "Player", now, true, false)
+	}
+	mock.ExpectQuery("SELECT p.id, p.user_id, u.username, p.joined_at, p.is_active, p.is_ready FROM players p").
+		WithArgs(lobbyID).
+		WillReturnRows(rows)
+}
+
+func TestUpdateLobbySettings_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock DB: %v", err)
+	}
+	defer db.Close()
+
+	lobbyID := uuid.New()
+	expectSettingsLobby(mock, lobbyID, 2)
+	mock.ExpectExec("UPDATE lobby_settings SET").
+		WithArgs(3, 60, false, models.VariantClassic, false, lobbyID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	expectEnqueue(mock, lobbyID, models.EventSettingsChanged, nil, payloadContains(`"max_players":3,"turn_timeout_seconds":60,"is_private":false`))
+	mock.ExpectCommit()
+
+	rec := updateSettings(UpdateLobbySettingsHandler(repository.New(db)), lobbyID,
+		`{"max_players":3,"turn_timeout_seconds":60,"is_private":false}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var resp models.LobbySettings
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	want := models.LobbySettings{MaxPlayers: 3, TurnTimeoutSeconds: 60, IsPrivate: false, Variant: models.VariantClassic}
+	if resp != want {
+		t.Fatalf("expected %+v, got %+v", want, resp)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestUpdateLobbySettings_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		players int
+		field   string
+	}{
+		// range checks fail before the players are loaded
+		{name: "max_players out of range", body: `{"max_players":1}`, players: -1, field: "max_players"},
+		{name: "turn timeout out of range", body: `{"turn_timeout_seconds":301}`, players: -1, field: "turn_timeout_seconds"},
+		{name: "unknown variant", body: `{"variant":"yahtzee"}`, players: -1, field: "variant"},
+		{name: "max_players below player count", body: `{"max_players":3}`, players: 4, field: "max_players"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("failed to create mock DB: %v", err)
+			}
+			defer db.Close()
+
+			lobbyID := uuid.New()
+			expectSettingsLobby(mock, lobbyID, tt.players)
+			// no outbox insert, nothing changed
+			mock.ExpectRollback()
+
+			rec := updateSettings(UpdateLobbySettingsHandler(repository.New(db)), lobbyID, tt.body)
+			if rec.Code != http.StatusBadRequest {
+				t.Fatalf("expected 400, got %d: %s", rec.Code, rec.Body.String())
+			}
+			var resp map[string]interface{}
+			_ = json.Unmarshal(rec.Body.Bytes(), &resp)
+			details, _ := resp["details"].(map[string]interface{})
+			if resp["error"] != "invalid_settings" || details[tt.field] == nil {
+				t.Fatalf("expected invalid_settings for %s, got %+v", tt.field, resp)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Fatalf("unfulfilled expectations: %v", err)
+			}
+		})
+	}
+}
+
+func TestUpdateLobbySettings_GameStarted(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock DB: %v", err)
+	}
+	defer db.Close()
+
+	lobbyID := uuid.New()
+	now := time.Now()
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT id, join_code, leader_id, status, game_id, created_at, updated_at FROM lobbies WHERE id = \\$1 FOR UPDATE").
+		WithArgs(lobbyID).
+		WillReturnRows(sqlmock.NewRows(lobbyColumns).AddRow(lobbyID, "ABC123", uuid.New(), models.LobbyStatusInGame, uuid.New(), now, now))
+	mock.ExpectRollback()
+
+	rec := updateSettings(UpdateLobbySettingsHandler(repository.New(db)), lobbyID, `{"max_players":4}`)
+	if rec.Code != http.StatusConflict {
+		t.Fatalf("expected 409, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unfulfilled expectations: %v", err)
+	}
+}
@@ -0,0 +1,207 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"net/http"
+
+	"github.com/KnuffelGame/KnuffelGame/backend/libs/httpx"
+	"github.com/KnuffelGame/KnuffelGame/backend/libs/logger"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/LobbyService/internal/events"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/LobbyService/internal/game"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/LobbyService/internal/models"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/LobbyService/internal/repository"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// StartGameHandler returns an http.HandlerFunc that starts the game of a lobby
+// Must be mounted behind RequireLobbyLeader
+// Path parameter: lobby_id (UUID)
+// The lobby row stays locked while the game is created with a random turn order and registered with the SSE Service;
+// if either call fails the transaction is rolled back and the lobby stays waiting.
+// Any failure after the game was created aborts it again, so its turn timeout cannot flag players inactive.
+// game_started is written to the event outbox in the same transaction.
+// The lobby settings bound the player count and are forwarded to the Game Service.
+// With require_ready set, every player must be ready.
+// Returns: 200 OK with StartGameResponse, 400 for too few, too many or inactive players, 409 if the game already started
+// or players are not ready, 502 if the Game or SSE Service failed
+func StartGameHandler(repo repository.Repository, games game.Client, pub events.Publisher) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log := logger.Logger(r.Context()).WithGroup("handler").With(slog.String("action", "start_game"))
+
+		lobbyIDStr := chi.URLParam(r, "lobby_id")
+		lobbyID, err := uuid.Parse(lobbyIDStr)
+		if err != nil {
+			log.Warn("invalid lobby_id format", slog.String("lobby_id", lobbyIDStr))
+			httpx.WriteBadRequest(w, "Invalid lobby ID format", nil, log)
+			return
+		}
+
+		tx, err := repo.BeginTx(r.Context())
+		if err != nil {
+			log.Error("failed to begin transaction", slog.String("error", err.Error()))
+			httpx.WriteInternalError(w, "Database error", nil, log)
+			return
+		}
+		defer tx.Rollback()
+
+		// 1. Lock the lobby and check its status
+		lobby, err := repo.GetLobbyForUpdateTx(tx, lobbyID)
+		if err == sql.ErrNoRows {
+			log.Info("lobby not found", slog.String("lobby_id", lobbyID.String()))
+			httpx.WriteNotFound(w, "Lobby not found", log)
+			return
+		}
+		if err != nil {
+			log.Error("failed to get lobby", slog.String("error", err.Error()), slog.String("lobby_id", lobbyID.String()))
+			httpx.WriteInternalError(w, "Database error", nil, log)
+			return
+		}
+		if lobby.Status != models.LobbyStatusWaiting {
+			log.Warn("game already started", slog.String("lobby_id", lobbyID.String()), slog.String("status", lobby.Status))
+			var details map[string]interface{}
+			if lobby.GameID != nil {
+				details = map[string]interface{}{"game_id": lobby.GameID.String()}
+			}
+			httpx.WriteError(w, http.StatusConflict, "game_already_started", "Game is already running", details, log)
+			return
+		}
+
+		// 2. Validate the players
+		players, err := repo.GetPlayersTx(tx, lobbyID)
+		if err != nil {
+			log.Error("failed to get players", slog.String("error", err.Error()), slog.String("lobby_id", lobbyID.String()))
+			httpx.WriteInternalError(w, "Database error", nil, log)
+			return
+		}
+		settings, err := repo.GetLobbySettingsTx(tx, lobbyID)
+		if err != nil {
+			log.Error("failed to get settings", slog.String("error", err.Error()), slog.String("lobby_id", lobbyID.String()))
+			httpx.WriteInternalError(w, "Database error", nil, log)
+			return
+		}
+		if len(players) < minPlayers || len(players) > settings.MaxPlayers {
+			log.Info("invalid player count", slog.String("lobby_id", lobbyID.String()), slog.Int("players", len(players)))
+			httpx.WriteError(w, http.StatusBadRequest, "invalid_player_count", fmt.Sprintf("Need between %d and %d players to start game", minPlayers, settings.MaxPlayers),
+				map[string]interface{}{"current_count": len(players), "required_minimum": minPlayers, "required_maximum": settings.MaxPlayers}, log)
+			return
+		}
+		var inactive []string
+		for _, p := range players {
+			if !p.IsActive {
+				inactive = append(inactive, p.UserID.String())
+			}
+		}
+		if len(inactive) > 0 {
+			log.Info("inactive players", slog.String("lobby_id", lobbyID.String()), slog.Int("inactive", len(inactive)))
+			httpx.WriteError(w, http.StatusBadRequest, "players_inactive", "All players must be active to start game",
+				map[string]interface{}{"inactive_user_ids": inactive}, log)
+			return
+		}
+		if settings.RequireReady {
+			var notReady []string
+			for _, p := range players {
+				if !p.IsReady {
+					notReady = append(notReady, p.UserID.String())
+				}
+			}
+			if len(notReady) > 0 {
+				log.Info("players not ready", slog.String("lobby_id", lobbyID.String()), slog.Int("not_ready", len(notReady)))
+				httpx.WriteError(w, http.StatusConflict, "players_not_ready", "All players must be ready to start game",
+					map[string]interface{}{"not_ready_user_ids": notReady}, log)
+				return
+			}
+		}
+
+		// 3. Random turn order
+		turnOrder := make([]game.Player, len(players))
+		names := make(map[uuid.UUID]string, len(players))
+		for i, p := range players {
+			turnOrder[i] = game.Player{UserID: p.UserID, Username: p.Username}
+			names[p.UserID] = p.Username
+		}
+		rand.Shuffle(len(turnOrder), func(i, j int) { turnOrder[i], turnOrder[j] = turnOrder[j], turnOrder[i] })
+
+		// 4. Create the game and register it for event streams; the lobby stays waiting if either fails
+		created, err := games.CreateGame(r.Context(), game.CreateGameRequest{
+			LobbyID:            lobbyID,
+			LeaderID:           lobby.LeaderID,
+			TurnOrder:          turnOrder,
+			TurnTimeoutSeconds: settings.TurnTimeoutSeconds,
+			Variant:            settings.Variant,
+		})
+		if err != nil {
+			log.Error("failed to create game", slog.String("error", err.Error()), slog.String("lobby_id", lobbyID.String()))
+			httpx.WriteError(w, http.StatusBadGateway, "game_service_error", "Failed to create game", nil, log)
+			return
+		}
+		registered := false
+		abort := func() {
+			// the request may already be cancelled, the cleanup has to run regardless
+			ctx := context.WithoutCancel(r.Context())
+			if registered {
+				if err := pub.Unregister(ctx, events.TargetGame, created.GameID.String(), events.ReasonCleanup); err != nil {
+					log.Warn("failed to unregister game", slog.String("error", err.Error()), slog.String("game_id", created.GameID.String()))
+				}
+			}
+			if err := games.AbortGame(ctx, created.GameID); err != nil {
+				log.Error("failed to abort game", slog.String("error", err.Error()), slog.String("game_id", created.GameID.String()))
+			}
+		}
+		if err := pub.Register(r.Context(), events.TargetGame, created.GameID.String()); err != nil {
+			log.Error("failed to register game", slog.String("error", err.Error()), slog.String("game_id", created.GameID.String()))
+			abort()
+			httpx.WriteError(w, http.StatusBadGateway, "sse_service_error", "Failed to register game for events", nil, log)
+			return
+		}
+		registered = true
+
+		// 5. waiting -> running, announced through the outbox once the transaction commits
+		if err := repo.StartLobbyTx(tx, lobbyID, created.GameID); err != nil {
+			log.Error("failed to update lobby status", slog.String("error", err.Error()), slog.String("lobby_id", lobbyID.String()))
+			abort()
+			httpx.WriteInternalError(w, "Database error", nil, log)
+			return
+		}
+		if err := repo.EnqueueEventTx(tx, events.Event{
+			TargetType: events.TargetLobby,
+			TargetID:   lobbyID.String(),
+			EventType:  models.EventGameStarted,
+			Data: models.GameStartedEvent{
+				GameID:          created.GameID,
+				LobbyID:         lobbyID,
+				TurnOrder:       created.TurnOrder,
+				CurrentPlayerID: created.CurrentPlayerID,
+			},
+		}); err != nil {
+			log.Error("failed to enqueue event", slog.String("error", err.Error()), slog.String("lobby_id", lobbyID.String()))
+			abort()
+			httpx.WriteInternalError(w, "Database error", nil, log)
+			return
+		}
+		if err := tx.Commit(); err != nil {
+			log.Error("failed to commit transaction", slog.String("error", err.Error()), slog.String("game_id", created.GameID.String()))
+			abort()
+			httpx.WriteInternalError(w, "Database error", nil, log)
+			return
+		}
+
+		log.Info("game started",
+			slog.String("lobby_id", lobbyID.String()),
+			slog.String("game_id", created.GameID.String()),
+			slog.Int("players", len(players)))
+
+		httpx.WriteJSON(w, http.StatusOK, models.StartGameResponse{
+			Success:         true,
+			GameID:          created.GameID,
+			LobbyID:         lobbyID,
+			TurnOrder:       created.TurnOrder,
+			CurrentPlayerID: created.CurrentPlayerID,
+			Message:         "Game started! " + names[created.CurrentPlayerID] + " goes first.",
+		}, log)
+	}
+}
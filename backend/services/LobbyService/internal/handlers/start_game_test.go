@@ -0,0 +1,385 @@
+package handlers
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/LobbyService/internal/events"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/LobbyService/internal/game"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/LobbyService/internal/models"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/LobbyService/internal/repository"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// fakeGames creates and aborts games without a Game Service
+type fakeGames struct {
+	err       error
+	req       *game.CreateGameRequest
+	created   []uuid.UUID
+	aborted   []uuid.UUID
+	left      []string
+	activated []string
+	leaders   []string
+}
+
+func (f *fakeGames) CreateGame(_ context.Context, req game.CreateGameRequest) (game.CreateGameResponse, error) {
+	f.req = &req
+	if f.err != nil {
+		return game.CreateGameResponse{}, f.err
+	}
+	order := make([]uuid.UUID, len(req.TurnOrder))
+	for i, p := range req.TurnOrder {
+		order[i] = p.UserID
+	}
+	gameID := uuid.New()
+	f.created = append(f.created, gameID)
+	return game.CreateGameResponse{GameID: gameID, LobbyID: req.LobbyID, CurrentPlayerID: order[0], TurnOrder: order}, nil
+}
+
+func (f *fakeGames) AbortGame(_ context.Context, gameID uuid.UUID) error {
+	f.aborted = append(f.aborted, gameID)
+	return nil
+}
+
+func (f *fakeGames) LeaveGame(_ context.Context, gameID, userID uuid.UUID) error {
+	f.left = append(f.left, gameID.String()+":"+userID.String())
+	return nil
+}
+
+func (f *fakeGames) SetLeader(_ context.Context, gameID, leaderID uuid.UUID) error {
+	f.leaders = append(f.leaders, gameID.String()+":"+leaderID.String())
+	return nil
+}
+
+func (f *fakeGames) ActivatePlayer(_ context.Context, gameID, userID uuid.UUID) error {
+	f.activated = append(f.activated, gameID.String()+":"+userID.String())
+	return nil
+}
+
+// fakePublisher records registrations and events
+type fakePublisher struct {
+	registerErr  error
+	registered   []string
+	unregistered []string
+	events       []events.Event
+}
+
+func (f *fakePublisher) Register(_ context.Context, targetType, targetID string) error {
+	if f.registerErr != nil {
+		return f.registerErr
+	}
+	f.registered = append(f.registered, targetType+":"+targetID)
+	return nil
+}
+
+func (f *fakePublisher) Publish(_ context.Context, e events.Event) error {
+	f.events = append(f.events, e)
+	return nil
+}
+
+func (f *fakePublisher) Unregister(_ context.Context, targetType, targetID, reason string) error {
+	f.unregistered = append(f.unregistered, targetType+":"+targetID+":"+reason)
+	return nil
+}
+
+var (
+	lobbyColumns    = []string{"id", "join_code", "leader_id", "status", "game_id", "created_at", "updated_at"}
+	playerColumns   = []string{"id", "user_id", "username", "joined_at", "is_active", "is_ready"}
+	settingsColumns = []string{"max_players", "turn_timeout_seconds", "is_private", "variant", "require_ready"}
+)
+
+// payloadContains matches an outbox payload containing the given JSON fragment
+type payloadContains string
+
+func (p payloadContains) Match(v driver.Value) bool {
+	b, ok := v.([]byte)
+	return ok && strings.Contains(string(b), string(p))
+}
+
+// expectEnqueue expects an event for the lobby to be written to the outbox
+func expectEnqueue(mock sqlmock.Sqlmock, lobbyID uuid.UUID, eventType string, targetUserID interface{}, payload sqlmock.Argument) {
+	mock.ExpectExec("INSERT INTO event_outbox").
+		WithArgs(events.TargetLobby, lobbyID.String(), eventType, targetUserID, payload).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+}
+
+// expectSettings expects the settings lookup of a lobby with the default settings and the given max_players
+func expectSettings(mock sqlmock.Sqlmock, lobbyID interface{}, maxPlayers int) {
+	mock.ExpectQuery("SELECT max_players, turn_timeout_seconds, is_private, variant, require_ready FROM lobby_settings WHERE lobby_id = \\$1").
+		WithArgs(lobbyID).
+		WillReturnRows(sqlmock.NewRows(settingsColumns).AddRow(maxPlayers, 40, true, models.VariantClassic, false))
+}
+
+func startGame(h http.HandlerFunc, lobbyID uuid.UUID) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/lobbies/"+lobbyID.String()+"/start", nil)
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, &chi.Context{
+		URLParams: chi.RouteParams{Keys: []string{"lobby_id"}, Values: []string{lobbyID.String()}},
+	}))
+	rec := httptest.NewRecorder()
+	h(rec, req)
+	return rec
+}
+
+func expectWaitingLobby(mock sqlmock.Sqlmock, lobbyID, leaderID uuid.UUID, players map[uuid.UUID]string, active bool) {
+	now := time.Now()
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT id, join_code, leader_id, status, game_id, created_at, updated_at FROM lobbies WHERE id = \\$1 FOR UPDATE").
+		WithArgs(lobbyID).
+		WillReturnRows(sqlmock.NewRows(lobbyColumns).AddRow(lobbyID, "ABC123", leaderID, models.LobbyStatusWaiting, nil, now, now))
+	rows := sqlmock.NewRows(playerColumns)
+	for userID, name := range players {
+		rows.AddRow(uuid.New(), userID, name, now, active, false)
+	}
+	mock.ExpectQuery("SELECT p.id, p.user_id, u.username, p.joined_at, p.is_active, p.is_ready FROM players p").
+		WithArgs(lobbyID).
+		WillReturnRows(rows)
+	expectSettings(mock, lobbyID, 6)
+}
+
+func TestStartGame_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock DB: %v", err)
+	}
+	defer db.Close()
+
+	lobbyID, leaderID, bobID := uuid.New(), uuid.New(), uuid.New()
+	expectWaitingLobby(mock, lobbyID, leaderID, map[uuid.UUID]string{leaderID: "Alice", bobID: "Bob"}, true)
+	mock.ExpectExec("UPDATE lobbies SET status = \\$1, game_id = \\$2").
+		WithArgs(models.LobbyStatusInGame, sqlmock.AnyArg(), lobbyID, models.LobbyStatusWaiting).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	expectEnqueue(mock, lobbyID, models.EventGameStarted, nil, payloadContains(`"lobby_id":"`+lobbyID.String()+`"`))
+	mock.ExpectCommit()
+
+	games, pub := &fakeGames{}, &fakePublisher{}
+	rec := startGame(StartGameHandler(repository.New(db), games, pub), lobbyID)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	var resp models.StartGameResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if !resp.Success || resp.LobbyID != lobbyID || len(resp.TurnOrder) != 2 || resp.CurrentPlayerID != resp.TurnOrder[0] {
+		t.Fatalf("unexpected response %+v", resp)
+	}
+	if games.req.LeaderID != leaderID || len(games.req.TurnOrder) != 2 {
+		t.Fatalf("unexpected create game request %+v", games.req)
+	}
+	if len(pub.registered) != 1 || pub.registered[0] != "game:"+resp.GameID.String() {
+		t.Fatalf("expected the game to be registered, got %v", pub.registered)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestStartGame_NotEnoughPlayers(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock DB: %v", err)
+	}
+	defer db.Close()
+
+	lobbyID, leaderID := uuid.New(), uuid.New()
+	expectWaitingLobby(mock, lobbyID, leaderID, map[uuid.UUID]string{leaderID: "Alice"}, true)
+	mock.ExpectRollback()
+
+	games := &fakeGames{}
+	rec := startGame(StartGameHandler(repository.New(db), games, &fakePublisher{}), lobbyID)
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", rec.Code)
+	}
+	var resp map[string]interface{}
+	_ = json.Unmarshal(rec.Body.Bytes(), &resp)
+	if resp["error"] != "invalid_player_count" {
+		t.Fatalf("expected invalid_player_count, got %+v", resp)
+	}
+	if games.req != nil {
+		t.Fatalf("expected no game to be created")
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestStartGame_InactivePlayers(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock DB: %v", err)
+	}
+	defer db.Close()
+
+	lobbyID, leaderID := uuid.New(), uuid.New()
+	expectWaitingLobby(mock, lobbyID, leaderID, map[uuid.UUID]string{leaderID: "Alice", uuid.New(): "Bob"}, false)
+	mock.ExpectRollback()
+
+	rec := startGame(StartGameHandler(repository.New(db), &fakeGames{}, &fakePublisher{}), lobbyID)
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", rec.Code)
+	}
+	var resp map[string]interface{}
+	_ = json.Unmarshal(rec.Body.Bytes(), &resp)
+	if resp["error"] != "players_inactive" {
+		t.Fatalf("expected players_inactive, got %+v", resp)
+	}
+}
+
+func TestStartGame_AlreadyRunning(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock DB: %v", err)
+	}
+	defer db.Close()
+
+	lobbyID, gameID := uuid.New(), uuid.New()
+	now := time.Now()
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT id, join_code, leader_id, status, game_id").
+		WithArgs(lobbyID).
+		WillReturnRows(sqlmock.NewRows(lobbyColumns).AddRow(lobbyID, "ABC123", uuid.New(), models.LobbyStatusInGame, gameID, now, now))
+	mock.ExpectRollback()
+
+	rec := startGame(StartGameHandler(repository.New(db), &fakeGames{}, &fakePublisher{}), lobbyID)
+	if rec.Code != http.StatusConflict {
+		t.Fatalf("expected 409, got %d", rec.Code)
+	}
+	var resp struct {
+		Error   string                 `json:"error"`
+		Details map[string]interface{} `json:"details"`
+	}
+	_ = json.Unmarshal(rec.Body.Bytes(), &resp)
+	if resp.Error != "game_already_started" || resp.Details["game_id"] != gameID.String() {
+		t.Fatalf("expected game_already_started with the game id, got %+v", resp)
+	}
+}
+
+func TestStartGame_DownstreamFailureRollsBack(t *testing.T) {
+	tests := []struct {
+		name  string
+		games *fakeGames
+		pub   *fakePublisher
+	}{
+		{"game service", &fakeGames{err: errors.New("connection refused")}, &fakePublisher{}},
+		{"sse service", &fakeGames{}, &fakePublisher{registerErr: errors.New("register returned status 500")}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("failed to create mock DB: %v", err)
+			}
+			defer db.Close()
+
+			lobbyID, leaderID := uuid.New(), uuid.New()
+			expectWaitingLobby(mock, lobbyID, leaderID, map[uuid.UUID]string{leaderID: "Alice", uuid.New(): "Bob"}, true)
+			// no status update, the lobby stays waiting
+			mock.ExpectRollback()
+
+			rec := startGame(StartGameHandler(repository.New(db), tt.games, tt.pub), lobbyID)
+			if rec.Code != http.StatusBadGateway {
+				t.Fatalf("expected 502, got %d", rec.Code)
+			}
+			if len(tt.pub.events) != 0 {
+				t.Fatalf("expected no event, got %+v", tt.pub.events)
+			}
+			// a game that was created must not outlive the failed start
+			if len(tt.games.aborted) != len(tt.games.created) || len(tt.games.created) > 0 && tt.games.aborted[0] != tt.games.created[0] {
+				t.Fatalf("expected created games %v to be aborted, got %v", tt.games.created, tt.games.aborted)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Fatalf("unfulfilled expectations: %v", err)
+			}
+		})
+	}
+}
+
+func TestStartGame_CommitFailureAbortsGame(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock DB: %v", err)
+	}
+	defer db.Close()
+
+	lobbyID, leaderID := uuid.New(), uuid.New()
+	expectWaitingLobby(mock, lobbyID, leaderID, map[uuid.UUID]string{leaderID: "Alice", uuid.New(): "Bob"}, true)
+	mock.ExpectExec("UPDATE lobbies SET status = \\$1, game_id = \\$2").
+		WithArgs(models.LobbyStatusInGame, sqlmock.AnyArg(), lobbyID, models.LobbyStatusWaiting).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	expectEnqueue(mock, lobbyID, models.EventGameStarted, nil, payloadContains(`"lobby_id":"`+lobbyID.String()+`"`))
+	mock.ExpectCommit().WillReturnError(errors.New("connection reset"))
+
+	games, pub := &fakeGames{}, &fakePublisher{}
+	rec := startGame(StartGameHandler(repository.New(db), games, pub), lobbyID)
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("expected 500, got %d", rec.Code)
+	}
+	if len(games.created) != 1 || len(games.aborted) != 1 || games.aborted[0] != games.created[0] {
+		t.Fatalf("expected the created game to be aborted, got created %v, aborted %v", games.created, games.aborted)
+	}
+	if want := "game:" + games.created[0].String() + ":" + events.ReasonCleanup; len(pub.unregistered) != 1 || pub.unregistered[0] != want {
+		t.Fatalf("expected %s to be unregistered, got %v", want, pub.unregistered)
+	}
+	if len(pub.events) != 0 {
+		t.Fatalf("expected no event, got %+v", pub.events)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestStartGame_PlayersNotReady(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock DB: %v", err)
+	}
+	defer db.Close()
+
+	lobbyID, leaderID, bobID := uuid.New(), uuid.New(), uuid.New()
+	now := time.Now()
+	mock.ExpectBegin()
+	mock.ExpectQuery("FROM lobbies WHERE id = \\$1 FOR UPDATE").
+		WithArgs(lobbyID).
+		WillReturnRows(sqlmock.NewRows(lobbyColumns).AddRow(lobbyID, "ABC123", leaderID, models.LobbyStatusWaiting, nil, now, now))
+	mock.ExpectQuery("FROM players p").
+		WithArgs(lobbyID).
+		WillReturnRows(sqlmock.NewRows(playerColumns).
+			AddRow(uuid.New(), leaderID, "Alice", now, true, true).
+			AddRow(uuid.New(), bobID, "Bob", now, true, false))
+	mock.ExpectQuery("FROM lobby_settings").
+		WithArgs(lobbyID).
+		WillReturnRows(sqlmock.NewRows(settingsColumns).AddRow(6, 40, true, models.VariantClassic, true))
+	mock.ExpectRollback()
+
+	games := &fakeGames{}
+	rec := startGame(StartGameHandler(repository.New(db), games, &fakePublisher{}), lobbyID)
+	if rec.Code != http.StatusConflict {
+		t.Fatalf("expected 409, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var resp struct {
+		Error   string `json:"error"`
+		Details struct {
+			NotReady []string `json:"not_ready_user_ids"`
+		} `json:"details"`
+	}
+	_ = json.Unmarshal(rec.Body.Bytes(), &resp)
+	if resp.Error != "players_not_ready" || len(resp.Details.NotReady) != 1 || resp.Details.NotReady[0] != bobID.String() {
+		t.Fatalf("expected players_not_ready for Bob, got %s", rec.Body.String())
+	}
+	if games.req != nil {
+		t.Fatal("game must not be created")
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unfulfilled expectations: %v", err)
+	}
+}
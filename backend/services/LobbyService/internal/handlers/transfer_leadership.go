@@ -0,0 +1,167 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/KnuffelGame/KnuffelGame/backend/libs/httpx"
+	"github.com/KnuffelGame/KnuffelGame/backend/libs/logger"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/LobbyService/internal/events"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/LobbyService/internal/game"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/LobbyService/internal/models"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/LobbyService/internal/repository"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// TransferLeadershipHandler returns an http.HandlerFunc that makes another player the lobby leader
+// Must be mounted behind RequireLobbyLeader
+// Headers required: X-User-ID, X-Username (from Gateway)
+// Path parameter: lobby_id (UUID)
+// Request body: TransferLeadershipRequest with target_user_id field
+// Leadership is re-checked on the locked lobby row, so two concurrent transfers cannot both succeed.
+// leader_changed is written to the event outbox in the same transaction.
+// In a running lobby the new leader also takes over the game, so they may end it prematurely.
+// Returns: 200 OK with SuccessResponse, 400 for a transfer to yourself, 404 if the target is not in the lobby
+func TransferLeadershipHandler(repo repository.Repository, games game.Client) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log := logger.Logger(r.Context()).WithGroup("handler").With(slog.String("action", "transfer_leadership"))
+
+		// Extract user context from headers
+		userIDStr := r.Header.Get(headerUserID)
+		username := r.Header.Get(headerUsername)
+
+		if userIDStr == "" || username == "" {
+			log.Warn("missing required headers", slog.String("user_id", userIDStr), slog.String("username", username))
+			httpx.WriteBadRequest(w, "Missing required headers: X-User-ID and X-Username", nil, log)
+			return
+		}
+
+		userID, err := uuid.Parse(userIDStr)
+		if err != nil {
+			log.Warn("invalid user_id format", slog.String("user_id", userIDStr), slog.String("error", err.Error()))
+			httpx.WriteBadRequest(w, "Invalid user ID format", map[string]interface{}{"detail": err.Error()}, log)
+			return
+		}
+
+		lobbyIDStr := chi.URLParam(r, "lobby_id")
+		lobbyID, err := uuid.Parse(lobbyIDStr)
+		if err != nil {
+			log.Warn("invalid lobby_id format", slog.String("lobby_id", lobbyIDStr))
+			httpx.WriteBadRequest(w, "Invalid lobby ID format", nil, log)
+			return
+		}
+
+		// Parse and validate request body
+		var req models.TransferLeadershipRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			log.Warn("failed to decode request body", slog.String("error", err.Error()))
+			httpx.WriteBadRequest(w, "Invalid request body", map[string]interface{}{"detail": err.Error()}, log)
+			return
+		}
+
+		targetUserID, err := uuid.Parse(req.TargetUserID)
+		if err != nil {
+			log.Warn("invalid target_user_id format", slog.String("target_user_id", req.TargetUserID), slog.String("error", err.Error()))
+			httpx.WriteBadRequest(w, "Invalid target user ID format", map[string]interface{}{"detail": err.Error()}, log)
+			return
+		}
+
+		if targetUserID == userID {
+			log.Warn("cannot transfer leadership to yourself", slog.String("user_id", userID.String()))
+			httpx.WriteError(w, http.StatusBadRequest, "cannot_transfer_to_self", "You are already the lobby leader", nil, log)
+			return
+		}
+
+		tx, err := repo.BeginTx(r.Context())
+		if err != nil {
+			log.Error("failed to begin transaction", slog.String("error", err.Error()))
+			httpx.WriteInternalError(w, "Database error", nil, log)
+			return
+		}
+		defer tx.Rollback()
+
+		// 1. Lock the lobby; leadership may have moved since RequireLobbyLeader ran
+		lobby, err := repo.GetLobbyForUpdateTx(tx, lobbyID)
+		if err == sql.ErrNoRows {
+			log.Info("lobby not found", slog.String("lobby_id", lobbyID.String()))
+			httpx.WriteNotFound(w, "Lobby not found", log)
+			return
+		}
+		if err != nil {
+			log.Error("failed to get lobby", slog.String("error", err.Error()), slog.String("lobby_id", lobbyID.String()))
+			httpx.WriteInternalError(w, "Database error", nil, log)
+			return
+		}
+		if lobby.LeaderID != userID {
+			log.Warn("user is no longer the leader", slog.String("lobby_id", lobbyID.String()), slog.String("user_id", userID.String()))
+			httpx.WriteForbidden(w, "User is not the lobby leader", log)
+			return
+		}
+
+		// 2. The target must be a current member
+		isMember, err := repo.IsMemberTx(tx, lobbyID, targetUserID)
+		if err != nil {
+			log.Error("failed to check membership", slog.String("error", err.Error()), slog.String("lobby_id", lobbyID.String()), slog.String("target_user_id", targetUserID.String()))
+			httpx.WriteInternalError(w, "Database error", nil, log)
+			return
+		}
+		if !isMember {
+			log.Warn("target user is not in lobby", slog.String("lobby_id", lobbyID.String()), slog.String("target_user_id", targetUserID.String()))
+			httpx.WriteError(w, http.StatusNotFound, "player_not_in_lobby", "Target user is not in the lobby", nil, log)
+			return
+		}
+
+		targetUsername, err := repo.GetUsernameTx(tx, targetUserID)
+		if err != nil {
+			log.Error("failed to get target username", slog.String("error", err.Error()), slog.String("target_user_id", targetUserID.String()))
+			httpx.WriteInternalError(w, "Database error", nil, log)
+			return
+		}
+
+		// 3. Hand over
+		if err := repo.SetLobbyLeaderTx(tx, lobbyID, targetUserID); err != nil {
+			log.Error("failed to update leader", slog.String("error", err.Error()), slog.String("lobby_id", lobbyID.String()))
+			httpx.WriteInternalError(w, "Database error", nil, log)
+			return
+		}
+
+		// 4. Announce the new leader; the outbox delivers the event once the transaction commits
+		if err := repo.EnqueueEventTx(tx, events.Event{
+			TargetType: events.TargetLobby,
+			TargetID:   lobbyID.String(),
+			EventType:  models.EventLeaderChanged,
+			Data: models.LeaderChangedEvent{
+				OldLeaderID:       userID,
+				NewLeaderID:       targetUserID,
+				NewLeaderUsername: targetUsername,
+			},
+		}); err != nil {
+			log.Error("failed to enqueue event", slog.String("error", err.Error()), slog.String("lobby_id", lobbyID.String()))
+			httpx.WriteInternalError(w, "Database error", nil, log)
+			return
+		}
+
+		if err := tx.Commit(); err != nil {
+			log.Error("failed to commit transaction", slog.String("error", err.Error()))
+			httpx.WriteInternalError(w, "Database error", nil, log)
+			return
+		}
+
+		// 5. The new leader also takes over the running game
+		if lobby.Status == models.LobbyStatusInGame && lobby.GameID != nil {
+			if err := games.SetLeader(r.Context(), *lobby.GameID, targetUserID); err != nil {
+				log.Warn("failed to set game leader", slog.String("game_id", lobby.GameID.String()), slog.String("error", err.Error()))
+			}
+		}
+
+		log.Info("leadership transferred",
+			slog.String("lobby_id", lobbyID.String()),
+			slog.String("old_leader_id", userID.String()),
+			slog.String("new_leader_id", targetUserID.String()))
+
+		httpx.WriteJSON(w, http.StatusOK, models.SuccessResponse{Success: true, Message: targetUsername + " is now the lobby leader"}, log)
+	}
+}
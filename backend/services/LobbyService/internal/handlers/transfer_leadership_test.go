@@ -0,0 +1,163 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/LobbyService/internal/models"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/LobbyService/internal/repository"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+func transferLeadership(h http.HandlerFunc, lobbyID, userID, targetUserID uuid.UUID) *httptest.ResponseRecorder {
+	body, _ := json.Marshal(models.TransferLeadershipRequest{TargetUserID: targetUserID.String()})
+	req := httptest.NewRequest(http.MethodPost, "/lobbies/"+lobbyID.String()+"/transfer-leadership", bytes.NewReader(body))
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, &chi.Context{
+		URLParams: chi.RouteParams{Keys: []string{"lobby_id"}, Values: []string{lobbyID.String()}},
+	}))
+	req.Header.Set(headerUserID, userID.String())
+	req.Header.Set(headerUsername, "Alice")
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	h(rec, req)
+	return rec
+}
+
+func expectLockedLobby(mock sqlmock.Sqlmock, lobbyID, leaderID uuid.UUID) {
+	now := time.Now()
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT id, join_code, leader_id, status, game_id, created_at, updated_at FROM lobbies WHERE id = \\$1 FOR UPDATE").
+		WithArgs(lobbyID).
+		WillReturnRows(sqlmock.NewRows(lobbyColumns).AddRow(lobbyID, "ABC123", leaderID, models.LobbyStatusWaiting, nil, now, now))
+}
+
+func TestTransferLeadership_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock DB: %v", err)
+	}
+	defer db.Close()
+
+	lobbyID, leaderID, bobID := uuid.New(), uuid.New(), uuid.New()
+	expectLockedLobby(mock, lobbyID, leaderID)
+	mock.ExpectQuery("SELECT EXISTS\\(SELECT 1 FROM players WHERE lobby_id = \\$1 AND user_id = \\$2 AND left_at IS NULL\\)").
+		WithArgs(lobbyID, bobID).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	mock.ExpectQuery("SELECT username FROM users WHERE id = \\$1").
+		WithArgs(bobID).
+		WillReturnRows(sqlmock.NewRows([]string{"username"}).AddRow("Bob"))
+	mock.ExpectExec("UPDATE lobbies SET leader_id = \\$1").
+		WithArgs(bobID, lobbyID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	expectEnqueue(mock, lobbyID, models.EventLeaderChanged, nil,
+		payloadContains(`"old_leader_id":"`+leaderID.String()+`","new_leader_id":"`+bobID.String()+`","new_leader_username":"Bob"`))
+	mock.ExpectCommit()
+
+	rec := transferLeadership(TransferLeadershipHandler(repository.New(db), &fakeGames{}), lobbyID, leaderID, bobID)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestTransferLeadership_RunningLobbyHandsOverGame(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock DB: %v", err)
+	}
+	defer db.Close()
+
+	lobbyID, leaderID, bobID, gameID := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	now := time.Now()
+	mock.ExpectBegin()
+	mock.ExpectQuery("FROM lobbies WHERE id = \\$1 FOR UPDATE").
+		WithArgs(lobbyID).
+		WillReturnRows(sqlmock.NewRows(lobbyColumns).AddRow(lobbyID, "ABC123", leaderID, models.LobbyStatusInGame, gameID, now, now))
+	mock.ExpectQuery("SELECT EXISTS").WithArgs(lobbyID, bobID).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	mock.ExpectQuery("SELECT username FROM users").WithArgs(bobID).WillReturnRows(sqlmock.NewRows([]string{"username"}).AddRow("Bob"))
+	mock.ExpectExec("UPDATE lobbies SET leader_id = \\$1").WithArgs(bobID, lobbyID).WillReturnResult(sqlmock.NewResult(0, 1))
+	expectEnqueue(mock, lobbyID, models.EventLeaderChanged, nil, payloadContains(`"new_leader_id":"`+bobID.String()+`"`))
+	mock.ExpectCommit()
+
+	games := &fakeGames{}
+	rec := transferLeadership(TransferLeadershipHandler(repository.New(db), games), lobbyID, leaderID, bobID)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if len(games.leaders) != 1 || games.leaders[0] != gameID.String()+":"+bobID.String() {
+		t.Fatalf("expected Bob to lead the game, got %v", games.leaders)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestTransferLeadership_TargetNotInLobby(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock DB: %v", err)
+	}
+	defer db.Close()
+
+	lobbyID, leaderID, strangerID := uuid.New(), uuid.New(), uuid.New()
+	expectLockedLobby(mock, lobbyID, leaderID)
+	mock.ExpectQuery("SELECT EXISTS").
+		WithArgs(lobbyID, strangerID).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+	// no outbox insert, the leader stays
+	mock.ExpectRollback()
+
+	rec := transferLeadership(TransferLeadershipHandler(repository.New(db), &fakeGames{}), lobbyID, leaderID, strangerID)
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d", rec.Code)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestTransferLeadership_LeaderChangedConcurrently(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock DB: %v", err)
+	}
+	defer db.Close()
+
+	lobbyID, formerLeaderID, bobID := uuid.New(), uuid.New(), uuid.New()
+	expectLockedLobby(mock, lobbyID, bobID)
+	mock.ExpectRollback()
+
+	rec := transferLeadership(TransferLeadershipHandler(repository.New(db), &fakeGames{}), lobbyID, formerLeaderID, bobID)
+	if rec.Code != http.StatusForbidden {
+		t.Fatalf("expected 403, got %d", rec.Code)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestTransferLeadership_ToSelf(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock DB: %v", err)
+	}
+	defer db.Close()
+
+	lobbyID, leaderID := uuid.New(), uuid.New()
+	rec := transferLeadership(TransferLeadershipHandler(repository.New(db), &fakeGames{}), lobbyID, leaderID, leaderID)
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", rec.Code)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unfulfilled expectations: %v", err)
+	}
+}
@@ -8,6 +8,8 @@ import (
 
 	"github.com/KnuffelGame/KnuffelGame/backend/libs/httpx"
 	"github.com/KnuffelGame/KnuffelGame/backend/libs/logger"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/LobbyService/internal/events"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/LobbyService/internal/game"
 	"github.com/KnuffelGame/KnuffelGame/backend/services/LobbyService/internal/models"
 	"github.com/KnuffelGame/KnuffelGame/backend/services/LobbyService/internal/repository"
 	"github.com/go-chi/chi/v5"
@@ -17,8 +19,10 @@ import (
 // UpdatePlayerActiveStatusHandler returns an http.HandlerFunc that updates a player's active status in a lobby
 // Path parameters: lobby_id (UUID), player_id (UUID)
 // Request body: UpdatePlayerActiveStatusRequest with is_active boolean field
+// player_active or player_inactive is written to the event outbox in the same transaction.
+// A player of a running lobby that is flagged active again is taken back into the turn order of the game.
 // Returns: 204 No Content on success, various error responses on failure
-func UpdatePlayerActiveStatusHandler(repo repository.Repository) http.HandlerFunc {
+func UpdatePlayerActiveStatusHandler(repo repository.Repository, games game.Client) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		log := logger.Logger(r.Context()).WithGroup("handler").With(slog.String("action", "update_player_active_status"))
 
@@ -70,14 +74,14 @@ func UpdatePlayerActiveStatusHandler(repo repository.Repository) http.HandlerFun
 		defer tx.Rollback()
 
 		// 1. Validate that the lobby exists
-		_, err = repo.GetLobbyLeaderID(r.Context(), lobbyID)
+		lobby, err := repo.GetLobbyForUpdateTx(tx, lobbyID)
 		if err != nil {
 			if err == sql.ErrNoRows {
 				log.Warn("lobby not found", slog.String("lobby_id", lobbyID.String()))
 				httpx.WriteNotFound(w, "Lobby not found", log)
 				return
 			}
-			log.Error("failed to get lobby leader", slog.String("error", err.Error()), slog.String("lobby_id", lobbyID.String()))
+			log.Error("failed to get lobby", slog.String("error", err.Error()), slog.String("lobby_id", lobbyID.String()))
 			httpx.WriteInternalError(w, "Database error", nil, log)
 			return
 		}
@@ -108,6 +112,28 @@ func UpdatePlayerActiveStatusHandler(repo repository.Repository) http.HandlerFun
 			return
 		}
 
+		// 4. Announce the change; the outbox delivers the event once the transaction commits
+		username, err := repo.GetUsernameTx(tx, playerID)
+		if err != nil {
+			log.Error("failed to get username", slog.String("error", err.Error()), slog.String("player_id", playerID.String()))
+			httpx.WriteInternalError(w, "Database error", nil, log)
+			return
+		}
+		eventType := models.EventPlayerInactive
+		if req.IsActive {
+			eventType = models.EventPlayerActive
+		}
+		if err := repo.EnqueueEventTx(tx, events.Event{
+			TargetType: events.TargetLobby,
+			TargetID:   lobbyID.String(),
+			EventType:  eventType,
+			Data:       models.PlayerActivityEvent{UserID: playerID, Username: username},
+		}); err != nil {
+			log.Error("failed to enqueue event", slog.String("error", err.Error()), slog.String("lobby_id", lobbyID.String()))
+			httpx.WriteInternalError(w, "Database error", nil, log)
+			return
+		}
+
 		// Commit transaction
 		if err := tx.Commit(); err != nil {
 			log.Error("failed to commit transaction", slog.String("error", err.Error()))
@@ -115,6 +141,13 @@ func UpdatePlayerActiveStatusHandler(repo repository.Repository) http.HandlerFun
 			return
 		}
 
+		// 5. Take the player back into the turn order of the running game
+		if req.IsActive && lobby.Status == models.LobbyStatusInGame && lobby.GameID != nil {
+			if err := games.ActivatePlayer(r.Context(), *lobby.GameID, playerID); err != nil {
+				log.Warn("failed to activate player in game", slog.String("game_id", lobby.GameID.String()), slog.String("error", err.Error()))
+			}
+		}
+
 		log.Info("player active status updated successfully",
 			slog.String("lobby_id", lobbyID.String()),
 			slog.String("player_id", playerID.String()),
@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/KnuffelGame/KnuffelGame/backend/services/LobbyService/internal/models"
@@ -16,6 +17,14 @@ import (
 	"github.com/google/uuid"
 )
 
+// expectActiveLobby expects the lobby to be locked; gameID is nil for lobbies without a game
+func expectActiveLobby(mock sqlmock.Sqlmock, lobbyID uuid.UUID, status string, gameID interface{}) {
+	now := time.Now()
+	mock.ExpectQuery("SELECT id, join_code, leader_id, status, game_id, created_at, updated_at FROM lobbies WHERE id = \\$1 FOR UPDATE").
+		WithArgs(lobbyID).
+		WillReturnRows(sqlmock.NewRows(lobbyColumns).AddRow(lobbyID, "ABC123", uuid.New(), status, gameID, now, now))
+}
+
 func TestUpdatePlayerActiveStatus_Success_ActiveToInactive(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	if err != nil {
@@ -29,24 +38,27 @@ func TestUpdatePlayerActiveStatus_Success_ActiveToInactive(t *testing.T) {
 	// Transaction expectations
 	mock.ExpectBegin()
 
-	// Get lobby leader ID (to verify lobby exists)
-	mock.ExpectQuery("SELECT leader_id::text FROM lobbies WHERE id =").
-		WithArgs(lobbyID).
-		WillReturnRows(sqlmock.NewRows([]string{"leader_id"}).AddRow(uuid.New().String()))
+	// Lock the lobby (to verify lobby exists)
+	expectActiveLobby(mock, lobbyID, models.LobbyStatusWaiting, nil)
 
 	// Check if player is member
-	mock.ExpectQuery("SELECT EXISTS\\(SELECT 1 FROM players WHERE lobby_id = \\$1 AND user_id = \\$2\\)").
+	mock.ExpectQuery("SELECT EXISTS\\(SELECT 1 FROM players WHERE lobby_id = \\$1 AND user_id = \\$2 AND left_at IS NULL\\)").
 		WithArgs(lobbyID, playerID).
 		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
 
 	// Update player active status
-	mock.ExpectExec("UPDATE players SET is_active = \\$1 WHERE lobby_id = \\$2 AND id = \\$3").
+	mock.ExpectExec("UPDATE players SET is_active = \\$1 WHERE lobby_id = \\$2 AND user_id = \\$3").
 		WithArgs(false, lobbyID, playerID).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
+	mock.ExpectQuery("SELECT username FROM users WHERE id = \\$1").
+		WithArgs(playerID).
+		WillReturnRows(sqlmock.NewRows([]string{"username"}).AddRow("Alice"))
+	expectEnqueue(mock, lobbyID, models.EventPlayerInactive, nil, payloadContains(`"username":"Alice"`))
+
 	mock.ExpectCommit()
 
-	h := UpdatePlayerActiveStatusHandler(repository.New(db))
+	h := UpdatePlayerActiveStatusHandler(repository.New(db), &fakeGames{})
 
 	reqBody := models.UpdatePlayerActiveStatusRequest{IsActive: false}
 	bodyBytes, _ := json.Marshal(reqBody)
@@ -84,24 +96,27 @@ func TestUpdatePlayerActiveStatus_Success_InactiveToActive(t *testing.T) {
 	// Transaction expectations
 	mock.ExpectBegin()
 
-	// Get lobby leader ID (to verify lobby exists)
-	mock.ExpectQuery("SELECT leader_id::text FROM lobbies WHERE id =").
-		WithArgs(lobbyID).
-		WillReturnRows(sqlmock.NewRows([]string{"leader_id"}).AddRow(uuid.New().String()))
+	// Lock the lobby (to verify lobby exists)
+	expectActiveLobby(mock, lobbyID, models.LobbyStatusWaiting, nil)
 
 	// Check if player is member
-	mock.ExpectQuery("SELECT EXISTS\\(SELECT 1 FROM players WHERE lobby_id = \\$1 AND user_id = \\$2\\)").
+	mock.ExpectQuery("SELECT EXISTS\\(SELECT 1 FROM players WHERE lobby_id = \\$1 AND user_id = \\$2 AND left_at IS NULL\\)").
 		WithArgs(lobbyID, playerID).
 		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
 
 	// Update player active status
-	mock.ExpectExec("UPDATE players SET is_active = \\$1 WHERE lobby_id = \\$2 AND id = \\$3").
+	mock.ExpectExec("UPDATE players SET is_active = \\$1 WHERE lobby_id = \\$2 AND user_id = \\$3").
 		WithArgs(true, lobbyID, playerID).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
+	mock.ExpectQuery("SELECT username FROM users WHERE id = \\$1").
+		WithArgs(playerID).
+		WillReturnRows(sqlmock.NewRows([]string{"username"}).AddRow("Alice"))
+	expectEnqueue(mock, lobbyID, models.EventPlayerActive, nil, payloadContains(`"username":"Alice"`))
+
 	mock.ExpectCommit()
 
-	h := UpdatePlayerActiveStatusHandler(repository.New(db))
+	h := UpdatePlayerActiveStatusHandler(repository.New(db), &fakeGames{})
 
 	reqBody := models.UpdatePlayerActiveStatusRequest{IsActive: true}
 	bodyBytes, _ := json.Marshal(reqBody)
@@ -126,6 +141,64 @@ func TestUpdatePlayerActiveStatus_Success_InactiveToActive(t *testing.T) {
 	}
 }
 
+func TestUpdatePlayerActiveStatus_RunningLobbyActivatesPlayerInGame(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock DB: %v", err)
+	}
+	defer db.Close()
+
+	lobbyID := uuid.New()
+	playerID := uuid.New()
+	gameID := uuid.New()
+
+	for _, isActive := range []bool{false, true} {
+		eventType := models.EventPlayerInactive
+		if isActive {
+			eventType = models.EventPlayerActive
+		}
+		mock.ExpectBegin()
+		expectActiveLobby(mock, lobbyID, models.LobbyStatusInGame, gameID)
+		mock.ExpectQuery("SELECT EXISTS\\(SELECT 1 FROM players WHERE lobby_id = \\$1 AND user_id = \\$2 AND left_at IS NULL\\)").
+			WithArgs(lobbyID, playerID).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+		mock.ExpectExec("UPDATE players SET is_active = \\$1 WHERE lobby_id = \\$2 AND user_id = \\$3").
+			WithArgs(isActive, lobbyID, playerID).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectQuery("SELECT username FROM users WHERE id = \\$1").
+			WithArgs(playerID).
+			WillReturnRows(sqlmock.NewRows([]string{"username"}).AddRow("Alice"))
+		expectEnqueue(mock, lobbyID, eventType, nil, payloadContains(`"username":"Alice"`))
+		mock.ExpectCommit()
+	}
+
+	games := &fakeGames{}
+	h := UpdatePlayerActiveStatusHandler(repository.New(db), games)
+	for _, isActive := range []bool{false, true} {
+		bodyBytes, _ := json.Marshal(models.UpdatePlayerActiveStatusRequest{IsActive: isActive})
+		req := httptest.NewRequest(http.MethodPut, "/lobbies/"+lobbyID.String()+"/players/"+playerID.String()+"/active", bytes.NewReader(bodyBytes))
+		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, &chi.Context{
+			URLParams: chi.RouteParams{
+				Keys:   []string{"lobby_id", "player_id"},
+				Values: []string{lobbyID.String(), playerID.String()},
+			},
+		}))
+		rec := httptest.NewRecorder()
+		h(rec, req)
+		if rec.Code != http.StatusNoContent {
+			t.Fatalf("expected 204, got %d: %s", rec.Code, rec.Body.String())
+		}
+	}
+
+	// only the reactivation reaches the game; the game itself flags timed out players inactive
+	if len(games.activated) != 1 || games.activated[0] != gameID.String()+":"+playerID.String() {
+		t.Fatalf("expected the player to be activated in the game once, got %v", games.activated)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unfulfilled expectations: %v", err)
+	}
+}
+
 func TestUpdatePlayerActiveStatus_InvalidLobbyID(t *testing.T) {
 	db, _, err := sqlmock.New()
 	if err != nil {
@@ -133,7 +206,7 @@ func TestUpdatePlayerActiveStatus_InvalidLobbyID(t *testing.T) {
 	}
 	defer db.Close()
 
-	h := UpdatePlayerActiveStatusHandler(repository.New(db))
+	h := UpdatePlayerActiveStatusHandler(repository.New(db), &fakeGames{})
 
 	reqBody := models.UpdatePlayerActiveStatusRequest{IsActive: true}
 	bodyBytes, _ := json.Marshal(reqBody)
@@ -155,7 +228,7 @@ func TestUpdatePlayerActiveStatus_InvalidPlayerID(t *testing.T) {
 	}
 	defer db.Close()
 
-	h := UpdatePlayerActiveStatusHandler(repository.New(db))
+	h := UpdatePlayerActiveStatusHandler(repository.New(db), &fakeGames{})
 
 	reqBody := models.UpdatePlayerActiveStatusRequest{IsActive: true}
 	bodyBytes, _ := json.Marshal(reqBody)
@@ -181,12 +254,12 @@ func TestUpdatePlayerActiveStatus_LobbyNotFound(t *testing.T) {
 	playerID := uuid.New()
 
 	mock.ExpectBegin()
-	mock.ExpectQuery("SELECT leader_id::text FROM lobbies WHERE id =").
+	mock.ExpectQuery("FROM lobbies WHERE id = \\$1 FOR UPDATE").
 		WithArgs(lobbyID).
 		WillReturnError(sql.ErrNoRows)
 	mock.ExpectRollback()
 
-	h := UpdatePlayerActiveStatusHandler(repository.New(db))
+	h := UpdatePlayerActiveStatusHandler(repository.New(db), &fakeGames{})
 
 	reqBody := models.UpdatePlayerActiveStatusRequest{IsActive: true}
 	bodyBytes, _ := json.Marshal(reqBody)
@@ -224,18 +297,16 @@ func TestUpdatePlayerActiveStatus_PlayerNotFoundInLobby(t *testing.T) {
 	mock.ExpectBegin()
 
 	// Get lobby leader ID (lobby exists)
-	mock.ExpectQuery("SELECT leader_id::text FROM lobbies WHERE id =").
-		WithArgs(lobbyID).
-		WillReturnRows(sqlmock.NewRows([]string{"leader_id"}).AddRow(uuid.New().String()))
+	expectActiveLobby(mock, lobbyID, models.LobbyStatusWaiting, nil)
 
 	// Check if player is member (not a member)
-	mock.ExpectQuery("SELECT EXISTS\\(SELECT 1 FROM players WHERE lobby_id = \\$1 AND user_id = \\$2\\)").
+	mock.ExpectQuery("SELECT EXISTS\\(SELECT 1 FROM players WHERE lobby_id = \\$1 AND user_id = \\$2 AND left_at IS NULL\\)").
 		WithArgs(lobbyID, playerID).
 		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
 
 	mock.ExpectRollback()
 
-	h := UpdatePlayerActiveStatusHandler(repository.New(db))
+	h := UpdatePlayerActiveStatusHandler(repository.New(db), &fakeGames{})
 
 	reqBody := models.UpdatePlayerActiveStatusRequest{IsActive: true}
 	bodyBytes, _ := json.Marshal(reqBody)
@@ -267,7 +338,7 @@ func TestUpdatePlayerActiveStatus_InvalidRequestBody(t *testing.T) {
 	}
 	defer db.Close()
 
-	h := UpdatePlayerActiveStatusHandler(repository.New(db))
+	h := UpdatePlayerActiveStatusHandler(repository.New(db), &fakeGames{})
 
 	// Invalid JSON
 	req := httptest.NewRequest(http.MethodPut, "/lobbies/"+uuid.New().String()+"/players/"+uuid.New().String()+"/active-status", bytes.NewReader([]byte("invalid json")))
@@ -294,23 +365,21 @@ func TestUpdatePlayerActiveStatus_DatabaseTransactionError(t *testing.T) {
 	mock.ExpectBegin()
 
 	// Get lobby leader ID (lobby exists)
-	mock.ExpectQuery("SELECT leader_id::text FROM lobbies WHERE id =").
-		WithArgs(lobbyID).
-		WillReturnRows(sqlmock.NewRows([]string{"leader_id"}).AddRow(uuid.New().String()))
+	expectActiveLobby(mock, lobbyID, models.LobbyStatusWaiting, nil)
 
 	// Check if player is member
-	mock.ExpectQuery("SELECT EXISTS\\(SELECT 1 FROM players WHERE lobby_id = \\$1 AND user_id = \\$2\\)").
+	mock.ExpectQuery("SELECT EXISTS\\(SELECT 1 FROM players WHERE lobby_id = \\$1 AND user_id = \\$2 AND left_at IS NULL\\)").
 		WithArgs(lobbyID, playerID).
 		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
 
 	// Update player active status fails
-	mock.ExpectExec("UPDATE players SET is_active = \\$1 WHERE lobby_id = \\$2 AND id = \\$3").
+	mock.ExpectExec("UPDATE players SET is_active = \\$1 WHERE lobby_id = \\$2 AND user_id = \\$3").
 		WithArgs(true, lobbyID, playerID).
 		WillReturnError(sql.ErrConnDone)
 
 	mock.ExpectRollback()
 
-	h := UpdatePlayerActiveStatusHandler(repository.New(db))
+	h := UpdatePlayerActiveStatusHandler(repository.New(db), &fakeGames{})
 
 	reqBody := models.UpdatePlayerActiveStatusRequest{IsActive: true}
 	bodyBytes, _ := json.Marshal(reqBody)
@@ -0,0 +1,129 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/KnuffelGame/KnuffelGame/backend/libs/httpx"
+	"github.com/KnuffelGame/KnuffelGame/backend/libs/logger"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/LobbyService/internal/events"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/LobbyService/internal/models"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/LobbyService/internal/repository"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// UpdatePlayerReadyHandler returns an http.HandlerFunc that toggles the requesting player's ready status
+// Must be mounted behind RequireLobbyMember
+// Headers required: X-User-ID, X-Username (from Gateway)
+// Path parameter: lobby_id (UUID)
+// Request body: UpdatePlayerReadyRequest with is_ready boolean field
+// The lobby row is locked so the toggle cannot race a start.
+// player_ready or player_not_ready is written to the event outbox in the same transaction.
+// Returns: 204 No Content on success, 404 if the user has left the lobby, 409 if the game already started
+func UpdatePlayerReadyHandler(repo repository.Repository) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log := logger.Logger(r.Context()).WithGroup("handler").With(slog.String("action", "update_player_ready"))
+
+		// Extract user context from headers
+		userIDStr := r.Header.Get(headerUserID)
+		username := r.Header.Get(headerUsername)
+
+		if userIDStr == "" || username == "" {
+			log.Warn("missing required headers", slog.String("user_id", userIDStr), slog.String("username", username))
+			httpx.WriteBadRequest(w, "Missing required headers: X-User-ID and X-Username", nil, log)
+			return
+		}
+
+		userID, err := uuid.Parse(userIDStr)
+		if err != nil {
+			log.Warn("invalid user_id format", slog.String("user_id", userIDStr), slog.String("error", err.Error()))
+			httpx.WriteBadRequest(w, "Invalid user ID format", map[string]interface{}{"detail": err.Error()}, log)
+			return
+		}
+
+		lobbyIDStr := chi.URLParam(r, "lobby_id")
+		lobbyID, err := uuid.Parse(lobbyIDStr)
+		if err != nil {
+			log.Warn("invalid lobby_id format", slog.String("lobby_id", lobbyIDStr))
+			httpx.WriteBadRequest(w, "Invalid lobby ID format", nil, log)
+			return
+		}
+
+		var req models.UpdatePlayerReadyRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			log.Warn("failed to decode request body", slog.String("error", err.Error()))
+			httpx.WriteBadRequest(w, "Invalid request body", map[string]interface{}{"detail": err.Error()}, log)
+			return
+		}
+
+		tx, err := repo.BeginTx(r.Context())
+		if err != nil {
+			log.Error("failed to begin transaction", slog.String("error", err.Error()))
+			httpx.WriteInternalError(w, "Database error", nil, log)
+			return
+		}
+		defer tx.Rollback()
+
+		// 1. Lock the lobby; readiness only matters before the game starts
+		lobby, err := repo.GetLobbyForUpdateTx(tx, lobbyID)
+		if err == sql.ErrNoRows {
+			log.Info("lobby not found", slog.String("lobby_id", lobbyID.String()))
+			httpx.WriteNotFound(w, "Lobby not found", log)
+			return
+		}
+		if err != nil {
+			log.Error("failed to get lobby", slog.String("error", err.Error()), slog.String("lobby_id", lobbyID.String()))
+			httpx.WriteInternalError(w, "Database error", nil, log)
+			return
+		}
+		if lobby.Status != models.LobbyStatusWaiting {
+			log.Info("lobby not waiting", slog.String("lobby_id", lobbyID.String()), slog.String("status", lobby.Status))
+			httpx.WriteError(w, http.StatusConflict, "lobby_not_waiting", "Ready status can only be changed before the game starts", nil, log)
+			return
+		}
+
+		// 2. Store the ready status
+		if err := repo.UpdatePlayerReadyTx(tx, lobbyID, userID, req.IsReady); err != nil {
+			if err == sql.ErrNoRows {
+				log.Info("user not in lobby", slog.String("lobby_id", lobbyID.String()), slog.String("user_id", userID.String()))
+				httpx.WriteError(w, http.StatusNotFound, "not_in_lobby", "You are not in this lobby", nil, log)
+				return
+			}
+			log.Error("failed to update ready status", slog.String("error", err.Error()), slog.String("lobby_id", lobbyID.String()))
+			httpx.WriteInternalError(w, "Database error", nil, log)
+			return
+		}
+
+		// 3. Announce the change; the outbox delivers the event once the transaction commits
+		eventType := models.EventPlayerNotReady
+		if req.IsReady {
+			eventType = models.EventPlayerReady
+		}
+		if err := repo.EnqueueEventTx(tx, events.Event{
+			TargetType: events.TargetLobby,
+			TargetID:   lobbyID.String(),
+			EventType:  eventType,
+			Data:       models.PlayerActivityEvent{UserID: userID, Username: username},
+		}); err != nil {
+			log.Error("failed to enqueue event", slog.String("error", err.Error()), slog.String("lobby_id", lobbyID.String()))
+			httpx.WriteInternalError(w, "Database error", nil, log)
+			return
+		}
+
+		if err := tx.Commit(); err != nil {
+			log.Error("failed to commit transaction", slog.String("error", err.Error()))
+			httpx.WriteInternalError(w, "Database error", nil, log)
+			return
+		}
+
+		log.Info("player ready status updated",
+			slog.String("lobby_id", lobbyID.String()),
+			slog.String("user_id", userID.String()),
+			slog.Bool("is_ready", req.IsReady))
+
+		httpx.WriteNoContent(w)
+	}
+}
@@ -0,0 +1,122 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/LobbyService/internal/models"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/LobbyService/internal/repository"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+func updateReady(h http.HandlerFunc, lobbyID, userID uuid.UUID, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPut, "/lobbies/"+lobbyID.String()+"/ready", strings.NewReader(body))
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, &chi.Context{
+		URLParams: chi.RouteParams{Keys: []string{"lobby_id"}, Values: []string{lobbyID.String()}},
+	}))
+	req.Header.Set(headerUserID, userID.String())
+	req.Header.Set(headerUsername, "Bob")
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	h(rec, req)
+	return rec
+}
+
+func expectReadyLobby(mock sqlmock.Sqlmock, lobbyID uuid.UUID, status string) {
+	now := time.Now()
+	mock.ExpectBegin()
+	mock.ExpectQuery("FROM lobbies WHERE id = \\$1 FOR UPDATE").
+		WithArgs(lobbyID).
+		WillReturnRows(sqlmock.NewRows(lobbyColumns).AddRow(lobbyID, "ABC123", uuid.New(), status, nil, now, now))
+}
+
+func TestUpdatePlayerReady_Success(t *testing.T) {
+	tests := []struct {
+		isReady   bool
+		eventType string
+	}{
+		{true, models.EventPlayerReady},
+		{false, models.EventPlayerNotReady},
+	}
+	for _, tt := range tests {
+		t.Run(tt.eventType, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("failed to create mock DB: %v", err)
+			}
+			defer db.Close()
+
+			lobbyID, userID := uuid.New(), uuid.New()
+			expectReadyLobby(mock, lobbyID, models.LobbyStatusWaiting)
+			mock.ExpectExec("UPDATE players SET is_ready = \\$1 WHERE lobby_id = \\$2 AND user_id = \\$3 AND left_at IS NULL").
+				WithArgs(tt.isReady, lobbyID, userID).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			expectEnqueue(mock, lobbyID, tt.eventType, nil, payloadContains(`"username":"Bob"`))
+			mock.ExpectCommit()
+
+			body, _ := json.Marshal(models.UpdatePlayerReadyRequest{IsReady: tt.isReady})
+			rec := updateReady(UpdatePlayerReadyHandler(repository.New(db)), lobbyID, userID, string(body))
+			if rec.Code != http.StatusNoContent {
+				t.Fatalf("expected 204, got %d: %s", rec.Code, rec.Body.String())
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Fatalf("unfulfilled expectations: %v", err)
+			}
+		})
+	}
+}
+
+func TestUpdatePlayerReady_GameStarted(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock DB: %v", err)
+	}
+	defer db.Close()
+
+	lobbyID := uuid.New()
+	expectReadyLobby(mock, lobbyID, models.LobbyStatusInGame)
+	mock.ExpectRollback()
+
+	rec := updateReady(UpdatePlayerReadyHandler(repository.New(db)), lobbyID, uuid.New(), `{"is_ready":true}`)
+	if rec.Code != http.StatusConflict {
+		t.Fatalf("expected 409, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var resp map[string]interface{}
+	_ = json.Unmarshal(rec.Body.Bytes(), &resp)
+	if resp["error"] != "lobby_not_waiting" {
+		t.Fatalf("expected lobby_not_waiting, got %+v", resp)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestUpdatePlayerReady_NotInLobby(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock DB: %v", err)
+	}
+	defer db.Close()
+
+	lobbyID, userID := uuid.New(), uuid.New()
+	expectReadyLobby(mock, lobbyID, models.LobbyStatusWaiting)
+	mock.ExpectExec("UPDATE players SET is_ready").
+		WithArgs(true, lobbyID, userID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	rec := updateReady(UpdatePlayerReadyHandler(repository.New(db)), lobbyID, userID, `{"is_ready":true}`)
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unfulfilled expectations: %v", err)
+	}
+}
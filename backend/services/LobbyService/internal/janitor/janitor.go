@@ -0,0 +1,122 @@
+// Package janitor closes expired lobbies so the database and the join code space do not fill up.
+// Idle or abandoned waiting lobbies are deleted, abandoned running lobbies are finished and finished lobbies are
+// deleted once their retention passed; deleting a lobby frees its join code.
+// Expired lobbies are claimed with FOR UPDATE SKIP LOCKED, so every replica can run a Janitor.
+// The game of a finished running lobby is aborted in the Game Service.
+package janitor
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/KnuffelGame/KnuffelGame/backend/services/LobbyService/internal/events"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/LobbyService/internal/game"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/LobbyService/internal/models"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/LobbyService/internal/repository"
+)
+
+// batchSize bounds the lobbies closed per transaction
+const batchSize = 100
+
+// Janitor periodically closes the lobbies expired according to its LobbyExpiry.
+type Janitor struct {
+	repo     repository.Repository
+	games    game.Client
+	expiry   models.LobbyExpiry
+	interval time.Duration
+	log      *slog.Logger
+}
+
+// NewJanitor creates a Janitor sweeping every interval.
+func NewJanitor(repo repository.Repository, games game.Client, expiry models.LobbyExpiry, interval time.Duration, log *slog.Logger) *Janitor {
+	return &Janitor{repo: repo, games: games, expiry: expiry, interval: interval, log: log.With(slog.String("component", "janitor"))}
+}
+
+// Run sweeps until ctx is cancelled.
+// A full batch is followed by the next one immediately, so a backlog is cleared within one interval.
+func (j *Janitor) Run(ctx context.Context) {
+	for {
+		n, err := j.SweepOnce(ctx)
+		if err != nil {
+			j.log.Error("failed to close expired lobbies", slog.String("error", err.Error()))
+		}
+		if n == batchSize && err == nil {
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(j.interval):
+		}
+	}
+}
+
+// SweepOnce closes up to one batch of expired lobbies in one transaction and returns their number.
+// For lobbies that were still open, lobby_closed and the closing of their stream are written to the event outbox
+// in the same transaction, so the stream closes after the event was delivered; clients that miss the event get a 404
+// or the finished status on their next fetch.
+// Games of finished running lobbies are aborted after commit; if that fails, their turn timeouts end them.
+func (j *Janitor) SweepOnce(ctx context.Context) (int, error) {
+	tx, err := j.repo.BeginTx(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	expired, err := j.repo.ClaimExpiredLobbiesTx(tx, j.expiry, batchSize)
+	if err != nil {
+		return 0, err
+	}
+	for _, l := range expired {
+		if l.Status == models.LobbyStatusInGame {
+			err = j.repo.SetLobbyStatusTx(tx, l.ID, models.LobbyStatusFinished)
+		} else {
+			err = j.repo.DeleteLobbyTx(tx, l.ID)
+		}
+		if err != nil {
+			return 0, err
+		}
+		// finished lobbies have no open streams left
+		if l.Status == models.LobbyStatusFinished {
+			continue
+		}
+		reason := events.ReasonCleanup
+		if l.Status == models.LobbyStatusWaiting {
+			reason = events.ReasonLobbyDeleted
+		}
+		for _, e := range []events.Event{
+			{
+				TargetType: events.TargetLobby,
+				TargetID:   l.ID.String(),
+				EventType:  models.EventLobbyClosed,
+				Data:       models.LobbyClosedEvent{LobbyID: l.ID, Reason: l.Reason},
+			},
+			{
+				TargetType: events.TargetLobby,
+				TargetID:   l.ID.String(),
+				EventType:  events.EventUnregister,
+				Data:       events.UnregisterData{Reason: reason},
+			},
+		} {
+			if err := j.repo.EnqueueEventTx(tx, e); err != nil {
+				return 0, err
+			}
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+
+	for _, l := range expired {
+		log := j.log.With(slog.String("lobby_id", l.ID.String()), slog.String("status", l.Status), slog.String("reason", l.Reason))
+		log.Info("lobby closed")
+		if l.Status != models.LobbyStatusInGame || l.GameID == nil {
+			continue
+		}
+		if err := j.games.AbortGame(ctx, *l.GameID); err != nil {
+			log.Warn("failed to abort game", slog.String("game_id", l.GameID.String()), slog.String("error", err.Error()))
+		}
+	}
+	return len(expired), nil
+}
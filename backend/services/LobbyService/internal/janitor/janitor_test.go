@@ -0,0 +1,166 @@
+package janitor
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/LobbyService/internal/events"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/LobbyService/internal/game"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/LobbyService/internal/models"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/LobbyService/internal/repository"
+	"github.com/google/uuid"
+)
+
+// fakeGames records aborted games
+type fakeGames struct {
+	game.Client
+	aborted []uuid.UUID
+}
+
+func (f *fakeGames) AbortGame(_ context.Context, gameID uuid.UUID) error {
+	f.aborted = append(f.aborted, gameID)
+	return nil
+}
+
+// payloadContains matches an outbox payload containing the given JSON fragment
+type payloadContains string
+
+func (p payloadContains) Match(v driver.Value) bool {
+	b, ok := v.([]byte)
+	return ok && strings.Contains(string(b), string(p))
+}
+
+var (
+	expiredColumns = []string{"id", "status", "reason", "game_id"}
+	testExpiry     = models.LobbyExpiry{IdleTimeout: 2 * time.Hour, InactiveTimeout: 15 * time.Minute, FinishedRetention: 168 * time.Hour}
+)
+
+func newJanitor(t *testing.T, games game.Client) (*Janitor, sqlmock.Sqlmock) {
+	t.Helper()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock DB: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return NewJanitor(repository.New(db), games, testExpiry, time.Minute, slog.New(slog.NewTextHandler(io.Discard, nil))), mock
+}
+
+func expectClaim(mock sqlmock.Sqlmock, rows *sqlmock.Rows) {
+	mock.ExpectQuery("FROM lobbies l.*LEFT JOIN LATERAL.*FOR UPDATE OF l SKIP LOCKED").
+		WithArgs(models.LobbyStatusFinished, float64(168*3600), models.LobbyStatusWaiting, float64(2*3600),
+			models.LobbyStatusInGame, float64(15*60), batchSize).
+		WillReturnRows(rows)
+}
+
+// expectClosed expects lobby_closed followed by the closing of the lobby stream to be written to the outbox
+func expectClosed(mock sqlmock.Sqlmock, lobbyID uuid.UUID, reason, unregisterReason string) {
+	mock.ExpectExec("INSERT INTO event_outbox").
+		WithArgs(events.TargetLobby, lobbyID.String(), models.EventLobbyClosed, nil, payloadContains(`"reason":"`+reason+`"`)).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("INSERT INTO event_outbox").
+		WithArgs(events.TargetLobby, lobbyID.String(), events.EventUnregister, nil, payloadContains(`{"reason":"`+unregisterReason+`"}`)).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+}
+
+func TestSweepOnce(t *testing.T) {
+	idleID, runningID, finishedID, gameID := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	games := &fakeGames{}
+	j, mock := newJanitor(t, games)
+
+	mock.ExpectBegin()
+	expectClaim(mock, sqlmock.NewRows(expiredColumns).
+		AddRow(idleID, models.LobbyStatusWaiting, models.ExpiryIdle, nil).
+		AddRow(runningID, models.LobbyStatusInGame, models.ExpiryInactive, gameID).
+		AddRow(finishedID, models.LobbyStatusFinished, models.ExpiryRetention, uuid.New()))
+	mock.ExpectExec("DELETE FROM lobbies WHERE id = \\$1").WithArgs(idleID).WillReturnResult(sqlmock.NewResult(0, 1))
+	expectClosed(mock, idleID, models.ExpiryIdle, events.ReasonLobbyDeleted)
+	mock.ExpectExec("UPDATE lobbies").WithArgs(models.LobbyStatusFinished, runningID).WillReturnResult(sqlmock.NewResult(0, 1))
+	expectClosed(mock, runningID, models.ExpiryInactive, events.ReasonCleanup)
+	// the finished lobby has no open streams left
+	mock.ExpectExec("DELETE FROM lobbies WHERE id = \\$1").WithArgs(finishedID).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	n, err := j.SweepOnce(context.Background())
+	if err != nil || n != 3 {
+		t.Fatalf("expected 3 closed lobbies, got %d, %v", n, err)
+	}
+	// only the game of the lobby finished now is still running
+	if len(games.aborted) != 1 || games.aborted[0] != gameID {
+		t.Fatalf("expected game %s to be aborted, got %v", gameID, games.aborted)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestSweepOnce_LobbyOfEndedGame(t *testing.T) {
+	lobbyID := uuid.New()
+	games := &fakeGames{}
+	j, mock := newJanitor(t, games)
+
+	// the Game Service finished the lobby when its game ended; it is deleted after the retention
+	mock.ExpectBegin()
+	expectClaim(mock, sqlmock.NewRows(expiredColumns).AddRow(lobbyID, models.LobbyStatusFinished, models.ExpiryRetention, uuid.New()))
+	mock.ExpectExec("DELETE FROM lobbies WHERE id = \\$1").WithArgs(lobbyID).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	if n, err := j.SweepOnce(context.Background()); err != nil || n != 1 {
+		t.Fatalf("expected 1 closed lobby, got %d, %v", n, err)
+	}
+	// the game has already ended
+	if len(games.aborted) != 0 {
+		t.Fatalf("expected no aborted games, got %v", games.aborted)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestSweepOnce_Empty(t *testing.T) {
+	games := &fakeGames{}
+	j, mock := newJanitor(t, games)
+
+	mock.ExpectBegin()
+	expectClaim(mock, sqlmock.NewRows(expiredColumns))
+	mock.ExpectCommit()
+
+	if n, err := j.SweepOnce(context.Background()); err != nil || n != 0 {
+		t.Fatalf("expected nothing to close, got %d, %v", n, err)
+	}
+	if len(games.aborted) != 0 {
+		t.Fatalf("expected no aborted games, got %v", games.aborted)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestSweepOnce_ErrorRollsBack(t *testing.T) {
+	lobbyID := uuid.New()
+	games := &fakeGames{}
+	j, mock := newJanitor(t, games)
+
+	mock.ExpectBegin()
+	expectClaim(mock, sqlmock.NewRows(expiredColumns).AddRow(lobbyID, models.LobbyStatusInGame, models.ExpiryInactive, uuid.New()))
+	mock.ExpectExec("UPDATE lobbies").WithArgs(models.LobbyStatusFinished, lobbyID).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO event_outbox").WillReturnError(errors.New("connection reset"))
+	mock.ExpectRollback()
+
+	if _, err := j.SweepOnce(context.Background()); err == nil {
+		t.Fatal("expected an error")
+	}
+	// nothing was closed, so the game keeps running
+	if len(games.aborted) != 0 {
+		t.Fatalf("expected no aborted games, got %v", games.aborted)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unfulfilled expectations: %v", err)
+	}
+}
@@ -1,92 +1,119 @@
+// Package joincode generates the codes players use to join a lobby.
+// Codes are only drawn here; uniqueness is enforced by the database when the lobby is inserted,
+// and a taken code is retried with the next one (see MaxRetries).
 package joincode
 
 import (
 	"crypto/rand"
-	"database/sql"
 	"errors"
 	"fmt"
+	"io"
 	"strings"
 )
 
 const (
-	// CodeLength is the length of the join code
-	CodeLength = 6
-	// Charset contains all valid characters for the join code
-	Charset = "ABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
-	// MaxRetries is the maximum number of attempts to generate a unique code
+	// DefaultAlphabet contains the characters of generated codes; 0/O and 1/I are left out as they are easily confused
+	DefaultAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+	// DefaultLength is the length of generated codes
+	DefaultLength = 6
+	// MinLength and MaxLength bound the configurable code length; lobbies.join_code holds up to MaxLength characters
+	MinLength = 4
+	MaxLength = 12
+	// MaxRetries is the maximum number of codes tried when inserting a lobby before giving up
 	MaxRetries = 5
 )
 
 var (
-	// ErrMaxRetriesExceeded is returned when we can't generate a unique code after MaxRetries attempts
+	// ErrMaxRetriesExceeded is returned when MaxRetries codes were all taken
 	ErrMaxRetriesExceeded = errors.New("failed to generate unique join code after maximum retries")
-	// ErrInvalidDB is returned when the database connection is nil
-	ErrInvalidDB = errors.New("database connection is nil")
+	// ErrInvalidAlphabet is returned for alphabets that are not at least two distinct characters from A-Z and 0-9
+	ErrInvalidAlphabet = errors.New("join code alphabet must contain at least two distinct characters from A-Z and 0-9")
+	// ErrInvalidLength is returned for lengths outside MinLength..MaxLength
+	ErrInvalidLength = fmt.Errorf("join code length must be between %d and %d", MinLength, MaxLength)
+	// ErrSourceExhausted is returned by a Fixed source that has no codes left
+	ErrSourceExhausted = errors.New("no join codes left")
 )
 
-// Generator handles the generation of unique join codes
-type Generator struct {
-	db *sql.DB
+// Source provides candidate join codes
+type Source interface {
+	// NextCode returns the next candidate; it may already be taken
+	NextCode() (string, error)
 }
 
-// NewGenerator creates a new join code generator
-func NewGenerator(db *sql.DB) *Generator {
-	return &Generator{db: db}
+// Generator draws uniformly random codes from an alphabet using crypto/rand
+type Generator struct {
+	alphabet string
+	length   int
+	random   io.Reader
 }
 
-// GenerateJoinCode generates a unique 6-character alphanumeric join code
-// Format: 6 characters, A-Z + 0-9 (e.g. "ABC123")
-// Returns uppercase code and error if unable to generate unique code
-func (g *Generator) GenerateJoinCode() (string, error) {
-	if g.db == nil {
-		return "", ErrInvalidDB
+// NewGenerator creates a Generator for codes of length characters from alphabet.
+// The alphabet is normalized to uppercase, since codes entered by players are.
+func NewGenerator(alphabet string, length int) (*Generator, error) {
+	alphabet = strings.ToUpper(alphabet)
+	if !validAlphabet(alphabet) {
+		return nil, ErrInvalidAlphabet
 	}
+	if length < MinLength || length > MaxLength {
+		return nil, ErrInvalidLength
+	}
+	return &Generator{alphabet: alphabet, length: length, random: rand.Reader}, nil
+}
 
-	for attempt := 1; attempt <= MaxRetries; attempt++ {
-		code, err := generateRandomCode()
-		if err != nil {
-			return "", fmt.Errorf("failed to generate random code: %w", err)
-		}
-
-		// Normalize to uppercase
-		code = strings.ToUpper(code)
-
-		// Check for collision in database
-		exists, err := g.codeExists(code)
-		if err != nil {
-			return "", fmt.Errorf("failed to check code collision: %w", err)
+// NextCode returns a random code.
+// Random bytes at or above the largest multiple of the alphabet size are rejected, so every character is equally likely.
+func (g *Generator) NextCode() (string, error) {
+	n := len(g.alphabet)
+	limit := 256 - 256%n
+	code := make([]byte, 0, g.length)
+	buf := make([]byte, g.length)
+	for len(code) < g.length {
+		if _, err := io.ReadFull(g.random, buf); err != nil {
+			return "", fmt.Errorf("failed to read random bytes: %w", err)
 		}
-
-		if !exists {
-			return code, nil
+		for _, b := range buf {
+			if int(b) >= limit {
+				continue
+			}
+			code = append(code, g.alphabet[int(b)%n])
+			if len(code) == g.length {
+				break
+			}
 		}
 	}
-
-	return "", ErrMaxRetriesExceeded
+	return string(code), nil
 }
 
-// generateRandomCode generates a random code using crypto/rand
-func generateRandomCode() (string, error) {
-	bytes := make([]byte, CodeLength)
-	if _, err := rand.Read(bytes); err != nil {
-		return "", fmt.Errorf("failed to read random bytes: %w", err)
+// validAlphabet reports whether alphabet has at least two characters, all distinct and from A-Z and 0-9
+func validAlphabet(alphabet string) bool {
+	if len(alphabet) < 2 {
+		return false
 	}
-
-	charsetLen := len(Charset)
-	for i := 0; i < CodeLength; i++ {
-		bytes[i] = Charset[int(bytes[i])%charsetLen]
+	seen := make(map[rune]bool, len(alphabet))
+	for _, c := range alphabet {
+		if !(c >= 'A' && c <= 'Z' || c >= '0' && c <= '9') || seen[c] {
+			return false
+		}
+		seen[c] = true
 	}
+	return true
+}
+
+// fixedSource returns predefined codes in order
+type fixedSource struct {
+	codes []string
+}
 
-	return string(bytes), nil
+// Fixed returns a Source yielding codes in order and ErrSourceExhausted afterwards, for deterministic tests
+func Fixed(codes ...string) Source {
+	return &fixedSource{codes: codes}
 }
 
-// codeExists checks if a join code already exists in the database
-func (g *Generator) codeExists(code string) (bool, error) {
-	var exists bool
-	query := `SELECT EXISTS(SELECT 1 FROM lobbies WHERE join_code = $1)`
-	err := g.db.QueryRow(query, code).Scan(&exists)
-	if err != nil {
-		return false, fmt.Errorf("failed to query join code: %w", err)
+func (s *fixedSource) NextCode() (string, error) {
+	if len(s.codes) == 0 {
+		return "", ErrSourceExhausted
 	}
-	return exists, nil
+	code := s.codes[0]
+	s.codes = s.codes[1:]
+	return code, nil
 }
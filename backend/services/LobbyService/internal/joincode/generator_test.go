@@ -1,49 +1,51 @@
 package joincode
 
 import (
-	"database/sql"
+	"bytes"
 	"errors"
-	"regexp"
+	"strings"
 	"testing"
-
-	"github.com/DATA-DOG/go-sqlmock"
 )
 
-// TestGenerateRandomCode tests the random code generation format
-func TestGenerateRandomCode(t *testing.T) {
-	// Test multiple generations to ensure consistency
+// TestNextCode_Format tests that codes have the configured length and only use the alphabet
+func TestNextCode_Format(t *testing.T) {
+	generator, err := NewGenerator(DefaultAlphabet, DefaultLength)
+	if err != nil {
+		t.Fatalf("NewGenerator() failed: %v", err)
+	}
+
 	for i := 0; i < 100; i++ {
-		code, err := generateRandomCode()
+		code, err := generator.NextCode()
 		if err != nil {
-			t.Fatalf("generateRandomCode() failed: %v", err)
+			t.Fatalf("NextCode() failed: %v", err)
 		}
 
-		// Check length
-		if len(code) != CodeLength {
-			t.Errorf("Expected code length %d, got %d", CodeLength, len(code))
+		if len(code) != DefaultLength {
+			t.Errorf("Expected code length %d, got %d", DefaultLength, len(code))
 		}
 
-		// Check format: only A-Z and 0-9
-		matched, err := regexp.MatchString("^[A-Z0-9]+$", code)
-		if err != nil {
-			t.Fatalf("regex error: %v", err)
-		}
-		if !matched {
-			t.Errorf("Code %s does not match expected format [A-Z0-9]", code)
+		// Confusable characters never appear
+		for _, c := range code {
+			if !strings.ContainsRune(DefaultAlphabet, c) {
+				t.Errorf("Code %s contains %q outside the alphabet", code, c)
+			}
 		}
 	}
 }
 
-// TestGenerateRandomCodeUniqueness tests that generated codes are diverse
-func TestGenerateRandomCodeUniqueness(t *testing.T) {
-	// Generate multiple codes and check for diversity
+// TestNextCode_Uniqueness tests that generated codes are diverse
+func TestNextCode_Uniqueness(t *testing.T) {
+	generator, err := NewGenerator(DefaultAlphabet, DefaultLength)
+	if err != nil {
+		t.Fatalf("NewGenerator() failed: %v", err)
+	}
+
 	codes := make(map[string]bool)
 	iterations := 100
-
 	for i := 0; i < iterations; i++ {
-		code, err := generateRandomCode()
+		code, err := generator.NextCode()
 		if err != nil {
-			t.Fatalf("generateRandomCode() failed: %v", err)
+			t.Fatalf("NextCode() failed: %v", err)
 		}
 		codes[code] = true
 	}
@@ -55,210 +57,79 @@ func TestGenerateRandomCodeUniqueness(t *testing.T) {
 	}
 }
 
-// TestGenerateJoinCode_Success tests successful code generation
-func TestGenerateJoinCode_Success(t *testing.T) {
-	db, mock, err := sqlmock.New()
-	if err != nil {
-		t.Fatalf("Failed to create mock DB: %v", err)
-	}
-	defer db.Close()
-
-	generator := NewGenerator(db)
-
-	// Mock database query to return false (code doesn't exist)
-	mock.ExpectQuery(`SELECT EXISTS\(SELECT 1 FROM lobbies WHERE join_code = \$1\)`).
-		WithArgs(sqlmock.AnyArg()).
-		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
-
-	code, err := generator.GenerateJoinCode()
-	if err != nil {
-		t.Fatalf("GenerateJoinCode() failed: %v", err)
-	}
-
-	// Verify code format
-	if len(code) != CodeLength {
-		t.Errorf("Expected code length %d, got %d", CodeLength, len(code))
-	}
-
-	// Verify uppercase
-	matched, _ := regexp.MatchString("^[A-Z0-9]+$", code)
-	if !matched {
-		t.Errorf("Code %s is not uppercase alphanumeric", code)
-	}
-
-	// Ensure all expectations were met
-	if err := mock.ExpectationsWereMet(); err != nil {
-		t.Errorf("Unfulfilled expectations: %v", err)
-	}
-}
-
-// TestGenerateJoinCode_Collision tests collision handling with retry
-func TestGenerateJoinCode_Collision(t *testing.T) {
-	db, mock, err := sqlmock.New()
+// TestNextCode_RejectionSampling tests that bytes which would bias the result are skipped
+func TestNextCode_RejectionSampling(t *testing.T) {
+	generator, err := NewGenerator("ABC", 4)
 	if err != nil {
-		t.Fatalf("Failed to create mock DB: %v", err)
+		t.Fatalf("NewGenerator() failed: %v", err)
 	}
-	defer db.Close()
-
-	generator := NewGenerator(db)
-
-	// First two attempts have collisions, third succeeds
-	mock.ExpectQuery(`SELECT EXISTS\(SELECT 1 FROM lobbies WHERE join_code = \$1\)`).
-		WithArgs(sqlmock.AnyArg()).
-		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
-
-	mock.ExpectQuery(`SELECT EXISTS\(SELECT 1 FROM lobbies WHERE join_code = \$1\)`).
-		WithArgs(sqlmock.AnyArg()).
-		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
-
-	mock.ExpectQuery(`SELECT EXISTS\(SELECT 1 FROM lobbies WHERE join_code = \$1\)`).
-		WithArgs(sqlmock.AnyArg()).
-		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+	// 255 is the only byte at or above 256 - 256%3
+	generator.random = bytes.NewReader([]byte{255, 0, 1, 2, 255, 255, 5, 9})
 
-	code, err := generator.GenerateJoinCode()
+	code, err := generator.NextCode()
 	if err != nil {
-		t.Fatalf("GenerateJoinCode() failed: %v", err)
+		t.Fatalf("NextCode() failed: %v", err)
 	}
-
-	if len(code) != CodeLength {
-		t.Errorf("Expected code length %d, got %d", CodeLength, len(code))
-	}
-
-	if err := mock.ExpectationsWereMet(); err != nil {
-		t.Errorf("Unfulfilled expectations: %v", err)
+	if code != "ABCC" {
+		t.Errorf("Expected ABCC, got %s", code)
 	}
 }
 
-// TestGenerateJoinCode_MaxRetriesExceeded tests behavior when max retries exceeded
-func TestGenerateJoinCode_MaxRetriesExceeded(t *testing.T) {
-	db, mock, err := sqlmock.New()
+// TestNextCode_RandomError tests that a failing random source is reported
+func TestNextCode_RandomError(t *testing.T) {
+	generator, err := NewGenerator(DefaultAlphabet, DefaultLength)
 	if err != nil {
-		t.Fatalf("Failed to create mock DB: %v", err)
-	}
-	defer db.Close()
-
-	generator := NewGenerator(db)
-
-	// All attempts result in collision
-	for i := 0; i < MaxRetries; i++ {
-		mock.ExpectQuery(`SELECT EXISTS\(SELECT 1 FROM lobbies WHERE join_code = \$1\)`).
-			WithArgs(sqlmock.AnyArg()).
-			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
-	}
-
-	code, err := generator.GenerateJoinCode()
-	if err == nil {
-		t.Fatal("Expected error when max retries exceeded, got nil")
-	}
-
-	if !errors.Is(err, ErrMaxRetriesExceeded) {
-		t.Errorf("Expected ErrMaxRetriesExceeded, got %v", err)
-	}
-
-	if code != "" {
-		t.Errorf("Expected empty code on error, got %s", code)
+		t.Fatalf("NewGenerator() failed: %v", err)
 	}
+	generator.random = bytes.NewReader(nil)
 
-	if err := mock.ExpectationsWereMet(); err != nil {
-		t.Errorf("Unfulfilled expectations: %v", err)
+	if code, err := generator.NextCode(); err == nil || code != "" {
+		t.Fatalf("Expected an error and no code, got %q, %v", code, err)
 	}
 }
 
-// TestGenerateJoinCode_NilDB tests handling of nil database
-func TestGenerateJoinCode_NilDB(t *testing.T) {
-	generator := NewGenerator(nil)
-
-	code, err := generator.GenerateJoinCode()
-	if err == nil {
-		t.Fatal("Expected error with nil DB, got nil")
-	}
-
-	if !errors.Is(err, ErrInvalidDB) {
-		t.Errorf("Expected ErrInvalidDB, got %v", err)
-	}
-
-	if code != "" {
-		t.Errorf("Expected empty code on error, got %s", code)
-	}
-}
-
-// TestGenerateJoinCode_DatabaseError tests handling of database errors
-func TestGenerateJoinCode_DatabaseError(t *testing.T) {
-	db, mock, err := sqlmock.New()
-	if err != nil {
-		t.Fatalf("Failed to create mock DB: %v", err)
-	}
-	defer db.Close()
-
-	generator := NewGenerator(db)
-
-	// Mock database error
-	mock.ExpectQuery(`SELECT EXISTS\(SELECT 1 FROM lobbies WHERE join_code = \$1\)`).
-		WithArgs(sqlmock.AnyArg()).
-		WillReturnError(sql.ErrConnDone)
-
-	code, err := generator.GenerateJoinCode()
-	if err == nil {
-		t.Fatal("Expected error when database query fails, got nil")
-	}
-
-	if code != "" {
-		t.Errorf("Expected empty code on error, got %s", code)
-	}
-
-	if err := mock.ExpectationsWereMet(); err != nil {
-		t.Errorf("Unfulfilled expectations: %v", err)
+// TestNewGenerator tests the validation of alphabet and length
+func TestNewGenerator(t *testing.T) {
+	tests := []struct {
+		name     string
+		alphabet string
+		length   int
+		wantErr  error
+	}{
+		{"default", DefaultAlphabet, DefaultLength, nil},
+		{"lowercase is normalized", "abcdef", MinLength, nil},
+		{"longest", "AB", MaxLength, nil},
+		{"empty alphabet", "", DefaultLength, ErrInvalidAlphabet},
+		{"single character", "A", DefaultLength, ErrInvalidAlphabet},
+		{"duplicate character", "ABCA", DefaultLength, ErrInvalidAlphabet},
+		{"invalid character", "ABC-", DefaultLength, ErrInvalidAlphabet},
+		{"too short", DefaultAlphabet, MinLength - 1, ErrInvalidLength},
+		{"too long", DefaultAlphabet, MaxLength + 1, ErrInvalidLength},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			generator, err := NewGenerator(tt.alphabet, tt.length)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Expected %v, got %v", tt.wantErr, err)
+			}
+			if err == nil && generator.alphabet != strings.ToUpper(tt.alphabet) {
+				t.Errorf("Expected alphabet %s, got %s", strings.ToUpper(tt.alphabet), generator.alphabet)
+			}
+		})
 	}
 }
 
-// TestCodeFormat_AlwaysUppercase tests that generated codes are always uppercase
-func TestCodeFormat_AlwaysUppercase(t *testing.T) {
-	db, mock, err := sqlmock.New()
-	if err != nil {
-		t.Fatalf("Failed to create mock DB: %v", err)
-	}
-	defer db.Close()
-
-	generator := NewGenerator(db)
+// TestFixed tests that a fixed source yields its codes in order
+func TestFixed(t *testing.T) {
+	source := Fixed("ABC234", "XYZ789")
 
-	// Generate multiple codes and verify all are uppercase
-	for i := 0; i < 10; i++ {
-		mock.ExpectQuery(`SELECT EXISTS\(SELECT 1 FROM lobbies WHERE join_code = \$1\)`).
-			WithArgs(sqlmock.AnyArg()).
-			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
-
-		code, err := generator.GenerateJoinCode()
-		if err != nil {
-			t.Fatalf("GenerateJoinCode() failed: %v", err)
-		}
-
-		// Check that code equals its uppercase version
-		if code != code {
-			t.Errorf("Code %s is not uppercase", code)
-		}
-
-		// Check no lowercase letters
-		matched, _ := regexp.MatchString("[a-z]", code)
-		if matched {
-			t.Errorf("Code %s contains lowercase letters", code)
+	for _, want := range []string{"ABC234", "XYZ789"} {
+		code, err := source.NextCode()
+		if err != nil || code != want {
+			t.Fatalf("Expected %s, got %q, %v", want, code, err)
 		}
 	}
-}
-
-// TestNewGenerator tests the constructor
-func TestNewGenerator(t *testing.T) {
-	db, _, err := sqlmock.New()
-	if err != nil {
-		t.Fatalf("Failed to create mock DB: %v", err)
-	}
-	defer db.Close()
-
-	generator := NewGenerator(db)
-	if generator == nil {
-		t.Fatal("Expected non-nil generator")
-	}
-
-	if generator.db != db {
-		t.Error("Generator database not set correctly")
+	if _, err := source.NextCode(); !errors.Is(err, ErrSourceExhausted) {
+		t.Errorf("Expected ErrSourceExhausted, got %v", err)
 	}
 }
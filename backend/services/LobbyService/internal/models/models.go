@@ -1,6 +1,7 @@
 package models
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
@@ -15,12 +16,13 @@ type User struct {
 
 // Lobby represents a game lobby
 type Lobby struct {
-	ID        uuid.UUID `json:"id" db:"id"`
-	JoinCode  string    `json:"join_code" db:"join_code"`
-	LeaderID  uuid.UUID `json:"leader_id" db:"leader_id"`
-	Status    string    `json:"status" db:"status"`
-	CreatedAt time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+	ID        uuid.UUID  `json:"id" db:"id"`
+	JoinCode  string     `json:"join_code" db:"join_code"`
+	LeaderID  uuid.UUID  `json:"leader_id" db:"leader_id"`
+	Status    string     `json:"status" db:"status"`
+	GameID    *uuid.UUID `json:"game_id,omitempty" db:"game_id"`
+	CreatedAt time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at" db:"updated_at"`
 }
 
 // Player represents a player in a lobby
@@ -30,6 +32,7 @@ type Player struct {
 	UserID   uuid.UUID  `json:"user_id" db:"user_id"`
 	JoinedAt time.Time  `json:"joined_at" db:"joined_at"`
 	IsActive bool       `json:"is_active" db:"is_active"`
+	IsReady  bool       `json:"is_ready" db:"is_ready"`
 	LeftAt   *time.Time `json:"left_at,omitempty" db:"left_at"`
 }
 
@@ -40,6 +43,43 @@ const (
 	LobbyStatusFinished = "finished"
 )
 
+// Event types published to the SSE Service
+const (
+	EventGameStarted     = "game_started"
+	EventPlayerJoined    = "player_joined"
+	EventPlayerLeft      = "player_left"
+	EventPlayerKicked    = "player_kicked"
+	EventYouWereKicked   = "you_were_kicked"
+	EventPlayerActive    = "player_active"
+	EventPlayerInactive  = "player_inactive"
+	EventPlayerReady     = "player_ready"
+	EventPlayerNotReady  = "player_not_ready"
+	EventLeaderChanged   = "leader_changed"
+	EventSettingsChanged = "settings_changed"
+	EventLobbyClosed     = "lobby_closed"
+)
+
+// Rule variants understood by the Game Service
+const (
+	VariantClassic   = "classic"
+	VariantFreeJoker = "free_joker"
+)
+
+// LobbySettings represents the settings chosen by the lobby leader
+type LobbySettings struct {
+	MaxPlayers         int    `json:"max_players" db:"max_players"`
+	TurnTimeoutSeconds int    `json:"turn_timeout_seconds" db:"turn_timeout_seconds"`
+	IsPrivate          bool   `json:"is_private" db:"is_private"`
+	Variant            string `json:"variant" db:"variant"`
+	// RequireReady refuses the start until every player is ready
+	RequireReady bool `json:"require_ready" db:"require_ready"`
+}
+
+// DefaultLobbySettings returns the settings of a lobby created without a settings body
+func DefaultLobbySettings() LobbySettings {
+	return LobbySettings{MaxPlayers: 6, TurnTimeoutSeconds: 40, IsPrivate: true, Variant: VariantClassic}
+}
+
 // PlayerInfo represents a player in the response with user information
 type PlayerInfo struct {
 	ID       uuid.UUID `json:"id"`
@@ -47,38 +87,225 @@ type PlayerInfo struct {
 	Username string    `json:"username"`
 	JoinedAt time.Time `json:"joined_at"`
 	IsActive bool      `json:"is_active"`
+	IsReady  bool      `json:"is_ready"`
+	// LeftAt is set for players that left a running game; they stay listed until the lobby ends
+	LeftAt *time.Time `json:"left_at,omitempty"`
+}
+
+// LobbySettingsRequest represents settings sent on creation or edit; omitted fields keep their value
+type LobbySettingsRequest struct {
+	MaxPlayers         *int    `json:"max_players,omitempty"`
+	TurnTimeoutSeconds *int    `json:"turn_timeout_seconds,omitempty"`
+	IsPrivate          *bool   `json:"is_private,omitempty"`
+	Variant            *string `json:"variant,omitempty"`
+	RequireReady       *bool   `json:"require_ready,omitempty"`
+}
+
+// CreateLobbyRequest represents the optional body when creating a lobby
+type CreateLobbyRequest struct {
+	Settings *LobbySettingsRequest `json:"settings,omitempty"`
 }
 
 // CreateLobbyResponse represents the response when creating a lobby
 type CreateLobbyResponse struct {
-	LobbyID  uuid.UUID    `json:"lobby_id"`
-	JoinCode string       `json:"join_code"`
-	LeaderID uuid.UUID    `json:"leader_id"`
-	Status   string       `json:"status"`
-	Players  []PlayerInfo `json:"players"`
+	LobbyID  uuid.UUID     `json:"lobby_id"`
+	JoinCode string        `json:"join_code"`
+	LeaderID uuid.UUID     `json:"leader_id"`
+	Status   string        `json:"status"`
+	Players  []PlayerInfo  `json:"players"`
+	Settings LobbySettings `json:"settings"`
 }
 
 // LobbyDetailResponse represents the response when getting lobby details
 // Same structure as CreateLobbyResponse
 type LobbyDetailResponse struct {
-	LobbyID  uuid.UUID    `json:"lobby_id"`
-	JoinCode string       `json:"join_code"`
-	Status   string       `json:"status"`
-	LeaderID uuid.UUID    `json:"leader_id"`
-	Players  []PlayerInfo `json:"players"`
+	LobbyID  uuid.UUID     `json:"lobby_id"`
+	JoinCode string        `json:"join_code"`
+	Status   string        `json:"status"`
+	LeaderID uuid.UUID     `json:"leader_id"`
+	Players  []PlayerInfo  `json:"players"`
+	Settings LobbySettings `json:"settings"`
+}
+
+// LobbyCursor is the position after which the next page of the lobby browser starts
+type LobbyCursor struct {
+	CreatedAt time.Time
+	ID        uuid.UUID
+}
+
+// LobbyListFilter selects the public lobbies listed by the lobby browser
+type LobbyListFilter struct {
+	Status     string
+	FreeSeats  bool // only lobbies with fewer players than max_players
+	Descending bool // newest first
+	After      *LobbyCursor
+	Limit      int
+}
+
+// LobbySummary represents a public lobby in the lobby browser
+type LobbySummary struct {
+	LobbyID        uuid.UUID     `json:"lobby_id"`
+	JoinCode       string        `json:"join_code"`
+	Status         string        `json:"status"`
+	LeaderUsername string        `json:"leader_username"`
+	PlayerCount    int           `json:"player_count"`
+	Settings       LobbySettings `json:"settings"`
+	CreatedAt      time.Time     `json:"created_at"`
+}
+
+// LobbyListResponse represents a page of the lobby browser
+// NextCursor is omitted on the last page
+type LobbyListResponse struct {
+	Lobbies    []LobbySummary `json:"lobbies"`
+	NextCursor string         `json:"next_cursor,omitempty"`
 }
 
 // JoinLobbyRequest represents the request to join a lobby by join code
 type JoinLobbyRequest struct {
-	JoinCode string `json:"join_code" validate:"required,len=6"`
+	JoinCode string `json:"join_code" validate:"required,min=4,max=12"`
 }
 
 // KickPlayerRequest represents the request to kick a player from a lobby
+// Ban additionally keeps the player from joining again until the ban is lifted
 type KickPlayerRequest struct {
 	TargetUserID string `json:"target_user_id" validate:"required,uuid"`
+	Ban          bool   `json:"ban,omitempty"`
+}
+
+// LobbyBan represents a user banned from a lobby
+type LobbyBan struct {
+	UserID   uuid.UUID `json:"user_id"`
+	Username string    `json:"username"`
+	BannedBy uuid.UUID `json:"banned_by"`
+	BannedAt time.Time `json:"banned_at"`
+}
+
+// LobbyBansResponse represents the bans of a lobby, newest first
+type LobbyBansResponse struct {
+	Bans []LobbyBan `json:"bans"`
+}
+
+// TransferLeadershipRequest represents the request to hand the lobby over to another player
+type TransferLeadershipRequest struct {
+	TargetUserID string `json:"target_user_id" validate:"required,uuid"`
 }
 
 // UpdatePlayerActiveStatusRequest represents the request to update a player's active status
 type UpdatePlayerActiveStatusRequest struct {
 	IsActive bool `json:"is_active"`
 }
+
+// FinishLobbyRequest is sent by the Game Service when the game of a running lobby has ended
+type FinishLobbyRequest struct {
+	GameID uuid.UUID `json:"game_id"`
+}
+
+// UpdatePlayerReadyRequest represents the request to toggle the requesting player's ready status
+type UpdatePlayerReadyRequest struct {
+	IsReady bool `json:"is_ready"`
+}
+
+// StartGameResponse represents the response when the leader starts the game
+type StartGameResponse struct {
+	Success         bool        `json:"success"`
+	GameID          uuid.UUID   `json:"game_id"`
+	LobbyID         uuid.UUID   `json:"lobby_id"`
+	TurnOrder       []uuid.UUID `json:"turn_order"`
+	CurrentPlayerID uuid.UUID   `json:"current_player_id"`
+	Message         string      `json:"message"`
+}
+
+// GameStartedEvent is the payload of a game_started event
+type GameStartedEvent struct {
+	GameID          uuid.UUID   `json:"game_id"`
+	LobbyID         uuid.UUID   `json:"lobby_id"`
+	TurnOrder       []uuid.UUID `json:"turn_order"`
+	CurrentPlayerID uuid.UUID   `json:"current_player_id"`
+}
+
+// SuccessResponse represents a plain success message
+type SuccessResponse struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+// Reasons for which the janitor closes a lobby
+const (
+	ExpiryIdle      = "idle"      // waiting without activity for the idle timeout
+	ExpiryInactive  = "inactive"  // all players inactive for the inactive timeout
+	ExpiryRetention = "retention" // finished for longer than the retention
+)
+
+// LobbyExpiry configures when the janitor closes lobbies
+type LobbyExpiry struct {
+	IdleTimeout       time.Duration
+	InactiveTimeout   time.Duration
+	FinishedRetention time.Duration
+}
+
+// ExpiredLobby is a lobby due for cleanup; GameID is set once its game started
+type ExpiredLobby struct {
+	ID     uuid.UUID
+	Status string
+	Reason string
+	GameID *uuid.UUID
+}
+
+// LobbyClosedEvent is the payload of a lobby_closed event
+type LobbyClosedEvent struct {
+	LobbyID uuid.UUID `json:"lobby_id"`
+	Reason  string    `json:"reason"`
+}
+
+// OutboxEvent is an event waiting in the outbox for delivery to the SSE Service
+type OutboxEvent struct {
+	ID           int64
+	TargetType   string
+	TargetID     string
+	EventType    string
+	TargetUserID *uuid.UUID
+	Payload      json.RawMessage
+	Attempts     int
+}
+
+// PlayerJoinedEvent is the payload of a player_joined event
+type PlayerJoinedEvent struct {
+	UserID      uuid.UUID `json:"user_id"`
+	Username    string    `json:"username"`
+	PlayerCount int       `json:"player_count"`
+}
+
+// PlayerKickedEvent is the payload of a player_kicked event
+type PlayerKickedEvent struct {
+	UserID   uuid.UUID `json:"user_id"`
+	Username string    `json:"username"`
+	KickedBy uuid.UUID `json:"kicked_by"`
+	Banned   bool      `json:"banned"`
+}
+
+// YouWereKickedEvent is the payload of the you_were_kicked event sent only to the kicked player
+type YouWereKickedEvent struct {
+	Message  string    `json:"message"`
+	KickedBy uuid.UUID `json:"kicked_by"`
+	Banned   bool      `json:"banned"`
+}
+
+// PlayerActivityEvent is the payload of player_active, player_inactive, player_ready and player_not_ready events
+type PlayerActivityEvent struct {
+	UserID   uuid.UUID `json:"user_id"`
+	Username string    `json:"username"`
+}
+
+// PlayerLeftEvent is the payload of a player_left event
+type PlayerLeftEvent struct {
+	UserID      uuid.UUID `json:"user_id"`
+	Username    string    `json:"username"`
+	PlayerCount int       `json:"player_count"`
+}
+
+// LeaderChangedEvent is the payload of a leader_changed event
+type LeaderChangedEvent struct {
+	OldLeaderID       uuid.UUID `json:"old_leader_id"`
+	NewLeaderID       uuid.UUID `json:"new_leader_id"`
+	NewLeaderUsername string    `json:"new_leader_username"`
+}
@@ -0,0 +1,161 @@
+// Package outbox delivers the lobby events stored in the event_outbox table to the SSE Service.
+// Handlers write events in the transaction of the change they describe (repository.EnqueueEventTx),
+// so an event exists if and only if the change committed. The Dispatcher publishes them at least once:
+// an event is deleted only after the SSE Service accepted it, and a crash in between delivers it again
+// once its lease has run out.
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/KnuffelGame/KnuffelGame/backend/services/LobbyService/internal/events"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/LobbyService/internal/models"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/LobbyService/internal/repository"
+)
+
+const (
+	// pollInterval is the wait between polls once the outbox has no due events
+	pollInterval = 500 * time.Millisecond
+	// batchSize bounds the events claimed per round; at most one per target
+	batchSize = 50
+	// leaseDuration is how long claimed events are reserved for the claiming dispatcher.
+	// It exceeds a round of batchSize publishes at the SSE publish timeout, so events are only claimed twice
+	// if a dispatcher stopped mid-round.
+	leaseDuration = 5 * time.Minute
+	// retryBaseDelay is the wait after the first failed delivery; it doubles per attempt up to retryMaxDelay
+	retryBaseDelay = time.Second
+	retryMaxDelay  = time.Minute
+	// MaxAttempts is the number of failed deliveries after which an event is dropped.
+	// Clients refetch the lobby when they reconnect, so a lost event is recovered by then.
+	MaxAttempts = 10
+)
+
+// Dispatcher publishes outbox events in order per target, retrying failed deliveries with exponential backoff.
+type Dispatcher struct {
+	repo repository.Repository
+	pub  events.Publisher
+	log  *slog.Logger
+}
+
+// NewDispatcher creates a Dispatcher delivering to pub.
+func NewDispatcher(repo repository.Repository, pub events.Publisher, log *slog.Logger) *Dispatcher {
+	return &Dispatcher{repo: repo, pub: pub, log: log.With(slog.String("component", "outbox"))}
+}
+
+// Run dispatches events until ctx is cancelled.
+// Rounds follow each other immediately while events are due, so bursts for one target drain without waiting.
+func (d *Dispatcher) Run(ctx context.Context) {
+	for {
+		n, err := d.DispatchOnce(ctx)
+		if err != nil {
+			d.log.Error("failed to dispatch outbox events", slog.String("error", err.Error()))
+		}
+		if n > 0 && err == nil {
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+// DispatchOnce leases the due events, publishes them and records the outcome.
+// Claiming and recording run in two short transactions; no row stays locked while the SSE Service is called.
+// If recording fails, the events are published again once their lease runs out.
+// It returns the number of claimed events.
+func (d *Dispatcher) DispatchOnce(ctx context.Context) (int, error) {
+	claimed, err := d.claim(ctx)
+	if err != nil || len(claimed) == 0 {
+		return 0, err
+	}
+
+	failures := make([]error, len(claimed))
+	for i, e := range claimed {
+		failures[i] = d.deliver(ctx, e)
+	}
+
+	tx, err := d.repo.BeginTx(ctx)
+	if err != nil {
+		return len(claimed), err
+	}
+	defer tx.Rollback()
+
+	for i, e := range claimed {
+		log := d.log.With(slog.Int64("outbox_id", e.ID), slog.String("event_type", e.EventType), slog.String("target_id", e.TargetID))
+
+		var err error
+		switch failed := failures[i]; {
+		case failed == nil:
+			err = d.repo.DeleteOutboxEventTx(tx, e.ID)
+		case e.Attempts+1 >= MaxAttempts:
+			log.Error("dropping undeliverable event", slog.Int("attempts", e.Attempts+1), slog.String("error", failed.Error()))
+			err = d.repo.DeleteOutboxEventTx(tx, e.ID)
+		default:
+			delay := retryDelay(e.Attempts + 1)
+			log.Warn("failed to deliver event, retrying", slog.Int("attempts", e.Attempts+1), slog.Duration("retry_in", delay), slog.String("error", failed.Error()))
+			err = d.repo.RescheduleOutboxEventTx(tx, e.ID, delay, failed.Error())
+		}
+		if err != nil {
+			return len(claimed), err
+		}
+	}
+	return len(claimed), tx.Commit()
+}
+
+// claim leases the due events in a transaction of its own
+func (d *Dispatcher) claim(ctx context.Context) ([]models.OutboxEvent, error) {
+	tx, err := d.repo.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	claimed, err := d.repo.ClaimOutboxEventsTx(tx, batchSize, leaseDuration)
+	if err != nil {
+		return nil, err
+	}
+	return claimed, tx.Commit()
+}
+
+// deliver publishes e, or unregisters its target for an events.EventUnregister entry
+func (d *Dispatcher) deliver(ctx context.Context, e models.OutboxEvent) error {
+	if e.EventType != events.EventUnregister {
+		return d.pub.Publish(ctx, toEvent(e))
+	}
+	var data events.UnregisterData
+	if err := json.Unmarshal(e.Payload, &data); err != nil {
+		return fmt.Errorf("invalid unregister payload: %w", err)
+	}
+	return d.pub.Unregister(ctx, e.TargetType, e.TargetID, data.Reason)
+}
+
+// retryDelay returns the backoff after the given number of failed attempts
+func retryDelay(attempts int) time.Duration {
+	delay := retryBaseDelay
+	for i := 1; i < attempts && delay < retryMaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > retryMaxDelay {
+		delay = retryMaxDelay
+	}
+	return delay
+}
+
+func toEvent(e models.OutboxEvent) events.Event {
+	out := events.Event{
+		TargetType: e.TargetType,
+		TargetID:   e.TargetID,
+		EventType:  e.EventType,
+		Data:       json.RawMessage(e.Payload),
+	}
+	if e.TargetUserID != nil {
+		out.TargetUserID = e.TargetUserID.String()
+	}
+	return out
+}
@@ -0,0 +1,177 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/LobbyService/internal/events"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/LobbyService/internal/repository"
+	"github.com/google/uuid"
+)
+
+// fakePublisher records published events and unregistered targets and fails those of the targets in fail
+type fakePublisher struct {
+	events.NopPublisher
+	published    []events.Event
+	unregistered []string
+	fail         map[string]bool
+}
+
+func (p *fakePublisher) Publish(_ context.Context, e events.Event) error {
+	if p.fail[e.TargetID] {
+		return errors.New("sse service unavailable")
+	}
+	p.published = append(p.published, e)
+	return nil
+}
+
+func (p *fakePublisher) Unregister(_ context.Context, targetType, targetID, reason string) error {
+	if p.fail[targetID] {
+		return errors.New("sse service unavailable")
+	}
+	p.unregistered = append(p.unregistered, targetType+"/"+targetID+"/"+reason)
+	return nil
+}
+
+var outboxColumns = []string{"id", "target_type", "target_id", "event_type", "target_user_id", "payload", "attempts"}
+
+func newDispatcher(t *testing.T, pub events.Publisher) (*Dispatcher, sqlmock.Sqlmock) {
+	t.Helper()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock DB: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return NewDispatcher(repository.New(db), pub, slog.New(slog.NewTextHandler(io.Discard, nil))), mock
+}
+
+// expectClaim expects the due events to be leased in a transaction of their own
+func expectClaim(mock sqlmock.Sqlmock, rows *sqlmock.Rows) {
+	mock.ExpectBegin()
+	mock.ExpectQuery("UPDATE event_outbox SET locked_until = .*FROM event_outbox o.*locked_until <= CURRENT_TIMESTAMP.*NOT EXISTS.*FOR UPDATE SKIP LOCKED.*RETURNING").
+		WithArgs(batchSize, leaseDuration.Seconds()).
+		WillReturnRows(rows)
+	mock.ExpectCommit()
+}
+
+func TestDispatchOnce(t *testing.T) {
+	kickedID := uuid.New()
+	pub := &fakePublisher{fail: map[string]bool{"down": true, "down-for-good": true}}
+	d, mock := newDispatcher(t, pub)
+
+	// RETURNING yields the leased rows in any order
+	expectClaim(mock, sqlmock.NewRows(outboxColumns).
+		AddRow(3, "lobby", "down-for-good", "player_joined", nil, []byte(`{}`), MaxAttempts-1).
+		AddRow(1, "lobby", "up", "you_were_kicked", kickedID.String(), []byte(`{"message":"bye"}`), 0).
+		AddRow(2, "lobby", "down", "player_joined", nil, []byte(`{}`), 2))
+	// the outcome is recorded in a second transaction after publishing
+	mock.ExpectBegin()
+	// delivered
+	mock.ExpectExec("DELETE FROM event_outbox WHERE id = \\$1").WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+	// third failed attempt waits 4s
+	mock.ExpectExec("UPDATE event_outbox SET attempts = attempts \\+ 1").
+		WithArgs(2, "sse service unavailable", float64(4)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	// last attempt failed, dropped
+	mock.ExpectExec("DELETE FROM event_outbox WHERE id = \\$1").WithArgs(3).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	n, err := d.DispatchOnce(context.Background())
+	if err != nil || n != 3 {
+		t.Fatalf("expected 3 claimed events, got %d, %v", n, err)
+	}
+	if len(pub.published) != 1 {
+		t.Fatalf("expected one published event, got %+v", pub.published)
+	}
+	e := pub.published[0]
+	payload, _ := json.Marshal(e.Data)
+	if e.TargetID != "up" || e.EventType != "you_were_kicked" || e.TargetUserID != kickedID.String() || string(payload) != `{"message":"bye"}` {
+		t.Fatalf("unexpected event %+v with data %s", e, payload)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestDispatchOnce_Empty(t *testing.T) {
+	d, mock := newDispatcher(t, &fakePublisher{})
+
+	expectClaim(mock, sqlmock.NewRows(outboxColumns))
+
+	if n, err := d.DispatchOnce(context.Background()); err != nil || n != 0 {
+		t.Fatalf("expected nothing to dispatch, got %d, %v", n, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestDispatchOnce_Unregister(t *testing.T) {
+	pub := &fakePublisher{}
+	d, mock := newDispatcher(t, pub)
+
+	expectClaim(mock, sqlmock.NewRows(outboxColumns).
+		AddRow(1, "lobby", "closed", events.EventUnregister, nil, []byte(`{"reason":"lobby_deleted"}`), 0))
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE FROM event_outbox WHERE id = \\$1").WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	if n, err := d.DispatchOnce(context.Background()); err != nil || n != 1 {
+		t.Fatalf("expected 1 claimed event, got %d, %v", n, err)
+	}
+	// the target is unregistered instead of receiving an event
+	if len(pub.published) != 0 {
+		t.Fatalf("expected nothing to be published, got %+v", pub.published)
+	}
+	if len(pub.unregistered) != 1 || pub.unregistered[0] != "lobby/closed/lobby_deleted" {
+		t.Fatalf("expected the lobby to be unregistered, got %v", pub.unregistered)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestDispatchOnce_StorageErrorRollsBack(t *testing.T) {
+	pub := &fakePublisher{}
+	d, mock := newDispatcher(t, pub)
+
+	expectClaim(mock, sqlmock.NewRows(outboxColumns).AddRow(1, "lobby", "up", "player_joined", nil, []byte(`{}`), 0))
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE FROM event_outbox").WithArgs(1).WillReturnError(errors.New("connection reset"))
+	mock.ExpectRollback()
+
+	if _, err := d.DispatchOnce(context.Background()); err == nil {
+		t.Fatal("expected an error")
+	}
+	// the event stays in the outbox and is delivered again once its lease runs out
+	if len(pub.published) != 1 {
+		t.Fatalf("expected the event to be published once, got %+v", pub.published)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{1, time.Second},
+		{2, 2 * time.Second},
+		{4, 8 * time.Second},
+		{7, time.Minute},
+		{MaxAttempts, time.Minute},
+	}
+	for _, tt := range tests {
+		if got := retryDelay(tt.attempts); got != tt.want {
+			t.Errorf("retryDelay(%d) = %v, want %v", tt.attempts, got, tt.want)
+		}
+	}
+}
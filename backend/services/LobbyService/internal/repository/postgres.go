@@ -3,8 +3,13 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"fmt"
+	"sort"
 	"time"
 
+	"github.com/KnuffelGame/KnuffelGame/backend/libs/username"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/LobbyService/internal/events"
 	"github.com/KnuffelGame/KnuffelGame/backend/services/LobbyService/internal/models"
 	"github.com/google/uuid"
 )
@@ -32,11 +37,14 @@ func (r *PostgresRepository) CreateUserIfNotExistsTx(tx *sql.Tx, userID uuid.UUI
 	return err
 }
 
+// CreateLobbyTx inserts a waiting lobby; sql.ErrNoRows if the join code is taken by a lobby that is not finished.
+// The conflict is skipped rather than raised, so the transaction stays usable for the next code.
 func (r *PostgresRepository) CreateLobbyTx(tx *sql.Tx, joinCode string, leaderID uuid.UUID) (uuid.UUID, error) {
 	var lobbyID uuid.UUID
 	if err := tx.QueryRow(`
 		INSERT INTO lobbies (join_code, leader_id, status)
 		VALUES ($1, $2, $3)
+		ON CONFLICT (join_code) WHERE status <> 'finished' DO NOTHING
 		RETURNING id
 	`, joinCode, leaderID, models.LobbyStatusWaiting).Scan(&lobbyID); err != nil {
 		return uuid.Nil, err
@@ -44,12 +52,45 @@ func (r *PostgresRepository) CreateLobbyTx(tx *sql.Tx, joinCode string, leaderID
 	return lobbyID, nil
 }
 
+func (r *PostgresRepository) CreateLobbySettingsTx(tx *sql.Tx, lobbyID uuid.UUID, settings models.LobbySettings) error {
+	_, err := tx.Exec(`
+		INSERT INTO lobby_settings (lobby_id, max_players, turn_timeout_seconds, is_private, variant, require_ready)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`, lobbyID, settings.MaxPlayers, settings.TurnTimeoutSeconds, settings.IsPrivate, settings.Variant, settings.RequireReady)
+	return err
+}
+
+func (r *PostgresRepository) GetLobbySettingsTx(tx *sql.Tx, lobbyID uuid.UUID) (*models.LobbySettings, error) {
+	var settings models.LobbySettings
+	err := tx.QueryRow(`
+		SELECT max_players, turn_timeout_seconds, is_private, variant, require_ready
+		FROM lobby_settings
+		WHERE lobby_id = $1
+	`, lobbyID).Scan(&settings.MaxPlayers, &settings.TurnTimeoutSeconds, &settings.IsPrivate, &settings.Variant, &settings.RequireReady)
+	if err != nil {
+		return nil, err
+	}
+	return &settings, nil
+}
+
+func (r *PostgresRepository) UpdateLobbySettingsTx(tx *sql.Tx, lobbyID uuid.UUID, settings models.LobbySettings) error {
+	_, err := tx.Exec(`
+		UPDATE lobby_settings
+		SET max_players = $1, turn_timeout_seconds = $2, is_private = $3, variant = $4, require_ready = $5
+		WHERE lobby_id = $6
+	`, settings.MaxPlayers, settings.TurnTimeoutSeconds, settings.IsPrivate, settings.Variant, settings.RequireReady, lobbyID)
+	return err
+}
+
+// AddPlayerTx adds the user to the lobby; a player that left before gets its row back with left_at cleared
 func (r *PostgresRepository) AddPlayerTx(tx *sql.Tx, lobbyID uuid.UUID, userID uuid.UUID) (uuid.UUID, time.Time, error) {
 	var playerID uuid.UUID
 	var joinedAt time.Time
 	if err := tx.QueryRow(`
 		INSERT INTO players (lobby_id, user_id, is_active)
 		VALUES ($1, $2, true)
+		ON CONFLICT (lobby_id, user_id) DO UPDATE
+		SET joined_at = CURRENT_TIMESTAMP, is_active = true, is_ready = false, left_at = NULL
 		RETURNING id, joined_at
 	`, lobbyID, userID).Scan(&playerID, &joinedAt); err != nil {
 		return uuid.Nil, time.Time{}, err
@@ -68,9 +109,17 @@ func (r *PostgresRepository) GetLobbyDetail(ctx context.Context, lobbyID uuid.UU
 			p.user_id,
 			u.username,
 			p.joined_at,
-			p.is_active
+			p.is_active,
+			p.left_at,
+			p.is_ready,
+			s.max_players,
+			s.turn_timeout_seconds,
+			s.is_private,
+			s.variant,
+			s.require_ready
 		FROM lobbies l
-		LEFT JOIN players p ON l.id = p.lobby_id
+		JOIN lobby_settings s ON s.lobby_id = l.id
+		LEFT JOIN players p ON l.id = p.lobby_id AND (p.left_at IS NULL OR l.status <> 'waiting')
 		LEFT JOIN users u ON p.user_id = u.id
 		WHERE l.id = $1
 		ORDER BY p.joined_at ASC
@@ -97,6 +146,9 @@ func (r *PostgresRepository) GetLobbyDetail(ctx context.Context, lobbyID uuid.UU
 			playerUsername sql.NullString
 			playerJoinedAt sql.NullTime
 			playerIsActive sql.NullBool
+			playerLeftAt   sql.NullTime
+			playerIsReady  sql.NullBool
+			settings       models.LobbySettings
 		)
 
 		if err := rows.Scan(
@@ -109,6 +161,13 @@ func (r *PostgresRepository) GetLobbyDetail(ctx context.Context, lobbyID uuid.UU
 			&playerUsername,
 			&playerJoinedAt,
 			&playerIsActive,
+			&playerLeftAt,
+			&playerIsReady,
+			&settings.MaxPlayers,
+			&settings.TurnTimeoutSeconds,
+			&settings.IsPrivate,
+			&settings.Variant,
+			&settings.RequireReady,
 		); err != nil {
 			return nil, err
 		}
@@ -118,6 +177,7 @@ func (r *PostgresRepository) GetLobbyDetail(ctx context.Context, lobbyID uuid.UU
 			response.JoinCode = joinCode
 			response.Status = status
 			response.LeaderID = leaderID
+			response.Settings = settings
 			lobbyFound = true
 		}
 
@@ -132,13 +192,18 @@ func (r *PostgresRepository) GetLobbyDetail(ctx context.Context, lobbyID uuid.UU
 				return nil, err
 			}
 
-			players = append(players, models.PlayerInfo{
+			player := models.PlayerInfo{
 				ID:       pid,
 				UserID:   puid,
 				Username: playerUsername.String,
 				JoinedAt: playerJoinedAt.Time,
 				IsActive: playerIsActive.Bool,
-			})
+				IsReady:  playerIsReady.Bool,
+			}
+			if playerLeftAt.Valid {
+				player.LeftAt = &playerLeftAt.Time
+			}
+			players = append(players, player)
 		}
 	}
 
@@ -150,10 +215,97 @@ func (r *PostgresRepository) GetLobbyDetail(ctx context.Context, lobbyID uuid.UU
 		return nil, sql.ErrNoRows
 	}
 
+	disambiguate(players)
 	response.Players = players
 	return &response, nil
 }
 
+// disambiguate replaces the usernames of players, in join order, by their display names:
+// later players sharing a name with an earlier one are shown as "Bob #2"
+func disambiguate(players []models.PlayerInfo) {
+	names := make([]string, len(players))
+	for i, p := range players {
+		names[i] = p.Username
+	}
+	for i, name := range username.Disambiguate(names) {
+		players[i].Username = name
+	}
+}
+
+// ListPublicLobbies returns up to filter.Limit public lobbies ordered by created_at, then id.
+// Paging uses the (created_at, id) position of filter.After instead of an offset, so lobbies
+// created or closed meanwhile neither repeat nor skip entries.
+func (r *PostgresRepository) ListPublicLobbies(ctx context.Context, filter models.LobbyListFilter) ([]models.LobbySummary, error) {
+	cmp, dir := ">", "ASC"
+	if filter.Descending {
+		cmp, dir = "<", "DESC"
+	}
+
+	args := []interface{}{filter.Status}
+	where := "l.status = $1 AND NOT s.is_private"
+	if filter.After != nil {
+		args = append(args, filter.After.CreatedAt, filter.After.ID)
+		where += fmt.Sprintf(" AND (l.created_at, l.id) %s ($2, $3)", cmp)
+	}
+	having := ""
+	if filter.FreeSeats {
+		having = "HAVING COUNT(p.id) < s.max_players"
+	}
+	args = append(args, filter.Limit)
+
+	query := fmt.Sprintf(`
+		SELECT
+			l.id,
+			l.join_code,
+			l.status,
+			u.username,
+			COUNT(p.id) AS player_count,
+			s.max_players,
+			s.turn_timeout_seconds,
+			s.is_private,
+			s.variant,
+			s.require_ready,
+			l.created_at
+		FROM lobbies l
+		JOIN lobby_settings s ON s.lobby_id = l.id
+		JOIN users u ON u.id = l.leader_id
+		LEFT JOIN players p ON p.lobby_id = l.id AND p.left_at IS NULL
+		WHERE %s
+		GROUP BY l.id, s.lobby_id, u.id
+		%s
+		ORDER BY l.created_at %s, l.id %s
+		LIMIT $%d
+	`, where, having, dir, dir, len(args))
+
+	rows, err := r.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	lobbies := []models.LobbySummary{}
+	for rows.Next() {
+		var l models.LobbySummary
+		if err := rows.Scan(
+			&l.LobbyID,
+			&l.JoinCode,
+			&l.Status,
+			&l.LeaderUsername,
+			&l.PlayerCount,
+			&l.Settings.MaxPlayers,
+			&l.Settings.TurnTimeoutSeconds,
+			&l.Settings.IsPrivate,
+			&l.Settings.Variant,
+			&l.Settings.RequireReady,
+			&l.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		lobbies = append(lobbies, l)
+	}
+	return lobbies, rows.Err()
+}
+
 func (r *PostgresRepository) GetLobbyLeaderID(ctx context.Context, lobbyID uuid.UUID) (uuid.UUID, error) {
 	var leaderIDStr string
 	err := r.DB.QueryRowContext(ctx, `SELECT leader_id::text FROM lobbies WHERE id = $1`, lobbyID).Scan(&leaderIDStr)
@@ -169,20 +321,21 @@ func (r *PostgresRepository) GetLobbyLeaderID(ctx context.Context, lobbyID uuid.
 
 func (r *PostgresRepository) IsMember(ctx context.Context, lobbyID uuid.UUID, userID uuid.UUID) (bool, error) {
 	var exists bool
-	err := r.DB.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM players WHERE lobby_id = $1 AND user_id = $2)`, lobbyID, userID).Scan(&exists)
+	err := r.DB.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM players WHERE lobby_id = $1 AND user_id = $2 AND left_at IS NULL)`, lobbyID, userID).Scan(&exists)
 	if err != nil {
 		return false, err
 	}
 	return exists, nil
 }
 
+// GetLobbyByJoinCode returns the lobby that is not finished with the given join code; finished lobbies may share it.
 func (r *PostgresRepository) GetLobbyByJoinCode(ctx context.Context, joinCode string) (*models.Lobby, error) {
 	var lobby models.Lobby
 	err := r.DB.QueryRowContext(ctx, `
 		SELECT id, join_code, leader_id, status, created_at, updated_at
 		FROM lobbies
-		WHERE join_code = $1
-	`, joinCode).Scan(
+		WHERE join_code = $1 AND status <> $2
+	`, joinCode, models.LobbyStatusFinished).Scan(
 		&lobby.ID,
 		&lobby.JoinCode,
 		&lobby.LeaderID,
@@ -199,12 +352,13 @@ func (r *PostgresRepository) GetLobbyByJoinCode(ctx context.Context, joinCode st
 	return &lobby, nil
 }
 
+// GetLobbyPlayerCount counts the players that have not left the lobby, inactive ones included, since they keep their seat.
 func (r *PostgresRepository) GetLobbyPlayerCount(ctx context.Context, lobbyID uuid.UUID) (int, error) {
 	var count int
 	err := r.DB.QueryRowContext(ctx, `
 		SELECT COUNT(*)
 		FROM players
-		WHERE lobby_id = $1 AND is_active = true
+		WHERE lobby_id = $1 AND left_at IS NULL
 	`, lobbyID).Scan(&count)
 	if err != nil {
 		return 0, err
@@ -213,13 +367,15 @@ func (r *PostgresRepository) GetLobbyPlayerCount(ctx context.Context, lobbyID uu
 }
 
 // Transaction-based versions for join lobby functionality
+
+// GetLobbyByJoinCodeTx is GetLobbyByJoinCode within tx.
 func (r *PostgresRepository) GetLobbyByJoinCodeTx(tx *sql.Tx, joinCode string) (*models.Lobby, error) {
 	var lobby models.Lobby
 	err := tx.QueryRow(`
 		SELECT id, join_code, leader_id, status, created_at, updated_at
 		FROM lobbies
-		WHERE join_code = $1
-	`, joinCode).Scan(
+		WHERE join_code = $1 AND status <> $2
+	`, joinCode, models.LobbyStatusFinished).Scan(
 		&lobby.ID,
 		&lobby.JoinCode,
 		&lobby.LeaderID,
@@ -236,12 +392,13 @@ func (r *PostgresRepository) GetLobbyByJoinCodeTx(tx *sql.Tx, joinCode string) (
 	return &lobby, nil
 }
 
+// GetLobbyPlayerCountTx is GetLobbyPlayerCount within tx.
 func (r *PostgresRepository) GetLobbyPlayerCountTx(tx *sql.Tx, lobbyID uuid.UUID) (int, error) {
 	var count int
 	err := tx.QueryRow(`
 		SELECT COUNT(*)
 		FROM players
-		WHERE lobby_id = $1 AND is_active = true
+		WHERE lobby_id = $1 AND left_at IS NULL
 	`, lobbyID).Scan(&count)
 	if err != nil {
 		return 0, err
@@ -251,7 +408,7 @@ func (r *PostgresRepository) GetLobbyPlayerCountTx(tx *sql.Tx, lobbyID uuid.UUID
 
 func (r *PostgresRepository) IsMemberTx(tx *sql.Tx, lobbyID uuid.UUID, userID uuid.UUID) (bool, error) {
 	var exists bool
-	err := tx.QueryRow(`SELECT EXISTS(SELECT 1 FROM players WHERE lobby_id = $1 AND user_id = $2)`, lobbyID, userID).Scan(&exists)
+	err := tx.QueryRow(`SELECT EXISTS(SELECT 1 FROM players WHERE lobby_id = $1 AND user_id = $2 AND left_at IS NULL)`, lobbyID, userID).Scan(&exists)
 	if err != nil {
 		return false, err
 	}
@@ -266,11 +423,74 @@ func (r *PostgresRepository) DeletePlayerTx(tx *sql.Tx, lobbyID uuid.UUID, targe
 	return err
 }
 
+// BanUserTx bans the user from the lobby; banning an already banned user keeps the first ban
+func (r *PostgresRepository) BanUserTx(tx *sql.Tx, lobbyID, userID, bannedBy uuid.UUID) error {
+	_, err := tx.Exec(`
+		INSERT INTO lobby_bans (lobby_id, user_id, banned_by)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (lobby_id, user_id) DO NOTHING
+	`, lobbyID, userID, bannedBy)
+	return err
+}
+
+func (r *PostgresRepository) IsBannedTx(tx *sql.Tx, lobbyID, userID uuid.UUID) (bool, error) {
+	var banned bool
+	err := tx.QueryRow(`SELECT EXISTS(SELECT 1 FROM lobby_bans WHERE lobby_id = $1 AND user_id = $2)`, lobbyID, userID).Scan(&banned)
+	if err != nil {
+		return false, err
+	}
+	return banned, nil
+}
+
+// ListBans returns the bans of the lobby, newest first
+func (r *PostgresRepository) ListBans(ctx context.Context, lobbyID uuid.UUID) ([]models.LobbyBan, error) {
+	rows, err := r.DB.QueryContext(ctx, `
+		SELECT b.user_id, u.username, b.banned_by, b.created_at
+		FROM lobby_bans b
+		JOIN users u ON u.id = b.user_id
+		WHERE b.lobby_id = $1
+		ORDER BY b.created_at DESC
+	`, lobbyID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	bans := []models.LobbyBan{}
+	for rows.Next() {
+		var b models.LobbyBan
+		if err := rows.Scan(&b.UserID, &b.Username, &b.BannedBy, &b.BannedAt); err != nil {
+			return nil, err
+		}
+		bans = append(bans, b)
+	}
+	return bans, rows.Err()
+}
+
+// DeleteBan lifts a ban; sql.ErrNoRows if the user is not banned
+func (r *PostgresRepository) DeleteBan(ctx context.Context, lobbyID, userID uuid.UUID) error {
+	result, err := r.DB.ExecContext(ctx, `
+		DELETE FROM lobby_bans
+		WHERE lobby_id = $1 AND user_id = $2
+	`, lobbyID, userID)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
 func (r *PostgresRepository) UpdatePlayerActiveStatusTx(tx *sql.Tx, lobbyID, playerID uuid.UUID, isActive bool) error {
 	result, err := tx.Exec(`
 		UPDATE players
 		SET is_active = $1
-		WHERE lobby_id = $2 AND id = $3
+		WHERE lobby_id = $2 AND user_id = $3 AND left_at IS NULL
 	`, isActive, lobbyID, playerID)
 	if err != nil {
 		return err
@@ -284,3 +504,279 @@ func (r *PostgresRepository) UpdatePlayerActiveStatusTx(tx *sql.Tx, lobbyID, pla
 	}
 	return nil
 }
+
+// UpdatePlayerReadyTx sets the ready status of a player that has not left; sql.ErrNoRows if there is none
+func (r *PostgresRepository) UpdatePlayerReadyTx(tx *sql.Tx, lobbyID, userID uuid.UUID, isReady bool) error {
+	result, err := tx.Exec(`
+		UPDATE players
+		SET is_ready = $1
+		WHERE lobby_id = $2 AND user_id = $3 AND left_at IS NULL
+	`, isReady, lobbyID, userID)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// GetLobbyForUpdateTx locks the lobby row until tx ends, so concurrent starts of the same lobby are serialized
+func (r *PostgresRepository) GetLobbyForUpdateTx(tx *sql.Tx, lobbyID uuid.UUID) (*models.Lobby, error) {
+	var lobby models.Lobby
+	err := tx.QueryRow(`
+		SELECT id, join_code, leader_id, status, game_id, created_at, updated_at
+		FROM lobbies
+		WHERE id = $1
+		FOR UPDATE
+	`, lobbyID).Scan(
+		&lobby.ID,
+		&lobby.JoinCode,
+		&lobby.LeaderID,
+		&lobby.Status,
+		&lobby.GameID,
+		&lobby.CreatedAt,
+		&lobby.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &lobby, nil
+}
+
+// GetPlayersTx returns the players that have not left the lobby in join order.
+// Usernames are returned as stored; they are sent to the Game Service, which has no room for display name suffixes.
+func (r *PostgresRepository) GetPlayersTx(tx *sql.Tx, lobbyID uuid.UUID) ([]models.PlayerInfo, error) {
+	rows, err := tx.Query(`
+		SELECT p.id, p.user_id, u.username, p.joined_at, p.is_active, p.is_ready
+		FROM players p
+		JOIN users u ON p.user_id = u.id
+		WHERE p.lobby_id = $1 AND p.left_at IS NULL
+		ORDER BY p.joined_at ASC
+	`, lobbyID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var players []models.PlayerInfo
+	for rows.Next() {
+		var p models.PlayerInfo
+		if err := rows.Scan(&p.ID, &p.UserID, &p.Username, &p.JoinedAt, &p.IsActive, &p.IsReady); err != nil {
+			return nil, err
+		}
+		players = append(players, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return players, nil
+}
+
+// StartLobbyTx moves a waiting lobby to running and records its game
+func (r *PostgresRepository) StartLobbyTx(tx *sql.Tx, lobbyID, gameID uuid.UUID) error {
+	result, err := tx.Exec(`
+		UPDATE lobbies
+		SET status = $1, game_id = $2, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $3 AND status = $4
+	`, models.LobbyStatusInGame, gameID, lobbyID, models.LobbyStatusWaiting)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// LeavePlayerTx marks the player as left and inactive; the row is kept so running games still list the player
+func (r *PostgresRepository) LeavePlayerTx(tx *sql.Tx, lobbyID, userID uuid.UUID) (*models.Player, error) {
+	var player models.Player
+	err := tx.QueryRow(`
+		UPDATE players
+		SET left_at = CURRENT_TIMESTAMP, is_active = false
+		WHERE lobby_id = $1 AND user_id = $2 AND left_at IS NULL
+		RETURNING id, lobby_id, user_id, joined_at, is_active, left_at
+	`, lobbyID, userID).Scan(
+		&player.ID,
+		&player.LobbyID,
+		&player.UserID,
+		&player.JoinedAt,
+		&player.IsActive,
+		&player.LeftAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &player, nil
+}
+
+func (r *PostgresRepository) SetLobbyLeaderTx(tx *sql.Tx, lobbyID, leaderID uuid.UUID) error {
+	_, err := tx.Exec(`
+		UPDATE lobbies
+		SET leader_id = $1, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $2
+	`, leaderID, lobbyID)
+	return err
+}
+
+func (r *PostgresRepository) GetUsernameTx(tx *sql.Tx, userID uuid.UUID) (string, error) {
+	var username string
+	err := tx.QueryRow(`SELECT username FROM users WHERE id = $1`, userID).Scan(&username)
+	if err != nil {
+		return "", err
+	}
+	return username, nil
+}
+
+func (r *PostgresRepository) SetLobbyStatusTx(tx *sql.Tx, lobbyID uuid.UUID, status string) error {
+	_, err := tx.Exec(`
+		UPDATE lobbies
+		SET status = $1, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $2
+	`, status, lobbyID)
+	return err
+}
+
+// DeleteLobbyTx deletes the lobby; its players are removed by the foreign key cascade
+func (r *PostgresRepository) DeleteLobbyTx(tx *sql.Tx, lobbyID uuid.UUID) error {
+	_, err := tx.Exec(`DELETE FROM lobbies WHERE id = $1`, lobbyID)
+	return err
+}
+
+// ClaimExpiredLobbiesTx locks up to limit lobbies due for cleanup until tx ends, oldest change first.
+// A lobby's last activity is the later of its last update and its last join.
+// Waiting lobbies expire after expiry.IdleTimeout without activity, waiting and running lobbies without
+// active players after expiry.InactiveTimeout, finished lobbies expiry.FinishedRetention after they finished.
+// Lobbies locked by another transaction are skipped, so concurrent janitors claim disjoint lobbies.
+func (r *PostgresRepository) ClaimExpiredLobbiesTx(tx *sql.Tx, expiry models.LobbyExpiry, limit int) ([]models.ExpiredLobby, error) {
+	rows, err := tx.Query(`
+		SELECT
+			l.id,
+			l.status,
+			CASE
+				WHEN l.status = $1 THEN 'retention'
+				WHEN COALESCE(a.any_active, false) THEN 'idle'
+				ELSE 'inactive'
+			END AS reason,
+			l.game_id
+		FROM lobbies l
+		LEFT JOIN LATERAL (
+			SELECT MAX(p.joined_at) AS last_join, BOOL_OR(p.is_active AND p.left_at IS NULL) AS any_active
+			FROM players p
+			WHERE p.lobby_id = l.id
+		) a ON true
+		WHERE (l.status = $1 AND l.updated_at < CURRENT_TIMESTAMP - make_interval(secs => $2))
+			OR (l.status = $3 AND GREATEST(l.updated_at, a.last_join) < CURRENT_TIMESTAMP - make_interval(secs => $4))
+			OR (l.status IN ($3, $5) AND NOT COALESCE(a.any_active, false)
+				AND GREATEST(l.updated_at, a.last_join) < CURRENT_TIMESTAMP - make_interval(secs => $6))
+		ORDER BY l.updated_at
+		LIMIT $7
+		FOR UPDATE OF l SKIP LOCKED
+	`,
+		models.LobbyStatusFinished, expiry.FinishedRetention.Seconds(),
+		models.LobbyStatusWaiting, expiry.IdleTimeout.Seconds(),
+		models.LobbyStatusInGame, expiry.InactiveTimeout.Seconds(),
+		limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var lobbies []models.ExpiredLobby
+	for rows.Next() {
+		var l models.ExpiredLobby
+		if err := rows.Scan(&l.ID, &l.Status, &l.Reason, &l.GameID); err != nil {
+			return nil, err
+		}
+		lobbies = append(lobbies, l)
+	}
+	return lobbies, rows.Err()
+}
+
+// EnqueueEventTx writes e to the outbox; it is published once tx commits
+func (r *PostgresRepository) EnqueueEventTx(tx *sql.Tx, e events.Event) error {
+	payload, err := json.Marshal(e.Data)
+	if err != nil {
+		return fmt.Errorf("failed to encode event data: %w", err)
+	}
+	var targetUserID *string
+	if e.TargetUserID != "" {
+		targetUserID = &e.TargetUserID
+	}
+	_, err = tx.Exec(`
+		INSERT INTO event_outbox (target_type, target_id, event_type, target_user_id, payload)
+		VALUES ($1, $2, $3, $4, $5)
+	`, e.TargetType, e.TargetID, e.EventType, targetUserID, payload)
+	return err
+}
+
+// ClaimOutboxEventsTx leases up to limit due events until lease has passed, at most one per target: the oldest one.
+// Later events of a target wait until the earlier ones are delivered, so each target receives its events in order.
+// Events leased by another dispatcher are skipped until their lease runs out. The events are returned in id order.
+func (r *PostgresRepository) ClaimOutboxEventsTx(tx *sql.Tx, limit int, lease time.Duration) ([]models.OutboxEvent, error) {
+	rows, err := tx.Query(`
+		UPDATE event_outbox
+		SET locked_until = CURRENT_TIMESTAMP + make_interval(secs => $2)
+		WHERE id IN (
+			SELECT o.id
+			FROM event_outbox o
+			WHERE o.next_attempt_at <= CURRENT_TIMESTAMP
+			AND (o.locked_until IS NULL OR o.locked_until <= CURRENT_TIMESTAMP)
+			AND NOT EXISTS (
+				SELECT 1 FROM event_outbox e
+				WHERE e.target_type = o.target_type AND e.target_id = o.target_id AND e.id < o.id
+			)
+			ORDER BY o.id
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, target_type, target_id, event_type, target_user_id, payload, attempts
+	`, limit, lease.Seconds())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var claimed []models.OutboxEvent
+	for rows.Next() {
+		var e models.OutboxEvent
+		var payload []byte
+		if err := rows.Scan(&e.ID, &e.TargetType, &e.TargetID, &e.EventType, &e.TargetUserID, &payload, &e.Attempts); err != nil {
+			return nil, err
+		}
+		e.Payload = payload
+		claimed = append(claimed, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	// RETURNING does not keep the order of the subquery
+	sort.Slice(claimed, func(i, j int) bool { return claimed[i].ID < claimed[j].ID })
+	return claimed, nil
+}
+
+// DeleteOutboxEventTx removes a delivered or abandoned event
+func (r *PostgresRepository) DeleteOutboxEventTx(tx *sql.Tx, id int64) error {
+	_, err := tx.Exec(`DELETE FROM event_outbox WHERE id = $1`, id)
+	return err
+}
+
+// RescheduleOutboxEventTx records a failed delivery, releases the lease and retries the event after delay
+func (r *PostgresRepository) RescheduleOutboxEventTx(tx *sql.Tx, id int64, delay time.Duration, lastErr string) error {
+	_, err := tx.Exec(`
+		UPDATE event_outbox
+		SET attempts = attempts + 1, last_error = $2, next_attempt_at = CURRENT_TIMESTAMP + make_interval(secs => $3),
+			locked_until = NULL
+		WHERE id = $1
+	`, id, lastErr, delay.Seconds())
+	return err
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/LobbyService/internal/events"
 	"github.com/KnuffelGame/KnuffelGame/backend/services/LobbyService/internal/models"
 	"github.com/google/uuid"
 )
@@ -150,9 +151,9 @@ func TestGetLobbyDetail(t *testing.T) {
 	username := "Alice"
 	joinedAt := time.Now()
 
-	columns := []string{"lobby_id", "join_code", "status", "leader_id", "player_id", "user_id", "username", "joined_at", "is_active"}
+	columns := []string{"lobby_id", "join_code", "status", "leader_id", "player_id", "user_id", "username", "joined_at", "is_active", "left_at", "is_ready", "max_players", "turn_timeout_seconds", "is_private", "variant", "require_ready"}
 	rows := sqlmock.NewRows(columns).
-		AddRow(lobbyID.String(), joinCode, status, leaderID.String(), playerID.String(), playerUserID.String(), username, joinedAt, true)
+		AddRow(lobbyID.String(), joinCode, status, leaderID.String(), playerID.String(), playerUserID.String(), username, joinedAt, true, nil, false, 6, 40, true, "classic", false)
 
 	mock.ExpectQuery("SELECT").WithArgs(lobbyID).WillReturnRows(rows)
 
@@ -190,3 +191,219 @@ func TestGetLobbyDetail(t *testing.T) {
 		t.Fatalf("unfulfilled expectations: %v", err)
 	}
 }
+
+func TestGetLobbyDetailDisambiguatesUsernames(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	repo := New(db)
+	lobbyID := uuid.New()
+	leaderID := uuid.New()
+	joinedAt := time.Now()
+
+	columns := []string{"lobby_id", "join_code", "status", "leader_id", "player_id", "user_id", "username", "joined_at", "is_active", "left_at", "is_ready", "max_players", "turn_timeout_seconds", "is_private", "variant", "require_ready"}
+	rows := sqlmock.NewRows(columns)
+	for i, name := range []string{"Bob", "Alice", "bob", "Bob"} {
+		rows.AddRow(lobbyID.String(), "XYZ789", models.LobbyStatusWaiting, leaderID.String(), uuid.NewString(), uuid.NewString(), name, joinedAt.Add(time.Duration(i)*time.Second), true, nil, false, 6, 40, true, "classic", false)
+	}
+	mock.ExpectQuery("SELECT").WithArgs(lobbyID).WillReturnRows(rows)
+
+	resp, err := repo.GetLobbyDetail(context.Background(), lobbyID)
+	if err != nil {
+		t.Fatalf("GetLobbyDetail error: %v", err)
+	}
+	want := []string{"Bob", "Alice", "bob #2", "Bob #3"}
+	for i, p := range resp.Players {
+		if p.Username != want[i] {
+			t.Fatalf("player %d: expected username %q, got %q", i, want[i], p.Username)
+		}
+	}
+}
+
+func TestListPublicLobbies(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	repo := New(db)
+	after := models.LobbyCursor{CreatedAt: time.Now(), ID: uuid.New()}
+	lobbyID := uuid.New()
+	createdAt := after.CreatedAt.Add(-time.Minute)
+
+	columns := []string{"id", "join_code", "status", "username", "player_count", "max_players", "turn_timeout_seconds", "is_private", "variant", "require_ready", "created_at"}
+	mock.ExpectQuery(`WHERE l.status = \$1 AND NOT s.is_private AND \(l.created_at, l.id\) < \(\$2, \$3\).*HAVING COUNT\(p.id\) < s.max_players.*ORDER BY l.created_at DESC, l.id DESC.*LIMIT \$4`).
+		WithArgs(models.LobbyStatusWaiting, after.CreatedAt, after.ID, 3).
+		WillReturnRows(sqlmock.NewRows(columns).AddRow(lobbyID, "ABC123", models.LobbyStatusWaiting, "Alice", 2, 4, 40, false, "classic", false, createdAt))
+
+	lobbies, err := repo.ListPublicLobbies(context.Background(), models.LobbyListFilter{
+		Status:     models.LobbyStatusWaiting,
+		FreeSeats:  true,
+		Descending: true,
+		After:      &after,
+		Limit:      3,
+	})
+	if err != nil {
+		t.Fatalf("ListPublicLobbies error: %v", err)
+	}
+	if len(lobbies) != 1 || lobbies[0].LobbyID != lobbyID || lobbies[0].LeaderUsername != "Alice" || lobbies[0].PlayerCount != 2 || lobbies[0].Settings.MaxPlayers != 4 {
+		t.Fatalf("unexpected lobbies %+v", lobbies)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestListPublicLobbiesFirstPageAscending(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	repo := New(db)
+	columns := []string{"id", "join_code", "status", "username", "player_count", "max_players", "turn_timeout_seconds", "is_private", "variant", "require_ready", "created_at"}
+	mock.ExpectQuery(`WHERE l.status = \$1 AND NOT s.is_private\s+GROUP BY l.id, s.lobby_id, u.id\s+ORDER BY l.created_at ASC, l.id ASC\s+LIMIT \$2`).
+		WithArgs(models.LobbyStatusInGame, 21).
+		WillReturnRows(sqlmock.NewRows(columns))
+
+	lobbies, err := repo.ListPublicLobbies(context.Background(), models.LobbyListFilter{Status: models.LobbyStatusInGame, Limit: 21})
+	if err != nil {
+		t.Fatalf("ListPublicLobbies error: %v", err)
+	}
+	if lobbies == nil || len(lobbies) != 0 {
+		t.Fatalf("expected an empty, non-nil list, got %#v", lobbies)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestEnqueueEventTx(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	repo := New(db)
+	lobbyID := uuid.New()
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO event_outbox \\(target_type, target_id, event_type, target_user_id, payload\\)").
+		WithArgs(events.TargetLobby, lobbyID.String(), models.EventPlayerJoined, nil, []byte(`{"user_id":"`+lobbyID.String()+`","username":"Bob","player_count":2}`)).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatalf("begin: %v", err)
+	}
+	if err := repo.EnqueueEventTx(tx, events.Event{
+		TargetType: events.TargetLobby,
+		TargetID:   lobbyID.String(),
+		EventType:  models.EventPlayerJoined,
+		Data:       models.PlayerJoinedEvent{UserID: lobbyID, Username: "Bob", PlayerCount: 2},
+	}); err != nil {
+		t.Fatalf("EnqueueEventTx error: %v", err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("commit: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestAddPlayerTxRejoinReusesRow(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	repo := New(db)
+	lobbyID, userID, playerID := uuid.New(), uuid.New(), uuid.New()
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO players .* ON CONFLICT \\(lobby_id, user_id\\) DO UPDATE .*left_at = NULL").
+		WithArgs(lobbyID, userID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "joined_at"}).AddRow(playerID, time.Now()))
+	mock.ExpectRollback()
+
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatalf("begin: %v", err)
+	}
+	defer tx.Rollback()
+	pid, _, err := repo.AddPlayerTx(tx, lobbyID, userID)
+	if err != nil {
+		t.Fatalf("AddPlayerTx error: %v", err)
+	}
+	if pid != playerID {
+		t.Fatalf("expected the existing player id %v, got %v", playerID, pid)
+	}
+}
+
+func TestGetPlayersTxKeepsUsernames(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	repo := New(db)
+	lobbyID := uuid.New()
+	joinedAt := time.Now()
+
+	rows := sqlmock.NewRows([]string{"id", "user_id", "username", "joined_at", "is_active", "is_ready"})
+	for i, name := range []string{"Bob", "bob"} {
+		rows.AddRow(uuid.New(), uuid.New(), name, joinedAt.Add(time.Duration(i)*time.Second), true, false)
+	}
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT p.id, p.user_id, u.username").WithArgs(lobbyID).WillReturnRows(rows)
+
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatalf("failed to begin tx: %v", err)
+	}
+	players, err := repo.GetPlayersTx(tx, lobbyID)
+	if err != nil {
+		t.Fatalf("GetPlayersTx error: %v", err)
+	}
+	// the Game Service stores these names, so no display name suffix is added
+	if len(players) != 2 || players[0].Username != "Bob" || players[1].Username != "bob" {
+		t.Fatalf("expected the stored usernames, got %+v", players)
+	}
+}
+
+func TestGetLobbyPlayerCountTxCountsInactivePlayers(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	repo := New(db)
+	lobbyID := uuid.New()
+	mock.ExpectBegin()
+	// inactive players keep their seat, so only players that left are excluded
+	mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM players WHERE lobby_id = \\$1 AND left_at IS NULL$").
+		WithArgs(lobbyID).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatalf("failed to begin tx: %v", err)
+	}
+	defer tx.Rollback()
+	count, err := repo.GetLobbyPlayerCountTx(tx, lobbyID)
+	if err != nil {
+		t.Fatalf("GetLobbyPlayerCountTx error: %v", err)
+	}
+	if count != 3 {
+		t.Fatalf("expected 3 players, got %d", count)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"time"
 
+	"github.com/KnuffelGame/KnuffelGame/backend/services/LobbyService/internal/events"
 	"github.com/KnuffelGame/KnuffelGame/backend/services/LobbyService/internal/models"
 	"github.com/google/uuid"
 )
@@ -15,11 +16,13 @@ type Repository interface {
 	CreateUserIfNotExistsTx(tx *sql.Tx, userID uuid.UUID, username string) error
 	CreateLobbyTx(tx *sql.Tx, joinCode string, leaderID uuid.UUID) (uuid.UUID, error)
 	AddPlayerTx(tx *sql.Tx, lobbyID uuid.UUID, userID uuid.UUID) (uuid.UUID, time.Time, error)
+	CreateLobbySettingsTx(tx *sql.Tx, lobbyID uuid.UUID, settings models.LobbySettings) error
 	GetLobbyDetail(ctx context.Context, lobbyID uuid.UUID) (*models.LobbyDetailResponse, error)
 	GetLobbyLeaderID(ctx context.Context, lobbyID uuid.UUID) (uuid.UUID, error)
 	IsMember(ctx context.Context, lobbyID uuid.UUID, userID uuid.UUID) (bool, error)
 	GetLobbyByJoinCode(ctx context.Context, joinCode string) (*models.Lobby, error)
 	GetLobbyPlayerCount(ctx context.Context, lobbyID uuid.UUID) (int, error)
+	ListPublicLobbies(ctx context.Context, filter models.LobbyListFilter) ([]models.LobbySummary, error)
 
 	// Transaction-based versions for join lobby functionality
 	GetLobbyByJoinCodeTx(tx *sql.Tx, joinCode string) (*models.Lobby, error)
@@ -29,6 +32,40 @@ type Repository interface {
 	// Delete player functionality
 	DeletePlayerTx(tx *sql.Tx, lobbyID uuid.UUID, targetUserID uuid.UUID) error
 
+	// Lobby bans
+	BanUserTx(tx *sql.Tx, lobbyID, userID, bannedBy uuid.UUID) error
+	IsBannedTx(tx *sql.Tx, lobbyID, userID uuid.UUID) (bool, error)
+	ListBans(ctx context.Context, lobbyID uuid.UUID) ([]models.LobbyBan, error)
+	DeleteBan(ctx context.Context, lobbyID, userID uuid.UUID) error
+
 	// Update player active status
 	UpdatePlayerActiveStatusTx(tx *sql.Tx, lobbyID, playerID uuid.UUID, isActive bool) error
+
+	// Ready check
+	UpdatePlayerReadyTx(tx *sql.Tx, lobbyID, userID uuid.UUID, isReady bool) error
+
+	// Start game functionality
+	GetLobbyForUpdateTx(tx *sql.Tx, lobbyID uuid.UUID) (*models.Lobby, error)
+	GetPlayersTx(tx *sql.Tx, lobbyID uuid.UUID) ([]models.PlayerInfo, error)
+	StartLobbyTx(tx *sql.Tx, lobbyID, gameID uuid.UUID) error
+
+	// Leave lobby functionality
+	LeavePlayerTx(tx *sql.Tx, lobbyID, userID uuid.UUID) (*models.Player, error)
+	SetLobbyLeaderTx(tx *sql.Tx, lobbyID, leaderID uuid.UUID) error
+	GetUsernameTx(tx *sql.Tx, userID uuid.UUID) (string, error)
+	SetLobbyStatusTx(tx *sql.Tx, lobbyID uuid.UUID, status string) error
+	DeleteLobbyTx(tx *sql.Tx, lobbyID uuid.UUID) error
+
+	// Lobby settings
+	GetLobbySettingsTx(tx *sql.Tx, lobbyID uuid.UUID) (*models.LobbySettings, error)
+	UpdateLobbySettingsTx(tx *sql.Tx, lobbyID uuid.UUID, settings models.LobbySettings) error
+
+	// Lobby expiry
+	ClaimExpiredLobbiesTx(tx *sql.Tx, expiry models.LobbyExpiry, limit int) ([]models.ExpiredLobby, error)
+
+	// Event outbox
+	EnqueueEventTx(tx *sql.Tx, e events.Event) error
+	ClaimOutboxEventsTx(tx *sql.Tx, limit int, lease time.Duration) ([]models.OutboxEvent, error)
+	DeleteOutboxEventTx(tx *sql.Tx, id int64) error
+	RescheduleOutboxEventTx(tx *sql.Tx, id int64, delay time.Duration, lastErr string) error
 }
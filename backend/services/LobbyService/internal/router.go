@@ -6,14 +6,16 @@ import (
 	"github.com/KnuffelGame/KnuffelGame/backend/libs/auth"
 	"github.com/KnuffelGame/KnuffelGame/backend/libs/healthcheck"
 	"github.com/KnuffelGame/KnuffelGame/backend/libs/logger"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/LobbyService/internal/events"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/LobbyService/internal/game"
 	"github.com/KnuffelGame/KnuffelGame/backend/services/LobbyService/internal/handlers"
 	"github.com/KnuffelGame/KnuffelGame/backend/services/LobbyService/internal/joincode"
 	"github.com/KnuffelGame/KnuffelGame/backend/services/LobbyService/internal/repository"
 	"github.com/go-chi/chi/v5"
 )
 
-// New constructs the HTTP router with repository and join code generator dependencies
-func New(repo repository.Repository, codeGen *joincode.Generator) http.Handler {
+// New constructs the HTTP router with repository, join code generator, Game Service client and event publisher dependencies
+func New(repo repository.Repository, codes joincode.Source, games game.Client, pub events.Publisher) http.Handler {
 	r := chi.NewRouter()
 	// replace chi default logger with structured slog based middleware
 	l := logger.Default()
@@ -25,7 +27,9 @@ func New(repo repository.Repository, codeGen *joincode.Generator) http.Handler {
 	// Internal endpoints (no auth required)
 	r.Route("/internal", func(r chi.Router) {
 		r.Route("/lobbies", func(r chi.Router) {
-			r.Put("/{lobby_id}/players/{player_id}/active", handlers.UpdatePlayerActiveStatusHandler(repo))
+			r.Get("/{lobby_id}/players/{player_id}", handlers.CheckMemberHandler(repo))
+			r.Put("/{lobby_id}/players/{player_id}/active", handlers.UpdatePlayerActiveStatusHandler(repo, games))
+			r.Post("/{lobby_id}/finish", handlers.FinishLobbyHandler(repo))
 		})
 	})
 
@@ -35,7 +39,10 @@ func New(repo repository.Repository, codeGen *joincode.Generator) http.Handler {
 		r.Use(auth.AuthMiddleware)
 
 		// Create lobby (any authenticated user)
-		r.Post("/", handlers.CreateLobbyHandler(repo, codeGen))
+		r.Post("/", handlers.CreateLobbyHandler(repo, codes))
+
+		// List public lobbies (any authenticated user)
+		r.Get("/", handlers.ListLobbiesHandler(repo))
 
 		// Join lobby (any authenticated user)
 		r.Post("/join", handlers.JoinLobbyHandler(repo))
@@ -43,11 +50,27 @@ func New(repo repository.Repository, codeGen *joincode.Generator) http.Handler {
 		// Get lobby details - require membership
 		r.With(handlers.RequireLobbyMember(repo)).Get("/{lobby_id}", handlers.GetLobbyHandler(repo))
 
+		// Toggle ready status - require membership
+		r.With(handlers.RequireLobbyMember(repo)).Put("/{lobby_id}/ready", handlers.UpdatePlayerReadyHandler(repo))
+
 		// Kick player - require leadership
 		r.With(handlers.RequireLobbyLeader(repo)).Post("/{lobby_id}/kick", handlers.KickPlayerHandler(repo))
 
-		// Other lobby routes can use RequireLobbyMember or RequireLobbyLeader as appropriate
-		// e.g. r.With(handlers.RequireLobbyLeader(repo)).Post("/{lobby_id}/start", handlers.StartLobbyHandler(db))
+		// List and lift bans - require leadership
+		r.With(handlers.RequireLobbyLeader(repo)).Get("/{lobby_id}/bans", handlers.ListBansHandler(repo))
+		r.With(handlers.RequireLobbyLeader(repo)).Delete("/{lobby_id}/bans/{user_id}", handlers.LiftBanHandler(repo))
+
+		// Edit settings - require leadership
+		r.With(handlers.RequireLobbyLeader(repo)).Patch("/{lobby_id}/settings", handlers.UpdateLobbySettingsHandler(repo))
+
+		// Transfer leadership - require leadership
+		r.With(handlers.RequireLobbyLeader(repo)).Post("/{lobby_id}/transfer-leadership", handlers.TransferLeadershipHandler(repo, games))
+
+		// Leave lobby (membership is checked by the handler)
+		r.Post("/{lobby_id}/leave", handlers.LeaveLobbyHandler(repo, games, pub))
+
+		// Start game - require leadership
+		r.With(handlers.RequireLobbyLeader(repo)).Post("/{lobby_id}/start", handlers.StartGameHandler(repo, games, pub))
 	})
 
 	return r
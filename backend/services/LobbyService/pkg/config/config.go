@@ -1,65 +1,128 @@
 package config
 
-import "os"
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+)
 
 // Config holds runtime configuration loaded from environment variables.
 // PORT defaults to 8083 if unset.
+// GAME_SERVICE_URL defaults to the docker compose address of the Game Service; it creates the games of started lobbies.
+// SSE_SERVICE_URL defaults to the docker compose address of the SSE Service;
+// set it to an empty value to disable event publishing.
+// JANITOR_INTERVAL is the wait between cleanups of expired lobbies as a Go duration (default 1m).
+// LOBBY_IDLE_TIMEOUT closes waiting lobbies without a join or lobby change for that long (default 2h).
+// LOBBY_INACTIVE_TIMEOUT closes waiting and running lobbies whose players are all inactive for that long (default 15m).
+// FINISHED_LOBBY_RETENTION is how long finished lobbies are kept before they are deleted (default 168h).
+// JOIN_CODE_ALPHABET and JOIN_CODE_LENGTH shape new join codes; existing codes stay valid when they change.
 // Database configuration must be provided via environment variables.
 // Extend here for future configuration values.
 
 type Config struct {
-	Port             string
-	DatabaseHost     string
-	DatabasePort     string
-	DatabaseUser     string
-	DatabasePassword string
-	DatabaseName     string
-	DatabaseSSLMode  string
+	Port              string
+	GameServiceURL    string
+	SSEServiceURL     string
+	JanitorInterval   time.Duration
+	IdleTimeout       time.Duration
+	InactiveTimeout   time.Duration
+	FinishedRetention time.Duration
+	JoinCodeAlphabet  string
+	JoinCodeLength    int
+	DatabaseHost      string
+	DatabasePort      string
+	DatabaseUser      string
+	DatabasePassword  string
+	DatabaseName      string
+	DatabaseSSLMode   string
 }
 
-func Load() *Config {
-	port := os.Getenv("PORT")
-	if port == "" {
-		port = "8083"
+// Load reads the configuration from the environment and validates it.
+func Load() (*Config, error) {
+	sseURL, ok := os.LookupEnv("SSE_SERVICE_URL")
+	if !ok {
+		sseURL = "http://SSEService:8084"
 	}
 
-	dbHost := os.Getenv("DATABASE_HOST")
-	if dbHost == "" {
-		dbHost = "Postgres"
+	janitorInterval, err := duration("JANITOR_INTERVAL", "1m")
+	if err != nil {
+		return nil, err
 	}
 
-	dbPort := os.Getenv("DATABASE_PORT")
-	if dbPort == "" {
-		dbPort = "5432"
+	idleTimeout, err := duration("LOBBY_IDLE_TIMEOUT", "2h")
+	if err != nil {
+		return nil, err
 	}
 
-	dbUser := os.Getenv("DATABASE_USER")
-	if dbUser == "" {
-		dbUser = "lobby"
+	inactiveTimeout, err := duration("LOBBY_INACTIVE_TIMEOUT", "15m")
+	if err != nil {
+		return nil, err
 	}
 
-	dbPassword := os.Getenv("DATABASE_PASSWORD")
-	if dbPassword == "" {
-		dbPassword = "secure"
+	finishedRetention, err := duration("FINISHED_LOBBY_RETENTION", "168h")
+	if err != nil {
+		return nil, err
 	}
 
-	dbName := os.Getenv("DATABASE_NAME")
-	if dbName == "" {
-		dbName = "lobby"
+	joinCodeLength, err := strconv.Atoi(env("JOIN_CODE_LENGTH", "6"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid JOIN_CODE_LENGTH %q", os.Getenv("JOIN_CODE_LENGTH"))
 	}
 
-	dbSSLMode := os.Getenv("DATABASE_SSLMODE")
-	if dbSSLMode == "" {
-		dbSSLMode = "disable"
+	cfg := &Config{
+		Port:              env("PORT", "8083"),
+		GameServiceURL:    env("GAME_SERVICE_URL", "http://GameService:8082"),
+		SSEServiceURL:     sseURL,
+		JanitorInterval:   janitorInterval,
+		IdleTimeout:       idleTimeout,
+		InactiveTimeout:   inactiveTimeout,
+		FinishedRetention: finishedRetention,
+		JoinCodeAlphabet:  env("JOIN_CODE_ALPHABET", "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"),
+		JoinCodeLength:    joinCodeLength,
+		DatabaseHost:      env("DATABASE_HOST", "Postgres"),
+		DatabasePort:      env("DATABASE_PORT", "5432"),
+		DatabaseUser:      env("DATABASE_USER", "lobby"),
+		DatabasePassword:  env("DATABASE_PASSWORD", "secure"),
+		DatabaseName:      env("DATABASE_NAME", "lobby"),
+		DatabaseSSLMode:   env("DATABASE_SSLMODE", "disable"),
 	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// Validate checks that the janitor durations are positive.
+// The join code alphabet and length are checked by joincode.NewGenerator.
+func (c *Config) Validate() error {
+	for name, d := range map[string]time.Duration{
+		"JANITOR_INTERVAL":         c.JanitorInterval,
+		"LOBBY_IDLE_TIMEOUT":       c.IdleTimeout,
+		"LOBBY_INACTIVE_TIMEOUT":   c.InactiveTimeout,
+		"FINISHED_LOBBY_RETENTION": c.FinishedRetention,
+	} {
+		if d <= 0 {
+			return fmt.Errorf("invalid %s, must be positive", name)
+		}
+	}
+	return nil
+}
+
+// env returns the variable, or def if it is unset or empty
+func env(name, def string) string {
+	if v := os.Getenv(name); v != "" {
+		return v
+	}
+	return def
+}
 
-	return &Config{
-		Port:             port,
-		DatabaseHost:     dbHost,
-		DatabasePort:     dbPort,
-		DatabaseUser:     dbUser,
-		DatabasePassword: dbPassword,
-		DatabaseName:     dbName,
-		DatabaseSSLMode:  dbSSLMode,
+// duration parses the variable as a Go duration, falling back to def
+func duration(name, def string) (time.Duration, error) {
+	v := env(name, def)
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s %q", name, v)
 	}
+	return d, nil
 }
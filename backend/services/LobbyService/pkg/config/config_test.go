@@ -0,0 +1,41 @@
+package config
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLoadDefaults(t *testing.T) {
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.JanitorInterval != time.Minute || cfg.IdleTimeout != 2*time.Hour ||
+		cfg.InactiveTimeout != 15*time.Minute || cfg.FinishedRetention != 168*time.Hour {
+		t.Fatalf("unexpected durations %+v", cfg)
+	}
+	if cfg.JoinCodeLength != 6 {
+		t.Fatalf("expected join code length 6, got %d", cfg.JoinCodeLength)
+	}
+}
+
+func TestLoadRejects(t *testing.T) {
+	for _, tc := range []struct {
+		name, key, value string
+	}{
+		{"unparsable interval", "JANITOR_INTERVAL", "often"},
+		{"negative idle timeout", "LOBBY_IDLE_TIMEOUT", "-1h"},
+		{"zero inactive timeout", "LOBBY_INACTIVE_TIMEOUT", "0s"},
+		{"unparsable retention", "FINISHED_LOBBY_RETENTION", "7d"},
+		{"unparsable join code length", "JOIN_CODE_LENGTH", "six"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv(tc.key, tc.value)
+			_, err := Load()
+			if err == nil || !strings.Contains(err.Error(), tc.key) {
+				t.Fatalf("expected an error about %s, got %v", tc.key, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/KnuffelGame/KnuffelGame/backend/libs/logger"
+	router "github.com/KnuffelGame/KnuffelGame/backend/services/SSEService/internal"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/SSEService/internal/bus"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/SSEService/internal/db"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/SSEService/internal/hub"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/SSEService/internal/membership"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/SSEService/pkg/config"
+	"github.com/lib/pq"
+)
+
+func main() {
+	// ensure SERVICE_NAME env is present (fallback if empty)
+	if os.Getenv("SERVICE_NAME") == "" {
+		_ = os.Setenv("SERVICE_NAME", "SSEService")
+	}
+	log := logger.FromEnv().With(slog.String("component", "bootstrap"))
+
+	cfg := config.Load()
+
+	keepAlive, err := time.ParseDuration(cfg.KeepAliveInterval)
+	if err != nil || keepAlive <= 0 {
+		log.Error("invalid KEEP_ALIVE_INTERVAL", slog.String("interval", cfg.KeepAliveInterval))
+		os.Exit(1)
+	}
+
+	historySize, err := strconv.Atoi(cfg.HistorySize)
+	if err != nil || historySize <= 0 {
+		log.Error("invalid HISTORY_SIZE", slog.String("size", cfg.HistorySize))
+		os.Exit(1)
+	}
+
+	historyRetention, err := time.ParseDuration(cfg.HistoryRetention)
+	if err != nil || historyRetention <= 0 {
+		log.Error("invalid HISTORY_RETENTION", slog.String("retention", cfg.HistoryRetention))
+		os.Exit(1)
+	}
+
+	queueSize, err := strconv.Atoi(cfg.QueueSize)
+	if err != nil || queueSize <= 0 {
+		log.Error("invalid QUEUE_SIZE", slog.String("size", cfg.QueueSize))
+		os.Exit(1)
+	}
+
+	overflow, ok := hub.ParseOverflowPolicy(cfg.OverflowPolicy)
+	if !ok {
+		log.Error("invalid QUEUE_OVERFLOW_POLICY", slog.String("policy", cfg.OverflowPolicy))
+		os.Exit(1)
+	}
+
+	// Connections are kept in memory by each replica; the event bus makes every replica see every event
+	h := hub.New(hub.Config{
+		HistorySize:      historySize,
+		HistoryRetention: historyRetention,
+		QueueSize:        queueSize,
+		Overflow:         overflow,
+	})
+
+	// Drop the replay history of lobbies and games nobody reconnected to
+	go func() {
+		for range time.Tick(time.Minute) {
+			if removed := h.Sweep(); removed > 0 {
+				log.Info("removed idle targets", slog.Int("count", removed))
+			}
+		}
+	}()
+
+	var b bus.EventBus
+	switch cfg.EventBus {
+	case "local":
+		b = bus.NewLocal(h)
+	case "postgres":
+		dbConfig := db.Config{
+			Host:     cfg.DatabaseHost,
+			Port:     cfg.DatabasePort,
+			User:     cfg.DatabaseUser,
+			Password: cfg.DatabasePassword,
+			Database: cfg.DatabaseName,
+			SSLMode:  cfg.DatabaseSSLMode,
+		}
+
+		dbConn, err := db.New(dbConfig)
+		if err != nil {
+			log.Error("failed to connect to database", slog.String("error", err.Error()))
+			os.Exit(1)
+		}
+		defer dbConn.Close()
+
+		if err := db.RunMigrations(dbConn.DB); err != nil {
+			log.Error("failed to run migrations", slog.String("error", err.Error()))
+			os.Exit(1)
+		}
+
+		// LISTEN needs a dedicated connection; pq.Listener reconnects on its own
+		listener := pq.NewListener(dbConfig.ConnString(), time.Second, time.Minute, func(ev pq.ListenerEventType, err error) {
+			if err != nil {
+				log.Warn("event bus listener error", slog.String("error", err.Error()))
+			}
+		})
+		if err := listener.Listen(bus.Channel); err != nil {
+			log.Error("failed to listen for events", slog.String("error", err.Error()))
+			os.Exit(1)
+		}
+		defer listener.Close()
+
+		pgBus := bus.NewPostgres(dbConn.DB, h, log)
+		go pgBus.Run(context.Background(), listener.Notify)
+		b = pgBus
+	default:
+		log.Error("invalid EVENT_BUS", slog.String("event_bus", cfg.EventBus))
+		os.Exit(1)
+	}
+	log.Info("event bus configured", slog.String("event_bus", cfg.EventBus))
+
+	// Streams are only opened for members of the lobby and players of the game
+	if cfg.LobbyServiceURL == "" {
+		log.Warn("LOBBY_SERVICE_URL is empty, lobby streams are open to every authenticated user")
+	}
+	if cfg.GameServiceURL == "" {
+		log.Warn("GAME_SERVICE_URL is empty, game streams are open to every authenticated user")
+	}
+	members := membership.NewHTTPChecker(cfg.LobbyServiceURL, cfg.GameServiceURL)
+
+	r := router.New(h, b, members, keepAlive)
+	log.Info("listening", slog.String("port", cfg.Port))
+	// no WriteTimeout: event streams stay open indefinitely
+	if err := http.ListenAndServe(":"+cfg.Port, r); err != nil {
+		log.Error("server exited", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,43 @@
+// Package bus distributes hub operations to every replica of the SSE Service.
+// A publish received by one replica reaches the connections held by all of them.
+package bus
+
+import (
+	"context"
+
+	"github.com/KnuffelGame/KnuffelGame/backend/services/SSEService/internal/hub"
+	"github.com/google/uuid"
+)
+
+// EventBus mirrors the mutating operations of hub.Hub. Every replica applies every operation to its own hub
+// in the same order; the returned result and error describe the outcome on this replica's hub.
+type EventBus interface {
+	Publish(ctx context.Context, topic hub.Topic, msg hub.Message, targetUser *uuid.UUID) (hub.PublishResult, error)
+	Register(ctx context.Context, topic hub.Topic) error
+	Unregister(ctx context.Context, topic hub.Topic, reason string) (int, error)
+}
+
+// Local applies operations to the hub of this process only. Used when a single replica is running.
+type Local struct {
+	hub *hub.Hub
+}
+
+// NewLocal creates an in-process bus for h
+func NewLocal(h *hub.Hub) *Local {
+	return &Local{hub: h}
+}
+
+// Publish queues msg on the matching connections of this replica
+func (l *Local) Publish(_ context.Context, topic hub.Topic, msg hub.Message, targetUser *uuid.UUID) (hub.PublishResult, error) {
+	return l.hub.Publish(topic, msg, targetUser)
+}
+
+// Register registers the topic on this replica
+func (l *Local) Register(_ context.Context, topic hub.Topic) error {
+	return l.hub.Register(topic)
+}
+
+// Unregister closes the connections of the topic on this replica
+func (l *Local) Unregister(_ context.Context, topic hub.Topic, reason string) (int, error) {
+	return l.hub.Unregister(topic, reason)
+}
@@ -0,0 +1,220 @@
+package bus
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/KnuffelGame/KnuffelGame/backend/services/SSEService/internal/hub"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// Channel is the LISTEN/NOTIFY channel shared by all replicas
+const Channel = "sse_events"
+
+// maxPayload is the largest payload Postgres accepts for NOTIFY
+const maxPayload = 7999
+
+// deliveryTimeout bounds how long a sender waits for its own notification to come back
+const deliveryTimeout = 5 * time.Second
+
+var (
+	// ErrPayloadTooLarge is returned for events that do not fit into a notification
+	ErrPayloadTooLarge = errors.New("event exceeds the notification payload limit")
+	// ErrNotDelivered is returned if the notification was sent but did not reach this replica in time
+	ErrNotDelivered = errors.New("event was not delivered back in time")
+)
+
+type op string
+
+const (
+	opPublish    op = "publish"
+	opRegister   op = "register"
+	opUnregister op = "unregister"
+)
+
+// envelope is the notification payload. ID comes from the sse_event_id sequence and doubles as the SSE event id.
+type envelope struct {
+	ID           uint64          `json:"id"`
+	Op           op              `json:"op"`
+	TargetType   hub.TargetType  `json:"target_type"`
+	TargetID     uuid.UUID       `json:"target_id"`
+	EventType    string          `json:"event_type,omitempty"`
+	Data         json.RawMessage `json:"data,omitempty"`
+	TargetUserID *uuid.UUID      `json:"target_user_id,omitempty"`
+	Reason       string          `json:"reason,omitempty"`
+}
+
+// outcome is the result of applying an envelope to this replica's hub
+type outcome struct {
+	res    hub.PublishResult
+	closed int
+	err    error
+}
+
+// Postgres distributes operations with LISTEN/NOTIFY. A sender does not touch its hub directly:
+// it waits until its own notification comes back through Run, so every replica applies all
+// operations in the same order, the order in which the notifying transactions committed.
+type Postgres struct {
+	db  *sql.DB
+	hub *hub.Hub
+	log *slog.Logger
+
+	mu      sync.Mutex
+	waiters map[uint64]chan outcome
+}
+
+// NewPostgres creates a bus that sends notifications over db and applies received ones to h.
+// Run must be started with the notifications of a listener on Channel.
+func NewPostgres(db *sql.DB, h *hub.Hub, log *slog.Logger) *Postgres {
+	return &Postgres{db: db, hub: h, log: log.With(slog.String("component", "event_bus")), waiters: make(map[uint64]chan outcome)}
+}
+
+// Publish sends the event to all replicas and returns the delivery on this one.
+// Once the event is on the bus another replica may deliver it, so a target unknown to this replica is not an error;
+// the result then counts no connections.
+func (p *Postgres) Publish(ctx context.Context, topic hub.Topic, msg hub.Message, targetUser *uuid.UUID) (hub.PublishResult, error) {
+	o, err := p.send(ctx, envelope{
+		Op:           opPublish,
+		TargetType:   topic.Type,
+		TargetID:     topic.ID,
+		EventType:    msg.Event,
+		Data:         msg.Data,
+		TargetUserID: targetUser,
+	})
+	if err != nil {
+		return hub.PublishResult{}, err
+	}
+	if errors.Is(o.err, hub.ErrTargetNotFound) {
+		return hub.PublishResult{}, nil
+	}
+	return o.res, o.err
+}
+
+// Register registers the topic on all replicas
+func (p *Postgres) Register(ctx context.Context, topic hub.Topic) error {
+	o, err := p.send(ctx, envelope{Op: opRegister, TargetType: topic.Type, TargetID: topic.ID})
+	if err != nil {
+		return err
+	}
+	return o.err
+}
+
+// Unregister closes the connections of the topic on all replicas and returns the number closed on this one
+func (p *Postgres) Unregister(ctx context.Context, topic hub.Topic, reason string) (int, error) {
+	o, err := p.send(ctx, envelope{Op: opUnregister, TargetType: topic.Type, TargetID: topic.ID, Reason: reason})
+	if err != nil {
+		return 0, err
+	}
+	return o.closed, o.err
+}
+
+func (p *Postgres) send(ctx context.Context, env envelope) (outcome, error) {
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return outcome{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Held until commit, so ids are committed, and therefore delivered, in increasing order
+	if _, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock(hashtext($1))`, Channel); err != nil {
+		return outcome{}, fmt.Errorf("failed to lock event sequence: %w", err)
+	}
+	if err := tx.QueryRowContext(ctx, `SELECT nextval('sse_event_id')`).Scan(&env.ID); err != nil {
+		return outcome{}, fmt.Errorf("failed to allocate event id: %w", err)
+	}
+
+	payload, err := json.Marshal(env)
+	if err != nil {
+		return outcome{}, fmt.Errorf("failed to encode event: %w", err)
+	}
+	if len(payload) > maxPayload {
+		return outcome{}, ErrPayloadTooLarge
+	}
+
+	done := make(chan outcome, 1)
+	p.mu.Lock()
+	p.waiters[env.ID] = done
+	p.mu.Unlock()
+	defer func() {
+		p.mu.Lock()
+		delete(p.waiters, env.ID)
+		p.mu.Unlock()
+	}()
+
+	if _, err := tx.ExecContext(ctx, `SELECT pg_notify($1, $2)`, Channel, string(payload)); err != nil {
+		return outcome{}, fmt.Errorf("failed to notify: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return outcome{}, fmt.Errorf("failed to commit notification: %w", err)
+	}
+
+	timer := time.NewTimer(deliveryTimeout)
+	defer timer.Stop()
+	select {
+	case o := <-done:
+		return o, nil
+	case <-timer.C:
+		return outcome{}, ErrNotDelivered
+	case <-ctx.Done():
+		return outcome{}, ctx.Err()
+	}
+}
+
+// Run applies received notifications to the hub until notify is closed or ctx is cancelled.
+// notify is typically the Notify channel of a pq.Listener on Channel; it sends nil after reconnecting,
+// in which case notifications may have been lost and the hub is reset.
+func (p *Postgres) Run(ctx context.Context, notify <-chan *pq.Notification) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case n, ok := <-notify:
+			if !ok {
+				return
+			}
+			if n == nil {
+				p.log.Warn("listener reconnected, clients have to resync")
+				p.hub.Reset()
+				continue
+			}
+
+			var env envelope
+			if err := json.Unmarshal([]byte(n.Extra), &env); err != nil {
+				p.log.Error("failed to decode notification", slog.String("error", err.Error()))
+				continue
+			}
+			o := p.apply(env)
+
+			p.mu.Lock()
+			done, ok := p.waiters[env.ID]
+			p.mu.Unlock()
+			if ok {
+				done <- o
+			}
+		}
+	}
+}
+
+func (p *Postgres) apply(env envelope) outcome {
+	topic := hub.Topic{Type: env.TargetType, ID: env.TargetID}
+	switch env.Op {
+	case opPublish:
+		res, err := p.hub.Publish(topic, hub.Message{ID: env.ID, Event: env.EventType, Data: env.Data}, env.TargetUserID)
+		return outcome{res: res, err: err}
+	case opRegister:
+		return outcome{err: p.hub.Register(topic)}
+	case opUnregister:
+		closed, err := p.hub.Unregister(topic, env.Reason)
+		return outcome{closed: closed, err: err}
+	default:
+		p.log.Warn("unknown operation", slog.String("op", string(env.Op)))
+		return outcome{err: fmt.Errorf("unknown operation %q", env.Op)}
+	}
+}
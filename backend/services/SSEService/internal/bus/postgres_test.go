@@ -0,0 +1,200 @@
+package bus
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/SSEService/internal/hub"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// fakePostgres stands in for the notification channel: payloads passed to pg_notify
+// are delivered to the listener of every replica, as Postgres does on commit
+type fakePostgres struct {
+	listeners []chan *pq.Notification
+}
+
+// Match implements sqlmock.Argument for the pg_notify payload
+func (f *fakePostgres) Match(v driver.Value) bool {
+	payload, ok := v.(string)
+	if !ok {
+		return false
+	}
+	for _, l := range f.listeners {
+		l <- &pq.Notification{Channel: Channel, Extra: payload}
+	}
+	return true
+}
+
+func (f *fakePostgres) listen() chan *pq.Notification {
+	l := make(chan *pq.Notification, 16)
+	f.listeners = append(f.listeners, l)
+	return l
+}
+
+type replica struct {
+	hub *hub.Hub
+	bus *Postgres
+}
+
+// newReplicas starts n buses sharing fake; the first one sends through mock
+func newReplicas(t *testing.T, n int) ([]replica, sqlmock.Sqlmock, *fakePostgres) {
+	t.Helper()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	fake := &fakePostgres{}
+	log := slog.New(slog.DiscardHandler)
+	replicas := make([]replica, n)
+	for i := range replicas {
+		h := hub.New(hub.Config{})
+		b := NewPostgres(db, h, log)
+		go b.Run(ctx, fake.listen())
+		replicas[i] = replica{hub: h, bus: b}
+	}
+	return replicas, mock, fake
+}
+
+func expectNotify(mock sqlmock.Sqlmock, fake *fakePostgres, id int) {
+	mock.ExpectBegin()
+	mock.ExpectExec("SELECT pg_advisory_xact_lock").WithArgs(Channel).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("SELECT nextval").WillReturnRows(sqlmock.NewRows([]string{"nextval"}).AddRow(id))
+	mock.ExpectExec("SELECT pg_notify").WithArgs(Channel, fake).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+}
+
+// await returns the next message queued on c, failing after a second
+func await(t *testing.T, c *hub.Conn) hub.Message {
+	t.Helper()
+	select {
+	case <-c.Ready():
+	case <-time.After(time.Second):
+		t.Fatalf("timed out waiting for a message")
+	}
+	msg, ok := c.Next()
+	if !ok {
+		t.Fatalf("expected a queued message")
+	}
+	return msg
+}
+
+func TestPostgresPublishReachesAllReplicas(t *testing.T) {
+	replicas, mock, fake := newReplicas(t, 2)
+	game := hub.Topic{Type: hub.TargetGame, ID: uuid.New()}
+	local, _ := replicas[0].hub.Subscribe(game, uuid.New(), "")
+	remote, _ := replicas[1].hub.Subscribe(game, uuid.New(), "")
+
+	expectNotify(mock, fake, 42)
+	res, err := replicas[0].bus.Publish(context.Background(), game, hub.Message{Event: "dice_rolled", Data: []byte(`{"dice":[1,2,3,4,5]}`)}, nil)
+	if err != nil {
+		t.Fatalf("Publish error: %v", err)
+	}
+	if res.ConnectionsFound != 1 || res.EventsSent != 1 {
+		t.Fatalf("expected the result of the sending replica, got %+v", res)
+	}
+
+	for _, c := range []*hub.Conn{local, remote} {
+		msg := await(t, c)
+		if msg.ID != 42 || msg.Event != "dice_rolled" || string(msg.Data) != `{"dice":[1,2,3,4,5]}` {
+			t.Fatalf("unexpected message %+v", msg)
+		}
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestPostgresPublishTargetUnknownToSender(t *testing.T) {
+	replicas, mock, fake := newReplicas(t, 2)
+	lobby := hub.Topic{Type: hub.TargetLobby, ID: uuid.New()}
+	bob := uuid.New()
+	remote, _ := replicas[1].hub.Subscribe(lobby, bob, "")
+
+	expectNotify(mock, fake, 3)
+	res, err := replicas[0].bus.Publish(context.Background(), lobby, hub.Message{Event: "you_were_kicked", Data: []byte(`{}`)}, &bob)
+	if err != nil {
+		t.Fatalf("expected the event to be accepted although the sending replica has no connections, got %v", err)
+	}
+	if res.ConnectionsFound != 0 || res.EventsSent != 0 {
+		t.Fatalf("expected no local deliveries, got %+v", res)
+	}
+	if msg := await(t, remote); msg.Event != "you_were_kicked" {
+		t.Fatalf("expected the other replica to deliver the event, got %q", msg.Event)
+	}
+}
+
+func TestPostgresRegisterAndUnregister(t *testing.T) {
+	replicas, mock, fake := newReplicas(t, 2)
+	lobby := hub.Topic{Type: hub.TargetLobby, ID: uuid.New()}
+
+	expectNotify(mock, fake, 1)
+	if err := replicas[0].bus.Register(context.Background(), lobby); err != nil {
+		t.Fatalf("Register error: %v", err)
+	}
+	expectNotify(mock, fake, 2)
+	if err := replicas[0].bus.Register(context.Background(), lobby); !errors.Is(err, hub.ErrAlreadyRegistered) {
+		t.Fatalf("expected ErrAlreadyRegistered, got %v", err)
+	}
+
+	remote, _ := replicas[1].hub.Subscribe(lobby, uuid.New(), "")
+	expectNotify(mock, fake, 3)
+	closed, err := replicas[0].bus.Unregister(context.Background(), lobby, "lobby_deleted")
+	if err != nil {
+		t.Fatalf("Unregister error: %v", err)
+	}
+	if closed != 0 {
+		t.Fatalf("expected no connections closed on the sending replica, got %d", closed)
+	}
+	if msg := await(t, remote); msg.Event != hub.EventConnectionClosed {
+		t.Fatalf("expected connection_closed on the other replica, got %q", msg.Event)
+	}
+	select {
+	case <-remote.Done():
+	case <-time.After(time.Second):
+		t.Fatalf("expected the remote connection to be closed")
+	}
+}
+
+func TestPostgresPayloadTooLarge(t *testing.T) {
+	replicas, mock, _ := newReplicas(t, 1)
+	game := hub.Topic{Type: hub.TargetGame, ID: uuid.New()}
+
+	mock.ExpectBegin()
+	mock.ExpectExec("SELECT pg_advisory_xact_lock").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("SELECT nextval").WillReturnRows(sqlmock.NewRows([]string{"nextval"}).AddRow(1))
+	mock.ExpectRollback()
+
+	data := []byte(`"` + strings.Repeat("x", maxPayload) + `"`)
+	if _, err := replicas[0].bus.Publish(context.Background(), game, hub.Message{Event: "game_ended", Data: data}, nil); !errors.Is(err, ErrPayloadTooLarge) {
+		t.Fatalf("expected ErrPayloadTooLarge, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestPostgresReconnectRequiresResync(t *testing.T) {
+	replicas, _, fake := newReplicas(t, 1)
+	game := hub.Topic{Type: hub.TargetGame, ID: uuid.New()}
+	c, _ := replicas[0].hub.Subscribe(game, uuid.New(), "")
+
+	// pq.Listener sends nil after it reconnected
+	fake.listeners[0] <- nil
+
+	if msg := await(t, c); msg.Event != hub.EventResyncRequired {
+		t.Fatalf("expected resync_required after a reconnect, got %q", msg.Event)
+	}
+}
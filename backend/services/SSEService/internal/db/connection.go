@@ -0,0 +1,58 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"log/slog"
+
+	_ "github.com/lib/pq"
+)
+
+// Connection holds the database connection pool
+type Connection struct {
+	DB *sql.DB
+}
+
+// Config holds the database connection configuration
+type Config struct {
+	Host     string
+	Port     string
+	User     string
+	Password string
+	Database string
+	SSLMode  string
+}
+
+// ConnString returns the lib/pq connection string; the event bus listener opens its own connection with it
+func (cfg Config) ConnString() string {
+	return fmt.Sprintf(
+		"host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
+		cfg.Host, cfg.Port, cfg.User, cfg.Password, cfg.Database, cfg.SSLMode,
+	)
+}
+
+// New creates a new database connection
+func New(cfg Config) (*Connection, error) {
+	db, err := sql.Open("postgres", cfg.ConnString())
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	if err := db.Ping(); err != nil {
+		return nil, fmt.Errorf("failed to ping database: %w", err)
+	}
+
+	slog.Info("database connection established",
+		slog.String("host", cfg.Host),
+		slog.String("database", cfg.Database))
+
+	return &Connection{DB: db}, nil
+}
+
+// Close closes the database connection
+func (c *Connection) Close() error {
+	if c.DB != nil {
+		return c.DB.Close()
+	}
+	return nil
+}
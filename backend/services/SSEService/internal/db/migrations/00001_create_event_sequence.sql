@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Event ids are shared by all replicas so Last-Event-ID means the same on each of them
+CREATE SEQUENCE IF NOT EXISTS sse_event_id;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP SEQUENCE IF EXISTS sse_event_id;
+
+-- +goose StatementEnd
@@ -0,0 +1,35 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/KnuffelGame/KnuffelGame/backend/libs/httpx"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/SSEService/internal/hub"
+	"github.com/google/uuid"
+)
+
+// parseTopic validates target_type and target_id from a request body, writing a 400 response on failure
+func parseTopic(w http.ResponseWriter, targetType, targetID string, log *slog.Logger) (hub.Topic, bool) {
+	if targetType == "" {
+		httpx.WriteError(w, http.StatusBadRequest, "invalid_request", "Missing required field: target_type", nil, log)
+		return hub.Topic{}, false
+	}
+	if targetID == "" {
+		httpx.WriteError(w, http.StatusBadRequest, "invalid_request", "Missing required field: target_id", nil, log)
+		return hub.Topic{}, false
+	}
+	tt, ok := hub.ParseTargetType(targetType)
+	if !ok {
+		log.Warn("invalid target_type", slog.String("target_type", targetType))
+		httpx.WriteError(w, http.StatusBadRequest, "invalid_request", "Invalid target_type - must be 'lobby' or 'game'", nil, log)
+		return hub.Topic{}, false
+	}
+	id, err := uuid.Parse(targetID)
+	if err != nil {
+		log.Warn("invalid target_id format", slog.String("target_id", targetID), slog.String("error", err.Error()))
+		httpx.WriteError(w, http.StatusBadRequest, "invalid_request", "Invalid target_id format", map[string]interface{}{"detail": err.Error()}, log)
+		return hub.Topic{}, false
+	}
+	return hub.Topic{Type: tt, ID: id}, true
+}
@@ -0,0 +1,41 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/KnuffelGame/KnuffelGame/backend/libs/httpx"
+	"github.com/KnuffelGame/KnuffelGame/backend/libs/logger"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/SSEService/internal/hub"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/SSEService/internal/models"
+)
+
+// ConnectionStatsHandler returns an http.HandlerFunc that reports targets, connections, queue depth and drops
+// Internal endpoint (no auth); intended for monitoring and debugging
+// Returns: 200 OK with ConnectionStatsResponse
+func ConnectionStatsHandler(h *hub.Hub) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log := logger.Logger(r.Context()).WithGroup("handler").With(slog.String("action", "connection_stats"))
+
+		s := h.Stats()
+		httpx.WriteJSON(w, http.StatusOK, models.ConnectionStatsResponse{
+			TotalTargets:     s.TotalTargets,
+			TotalConnections: s.TotalConnections,
+			Lobbies:          models.TargetStats{Count: s.Lobbies.Count, Connections: s.Lobbies.Connections},
+			Games:            models.TargetStats{Count: s.Games.Count, Connections: s.Games.Connections},
+			Queues: models.QueueStats{
+				Size:           s.Queues.Size,
+				OverflowPolicy: string(s.Queues.Overflow),
+				QueuedMessages: s.Queues.QueuedMessages,
+				MaxDepth:       s.Queues.MaxDepth,
+			},
+			Drops: models.DropStats{
+				Connections:     s.Drops.Connections,
+				Events:          s.Drops.Events,
+				CoalescedEvents: s.Drops.Coalesced,
+			},
+			Timestamp: time.Now().UTC(),
+		}, log)
+	}
+}
@@ -0,0 +1,46 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/KnuffelGame/KnuffelGame/backend/services/SSEService/internal/hub"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/SSEService/internal/models"
+	"github.com/google/uuid"
+)
+
+func TestConnectionStats(t *testing.T) {
+	h := hub.New(hub.Config{QueueSize: 2, Overflow: hub.OverflowDropOldest})
+	lobby := hub.Topic{Type: hub.TargetLobby, ID: uuid.New()}
+	h.Subscribe(lobby, uuid.New(), "")
+	h.Subscribe(lobby, uuid.New(), "")
+	_ = h.Register(hub.Topic{Type: hub.TargetGame, ID: uuid.New()})
+	for i := 0; i < 3; i++ {
+		_, _ = h.Publish(lobby, hub.Message{Event: "player_joined", Data: []byte(`{}`)}, nil)
+	}
+
+	rec := serveJSON(ConnectionStatsHandler(h), http.MethodGet, nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	var resp models.ConnectionStatsResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.TotalTargets != 2 || resp.TotalConnections != 2 ||
+		resp.Lobbies != (models.TargetStats{Count: 1, Connections: 2}) ||
+		resp.Games != (models.TargetStats{Count: 1, Connections: 0}) {
+		t.Fatalf("unexpected stats %+v", resp)
+	}
+	if resp.Queues != (models.QueueStats{Size: 2, OverflowPolicy: "drop_oldest", QueuedMessages: 4, MaxDepth: 2}) {
+		t.Fatalf("unexpected queue stats %+v", resp.Queues)
+	}
+	if resp.Drops != (models.DropStats{Events: 2}) {
+		t.Fatalf("unexpected drop stats %+v", resp.Drops)
+	}
+	if resp.Timestamp.IsZero() {
+		t.Fatalf("expected timestamp")
+	}
+}
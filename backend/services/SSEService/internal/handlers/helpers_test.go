@@ -0,0 +1,53 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/KnuffelGame/KnuffelGame/backend/services/SSEService/internal/hub"
+	"github.com/google/uuid"
+)
+
+// serveJSON runs h with body encoded as JSON
+func serveJSON(h http.HandlerFunc, method string, body interface{}) *httptest.ResponseRecorder {
+	var buf bytes.Buffer
+	if body != nil {
+		_ = json.NewEncoder(&buf).Encode(body)
+	}
+	req := httptest.NewRequest(method, "/internal", &buf)
+	req.Header.Set("Content-Type", "application/json")
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func decodeError(t *testing.T, rec *httptest.ResponseRecorder) map[string]interface{} {
+	t.Helper()
+	var payload map[string]interface{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &payload); err != nil {
+		t.Fatalf("failed to decode error response: %v", err)
+	}
+	return payload
+}
+
+// queued returns the message waiting on the connection, failing if there is none
+func queued(t *testing.T, c *hub.Conn) hub.Message {
+	t.Helper()
+	msg, ok := c.Next()
+	if !ok {
+		t.Fatalf("expected a queued message")
+	}
+	return msg
+}
+
+// fakeMembers answers every membership check with err
+type fakeMembers struct {
+	err error
+}
+
+func (f fakeMembers) Check(context.Context, hub.Topic, uuid.UUID) error { return f.err }
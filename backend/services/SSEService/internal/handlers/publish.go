@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/KnuffelGame/KnuffelGame/backend/libs/httpx"
+	"github.com/KnuffelGame/KnuffelGame/backend/libs/logger"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/SSEService/internal/bus"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/SSEService/internal/hub"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/SSEService/internal/models"
+	"github.com/google/uuid"
+)
+
+// PublishEventHandler returns an http.HandlerFunc that broadcasts an event to the connections of a lobby or game on all replicas
+// Internal endpoint (no auth); called by the Lobby and Game Service
+// Request body: PublishEventRequest; if target_user_id is set only that user's connections receive the event
+// Returns: 200 OK with PublishEventResponse for the connections held by this replica, listing connections that were dropped,
+// 404 if the target has neither been registered nor has connections (local bus only; on the postgres bus other
+// replicas may deliver the event), 413 if the event is too large for the event bus
+func PublishEventHandler(b bus.EventBus) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log := logger.Logger(r.Context()).WithGroup("handler").With(slog.String("action", "publish_event"))
+
+		var req models.PublishEventRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			log.Warn("failed to decode request body", slog.String("error", err.Error()))
+			httpx.WriteBadRequest(w, "Invalid request body", map[string]interface{}{"detail": err.Error()}, log)
+			return
+		}
+
+		topic, ok := parseTopic(w, req.TargetType, req.TargetID, log)
+		if !ok {
+			return
+		}
+		if req.EventType == "" {
+			httpx.WriteError(w, http.StatusBadRequest, "invalid_request", "Missing required field: event_type", nil, log)
+			return
+		}
+		if len(req.Data) == 0 {
+			httpx.WriteError(w, http.StatusBadRequest, "invalid_request", "Missing required field: data", nil, log)
+			return
+		}
+
+		var targetUser *uuid.UUID
+		if req.TargetUserID != nil {
+			id, err := uuid.Parse(*req.TargetUserID)
+			if err != nil {
+				log.Warn("invalid target_user_id format", slog.String("target_user_id", *req.TargetUserID), slog.String("error", err.Error()))
+				httpx.WriteError(w, http.StatusBadRequest, "invalid_request", "Invalid target_user_id format", map[string]interface{}{"detail": err.Error()}, log)
+				return
+			}
+			targetUser = &id
+		}
+
+		res, err := b.Publish(r.Context(), topic, hub.Message{Event: req.EventType, Data: req.Data}, targetUser)
+		if err != nil {
+			if errors.Is(err, hub.ErrTargetNotFound) {
+				log.Info("no connections for target", slog.String("topic", topic.String()), slog.String("event_type", req.EventType))
+				httpx.WriteError(w, http.StatusNotFound, "target_not_found", "No active connections for target", nil, log)
+				return
+			}
+			if errors.Is(err, bus.ErrPayloadTooLarge) {
+				log.Warn("event too large", slog.String("topic", topic.String()), slog.String("event_type", req.EventType), slog.Int("data_bytes", len(req.Data)))
+				httpx.WriteError(w, http.StatusRequestEntityTooLarge, "payload_too_large", "Event data is too large", nil, log)
+				return
+			}
+			log.Error("failed to publish event", slog.String("error", err.Error()))
+			httpx.WriteInternalError(w, "An unexpected error occurred", nil, log)
+			return
+		}
+
+		log.Info("event published",
+			slog.String("topic", topic.String()),
+			slog.String("event_type", req.EventType),
+			slog.Int("connections_found", res.ConnectionsFound),
+			slog.Int("events_sent", res.EventsSent),
+			slog.Int("failed_connections", res.FailedConnections))
+
+		resp := models.PublishEventResponse{
+			Success:           true,
+			ConnectionsFound:  res.ConnectionsFound,
+			EventsSent:        res.EventsSent,
+			FailedConnections: res.FailedConnections,
+		}
+		for _, f := range res.Failures {
+			log.Warn("connection dropped", slog.Uint64("connection_id", f.ConnectionID), slog.String("user_id", f.UserID.String()), slog.String("reason", f.Reason))
+			resp.Failures = append(resp.Failures, models.DeliveryFailure{ConnectionID: f.ConnectionID, UserID: f.UserID, Reason: f.Reason})
+		}
+		httpx.WriteJSON(w, http.StatusOK, resp, log)
+	}
+}
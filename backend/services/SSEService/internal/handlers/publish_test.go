@@ -0,0 +1,132 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/KnuffelGame/KnuffelGame/backend/services/SSEService/internal/bus"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/SSEService/internal/hub"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/SSEService/internal/models"
+	"github.com/google/uuid"
+)
+
+func TestPublishEvent_Broadcast(t *testing.T) {
+	h := hub.New(hub.Config{})
+	lobbyID := uuid.New()
+	conn, _ := h.Subscribe(hub.Topic{Type: hub.TargetLobby, ID: lobbyID}, uuid.New(), "")
+
+	rec := serveJSON(PublishEventHandler(bus.NewLocal(h)), http.MethodPost, map[string]interface{}{
+		"target_type": "lobby",
+		"target_id":   lobbyID.String(),
+		"event_type":  "player_joined",
+		"data":        map[string]interface{}{"username": "Bob", "player_count": 2},
+	})
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	var resp models.PublishEventResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if !resp.Success || resp.ConnectionsFound != 1 || resp.EventsSent != 1 || len(resp.Failures) != 0 {
+		t.Fatalf("unexpected response %+v", resp)
+	}
+
+	msg := queued(t, conn)
+	if msg.Event != "player_joined" || string(msg.Data) != `{"player_count":2,"username":"Bob"}` {
+		t.Fatalf("unexpected message %q %s", msg.Event, msg.Data)
+	}
+}
+
+func TestPublishEvent_TargetUser(t *testing.T) {
+	h := hub.New(hub.Config{})
+	lobby := hub.Topic{Type: hub.TargetLobby, ID: uuid.New()}
+	bob := uuid.New()
+	alice, _ := h.Subscribe(lobby, uuid.New(), "")
+	bobConn, _ := h.Subscribe(lobby, bob, "")
+
+	rec := serveJSON(PublishEventHandler(bus.NewLocal(h)), http.MethodPost, map[string]interface{}{
+		"target_type":    "lobby",
+		"target_id":      lobby.ID.String(),
+		"event_type":     "you_were_kicked",
+		"target_user_id": bob.String(),
+		"data":           map[string]interface{}{"message": "You were removed from the lobby"},
+	})
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if queued(t, bobConn).Event != "you_were_kicked" {
+		t.Fatalf("expected bob to receive the event")
+	}
+	if alice.Len() != 0 {
+		t.Fatalf("targeted event was delivered to alice")
+	}
+}
+
+func TestPublishEvent_ReportsDroppedConnections(t *testing.T) {
+	h := hub.New(hub.Config{QueueSize: 1})
+	game := hub.Topic{Type: hub.TargetGame, ID: uuid.New()}
+	slowUser := uuid.New()
+	slow, _ := h.Subscribe(game, slowUser, "")
+	body := map[string]interface{}{
+		"target_type": "game",
+		"target_id":   game.ID.String(),
+		"event_type":  "dice_toggled",
+		"data":        map[string]interface{}{},
+	}
+	serveJSON(PublishEventHandler(bus.NewLocal(h)), http.MethodPost, body)
+
+	rec := serveJSON(PublishEventHandler(bus.NewLocal(h)), http.MethodPost, body)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var resp models.PublishEventResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	want := models.DeliveryFailure{ConnectionID: slow.ID, UserID: slowUser, Reason: hub.FailureQueueFull}
+	if resp.FailedConnections != 1 || len(resp.Failures) != 1 || resp.Failures[0] != want {
+		t.Fatalf("expected the slow connection to be reported, got %+v", resp)
+	}
+}
+
+func TestPublishEvent_TargetNotFound(t *testing.T) {
+	h := hub.New(hub.Config{})
+
+	rec := serveJSON(PublishEventHandler(bus.NewLocal(h)), http.MethodPost, map[string]interface{}{
+		"target_type": "game",
+		"target_id":   uuid.New().String(),
+		"event_type":  "dice_rolled",
+		"data":        map[string]interface{}{},
+	})
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if payload := decodeError(t, rec); payload["error"] != "target_not_found" {
+		t.Fatalf("expected target_not_found, got %v", payload["error"])
+	}
+}
+
+func TestPublishEvent_InvalidRequest(t *testing.T) {
+	h := hub.New(hub.Config{})
+	cases := map[string]map[string]interface{}{
+		"missing target_id":   {"target_type": "lobby", "event_type": "x", "data": map[string]interface{}{}},
+		"invalid target_type": {"target_type": "chat", "target_id": uuid.New().String(), "event_type": "x", "data": map[string]interface{}{}},
+		"missing event_type":  {"target_type": "lobby", "target_id": uuid.New().String(), "data": map[string]interface{}{}},
+		"missing data":        {"target_type": "lobby", "target_id": uuid.New().String(), "event_type": "x"},
+		"invalid target user": {"target_type": "lobby", "target_id": uuid.New().String(), "event_type": "x", "target_user_id": "bob", "data": map[string]interface{}{}},
+	}
+	for name, body := range cases {
+		t.Run(name, func(t *testing.T) {
+			rec := serveJSON(PublishEventHandler(bus.NewLocal(h)), http.MethodPost, body)
+			if rec.Code != http.StatusBadRequest {
+				t.Fatalf("expected 400, got %d: %s", rec.Code, rec.Body.String())
+			}
+			if payload := decodeError(t, rec); payload["error"] != "invalid_request" {
+				t.Fatalf("expected invalid_request, got %v", payload["error"])
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/KnuffelGame/KnuffelGame/backend/libs/httpx"
+	"github.com/KnuffelGame/KnuffelGame/backend/libs/logger"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/SSEService/internal/bus"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/SSEService/internal/hub"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/SSEService/internal/models"
+)
+
+// RegisterTargetHandler returns an http.HandlerFunc that registers a lobby or game ahead of the first connection on all replicas
+// Internal endpoint (no auth); called by the Lobby Service when creating a lobby or starting a game
+// Request body: RegisterTargetRequest
+// Returns: 200 OK with SuccessResponse, 409 if the target is already registered
+func RegisterTargetHandler(b bus.EventBus) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log := logger.Logger(r.Context()).WithGroup("handler").With(slog.String("action", "register_target"))
+
+		var req models.RegisterTargetRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			log.Warn("failed to decode request body", slog.String("error", err.Error()))
+			httpx.WriteBadRequest(w, "Invalid request body", map[string]interface{}{"detail": err.Error()}, log)
+			return
+		}
+
+		topic, ok := parseTopic(w, req.TargetType, req.TargetID, log)
+		if !ok {
+			return
+		}
+
+		if err := b.Register(r.Context(), topic); err != nil {
+			if errors.Is(err, hub.ErrAlreadyRegistered) {
+				log.Warn("target already registered", slog.String("topic", topic.String()))
+				httpx.WriteError(w, http.StatusConflict, "already_exists", "Target is already registered", nil, log)
+				return
+			}
+			log.Error("failed to register target", slog.String("error", err.Error()))
+			httpx.WriteInternalError(w, "An unexpected error occurred", nil, log)
+			return
+		}
+
+		log.Info("target registered", slog.String("topic", topic.String()))
+		httpx.WriteJSON(w, http.StatusOK, models.SuccessResponse{Success: true, Message: "Target registered successfully"}, log)
+	}
+}
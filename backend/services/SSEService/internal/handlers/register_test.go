@@ -0,0 +1,38 @@
+package handlers
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/KnuffelGame/KnuffelGame/backend/services/SSEService/internal/bus"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/SSEService/internal/hub"
+	"github.com/google/uuid"
+)
+
+func TestRegisterTarget_Success(t *testing.T) {
+	h := hub.New(hub.Config{})
+	body := map[string]interface{}{"target_type": "game", "target_id": uuid.New().String()}
+
+	rec := serveJSON(RegisterTargetHandler(bus.NewLocal(h)), http.MethodPost, body)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if s := h.Stats(); s.Games.Count != 1 {
+		t.Fatalf("expected one registered game, got %+v", s)
+	}
+
+	rec = serveJSON(RegisterTargetHandler(bus.NewLocal(h)), http.MethodPost, body)
+	if rec.Code != http.StatusConflict {
+		t.Fatalf("expected 409, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if payload := decodeError(t, rec); payload["error"] != "already_exists" {
+		t.Fatalf("expected already_exists, got %v", payload["error"])
+	}
+}
+
+func TestRegisterTarget_InvalidTargetType(t *testing.T) {
+	rec := serveJSON(RegisterTargetHandler(bus.NewLocal(hub.New(hub.Config{}))), http.MethodPost, map[string]interface{}{"target_type": "chat", "target_id": uuid.New().String()})
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
@@ -0,0 +1,144 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/KnuffelGame/KnuffelGame/backend/libs/auth"
+	"github.com/KnuffelGame/KnuffelGame/backend/libs/httpx"
+	"github.com/KnuffelGame/KnuffelGame/backend/libs/logger"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/SSEService/internal/hub"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/SSEService/internal/membership"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// SubscribeHandler returns an http.HandlerFunc that streams events of a lobby or game as text/event-stream
+// Requires AuthMiddleware; the user id is used for targeted events and must belong to a member of the lobby
+// or a player of the game
+// Path parameter: lobby_id or game_id (UUID), depending on targetType
+// Header: Last-Event-ID (optional) replays the events missed since that id, or sends resync_required if they are gone
+// Sends a heartbeat comment every keepAlive so proxies keep the connection open
+// Returns: 200 OK and keeps the stream open until the client disconnects or the target is unregistered,
+// 403 for non-members, 404 if the lobby or game does not exist, 502 if membership could not be checked
+func SubscribeHandler(h *hub.Hub, members membership.Checker, targetType hub.TargetType, keepAlive time.Duration) http.HandlerFunc {
+	param := string(targetType) + "_id"
+	return func(w http.ResponseWriter, r *http.Request) {
+		log := logger.Logger(r.Context()).WithGroup("handler").With(slog.String("action", "subscribe"), slog.String("target_type", string(targetType)))
+
+		user, ok := auth.FromContext(r.Context())
+		if !ok {
+			log.Warn("user missing from context")
+			httpx.WriteUnauthorized(w, "Missing authentication headers", log)
+			return
+		}
+
+		idStr := chi.URLParam(r, param)
+		id, err := uuid.Parse(idStr)
+		if err != nil {
+			log.Warn("invalid target id format", slog.String(param, idStr), slog.String("error", err.Error()))
+			httpx.WriteBadRequest(w, fmt.Sprintf("Invalid %s format", param), map[string]interface{}{"detail": err.Error()}, log)
+			return
+		}
+
+		topic := hub.Topic{Type: targetType, ID: id}
+		if err := members.Check(r.Context(), topic, user.ID); err != nil {
+			writeMembershipError(w, targetType, err, log)
+			return
+		}
+
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			log.Error("response writer does not support flushing")
+			httpx.WriteInternalError(w, "Streaming unsupported", nil, log)
+			return
+		}
+
+		lastEventID := r.Header.Get("Last-Event-ID")
+		conn, replay := h.Subscribe(topic, user.ID, lastEventID)
+		defer h.Unsubscribe(conn)
+
+		log = log.With(slog.String("topic", topic.String()), slog.String("user_id", user.ID.String()))
+		log.Info("client connected", slog.String("last_event_id", lastEventID), slog.Int("replayed", len(replay)))
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("Connection", "keep-alive")
+		// disable response buffering in nginx style proxies
+		w.Header().Set("X-Accel-Buffering", "no")
+		w.WriteHeader(http.StatusOK)
+		for _, msg := range replay {
+			if _, err := msg.WriteTo(w); err != nil {
+				log.Warn("failed to replay event", slog.Uint64("event_id", msg.ID), slog.String("error", err.Error()))
+				return
+			}
+		}
+		flusher.Flush()
+
+		ticker := time.NewTicker(keepAlive)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-r.Context().Done():
+				log.Info("client disconnected")
+				return
+			case <-conn.Ready():
+				if err := drain(w, conn); err != nil {
+					log.Warn("failed to write event", slog.String("error", err.Error()))
+					return
+				}
+				flusher.Flush()
+			case <-conn.Done():
+				// deliver what was queued before the hub closed the connection, e.g. connection_closed
+				_ = drain(w, conn)
+				flusher.Flush()
+				log.Info("connection closed by hub")
+				return
+			case now := <-ticker.C:
+				if _, err := fmt.Fprintf(w, ": keep-alive %s\n\n", now.UTC().Format(time.RFC3339)); err != nil {
+					log.Warn("failed to write heartbeat", slog.String("error", err.Error()))
+					return
+				}
+				flusher.Flush()
+			}
+		}
+	}
+}
+
+// writeMembershipError maps a failed membership check to the error response of the stream
+func writeMembershipError(w http.ResponseWriter, targetType hub.TargetType, err error, log *slog.Logger) {
+	switch {
+	case errors.Is(err, membership.ErrNotMember) && targetType == hub.TargetGame:
+		log.Warn("user is not a player of the game")
+		httpx.WriteForbidden(w, "You are not a player in this game", log)
+	case errors.Is(err, membership.ErrNotMember):
+		log.Warn("user is not a member of the lobby")
+		httpx.WriteForbidden(w, "You are not a member of this lobby", log)
+	case errors.Is(err, membership.ErrTargetNotFound) && targetType == hub.TargetGame:
+		log.Info("game not found")
+		httpx.WriteError(w, http.StatusNotFound, "game_not_found", "Game not found", nil, log)
+	case errors.Is(err, membership.ErrTargetNotFound):
+		log.Info("lobby not found")
+		httpx.WriteError(w, http.StatusNotFound, "lobby_not_found", "Lobby not found", nil, log)
+	default:
+		log.Error("failed to check membership", slog.String("error", err.Error()))
+		httpx.WriteError(w, http.StatusBadGateway, string(targetType)+"_service_error", "Failed to check membership", nil, log)
+	}
+}
+
+// drain writes all queued messages of the connection
+func drain(w http.ResponseWriter, conn *hub.Conn) error {
+	for {
+		msg, ok := conn.Next()
+		if !ok {
+			return nil
+		}
+		if _, err := msg.WriteTo(w); err != nil {
+			return err
+		}
+	}
+}
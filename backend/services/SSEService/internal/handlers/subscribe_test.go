@@ -0,0 +1,218 @@
+package handlers
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/KnuffelGame/KnuffelGame/backend/libs/auth"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/SSEService/internal/hub"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/SSEService/internal/membership"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// openStream starts a server for the lobby stream and connects to it as user
+func openStream(t *testing.T, h *hub.Hub, keepAlive time.Duration, lobbyID, userID uuid.UUID, lastEventID string) (*bufio.Reader, context.CancelFunc) {
+	t.Helper()
+	r := chi.NewRouter()
+	r.With(auth.AuthMiddleware).Get("/events/lobby/{lobby_id}", SubscribeHandler(h, fakeMembers{}, hub.TargetLobby, keepAlive))
+	srv := httptest.NewServer(r)
+	t.Cleanup(srv.Close)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+"/events/lobby/"+lobbyID.String(), nil)
+	req.Header.Set(auth.DefaultHeaderUserID, userID.String())
+	req.Header.Set(auth.DefaultHeaderUsername, "Alice")
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		cancel()
+		t.Fatalf("failed to open stream: %v", err)
+	}
+	t.Cleanup(func() { resp.Body.Close() })
+	if resp.StatusCode != http.StatusOK {
+		cancel()
+		t.Fatalf("expected 200, got %d", resp.StatusCode)
+	}
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		cancel()
+		t.Fatalf("expected text/event-stream, got %q", ct)
+	}
+	return bufio.NewReader(resp.Body), cancel
+}
+
+// readFrame reads lines up to the blank line terminating an SSE frame
+func readFrame(t *testing.T, r *bufio.Reader) string {
+	t.Helper()
+	var frame strings.Builder
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			t.Fatalf("failed to read stream: %v", err)
+		}
+		if line == "\n" {
+			return frame.String()
+		}
+		frame.WriteString(line)
+	}
+}
+
+// waitFor polls cond until it holds or a second has passed
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("condition not met in time")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestSubscribe_ReceivesEvents(t *testing.T) {
+	h := hub.New(hub.Config{})
+	lobbyID := uuid.New()
+	stream, cancel := openStream(t, h, time.Hour, lobbyID, uuid.New(), "")
+	defer cancel()
+
+	waitFor(t, func() bool { return h.Stats().TotalConnections == 1 })
+	if _, err := h.Publish(hub.Topic{Type: hub.TargetLobby, ID: lobbyID}, hub.Message{Event: "player_joined", Data: []byte(`{"username":"Bob"}`)}, nil); err != nil {
+		t.Fatalf("Publish error: %v", err)
+	}
+
+	if frame := readFrame(t, stream); frame != "id: 1\nevent: player_joined\ndata: {\"username\":\"Bob\"}\n" {
+		t.Fatalf("unexpected frame %q", frame)
+	}
+}
+
+func TestSubscribe_ReplaysAfterLastEventID(t *testing.T) {
+	h := hub.New(hub.Config{})
+	lobby := hub.Topic{Type: hub.TargetLobby, ID: uuid.New()}
+	_ = h.Register(lobby)
+	for _, event := range []string{"player_joined", "player_left", "leader_changed"} {
+		if _, err := h.Publish(lobby, hub.Message{Event: event, Data: []byte(`{}`)}, nil); err != nil {
+			t.Fatalf("Publish error: %v", err)
+		}
+	}
+
+	stream, cancel := openStream(t, h, time.Hour, lobby.ID, uuid.New(), "1")
+	defer cancel()
+
+	if frame := readFrame(t, stream); frame != "id: 2\nevent: player_left\ndata: {}\n" {
+		t.Fatalf("unexpected frame %q", frame)
+	}
+	if frame := readFrame(t, stream); frame != "id: 3\nevent: leader_changed\ndata: {}\n" {
+		t.Fatalf("unexpected frame %q", frame)
+	}
+}
+
+func TestSubscribe_ResyncRequired(t *testing.T) {
+	h := hub.New(hub.Config{})
+	lobbyID := uuid.New()
+	stream, cancel := openStream(t, h, time.Hour, lobbyID, uuid.New(), "42")
+	defer cancel()
+
+	frame := readFrame(t, stream)
+	if !strings.HasPrefix(frame, "event: resync_required\n") || !strings.Contains(frame, `"target_id":"`+lobbyID.String()+`"`) {
+		t.Fatalf("unexpected frame %q", frame)
+	}
+}
+
+func TestSubscribe_Heartbeat(t *testing.T) {
+	h := hub.New(hub.Config{})
+	stream, cancel := openStream(t, h, 10*time.Millisecond, uuid.New(), uuid.New(), "")
+	defer cancel()
+
+	if frame := readFrame(t, stream); !strings.HasPrefix(frame, ": keep-alive ") {
+		t.Fatalf("expected heartbeat comment, got %q", frame)
+	}
+}
+
+func TestSubscribe_DisconnectRemovesConnection(t *testing.T) {
+	h := hub.New(hub.Config{})
+	_, cancel := openStream(t, h, time.Hour, uuid.New(), uuid.New(), "")
+
+	waitFor(t, func() bool { return h.Stats().TotalConnections == 1 })
+	cancel()
+	waitFor(t, func() bool { return h.Stats().TotalTargets == 0 })
+}
+
+func TestSubscribe_UnregisterClosesStream(t *testing.T) {
+	h := hub.New(hub.Config{})
+	lobbyID := uuid.New()
+	stream, cancel := openStream(t, h, time.Hour, lobbyID, uuid.New(), "")
+	defer cancel()
+
+	waitFor(t, func() bool { return h.Stats().TotalConnections == 1 })
+	if _, err := h.Unregister(hub.Topic{Type: hub.TargetLobby, ID: lobbyID}, "lobby_deleted"); err != nil {
+		t.Fatalf("Unregister error: %v", err)
+	}
+
+	if frame := readFrame(t, stream); frame != "event: connection_closed\ndata: {\"reason\":\"lobby_deleted\"}\n" {
+		t.Fatalf("unexpected frame %q", frame)
+	}
+	if _, err := stream.ReadString('\n'); err == nil {
+		t.Fatalf("expected stream to be closed")
+	}
+}
+
+func TestSubscribe_InvalidID(t *testing.T) {
+	h := hub.New(hub.Config{})
+	req := httptest.NewRequest(http.MethodGet, "/events/game/abc", nil)
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, &chi.Context{
+		URLParams: chi.RouteParams{Keys: []string{"game_id"}, Values: []string{"abc"}},
+	}))
+	req.Header.Set(auth.DefaultHeaderUserID, uuid.New().String())
+	req.Header.Set(auth.DefaultHeaderUsername, "Alice")
+
+	rec := httptest.NewRecorder()
+	auth.AuthMiddleware(SubscribeHandler(h, fakeMembers{}, hub.TargetGame, time.Hour)).ServeHTTP(rec, req)
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestSubscribe_MembershipRejected(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		err    error
+		status int
+		code   string
+	}{
+		{"not a member", membership.ErrNotMember, http.StatusForbidden, "forbidden"},
+		{"unknown lobby", membership.ErrTargetNotFound, http.StatusNotFound, "lobby_not_found"},
+		{"lobby service down", errors.New("connection refused"), http.StatusBadGateway, "lobby_service_error"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			h := hub.New(hub.Config{})
+			lobbyID := uuid.New()
+			req := httptest.NewRequest(http.MethodGet, "/events/lobby/"+lobbyID.String(), nil)
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, &chi.Context{
+				URLParams: chi.RouteParams{Keys: []string{"lobby_id"}, Values: []string{lobbyID.String()}},
+			}))
+			req.Header.Set(auth.DefaultHeaderUserID, uuid.New().String())
+			req.Header.Set(auth.DefaultHeaderUsername, "Mallory")
+
+			rec := httptest.NewRecorder()
+			auth.AuthMiddleware(SubscribeHandler(h, fakeMembers{err: tc.err}, hub.TargetLobby, time.Hour)).ServeHTTP(rec, req)
+			if rec.Code != tc.status {
+				t.Fatalf("expected %d, got %d: %s", tc.status, rec.Code, rec.Body.String())
+			}
+			if code := decodeError(t, rec)["error"]; code != tc.code {
+				t.Fatalf("expected error %q, got %v", tc.code, code)
+			}
+			if stats := h.Stats(); stats.TotalConnections != 0 {
+				t.Fatalf("expected no connection, got %d", stats.TotalConnections)
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/KnuffelGame/KnuffelGame/backend/libs/httpx"
+	"github.com/KnuffelGame/KnuffelGame/backend/libs/logger"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/SSEService/internal/bus"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/SSEService/internal/hub"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/SSEService/internal/models"
+)
+
+// UnregisterTargetHandler returns an http.HandlerFunc that closes all connections of a lobby or game on all replicas and removes it
+// Internal endpoint (no auth); called when a lobby is deleted or a game ends
+// Request body: UnregisterTargetRequest; reason defaults to "cleanup" and is sent to clients in a connection_closed event
+// Returns: 200 OK with UnregisterTargetResponse counting the connections closed on this replica, 404 if the target is unknown to this replica
+func UnregisterTargetHandler(b bus.EventBus) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log := logger.Logger(r.Context()).WithGroup("handler").With(slog.String("action", "unregister_target"))
+
+		var req models.UnregisterTargetRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			log.Warn("failed to decode request body", slog.String("error", err.Error()))
+			httpx.WriteBadRequest(w, "Invalid request body", map[string]interface{}{"detail": err.Error()}, log)
+			return
+		}
+
+		topic, ok := parseTopic(w, req.TargetType, req.TargetID, log)
+		if !ok {
+			return
+		}
+
+		reason := req.Reason
+		switch reason {
+		case "":
+			reason = models.ReasonCleanup
+		case models.ReasonLobbyDeleted, models.ReasonGameEnded, models.ReasonCleanup, models.ReasonError:
+		default:
+			log.Warn("invalid reason", slog.String("reason", reason))
+			httpx.WriteError(w, http.StatusBadRequest, "invalid_request", "Invalid reason", map[string]interface{}{"reason": reason}, log)
+			return
+		}
+
+		closed, err := b.Unregister(r.Context(), topic, reason)
+		if err != nil {
+			if errors.Is(err, hub.ErrNotRegistered) {
+				log.Warn("target not registered", slog.String("topic", topic.String()))
+				httpx.WriteError(w, http.StatusNotFound, "not_found", "Target is not registered", nil, log)
+				return
+			}
+			log.Error("failed to unregister target", slog.String("error", err.Error()))
+			httpx.WriteInternalError(w, "An unexpected error occurred", nil, log)
+			return
+		}
+
+		log.Info("target unregistered", slog.String("topic", topic.String()), slog.String("reason", reason), slog.Int("connections_closed", closed))
+		httpx.WriteJSON(w, http.StatusOK, models.UnregisterTargetResponse{
+			Success:           true,
+			ConnectionsClosed: closed,
+			Message:           "Target unregistered and connections closed",
+		}, log)
+	}
+}
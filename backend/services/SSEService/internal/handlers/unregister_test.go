@@ -0,0 +1,65 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/KnuffelGame/KnuffelGame/backend/services/SSEService/internal/bus"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/SSEService/internal/hub"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/SSEService/internal/models"
+	"github.com/google/uuid"
+)
+
+func TestUnregisterTarget_ClosesConnections(t *testing.T) {
+	h := hub.New(hub.Config{})
+	game := hub.Topic{Type: hub.TargetGame, ID: uuid.New()}
+	conn, _ := h.Subscribe(game, uuid.New(), "")
+
+	rec := serveJSON(UnregisterTargetHandler(bus.NewLocal(h)), http.MethodPost, map[string]interface{}{
+		"target_type": "game",
+		"target_id":   game.ID.String(),
+		"reason":      "game_ended",
+	})
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	var resp models.UnregisterTargetResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if !resp.Success || resp.ConnectionsClosed != 1 {
+		t.Fatalf("unexpected response %+v", resp)
+	}
+
+	select {
+	case <-conn.Done():
+	default:
+		t.Fatalf("expected connection to be closed")
+	}
+	if msg := queued(t, conn); msg.Event != hub.EventConnectionClosed || string(msg.Data) != `{"reason":"game_ended"}` {
+		t.Fatalf("unexpected close message %q %s", msg.Event, msg.Data)
+	}
+}
+
+func TestUnregisterTarget_NotFound(t *testing.T) {
+	rec := serveJSON(UnregisterTargetHandler(bus.NewLocal(hub.New(hub.Config{}))), http.MethodPost, map[string]interface{}{"target_type": "lobby", "target_id": uuid.New().String()})
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if payload := decodeError(t, rec); payload["error"] != "not_found" {
+		t.Fatalf("expected not_found, got %v", payload["error"])
+	}
+}
+
+func TestUnregisterTarget_InvalidReason(t *testing.T) {
+	h := hub.New(hub.Config{})
+	lobby := hub.Topic{Type: hub.TargetLobby, ID: uuid.New()}
+	_ = h.Register(lobby)
+
+	rec := serveJSON(UnregisterTargetHandler(bus.NewLocal(h)), http.MethodPost, map[string]interface{}{"target_type": "lobby", "target_id": lobby.ID.String(), "reason": "bored"})
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
@@ -0,0 +1,142 @@
+package hub
+
+import (
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// OverflowPolicy decides what happens when an event is published to a connection whose queue is full
+type OverflowPolicy string
+
+const (
+	// OverflowDropConnection closes the connection; the client reconnects and catches up via Last-Event-ID
+	OverflowDropConnection OverflowPolicy = "drop_connection"
+	// OverflowDropOldest discards the oldest queued event to make room
+	OverflowDropOldest OverflowPolicy = "drop_oldest"
+	// OverflowCoalesce discards the oldest queued event of the same type, since the newer one supersedes it.
+	// If there is none, the oldest queued event is discarded.
+	OverflowCoalesce OverflowPolicy = "coalesce"
+)
+
+// ParseOverflowPolicy validates a configured overflow policy
+func ParseOverflowPolicy(s string) (OverflowPolicy, bool) {
+	switch OverflowPolicy(s) {
+	case OverflowDropConnection, OverflowDropOldest, OverflowCoalesce:
+		return OverflowPolicy(s), true
+	}
+	return "", false
+}
+
+// Conn is a single client subscription.
+// Messages are queued by the hub and written by the HTTP handler owning the stream:
+// wait for Ready, then call Next until it reports an empty queue.
+type Conn struct {
+	ID     uint64
+	Topic  Topic
+	UserID uuid.UUID
+
+	mu    sync.Mutex
+	queue []Message
+	// ready holds a signal while the queue is non-empty
+	ready     chan struct{}
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+func newConn(id uint64, topic Topic, userID uuid.UUID) *Conn {
+	return &Conn{ID: id, Topic: topic, UserID: userID, ready: make(chan struct{}, 1), done: make(chan struct{})}
+}
+
+// Ready is signalled after messages were queued
+func (c *Conn) Ready() <-chan struct{} {
+	return c.ready
+}
+
+// Next removes and returns the oldest queued message
+func (c *Conn) Next() (Message, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.queue) == 0 {
+		return Message{}, false
+	}
+	msg := c.queue[0]
+	c.queue[0] = Message{}
+	c.queue = c.queue[1:]
+	return msg, true
+}
+
+// Len returns the number of queued messages
+func (c *Conn) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.queue)
+}
+
+// Done is closed when the hub dropped the connection, e.g. because it was unregistered or fell behind.
+// Messages queued before that are still available from Next.
+func (c *Conn) Done() <-chan struct{} {
+	return c.done
+}
+
+func (c *Conn) close() {
+	c.closeOnce.Do(func() { close(c.done) })
+}
+
+// enqueueResult tells the hub how a message was queued
+type enqueueResult int
+
+const (
+	enqueued enqueueResult = iota
+	// enqueuedDropped means an older message was discarded to make room
+	enqueuedDropped
+	// enqueuedCoalesced means an older message of the same type was discarded to make room
+	enqueuedCoalesced
+	// rejected means the queue is full and the policy is to drop the connection
+	rejected
+)
+
+// enqueue appends msg, applying policy if the queue already holds limit messages
+func (c *Conn) enqueue(msg Message, limit int, policy OverflowPolicy) enqueueResult {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	res := enqueued
+	if len(c.queue) >= limit {
+		switch policy {
+		case OverflowCoalesce:
+			res = enqueuedDropped
+			victim := 0
+			for i, queued := range c.queue {
+				if queued.Event == msg.Event {
+					victim, res = i, enqueuedCoalesced
+					break
+				}
+			}
+			c.queue = append(c.queue[:victim], c.queue[victim+1:]...)
+		case OverflowDropOldest:
+			res = enqueuedDropped
+			c.queue = c.queue[1:]
+		default:
+			return rejected
+		}
+	}
+	c.queue = append(c.queue, msg)
+	c.signal()
+	return res
+}
+
+// push appends msg regardless of the queue limit; used for the final message before closing
+func (c *Conn) push(msg Message) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.queue = append(c.queue, msg)
+	c.signal()
+}
+
+func (c *Conn) signal() {
+	select {
+	case c.ready <- struct{}{}:
+	default:
+	}
+}
@@ -0,0 +1,443 @@
+package hub
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrTargetNotFound is returned by Publish when the target is neither registered nor has connections
+	ErrTargetNotFound = errors.New("target not found")
+	// ErrAlreadyRegistered is returned by Register when the target was registered before
+	ErrAlreadyRegistered = errors.New("target already registered")
+	// ErrNotRegistered is returned by Unregister when the target is unknown
+	ErrNotRegistered = errors.New("target not registered")
+)
+
+// TargetType is the kind of entity clients subscribe to
+type TargetType string
+
+const (
+	TargetLobby TargetType = "lobby"
+	TargetGame  TargetType = "game"
+)
+
+// ParseTargetType validates a target type received over the API
+func ParseTargetType(s string) (TargetType, bool) {
+	switch TargetType(s) {
+	case TargetLobby, TargetGame:
+		return TargetType(s), true
+	}
+	return "", false
+}
+
+// Topic identifies the set of connections for one lobby or game
+type Topic struct {
+	Type TargetType
+	ID   uuid.UUID
+}
+
+func (t Topic) String() string {
+	return string(t.Type) + ":" + t.ID.String()
+}
+
+// EventConnectionClosed is sent to all connections of a target before Unregister closes them
+const EventConnectionClosed = "connection_closed"
+
+// EventResyncRequired is sent instead of a replay when the events after Last-Event-ID are no longer buffered.
+// The client has to refetch the lobby or game state.
+const EventResyncRequired = "resync_required"
+
+// Config tunes the hub; zero values fall back to the defaults
+type Config struct {
+	// HistorySize is the number of events kept per topic for Last-Event-ID replay (default 100)
+	HistorySize int
+	// HistoryRetention is how long a topic without connections keeps its history (default 5m)
+	HistoryRetention time.Duration
+	// QueueSize is the number of events queued per connection before Overflow applies (default 32)
+	QueueSize int
+	// Overflow is applied when a connection's queue is full (default drop_connection)
+	Overflow OverflowPolicy
+}
+
+func (c Config) withDefaults() Config {
+	if c.HistorySize <= 0 {
+		c.HistorySize = 100
+	}
+	if c.HistoryRetention <= 0 {
+		c.HistoryRetention = 5 * time.Minute
+	}
+	if c.QueueSize <= 0 {
+		c.QueueSize = 32
+	}
+	if c.Overflow == "" {
+		c.Overflow = OverflowDropConnection
+	}
+	return c
+}
+
+// Message is a single Server-Sent Event.
+// ID increases across all topics: Publish assigns the next one, or an EventBus takes it from the
+// sse_event_id sequence. 0 means no id.
+type Message struct {
+	ID    uint64
+	Event string
+	Data  []byte
+}
+
+// WriteTo writes the message in text/event-stream format.
+// Multi-line data is split into several data lines as required by the SSE spec.
+func (m Message) WriteTo(w io.Writer) (int64, error) {
+	var buf bytes.Buffer
+	if m.ID != 0 {
+		fmt.Fprintf(&buf, "id: %d\n", m.ID)
+	}
+	if m.Event != "" {
+		fmt.Fprintf(&buf, "event: %s\n", m.Event)
+	}
+	for _, line := range bytes.Split(m.Data, []byte("\n")) {
+		buf.WriteString("data: ")
+		buf.Write(bytes.TrimSuffix(line, []byte("\r")))
+		buf.WriteByte('\n')
+	}
+	buf.WriteByte('\n')
+	return buf.WriteTo(w)
+}
+
+// DeliveryFailure describes a connection that did not receive a published event
+type DeliveryFailure struct {
+	ConnectionID uint64
+	UserID       uuid.UUID
+	Reason       string
+}
+
+// FailureQueueFull is the reason for connections dropped because their queue was full
+const FailureQueueFull = "queue_full"
+
+// PublishResult reports how a published event was delivered.
+// Connections that received the event at the expense of an older queued one count as sent.
+type PublishResult struct {
+	ConnectionsFound  int
+	EventsSent        int
+	FailedConnections int
+	Failures          []DeliveryFailure
+}
+
+// TypeStats aggregates the targets of one TargetType
+type TypeStats struct {
+	Count       int
+	Connections int
+}
+
+// QueueStats describes the per-connection queues at the time of the snapshot
+type QueueStats struct {
+	Size           int
+	Overflow       OverflowPolicy
+	QueuedMessages int
+	MaxDepth       int
+}
+
+// DropStats counts losses since the hub was started
+type DropStats struct {
+	// Connections dropped because their queue was full
+	Connections uint64
+	// Events discarded from full queues by drop_oldest or coalesce
+	Events uint64
+	// Coalesced is the part of Events replaced by a newer event of the same type
+	Coalesced uint64
+}
+
+// Stats is a snapshot of the hub's registry
+type Stats struct {
+	TotalTargets     int
+	TotalConnections int
+	Lobbies          TypeStats
+	Games            TypeStats
+	Queues           QueueStats
+	Drops            DropStats
+}
+
+// record is a published event kept for replay; targetUser limits replay like live delivery
+type record struct {
+	msg        Message
+	targetUser *uuid.UUID
+}
+
+type target struct {
+	registered bool
+	conns      map[*Conn]struct{}
+	// since is the id after which history holds every event of the topic
+	since uint64
+	// history holds the most recent events in id order, at most Config.HistorySize
+	history []record
+	// idleSince is set when the last connection of an unregistered topic with history left
+	idleSince time.Time
+}
+
+// Hub keeps the in-memory registry of topics and their connections and fans out published events.
+// Topics are created on first subscription or explicit registration. Unregistered topics are removed
+// once the last connection is gone, or after Config.HistoryRetention if they have events to replay.
+type Hub struct {
+	mu         sync.Mutex
+	cfg        Config
+	targets    map[Topic]*target
+	now        func() time.Time
+	nextConnID uint64
+	drops      DropStats
+	// lastID is the highest event id seen; ids are global across topics
+	lastID uint64
+	// startID is the id before the first event this hub saw; earlier events are unknown
+	startID uint64
+}
+
+// New creates an empty Hub
+func New(cfg Config) *Hub {
+	return &Hub{cfg: cfg.withDefaults(), targets: make(map[Topic]*target), now: time.Now}
+}
+
+// Subscribe adds a connection for the user to the topic.
+// lastEventID is the Last-Event-ID sent by a reconnecting client, or empty for a fresh connection.
+// The returned messages must be written before any message from the connection's queue:
+// either the events the client missed or a single resync_required event.
+func (h *Hub) Subscribe(topic Topic, userID uuid.UUID, lastEventID string) (*Conn, []Message) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.nextConnID++
+	c := newConn(h.nextConnID, topic, userID)
+	t := h.targetLocked(topic)
+	t.conns[c] = struct{}{}
+	t.idleSince = time.Time{}
+
+	if lastEventID == "" {
+		return c, nil
+	}
+	return c, h.replayLocked(t, topic, userID, lastEventID)
+}
+
+// replayLocked returns the buffered events after lastEventID visible to userID,
+// or a resync_required event if they are not all buffered anymore
+func (h *Hub) replayLocked(t *target, topic Topic, userID uuid.UUID, lastEventID string) []Message {
+	last, err := strconv.ParseUint(lastEventID, 10, 64)
+	// ids above lastID were issued before a restart; ids below since may have been evicted or never seen
+	if err != nil || last > h.lastID || last < t.since || last < h.startID {
+		return []Message{h.resyncLocked(topic, lastEventID)}
+	}
+
+	var missed []Message
+	for _, rec := range t.history {
+		if rec.msg.ID <= last {
+			continue
+		}
+		if rec.targetUser != nil && *rec.targetUser != userID {
+			continue
+		}
+		missed = append(missed, rec.msg)
+	}
+	return missed
+}
+
+// resyncPayload is the data of a resync_required event
+type resyncPayload struct {
+	TargetType  TargetType `json:"target_type"`
+	TargetID    uuid.UUID  `json:"target_id"`
+	LastEventID string     `json:"last_event_id"`
+}
+
+// resyncLocked carries the current id so the client resumes from here after refetching the state
+func (h *Hub) resyncLocked(topic Topic, lastEventID string) Message {
+	data, _ := json.Marshal(resyncPayload{TargetType: topic.Type, TargetID: topic.ID, LastEventID: lastEventID})
+	return Message{ID: h.lastID, Event: EventResyncRequired, Data: data}
+}
+
+// Unsubscribe removes the connection from its topic. It is safe to call more than once.
+func (h *Hub) Unsubscribe(c *Conn) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.removeLocked(c)
+}
+
+// Publish keeps the message for replay and queues it on every connection of the topic,
+// or only on the connections of targetUser if it is not nil.
+// Messages without an id get the next one; ids assigned by an EventBus must increase across all topics.
+// Connections whose queue is full are handled according to Config.Overflow; it never blocks on a slow client.
+func (h *Hub) Publish(topic Topic, msg Message, targetUser *uuid.UUID) (PublishResult, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if msg.ID == 0 {
+		msg.ID = h.lastID + 1
+	}
+	if h.lastID == 0 {
+		h.startID = msg.ID - 1
+	}
+	if msg.ID > h.lastID {
+		h.lastID = msg.ID
+	}
+
+	t, ok := h.targets[topic]
+	if !ok {
+		return PublishResult{}, ErrTargetNotFound
+	}
+
+	t.history = append(t.history, record{msg: msg, targetUser: targetUser})
+	if evicted := len(t.history) - h.cfg.HistorySize; evicted > 0 {
+		t.since = t.history[evicted-1].msg.ID
+		t.history = t.history[evicted:]
+	}
+
+	var res PublishResult
+	for c := range t.conns {
+		if targetUser != nil && c.UserID != *targetUser {
+			continue
+		}
+		res.ConnectionsFound++
+		switch c.enqueue(msg, h.cfg.QueueSize, h.cfg.Overflow) {
+		case rejected:
+			res.FailedConnections++
+			res.Failures = append(res.Failures, DeliveryFailure{ConnectionID: c.ID, UserID: c.UserID, Reason: FailureQueueFull})
+			h.drops.Connections++
+			h.removeLocked(c)
+			continue
+		case enqueuedCoalesced:
+			h.drops.Events++
+			h.drops.Coalesced++
+		case enqueuedDropped:
+			h.drops.Events++
+		}
+		res.EventsSent++
+	}
+	return res, nil
+}
+
+// Register creates the topic ahead of the first connection and keeps it until Unregister
+func (h *Hub) Register(topic Topic) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if t, ok := h.targets[topic]; ok && t.registered {
+		return ErrAlreadyRegistered
+	}
+	h.targetLocked(topic).registered = true
+	return nil
+}
+
+// Unregister sends a connection_closed event with the reason to all connections of the topic,
+// closes them and removes the topic. It returns the number of closed connections.
+func (h *Hub) Unregister(topic Topic, reason string) (int, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	t, ok := h.targets[topic]
+	if !ok {
+		return 0, ErrNotRegistered
+	}
+
+	msg := Message{Event: EventConnectionClosed, Data: []byte(fmt.Sprintf(`{"reason":%q}`, reason))}
+	closed := 0
+	for c := range t.conns {
+		c.push(msg)
+		c.close()
+		closed++
+	}
+	delete(h.targets, topic)
+	return closed, nil
+}
+
+// Reset is called when events may have been missed, e.g. after the event bus reconnected.
+// It discards the replay history and sends resync_required to every connection; ids seen before
+// the next published event can no longer be replayed.
+func (h *Hub) Reset() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for topic, t := range h.targets {
+		t.history = nil
+		for c := range t.conns {
+			c.push(h.resyncLocked(topic, ""))
+		}
+	}
+	h.lastID = 0
+}
+
+// Sweep removes topics without connections whose history retention has expired.
+// It returns the number of removed topics.
+func (h *Hub) Sweep() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	cutoff := h.now().Add(-h.cfg.HistoryRetention)
+	removed := 0
+	for topic, t := range h.targets {
+		if len(t.conns) == 0 && !t.registered && !t.idleSince.IsZero() && t.idleSince.Before(cutoff) {
+			delete(h.targets, topic)
+			removed++
+		}
+	}
+	return removed
+}
+
+// Stats returns the current number of targets and connections
+func (h *Hub) Stats() Stats {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	s := Stats{
+		Queues: QueueStats{Size: h.cfg.QueueSize, Overflow: h.cfg.Overflow},
+		Drops:  h.drops,
+	}
+	for topic, t := range h.targets {
+		s.TotalTargets++
+		s.TotalConnections += len(t.conns)
+		switch topic.Type {
+		case TargetLobby:
+			s.Lobbies.Count++
+			s.Lobbies.Connections += len(t.conns)
+		case TargetGame:
+			s.Games.Count++
+			s.Games.Connections += len(t.conns)
+		}
+		for c := range t.conns {
+			depth := c.Len()
+			s.Queues.QueuedMessages += depth
+			if depth > s.Queues.MaxDepth {
+				s.Queues.MaxDepth = depth
+			}
+		}
+	}
+	return s
+}
+
+func (h *Hub) targetLocked(topic Topic) *target {
+	t, ok := h.targets[topic]
+	if !ok {
+		t = &target{conns: make(map[*Conn]struct{}), since: h.lastID}
+		h.targets[topic] = t
+	}
+	return t
+}
+
+func (h *Hub) removeLocked(c *Conn) {
+	c.close()
+	t, ok := h.targets[c.Topic]
+	if !ok {
+		return
+	}
+	delete(t.conns, c)
+	if len(t.conns) > 0 || t.registered {
+		return
+	}
+	if len(t.history) == 0 {
+		delete(h.targets, c.Topic)
+		return
+	}
+	// keep the history for clients that reconnect shortly
+	t.idleSince = h.now()
+}
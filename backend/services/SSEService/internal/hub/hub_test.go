@@ -0,0 +1,394 @@
+package hub
+
+import (
+	"bytes"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func newTopic(tt TargetType) Topic {
+	return Topic{Type: tt, ID: uuid.New()}
+}
+
+func receive(t *testing.T, c *Conn) Message {
+	t.Helper()
+	msg, ok := c.Next()
+	if !ok {
+		t.Fatalf("expected a queued message")
+	}
+	return msg
+}
+
+func TestPublishBroadcastsToTopic(t *testing.T) {
+	h := New(Config{})
+	lobby := newTopic(TargetLobby)
+	a, _ := h.Subscribe(lobby, uuid.New(), "")
+	b, _ := h.Subscribe(lobby, uuid.New(), "")
+	other, _ := h.Subscribe(newTopic(TargetLobby), uuid.New(), "")
+
+	res, err := h.Publish(lobby, Message{Event: "player_joined", Data: []byte(`{}`)}, nil)
+	if err != nil {
+		t.Fatalf("Publish error: %v", err)
+	}
+	if res.ConnectionsFound != 2 || res.EventsSent != 2 || len(res.Failures) != 0 {
+		t.Fatalf("unexpected result %+v", res)
+	}
+	if receive(t, a).Event != "player_joined" || receive(t, b).Event != "player_joined" {
+		t.Fatalf("expected both subscribers to receive the event")
+	}
+	if other.Len() != 0 {
+		t.Fatalf("subscriber of another topic received the event")
+	}
+}
+
+func TestPublishTargetedUser(t *testing.T) {
+	h := New(Config{})
+	lobby := newTopic(TargetLobby)
+	bob := uuid.New()
+	alice, _ := h.Subscribe(lobby, uuid.New(), "")
+	bobTab1, _ := h.Subscribe(lobby, bob, "")
+	bobTab2, _ := h.Subscribe(lobby, bob, "")
+
+	res, err := h.Publish(lobby, Message{Event: "you_were_kicked", Data: []byte(`{}`)}, &bob)
+	if err != nil {
+		t.Fatalf("Publish error: %v", err)
+	}
+	if res.ConnectionsFound != 2 || res.EventsSent != 2 {
+		t.Fatalf("unexpected result %+v", res)
+	}
+	receive(t, bobTab1)
+	receive(t, bobTab2)
+	if alice.Len() != 0 {
+		t.Fatalf("targeted event was delivered to another user")
+	}
+}
+
+func TestPublishUnknownTarget(t *testing.T) {
+	h := New(Config{})
+	if _, err := h.Publish(newTopic(TargetGame), Message{Event: "dice_rolled"}, nil); !errors.Is(err, ErrTargetNotFound) {
+		t.Fatalf("expected ErrTargetNotFound, got %v", err)
+	}
+
+	registered := newTopic(TargetGame)
+	if err := h.Register(registered); err != nil {
+		t.Fatalf("Register error: %v", err)
+	}
+	res, err := h.Publish(registered, Message{Event: "dice_rolled"}, nil)
+	if err != nil || res.ConnectionsFound != 0 || res.EventsSent != 0 {
+		t.Fatalf("expected empty result for registered target, got %+v, %v", res, err)
+	}
+}
+
+func TestPublishDropsSlowConnection(t *testing.T) {
+	h := New(Config{QueueSize: 4})
+	game := newTopic(TargetGame)
+	slowUser := uuid.New()
+	slow, _ := h.Subscribe(game, slowUser, "")
+
+	for i := 0; i < 4; i++ {
+		if _, err := h.Publish(game, Message{Event: "dice_toggled"}, nil); err != nil {
+			t.Fatalf("Publish error: %v", err)
+		}
+	}
+	res, err := h.Publish(game, Message{Event: "dice_toggled"}, nil)
+	if err != nil {
+		t.Fatalf("Publish error: %v", err)
+	}
+	if res.FailedConnections != 1 || res.EventsSent != 0 {
+		t.Fatalf("expected the full connection to fail, got %+v", res)
+	}
+	want := DeliveryFailure{ConnectionID: slow.ID, UserID: slowUser, Reason: FailureQueueFull}
+	if len(res.Failures) != 1 || res.Failures[0] != want {
+		t.Fatalf("expected failure %+v, got %+v", want, res.Failures)
+	}
+	select {
+	case <-slow.Done():
+	default:
+		t.Fatalf("expected the slow connection to be closed")
+	}
+	if s := h.Stats(); s.TotalConnections != 0 || s.Drops.Connections != 1 {
+		t.Fatalf("expected the connection to be removed, got %+v", s)
+	}
+}
+
+func TestPublishDropOldest(t *testing.T) {
+	h := New(Config{QueueSize: 2, Overflow: OverflowDropOldest})
+	game := newTopic(TargetGame)
+	c, _ := h.Subscribe(game, uuid.New(), "")
+
+	publishN(t, h, game, 3)
+
+	if first, second := receive(t, c), receive(t, c); first.ID != 2 || second.ID != 3 {
+		t.Fatalf("expected events 2 and 3 to remain, got %d and %d", first.ID, second.ID)
+	}
+	select {
+	case <-c.Done():
+		t.Fatalf("expected the connection to stay open")
+	default:
+	}
+	if s := h.Stats(); s.Drops != (DropStats{Events: 1}) {
+		t.Fatalf("unexpected drop stats %+v", s.Drops)
+	}
+}
+
+func TestPublishCoalesce(t *testing.T) {
+	h := New(Config{QueueSize: 3, Overflow: OverflowCoalesce})
+	game := newTopic(TargetGame)
+	c, _ := h.Subscribe(game, uuid.New(), "")
+
+	for _, event := range []string{"turn_changed", "dice_toggled", "dice_rolled", "dice_toggled", "score_recorded"} {
+		if _, err := h.Publish(game, Message{Event: event}, nil); err != nil {
+			t.Fatalf("Publish error: %v", err)
+		}
+	}
+
+	var got []string
+	for c.Len() > 0 {
+		got = append(got, receive(t, c).Event)
+	}
+	// the first dice_toggled is superseded by the second; without a match the oldest event goes
+	want := []string{"dice_rolled", "dice_toggled", "score_recorded"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	if s := h.Stats(); s.Drops != (DropStats{Events: 2, Coalesced: 1}) {
+		t.Fatalf("unexpected drop stats %+v", s.Drops)
+	}
+}
+
+func TestUnsubscribeRemovesTopic(t *testing.T) {
+	h := New(Config{})
+	lobby := newTopic(TargetLobby)
+	c, _ := h.Subscribe(lobby, uuid.New(), "")
+
+	h.Unsubscribe(c)
+	h.Unsubscribe(c)
+
+	if s := h.Stats(); s.TotalTargets != 0 {
+		t.Fatalf("expected no targets, got %+v", s)
+	}
+}
+
+func TestRegisterAndUnregister(t *testing.T) {
+	h := New(Config{})
+	lobby := newTopic(TargetLobby)
+
+	if err := h.Register(lobby); err != nil {
+		t.Fatalf("Register error: %v", err)
+	}
+	if err := h.Register(lobby); !errors.Is(err, ErrAlreadyRegistered) {
+		t.Fatalf("expected ErrAlreadyRegistered, got %v", err)
+	}
+
+	c, _ := h.Subscribe(lobby, uuid.New(), "")
+	h.Unsubscribe(c)
+	if s := h.Stats(); s.Lobbies.Count != 1 {
+		t.Fatalf("expected registered lobby to survive its last connection, got %+v", s)
+	}
+
+	c, _ = h.Subscribe(lobby, uuid.New(), "")
+	closed, err := h.Unregister(lobby, "lobby_deleted")
+	if err != nil {
+		t.Fatalf("Unregister error: %v", err)
+	}
+	if closed != 1 {
+		t.Fatalf("expected 1 closed connection, got %d", closed)
+	}
+	select {
+	case <-c.Done():
+	default:
+		t.Fatalf("expected connection to be closed")
+	}
+	if msg := receive(t, c); msg.Event != EventConnectionClosed || string(msg.Data) != `{"reason":"lobby_deleted"}` {
+		t.Fatalf("unexpected close message %q %s", msg.Event, msg.Data)
+	}
+	if _, err := h.Unregister(lobby, "cleanup"); !errors.Is(err, ErrNotRegistered) {
+		t.Fatalf("expected ErrNotRegistered, got %v", err)
+	}
+}
+
+func TestStats(t *testing.T) {
+	h := New(Config{})
+	lobby := newTopic(TargetLobby)
+	h.Subscribe(lobby, uuid.New(), "")
+	h.Subscribe(lobby, uuid.New(), "")
+	h.Subscribe(newTopic(TargetGame), uuid.New(), "")
+	_ = h.Register(newTopic(TargetGame))
+
+	s := h.Stats()
+	want := Stats{
+		TotalTargets:     3,
+		TotalConnections: 3,
+		Lobbies:          TypeStats{Count: 1, Connections: 2},
+		Games:            TypeStats{Count: 2, Connections: 1},
+		Queues:           QueueStats{Size: 32, Overflow: OverflowDropConnection},
+	}
+	if s != want {
+		t.Fatalf("expected %+v, got %+v", want, s)
+	}
+}
+
+func publishN(t *testing.T, h *Hub, topic Topic, n int) {
+	t.Helper()
+	for i := 0; i < n; i++ {
+		if _, err := h.Publish(topic, Message{Event: "dice_rolled", Data: []byte(`{}`)}, nil); err != nil {
+			t.Fatalf("Publish error: %v", err)
+		}
+	}
+}
+
+func TestPublishAssignsIncreasingIDs(t *testing.T) {
+	h := New(Config{})
+	lobby, game := newTopic(TargetLobby), newTopic(TargetGame)
+	l, _ := h.Subscribe(lobby, uuid.New(), "")
+	g, _ := h.Subscribe(game, uuid.New(), "")
+
+	publishN(t, h, lobby, 2)
+	publishN(t, h, game, 1)
+	if _, err := h.Publish(game, Message{ID: 10, Event: "dice_rolled"}, nil); err != nil {
+		t.Fatalf("Publish error: %v", err)
+	}
+	publishN(t, h, lobby, 1)
+
+	if receive(t, l).ID != 1 || receive(t, l).ID != 2 || receive(t, g).ID != 3 || receive(t, g).ID != 10 || receive(t, l).ID != 11 {
+		t.Fatalf("expected ids to increase across topics and to keep ids assigned by the bus")
+	}
+}
+
+func TestSubscribeResyncForEventsBeforeTopicWasKnown(t *testing.T) {
+	h := New(Config{})
+	game := newTopic(TargetGame)
+	// events of other topics or published before this replica tracked the game are unknown
+	if _, err := h.Publish(game, Message{ID: 40, Event: "dice_rolled"}, nil); !errors.Is(err, ErrTargetNotFound) {
+		t.Fatalf("expected ErrTargetNotFound, got %v", err)
+	}
+	_ = h.Register(game)
+	publishN(t, h, game, 2)
+
+	_, replay := h.Subscribe(game, uuid.New(), "39")
+	if len(replay) != 1 || replay[0].Event != EventResyncRequired || replay[0].ID != 42 {
+		t.Fatalf("expected resync_required for an id before the game was known, got %+v", replay)
+	}
+	_, replay = h.Subscribe(game, uuid.New(), "40")
+	if len(replay) != 2 || replay[0].ID != 41 {
+		t.Fatalf("expected events 41 and 42, got %+v", replay)
+	}
+}
+
+func TestSubscribeReplaysMissedEvents(t *testing.T) {
+	h := New(Config{})
+	game := newTopic(TargetGame)
+	alice, bob := uuid.New(), uuid.New()
+	first, _ := h.Subscribe(game, alice, "")
+
+	publishN(t, h, game, 2)
+	h.Unsubscribe(first)
+	publishN(t, h, game, 1)
+	if _, err := h.Publish(game, Message{Event: "you_were_kicked"}, &bob); err != nil {
+		t.Fatalf("Publish error: %v", err)
+	}
+	publishN(t, h, game, 1)
+
+	_, replay := h.Subscribe(game, alice, "2")
+	if len(replay) != 2 || replay[0].ID != 3 || replay[1].ID != 5 {
+		t.Fatalf("expected events 3 and 5 to be replayed, got %+v", replay)
+	}
+
+	_, replay = h.Subscribe(game, alice, "5")
+	if len(replay) != 0 {
+		t.Fatalf("expected nothing to replay for an up to date client, got %+v", replay)
+	}
+}
+
+func TestSubscribeResyncRequired(t *testing.T) {
+	h := New(Config{HistorySize: 3})
+	game := newTopic(TargetGame)
+	_ = h.Register(game)
+	publishN(t, h, game, 5)
+
+	cases := map[string]string{
+		"evicted":   "1",
+		"unknown":   "9",
+		"malformed": "abc",
+	}
+	for name, lastEventID := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, replay := h.Subscribe(game, uuid.New(), lastEventID)
+			if len(replay) != 1 || replay[0].Event != EventResyncRequired || replay[0].ID != 5 {
+				t.Fatalf("expected resync_required, got %+v", replay)
+			}
+		})
+	}
+
+	_, replay := h.Subscribe(game, uuid.New(), "2")
+	if len(replay) != 3 || replay[0].ID != 3 {
+		t.Fatalf("expected the buffered events 3-5, got %+v", replay)
+	}
+}
+
+func TestResetRequiresResync(t *testing.T) {
+	h := New(Config{})
+	game := newTopic(TargetGame)
+	c, _ := h.Subscribe(game, uuid.New(), "")
+	publishN(t, h, game, 2)
+	receive(t, c)
+	receive(t, c)
+
+	h.Reset()
+	if msg := receive(t, c); msg.Event != EventResyncRequired {
+		t.Fatalf("expected resync_required for the open connection, got %q", msg.Event)
+	}
+
+	if _, err := h.Publish(game, Message{ID: 9, Event: "dice_rolled"}, nil); err != nil {
+		t.Fatalf("Publish error: %v", err)
+	}
+	_, replay := h.Subscribe(game, uuid.New(), "2")
+	if len(replay) != 1 || replay[0].Event != EventResyncRequired {
+		t.Fatalf("expected resync_required across the gap, got %+v", replay)
+	}
+}
+
+func TestSweepRemovesIdleTopics(t *testing.T) {
+	h := New(Config{HistoryRetention: time.Minute})
+	now := time.Now()
+	h.now = func() time.Time { return now }
+
+	game := newTopic(TargetGame)
+	c, _ := h.Subscribe(game, uuid.New(), "")
+	publishN(t, h, game, 3)
+	h.Unsubscribe(c)
+
+	if removed := h.Sweep(); removed != 0 {
+		t.Fatalf("expected the history to be retained, removed %d", removed)
+	}
+	_, replay := h.Subscribe(game, uuid.New(), strconv.Itoa(1))
+	if len(replay) != 2 {
+		t.Fatalf("expected replay after reconnect, got %+v", replay)
+	}
+
+	h = New(Config{HistoryRetention: time.Minute})
+	h.now = func() time.Time { return now }
+	c, _ = h.Subscribe(game, uuid.New(), "")
+	publishN(t, h, game, 1)
+	h.Unsubscribe(c)
+	now = now.Add(2 * time.Minute)
+	if removed := h.Sweep(); removed != 1 {
+		t.Fatalf("expected the idle topic to be removed, removed %d", removed)
+	}
+}
+
+func TestMessageWriteTo(t *testing.T) {
+	var buf bytes.Buffer
+	if _, err := (Message{ID: 7, Event: "turn_changed", Data: []byte("{\n\"a\":1\n}")}).WriteTo(&buf); err != nil {
+		t.Fatalf("WriteTo error: %v", err)
+	}
+	want := "id: 7\nevent: turn_changed\ndata: {\ndata: \"a\":1\ndata: }\n\n"
+	if buf.String() != want {
+		t.Fatalf("expected %q, got %q", want, buf.String())
+	}
+}
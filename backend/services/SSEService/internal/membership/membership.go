@@ -0,0 +1,76 @@
+// Package membership asks the Lobby and Game Service whether a user may open the event stream of a lobby or game.
+package membership
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/KnuffelGame/KnuffelGame/backend/services/SSEService/internal/hub"
+	"github.com/google/uuid"
+)
+
+const requestTimeout = 3 * time.Second
+
+var (
+	// ErrNotMember is returned when the user is not a member of the lobby or not a player of the game
+	ErrNotMember = errors.New("not a member")
+	// ErrTargetNotFound is returned when the lobby or game does not exist
+	ErrTargetNotFound = errors.New("target not found")
+)
+
+// Checker decides whether a user may subscribe to a topic
+type Checker interface {
+	Check(ctx context.Context, topic hub.Topic, userID uuid.UUID) error
+}
+
+// HTTPChecker asks the owning service of the topic over HTTP
+type HTTPChecker struct {
+	lobbyURL string
+	gameURL  string
+	client   *http.Client
+}
+
+// NewHTTPChecker creates a checker for the Lobby Service at lobbyURL and the Game Service at gameURL
+// (e.g. http://LobbyService:8083 and http://GameService:8082). An empty URL lets everyone open the streams of that type.
+func NewHTTPChecker(lobbyURL, gameURL string) *HTTPChecker {
+	return &HTTPChecker{lobbyURL: lobbyURL, gameURL: gameURL, client: &http.Client{Timeout: requestTimeout}}
+}
+
+// Check calls GET /internal/lobbies/{lobby_id}/players/{player_id} of the Lobby Service for lobbies and
+// GET /internal/games/{game_id}/players/{user_id} of the Game Service for games
+func (c *HTTPChecker) Check(ctx context.Context, topic hub.Topic, userID uuid.UUID) error {
+	var base, path string
+	switch topic.Type {
+	case hub.TargetLobby:
+		base, path = c.lobbyURL, "/internal/lobbies/%s/players/%s"
+	case hub.TargetGame:
+		base, path = c.gameURL, "/internal/games/%s/players/%s"
+	default:
+		return fmt.Errorf("unknown target type %q", topic.Type)
+	}
+	if base == "" {
+		return nil
+	}
+	url := base + fmt.Sprintf(path, topic.ID, userID)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to build request: %w", err)
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to check membership: %w", err)
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusNoContent:
+		return nil
+	case http.StatusForbidden:
+		return ErrNotMember
+	case http.StatusNotFound:
+		return ErrTargetNotFound
+	}
+	return fmt.Errorf("membership check returned status %d", resp.StatusCode)
+}
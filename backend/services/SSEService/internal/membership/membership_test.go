@@ -0,0 +1,70 @@
+package membership
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/KnuffelGame/KnuffelGame/backend/services/SSEService/internal/hub"
+	"github.com/google/uuid"
+)
+
+func TestHTTPChecker_Check(t *testing.T) {
+	userID := uuid.New()
+	lobby := hub.Topic{Type: hub.TargetLobby, ID: uuid.New()}
+	game := hub.Topic{Type: hub.TargetGame, ID: uuid.New()}
+
+	for _, tc := range []struct {
+		name   string
+		topic  hub.Topic
+		status int
+		path   string
+		want   error
+	}{
+		{"lobby member", lobby, http.StatusNoContent, "/internal/lobbies/" + lobby.ID.String() + "/players/" + userID.String(), nil},
+		{"game player", game, http.StatusNoContent, "/internal/games/" + game.ID.String() + "/players/" + userID.String(), nil},
+		{"not a member", lobby, http.StatusForbidden, "", ErrNotMember},
+		{"unknown target", game, http.StatusNotFound, "", ErrTargetNotFound},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var gotPath, gotMethod string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotPath, gotMethod = r.URL.Path, r.Method
+				w.WriteHeader(tc.status)
+			}))
+			defer srv.Close()
+
+			err := NewHTTPChecker(srv.URL, srv.URL).Check(context.Background(), tc.topic, userID)
+			if !errors.Is(err, tc.want) {
+				t.Fatalf("expected %v, got %v", tc.want, err)
+			}
+			if gotMethod != http.MethodGet {
+				t.Fatalf("expected GET, got %s", gotMethod)
+			}
+			if tc.path != "" && gotPath != tc.path {
+				t.Fatalf("expected path %s, got %s", tc.path, gotPath)
+			}
+		})
+	}
+}
+
+func TestHTTPChecker_Check_ErrorStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	err := NewHTTPChecker(srv.URL, srv.URL).Check(context.Background(), hub.Topic{Type: hub.TargetLobby, ID: uuid.New()}, uuid.New())
+	if err == nil || errors.Is(err, ErrNotMember) || errors.Is(err, ErrTargetNotFound) {
+		t.Fatalf("expected a plain error, got %v", err)
+	}
+}
+
+func TestHTTPChecker_Check_Disabled(t *testing.T) {
+	err := NewHTTPChecker("", "").Check(context.Background(), hub.Topic{Type: hub.TargetGame, ID: uuid.New()}, uuid.New())
+	if err != nil {
+		t.Fatalf("expected no check without service URL, got %v", err)
+	}
+}
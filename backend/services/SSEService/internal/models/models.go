@@ -0,0 +1,99 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PublishEventRequest represents the request body for POST /internal/publish
+type PublishEventRequest struct {
+	TargetType   string          `json:"target_type"`
+	TargetID     string          `json:"target_id"`
+	EventType    string          `json:"event_type"`
+	TargetUserID *string         `json:"target_user_id,omitempty"`
+	Data         json.RawMessage `json:"data"`
+}
+
+// DeliveryFailure describes a connection that did not receive a published event
+type DeliveryFailure struct {
+	ConnectionID uint64    `json:"connection_id"`
+	UserID       uuid.UUID `json:"user_id"`
+	Reason       string    `json:"reason"`
+}
+
+// PublishEventResponse represents the response for POST /internal/publish
+type PublishEventResponse struct {
+	Success           bool              `json:"success"`
+	ConnectionsFound  int               `json:"connections_found"`
+	EventsSent        int               `json:"events_sent"`
+	FailedConnections int               `json:"failed_connections"`
+	Failures          []DeliveryFailure `json:"failures,omitempty"`
+}
+
+// RegisterTargetRequest represents the request body for POST /internal/register
+type RegisterTargetRequest struct {
+	TargetType string `json:"target_type"`
+	TargetID   string `json:"target_id"`
+}
+
+// UnregisterTargetRequest represents the request body for POST /internal/unregister
+type UnregisterTargetRequest struct {
+	TargetType string `json:"target_type"`
+	TargetID   string `json:"target_id"`
+	Reason     string `json:"reason,omitempty"`
+}
+
+// UnregisterTargetResponse represents the response for POST /internal/unregister
+type UnregisterTargetResponse struct {
+	Success           bool   `json:"success"`
+	ConnectionsClosed int    `json:"connections_closed"`
+	Message           string `json:"message"`
+}
+
+// SuccessResponse is a generic success envelope
+type SuccessResponse struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+// TargetStats aggregates targets of one type
+type TargetStats struct {
+	Count       int `json:"count"`
+	Connections int `json:"connections"`
+}
+
+// QueueStats describes the per-connection send queues
+type QueueStats struct {
+	Size           int    `json:"size"`
+	OverflowPolicy string `json:"overflow_policy"`
+	QueuedMessages int    `json:"queued_messages"`
+	MaxDepth       int    `json:"max_depth"`
+}
+
+// DropStats counts losses since the service was started
+type DropStats struct {
+	Connections     uint64 `json:"connections"`
+	Events          uint64 `json:"events"`
+	CoalescedEvents uint64 `json:"coalesced_events"`
+}
+
+// ConnectionStatsResponse represents the response for GET /internal/connections
+type ConnectionStatsResponse struct {
+	TotalTargets     int         `json:"total_targets"`
+	TotalConnections int         `json:"total_connections"`
+	Lobbies          TargetStats `json:"lobbies"`
+	Games            TargetStats `json:"games"`
+	Queues           QueueStats  `json:"queues"`
+	Drops            DropStats   `json:"drops"`
+	Timestamp        time.Time   `json:"timestamp"`
+}
+
+// Unregister reasons accepted by POST /internal/unregister
+const (
+	ReasonLobbyDeleted = "lobby_deleted"
+	ReasonGameEnded    = "game_ended"
+	ReasonCleanup      = "cleanup"
+	ReasonError        = "error"
+)
@@ -0,0 +1,46 @@
+package router
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/KnuffelGame/KnuffelGame/backend/libs/auth"
+	"github.com/KnuffelGame/KnuffelGame/backend/libs/healthcheck"
+	"github.com/KnuffelGame/KnuffelGame/backend/libs/logger"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/SSEService/internal/bus"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/SSEService/internal/handlers"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/SSEService/internal/hub"
+	"github.com/KnuffelGame/KnuffelGame/backend/services/SSEService/internal/membership"
+	"github.com/go-chi/chi/v5"
+)
+
+// New constructs the HTTP router with the connection hub of this replica, the event bus that distributes
+// internal operations to all replicas, the membership checker for streams and the heartbeat interval for streams
+func New(h *hub.Hub, b bus.EventBus, members membership.Checker, keepAlive time.Duration) http.Handler {
+	r := chi.NewRouter()
+	// replace chi default logger with structured slog based middleware
+	l := logger.Default()
+	r.Use(logger.ChiMiddleware(l))
+
+	// Healthcheck
+	healthcheck.Mount(r)
+
+	// Internal endpoints (no auth required)
+	r.Route("/internal", func(r chi.Router) {
+		r.Post("/publish", handlers.PublishEventHandler(b))
+		r.Post("/register", handlers.RegisterTargetHandler(b))
+		r.Post("/unregister", handlers.UnregisterTargetHandler(b))
+		r.Get("/connections", handlers.ConnectionStatsHandler(h))
+	})
+
+	// Event streams grouped under auth middleware
+	r.Route("/events", func(r chi.Router) {
+		// Authentication middleware (reads X-User-ID / X-Username and injects user into context)
+		r.Use(auth.AuthMiddleware)
+
+		r.Get("/lobby/{lobby_id}", handlers.SubscribeHandler(h, members, hub.TargetLobby, keepAlive))
+		r.Get("/game/{game_id}", handlers.SubscribeHandler(h, members, hub.TargetGame, keepAlive))
+	})
+
+	return r
+}
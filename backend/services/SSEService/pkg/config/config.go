@@ -0,0 +1,129 @@
+package config
+
+import "os"
+
+// Config holds runtime configuration loaded from environment variables.
+// PORT defaults to 8084 if unset.
+// KEEP_ALIVE_INTERVAL is the heartbeat interval for open streams as a Go duration (default 30s).
+// HISTORY_SIZE is the number of events kept per lobby/game for Last-Event-ID replay (default 100).
+// HISTORY_RETENTION is how long the history of a lobby/game without connections is kept (default 5m).
+// QUEUE_SIZE is the number of events queued per connection (default 32).
+// QUEUE_OVERFLOW_POLICY applies when a queue is full: "drop_connection" (default), "drop_oldest" or "coalesce".
+// EVENT_BUS is "local" (default, single replica) or "postgres" to share events between replicas via LISTEN/NOTIFY.
+// LOBBY_SERVICE_URL and GAME_SERVICE_URL default to the docker compose addresses of the Lobby and Game Service;
+// they check that subscribers are members of the lobby or players of the game. An empty value disables that check.
+// DATABASE_* default to the docker compose Postgres instance and are only used by the postgres event bus.
+// Extend here for future configuration values.
+
+type Config struct {
+	Port              string
+	KeepAliveInterval string
+	HistorySize       string
+	HistoryRetention  string
+	QueueSize         string
+	OverflowPolicy    string
+	EventBus          string
+	LobbyServiceURL   string
+	GameServiceURL    string
+	DatabaseHost      string
+	DatabasePort      string
+	DatabaseUser      string
+	DatabasePassword  string
+	DatabaseName      string
+	DatabaseSSLMode   string
+}
+
+func Load() *Config {
+	port := os.Getenv("PORT")
+	if port == "" {
+		port = "8084"
+	}
+
+	keepAlive := os.Getenv("KEEP_ALIVE_INTERVAL")
+	if keepAlive == "" {
+		keepAlive = "30s"
+	}
+
+	historySize := os.Getenv("HISTORY_SIZE")
+	if historySize == "" {
+		historySize = "100"
+	}
+
+	historyRetention := os.Getenv("HISTORY_RETENTION")
+	if historyRetention == "" {
+		historyRetention = "5m"
+	}
+
+	queueSize := os.Getenv("QUEUE_SIZE")
+	if queueSize == "" {
+		queueSize = "32"
+	}
+
+	overflowPolicy := os.Getenv("QUEUE_OVERFLOW_POLICY")
+	if overflowPolicy == "" {
+		overflowPolicy = "drop_connection"
+	}
+
+	eventBus := os.Getenv("EVENT_BUS")
+	if eventBus == "" {
+		eventBus = "local"
+	}
+
+	lobbyURL, ok := os.LookupEnv("LOBBY_SERVICE_URL")
+	if !ok {
+		lobbyURL = "http://LobbyService:8083"
+	}
+
+	gameURL, ok := os.LookupEnv("GAME_SERVICE_URL")
+	if !ok {
+		gameURL = "http://GameService:8082"
+	}
+
+	dbHost := os.Getenv("DATABASE_HOST")
+	if dbHost == "" {
+		dbHost = "Postgres"
+	}
+
+	dbPort := os.Getenv("DATABASE_PORT")
+	if dbPort == "" {
+		dbPort = "5432"
+	}
+
+	dbUser := os.Getenv("DATABASE_USER")
+	if dbUser == "" {
+		dbUser = "sse"
+	}
+
+	dbPassword := os.Getenv("DATABASE_PASSWORD")
+	if dbPassword == "" {
+		dbPassword = "secure"
+	}
+
+	dbName := os.Getenv("DATABASE_NAME")
+	if dbName == "" {
+		dbName = "sse"
+	}
+
+	dbSSLMode := os.Getenv("DATABASE_SSLMODE")
+	if dbSSLMode == "" {
+		dbSSLMode = "disable"
+	}
+
+	return &Config{
+		Port:              port,
+		KeepAliveInterval: keepAlive,
+		HistorySize:       historySize,
+		HistoryRetention:  historyRetention,
+		QueueSize:         queueSize,
+		OverflowPolicy:    overflowPolicy,
+		EventBus:          eventBus,
+		LobbyServiceURL:   lobbyURL,
+		GameServiceURL:    gameURL,
+		DatabaseHost:      dbHost,
+		DatabasePort:      dbPort,
+		DatabaseUser:      dbUser,
+		DatabasePassword:  dbPassword,
+		DatabaseName:      dbName,
+		DatabaseSSLMode:   dbSSLMode,
+	}
+}
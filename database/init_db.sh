@@ -20,4 +20,22 @@ psql -v ON_ERROR_STOP=1 --username "$POSTGRES_USER" --dbname "$POSTGRES_DB" <<-E
 
     -- Grant all privileges on database 2 to user 2
     GRANT ALL PRIVILEGES ON DATABASE $USER2_DB TO $USER2_USER;
+
+    -- Create user 3 with password from environment variable
+    CREATE USER $USER3_USER WITH PASSWORD '$USER3_PASSWORD';
+
+    -- Create database 3 and set its owner
+    CREATE DATABASE $USER3_DB OWNER $USER3_USER;
+
+    -- Grant all privileges on database 3 to user 3
+    GRANT ALL PRIVILEGES ON DATABASE $USER3_DB TO $USER3_USER;
+
+    -- Create user 4 with password from environment variable
+    CREATE USER $USER4_USER WITH PASSWORD '$USER4_PASSWORD';
+
+    -- Create database 4 and set its owner
+    CREATE DATABASE $USER4_DB OWNER $USER4_USER;
+
+    -- Grant all privileges on database 4 to user 4
+    GRANT ALL PRIVILEGES ON DATABASE $USER4_DB TO $USER4_USER;
 EOSQL
\ No newline at end of file